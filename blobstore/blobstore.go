@@ -0,0 +1,39 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/spf13/viper"
+)
+
+// Blob store providers.
+const (
+	ProviderLocal = "local"
+)
+
+// ErrNotFound is returned when there is no blob with the key.
+var ErrNotFound = errors.New("blob not found")
+
+// ErrInvalidKey is returned when the key contains characters which aren't allowed.
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// Store keeps uploaded files under keys.
+type Store interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// NewBlobStore creates Store based on configuration.
+func NewBlobStore() (Store, error) {
+	provider := viper.GetString(config.EnvBlobStoreProvider)
+	switch provider {
+	case ProviderLocal, "":
+		return NewLocalStore(viper.GetString(config.EnvBlobStoreLocalPath)), nil
+	default:
+		return nil, fmt.Errorf("unknown blob store provider: %s", provider)
+	}
+}
@@ -0,0 +1,94 @@
+package blobstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/pkg/errors"
+)
+
+// keyPattern allows only keys which can't escape the store directory.
+var keyPattern = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9._-]*$`)
+
+// LocalStore keeps blobs as files in a directory.
+type LocalStore struct {
+	dir string
+}
+
+// NewLocalStore creates local filesystem store.
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{
+		dir: dir,
+	}
+}
+
+// Put writes the blob. Existing blob with the same key is replaced.
+func (s *LocalStore) Put(_ context.Context, key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(s.dir, 0o750); err != nil {
+		return errors.Wrap(err, "cannot create blob directory")
+	}
+
+	// Blob is written to a temporary file first, so readers never see a partially written one.
+	f, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return errors.Wrap(err, "cannot create blob file")
+	}
+	defer os.Remove(f.Name()) //nolint:errcheck // file is already renamed when write succeeds
+
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "cannot write blob file")
+	}
+	if err = f.Close(); err != nil {
+		return errors.Wrap(err, "cannot write blob file")
+	}
+
+	if err = os.Rename(f.Name(), path); err != nil {
+		return errors.Wrap(err, "cannot write blob file")
+	}
+	return nil
+}
+
+// Get reads the blob.
+func (s *LocalStore) Get(_ context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // key is validated
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read blob file")
+	}
+	return data, nil
+}
+
+// Delete removes the blob. Removing blob which doesn't exist isn't an error.
+func (s *LocalStore) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrap(err, "cannot remove blob file")
+	}
+	return nil
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	if !keyPattern.MatchString(key) {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.dir, key), nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"os"
@@ -9,6 +10,21 @@ import (
 
 	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/config/databases"
+	db_audit "github.com/bitcoin-sv/spv-wallet-web-backend/data/audit"
+	db_idempotency "github.com/bitcoin-sv/spv-wallet-web-backend/data/idempotency"
+	db_identities "github.com/bitcoin-sv/spv-wallet-web-backend/data/identities"
+	db_lockout "github.com/bitcoin-sv/spv-wallet-web-backend/data/lockout"
+	db_operators "github.com/bitcoin-sv/spv-wallet-web-backend/data/operators"
+	db_outbox "github.com/bitcoin-sv/spv-wallet-web-backend/data/outbox"
+	db_passkeys "github.com/bitcoin-sv/spv-wallet-web-backend/data/passkeys"
+	db_paymails "github.com/bitcoin-sv/spv-wallet-web-backend/data/paymails"
+	db_payouts "github.com/bitcoin-sv/spv-wallet-web-backend/data/payouts"
+	db_profiles "github.com/bitcoin-sv/spv-wallet-web-backend/data/profiles"
+	db_roles "github.com/bitcoin-sv/spv-wallet-web-backend/data/roles"
+	db_sessions "github.com/bitcoin-sv/spv-wallet-web-backend/data/sessions"
+	db_tokens "github.com/bitcoin-sv/spv-wallet-web-backend/data/tokens"
+	db_transactions "github.com/bitcoin-sv/spv-wallet-web-backend/data/transactions"
+	db_twofactor "github.com/bitcoin-sv/spv-wallet-web-backend/data/twofactor"
 	db_users "github.com/bitcoin-sv/spv-wallet-web-backend/data/users"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/logging"
@@ -22,6 +38,11 @@ import (
 // @title           SPV Wallet WEB Backend
 // @version			1.0
 // @description     This is an API for the spv-wallet-web-frontend.
+//
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Operator token of the admin API prefixed with "Bearer ".
 func main() {
 	defaultLogger := logging.GetDefaultLogger()
 
@@ -38,14 +59,36 @@ func main() {
 	db := databases.SetUpDatabase(log)
 	defer db.Close() //nolint: all
 
-	repo := db_users.NewUsersRepository(db)
+	repos := &domain.Repositories{
+		Users:        db_users.NewUsersRepository(db),
+		Sessions:     db_sessions.NewSessionsRepository(db),
+		TwoFactor:    db_twofactor.NewTwoFactorRepository(db),
+		Lockout:      db_lockout.NewLockoutRepository(db),
+		Paymails:     db_paymails.NewPaymailsRepository(db),
+		Profiles:     db_profiles.NewProfilesRepository(db),
+		Operators:    db_operators.NewOperatorsRepository(db),
+		Roles:        db_roles.NewRolesRepository(db),
+		Audit:        db_audit.NewAuditRepository(db),
+		Identities:   db_identities.NewIdentitiesRepository(db),
+		Passkeys:     db_passkeys.NewPasskeysRepository(db),
+		Tokens:       db_tokens.NewTokensRepository(db),
+		Payouts:      db_payouts.NewPayoutsRepository(db),
+		Outbox:       db_outbox.NewOutboxRepository(db),
+		Idempotency:  db_idempotency.NewIdempotencyRepository(db),
+		Transactions: db_transactions.NewTransactionsRepository(db),
+	}
 
-	s, err := domain.NewServices(repo, log)
+	s, err := domain.NewServices(repos, log)
 	if err != nil {
 		log.Error().Msgf("cannot create services because of an error: %v", err)
 		os.Exit(1)
 	}
 
+	if err = s.OperatorsService.EnsureConfiguredOperator(); err != nil {
+		log.Error().Msgf("cannot create configured admin operator: %v", err)
+		os.Exit(1)
+	}
+
 	ws, err := websocket.NewServer(log, s, db)
 	if err != nil {
 		log.Error().Msgf("failed to init a new websocket server: %v", err)
@@ -63,11 +106,20 @@ func main() {
 
 	go startServer(server)
 
+	reaperCtx, stopReaper := context.WithCancel(context.Background())
+	go s.SessionsService.StartReaper(reaperCtx)
+	go s.UsersService.StartVerificationReaper(reaperCtx)
+	go s.OperatorsService.StartSessionReaper(reaperCtx)
+	go s.OutboxService.StartWorker(reaperCtx)
+	go s.IdempotencyService.StartReaper(reaperCtx)
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
 
 	<-quit
 
+	stopReaper()
+
 	if err = server.Shutdown(); err != nil {
 		log.Error().Msgf("failed to stop http server: %v", err)
 	}
@@ -34,6 +34,12 @@ const (
 	EnvHTTPServerCorsAllowedDomains = "http.server.cors.allowedDomains"
 	// EnvHTTPServerSessionSecret gin session store secret to encrypt session data in database.
 	EnvHTTPServerSessionSecret = "http.server.session.secret" //nolint: gosec
+	// EnvHTTPServerSessionSigningGrantTTL how long the xPriv unlocked with password can be used to sign without asking for it again.
+	EnvHTTPServerSessionSigningGrantTTL = "http.server.session.signingGrantTTL"
+	// EnvHTTPServerSessionMaxAge session max age in seconds, access key of the session is revoked after it passes.
+	EnvHTTPServerSessionMaxAge = "http.server.session.maxAge"
+	// EnvHTTPServerSessionReaperInterval how often access keys of expired sessions are revoked.
+	EnvHTTPServerSessionReaperInterval = "http.server.session.reaperInterval"
 )
 
 // Define basic spv-wallet config keys.
@@ -57,9 +63,150 @@ const (
 	EnvWebsocketHistoryTTL = "websocket.history.ttl"
 )
 
-// EnvHashSalt define the hash salt.
+// EnvHashSalt define the hash salt used to decrypt xPrivs stored in legacy format.
 const EnvHashSalt = "hash.salt"
 
+// EnvSealerSecret define the secret from which the key sealing access keys held by the server is derived.
+// It must be set and kept unchanged, sealed access keys of sessions and API tokens can't be opened after it's changed.
+const EnvSealerSecret = "sealer.secret" //nolint: gosec
+
+const (
+	// EnvKeyCustodyProvider define the key custody provider used to wrap encrypted xPrivs - none/local/vault.
+	EnvKeyCustodyProvider = "keyCustody.provider"
+	// EnvKeyCustodyLocalKekPath define the path to the file with hex encoded key encryption key.
+	EnvKeyCustodyLocalKekPath = "keyCustody.local.kekPath"
+	// EnvKeyCustodyVaultAddress define the address of the HashiCorp Vault server.
+	EnvKeyCustodyVaultAddress = "keyCustody.vault.address"
+	// EnvKeyCustodyVaultToken define the HashiCorp Vault token.
+	EnvKeyCustodyVaultToken = "keyCustody.vault.token" //nolint: gosec
+	// EnvKeyCustodyVaultKeyName define the name of the HashiCorp Vault transit key.
+	EnvKeyCustodyVaultKeyName = "keyCustody.vault.keyName"
+)
+
+const (
+	// EnvSignInLockoutBackoffAfter define the number of failed sign-in attempts after which next attempts are delayed.
+	EnvSignInLockoutBackoffAfter = "signIn.lockout.backoffAfter"
+	// EnvSignInLockoutBackoffBase define the delay after the first delayed attempt, it doubles with each next failure.
+	EnvSignInLockoutBackoffBase = "signIn.lockout.backoffBase"
+	// EnvSignInLockoutMaxAttempts define the number of failed sign-in attempts per email after which account is locked.
+	EnvSignInLockoutMaxAttempts = "signIn.lockout.maxAttempts"
+	// EnvSignInLockoutIPMaxAttempts define the number of failed sign-in attempts per ip after which sign-in from it is locked.
+	EnvSignInLockoutIPMaxAttempts = "signIn.lockout.ipMaxAttempts"
+	// EnvSignInLockoutDuration define how long the lock lasts and after what time failed attempts are forgotten.
+	EnvSignInLockoutDuration = "signIn.lockout.duration"
+)
+
+const (
+	// EnvUsersVerificationTTL define how long the email verification link is valid, unverified users are removed after it.
+	EnvUsersVerificationTTL = "users.verification.ttl"
+	// EnvUsersVerificationURL define the url of the email verification endpoint, token is appended as a query parameter.
+	EnvUsersVerificationURL = "users.verification.url"
+	// EnvUsersVerificationReaperInterval how often expired unverified users are removed.
+	EnvUsersVerificationReaperInterval = "users.verification.reaperInterval"
+)
+
+const (
+	// EnvMailerProvider define the mailer used to send emails - smtp/file/log, it's required. File mailer is intended for development,
+	// log mailer doesn't log bodies of emails, so it's useful only when emails aren't needed.
+	EnvMailerProvider = "mailer.provider"
+	// EnvMailerFrom define the sender address of emails.
+	EnvMailerFrom = "mailer.from"
+	// EnvMailerSMTPHost define the SMTP server host.
+	EnvMailerSMTPHost = "mailer.smtp.host"
+	// EnvMailerSMTPPort define the SMTP server port.
+	EnvMailerSMTPPort = "mailer.smtp.port"
+	// EnvMailerSMTPUsername define the SMTP username, authentication is skipped if it's empty.
+	EnvMailerSMTPUsername = "mailer.smtp.username"
+	// EnvMailerSMTPPassword define the SMTP password.
+	EnvMailerSMTPPassword = "mailer.smtp.password" //nolint: gosec
+	// EnvMailerFilePath define the path to the file which emails are appended to.
+	EnvMailerFilePath = "mailer.file.path"
+)
+
+const (
+	// EnvBlobStoreProvider define the store of uploaded files - local.
+	EnvBlobStoreProvider = "blobStore.provider"
+	// EnvBlobStoreLocalPath define the directory in which the local blob store keeps files.
+	EnvBlobStoreLocalPath = "blobStore.local.path"
+)
+
+const (
+	// EnvAvatarURL define the url of the avatar endpoint, avatar key is appended to it.
+	EnvAvatarURL = "avatar.url"
+	// EnvAvatarMaxSize define the maximum size of uploaded avatar in bytes.
+	EnvAvatarMaxSize = "avatar.maxSize"
+)
+
+const (
+	// EnvAdminSessionTTL define how long the operator stays signed in to the admin API.
+	EnvAdminSessionTTL = "admin.session.ttl"
+	// EnvAdminOperatorEmail define the email of the admin operator created on startup if it doesn't exist, skipped if it's empty.
+	EnvAdminOperatorEmail = "admin.operator.email"
+	// EnvAdminOperatorPassword define the password of the admin operator created on startup.
+	EnvAdminOperatorPassword = "admin.operator.password" //nolint: gosec
+)
+
+const (
+	// EnvOIDCProvider define the OpenID Connect provider used for single sign-on - none/oidc/stub.
+	EnvOIDCProvider = "oidc.provider"
+	// EnvOIDCIssuer define the issuer url of the OpenID Connect provider, its configuration is discovered from it.
+	EnvOIDCIssuer = "oidc.issuer"
+	// EnvOIDCClientID define the client id registered at the OpenID Connect provider.
+	EnvOIDCClientID = "oidc.clientId"
+	// EnvOIDCClientSecret define the client secret registered at the OpenID Connect provider.
+	EnvOIDCClientSecret = "oidc.clientSecret" //nolint: gosec
+	// EnvOIDCRedirectURL define the url of the callback endpoint registered at the OpenID Connect provider.
+	EnvOIDCRedirectURL = "oidc.redirectUrl"
+	// EnvOIDCFrontendURL define the url of the frontend page the user is redirected to after the callback with the result as a query parameter, result is returned as JSON if it's empty.
+	EnvOIDCFrontendURL = "oidc.frontendUrl"
+	// EnvOIDCStubSubject define the subject of the user signed in by the stub provider.
+	EnvOIDCStubSubject = "oidc.stub.subject"
+	// EnvOIDCStubEmail define the email of the user signed in by the stub provider.
+	EnvOIDCStubEmail = "oidc.stub.email"
+)
+
+const (
+	// EnvWebAuthnRPID define the relying party id of passkeys, it's the domain of the frontend or its parent domain.
+	EnvWebAuthnRPID = "webauthn.rpId"
+	// EnvWebAuthnRPName define the relying party name shown by authenticators.
+	EnvWebAuthnRPName = "webauthn.rpName"
+	// EnvWebAuthnOrigins define origins of the frontend from which passkey ceremonies are accepted.
+	EnvWebAuthnOrigins = "webauthn.origins"
+	// EnvWebAuthnChallengeTTL define how long the user has to finish a passkey ceremony.
+	EnvWebAuthnChallengeTTL = "webauthn.challengeTtl"
+)
+
+const (
+	// EnvPayoutsChunkSize define how many rows of the payout batch are paid by one transaction.
+	EnvPayoutsChunkSize = "payouts.chunkSize"
+	// EnvPayoutsMaxRows define the maximum number of rows in the uploaded payout batch.
+	EnvPayoutsMaxRows = "payouts.maxRows"
+)
+
+const (
+	// EnvTransactionsPreviewTTL define how long the previewed transaction can be sent. Inputs of the previewed transaction are reserved until it's sent or expired.
+	EnvTransactionsPreviewTTL = "transactions.previewTtl"
+	// EnvTransactionsRecordWindow define how long the sent transaction is retried to be recorded in SPV Wallet before it's given up.
+	// Its draft is valid for this time after the preview expires, so inputs of the preview which is not sent are reserved longer too.
+	// If SPV Wallet is unavailable for longer, the transaction is marked as failed and it's never broadcast, so it must be sent again.
+	EnvTransactionsRecordWindow = "transactions.recordWindow"
+	// EnvTransactionsOutboxInterval define how often sent transactions which are not recorded yet are retried.
+	EnvTransactionsOutboxInterval = "transactions.outbox.interval"
+	// EnvTransactionsOutboxMaxBackoff define the maximum delay between retries of recording the sent transaction.
+	EnvTransactionsOutboxMaxBackoff = "transactions.outbox.maxBackoff"
+	// EnvTransactionsIdempotencyKeyTTL define how long the outcome of the sent transaction is returned for repeated request with the same Idempotency-Key.
+	EnvTransactionsIdempotencyKeyTTL = "transactions.idempotencyKeyTtl"
+	// EnvTransactionsIdempotencyKeyLease define how long the request in progress holds its Idempotency-Key before a repeated request can take it over.
+	// The key is not taken over while the preview of the request is being sent.
+	EnvTransactionsIdempotencyKeyLease = "transactions.idempotencyKeyLease"
+)
+
+// EnvAuditHashChain define whether entries of the security audit log are hash-chained, so changed or removed entries can be detected.
+const EnvAuditHashChain = "audit.hashChain"
+
+// EnvTwoFactorIssuer define the issuer shown in authenticator apps.
+const EnvTwoFactorIssuer = "twoFactor.issuer"
+
 const (
 	// EnvLoggingLevel define logging level for running application.
 	EnvLoggingLevel = "logging.level"
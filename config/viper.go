@@ -14,6 +14,19 @@ func NewViperConfig() *Config {
 	setHTTPServerDefaults()
 	setSpvWalletDefaults()
 	setHashDefaults()
+	setKeyCustodyDefaults()
+	setTwoFactorDefaults()
+	setSignInLockoutDefaults()
+	setUsersDefaults()
+	setMailerDefaults()
+	setBlobStoreDefaults()
+	setAvatarDefaults()
+	setAdminDefaults()
+	setAuditDefaults()
+	setOIDCDefaults()
+	setWebAuthnDefaults()
+	setPayoutsDefaults()
+	setTransactionsDefaults()
 	setLoggingDefaults()
 	setEndpointsDefaults()
 	setWebsocketDefaults()
@@ -53,6 +66,9 @@ func setHTTPServerDefaults() {
 	viper.SetDefault(EnvHTTPServerCookieSecure, false)
 	viper.SetDefault(EnvHTTPServerCorsAllowedDomains, []string{})
 	viper.SetDefault(EnvHTTPServerSessionSecret, "secret")
+	viper.SetDefault(EnvHTTPServerSessionSigningGrantTTL, 5*time.Minute)
+	viper.SetDefault(EnvHTTPServerSessionMaxAge, 1800)
+	viper.SetDefault(EnvHTTPServerSessionReaperInterval, time.Minute)
 }
 
 // setSpvWalletDefaults sets default values for spv-wallet connection.
@@ -68,6 +84,107 @@ func setHashDefaults() {
 	viper.SetDefault(EnvHashSalt, "spv-wallet")
 }
 
+// setSignInLockoutDefaults sets default values for sign-in brute-force protection.
+func setSignInLockoutDefaults() {
+	viper.SetDefault(EnvSignInLockoutBackoffAfter, 3)
+	viper.SetDefault(EnvSignInLockoutBackoffBase, time.Second)
+	viper.SetDefault(EnvSignInLockoutMaxAttempts, 10)
+	viper.SetDefault(EnvSignInLockoutIPMaxAttempts, 100)
+	viper.SetDefault(EnvSignInLockoutDuration, 15*time.Minute)
+}
+
+// setUsersDefaults sets default values for user registration.
+func setUsersDefaults() {
+	viper.SetDefault(EnvUsersVerificationTTL, 24*time.Hour)
+	viper.SetDefault(EnvUsersVerificationURL, "http://localhost:8180/api/v1/user/verify")
+	viper.SetDefault(EnvUsersVerificationReaperInterval, 10*time.Minute)
+}
+
+// setMailerDefaults sets default values for mailer.
+// Provider has no default, emails carry verification tokens, so it must be chosen explicitly.
+func setMailerDefaults() {
+	viper.SetDefault(EnvMailerFrom, "no-reply@example.com")
+	viper.SetDefault(EnvMailerSMTPHost, "localhost")
+	viper.SetDefault(EnvMailerSMTPPort, 587)
+	viper.SetDefault(EnvMailerSMTPUsername, "")
+	viper.SetDefault(EnvMailerSMTPPassword, "")
+	viper.SetDefault(EnvMailerFilePath, "mails.log")
+}
+
+// setBlobStoreDefaults sets default values for blob store.
+func setBlobStoreDefaults() {
+	viper.SetDefault(EnvBlobStoreProvider, "local")
+	viper.SetDefault(EnvBlobStoreLocalPath, "blobs")
+}
+
+// setAvatarDefaults sets default values for avatars.
+func setAvatarDefaults() {
+	viper.SetDefault(EnvAvatarURL, "http://localhost:8180/api/v1/avatars")
+	viper.SetDefault(EnvAvatarMaxSize, 1<<20)
+}
+
+// setAdminDefaults sets default values for admin API.
+func setAdminDefaults() {
+	viper.SetDefault(EnvAdminSessionTTL, 8*time.Hour)
+	viper.SetDefault(EnvAdminOperatorEmail, "")
+	viper.SetDefault(EnvAdminOperatorPassword, "")
+}
+
+// setAuditDefaults sets default values for security audit log.
+func setAuditDefaults() {
+	viper.SetDefault(EnvAuditHashChain, true)
+}
+
+// setOIDCDefaults sets default values for OpenID Connect single sign-on.
+func setOIDCDefaults() {
+	viper.SetDefault(EnvOIDCProvider, "none")
+	viper.SetDefault(EnvOIDCIssuer, "")
+	viper.SetDefault(EnvOIDCClientID, "")
+	viper.SetDefault(EnvOIDCClientSecret, "")
+	viper.SetDefault(EnvOIDCRedirectURL, "http://localhost:8180/api/v1/oidc/callback")
+	viper.SetDefault(EnvOIDCFrontendURL, "")
+	viper.SetDefault(EnvOIDCStubSubject, "stub-user")
+	viper.SetDefault(EnvOIDCStubEmail, "")
+}
+
+// setWebAuthnDefaults sets default values for passkeys.
+func setWebAuthnDefaults() {
+	viper.SetDefault(EnvWebAuthnRPID, "localhost")
+	viper.SetDefault(EnvWebAuthnRPName, "SPV Wallet")
+	viper.SetDefault(EnvWebAuthnOrigins, []string{"http://localhost:3000"})
+	viper.SetDefault(EnvWebAuthnChallengeTTL, 5*time.Minute)
+}
+
+// setPayoutsDefaults sets default values for payout batches.
+func setPayoutsDefaults() {
+	viper.SetDefault(EnvPayoutsChunkSize, 20)
+	viper.SetDefault(EnvPayoutsMaxRows, 1000)
+}
+
+// setTransactionsDefaults sets default values for transactions.
+func setTransactionsDefaults() {
+	viper.SetDefault(EnvTransactionsPreviewTTL, 2*time.Minute)
+	viper.SetDefault(EnvTransactionsRecordWindow, 10*time.Minute)
+	viper.SetDefault(EnvTransactionsOutboxInterval, 10*time.Second)
+	viper.SetDefault(EnvTransactionsOutboxMaxBackoff, 2*time.Minute)
+	viper.SetDefault(EnvTransactionsIdempotencyKeyTTL, 24*time.Hour)
+	viper.SetDefault(EnvTransactionsIdempotencyKeyLease, time.Minute)
+}
+
+// setTwoFactorDefaults sets default values for two-factor authentication.
+func setTwoFactorDefaults() {
+	viper.SetDefault(EnvTwoFactorIssuer, "SPV Wallet")
+}
+
+// setKeyCustodyDefaults sets default values for key custody.
+func setKeyCustodyDefaults() {
+	viper.SetDefault(EnvKeyCustodyProvider, "none")
+	viper.SetDefault(EnvKeyCustodyLocalKekPath, "")
+	viper.SetDefault(EnvKeyCustodyVaultAddress, "http://localhost:8200")
+	viper.SetDefault(EnvKeyCustodyVaultToken, "")
+	viper.SetDefault(EnvKeyCustodyVaultKeyName, "spv-wallet-web-backend")
+}
+
 func setLoggingDefaults() {
 	viper.SetDefault(EnvLoggingLevel, "Debug")
 	viper.SetDefault(EnvLoggingInstanceName, "spv-wallet-web-backend")
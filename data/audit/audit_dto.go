@@ -0,0 +1,44 @@
+package audit
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
+)
+
+// EntryDto is a struct that represent security audit log database record.
+type EntryDto struct {
+	ID        int64          `db:"id"`
+	UserID    sql.NullInt64  `db:"user_id"`
+	Actor     string         `db:"actor"`
+	IP        sql.NullString `db:"ip"`
+	Action    string         `db:"action"`
+	Target    sql.NullString `db:"target"`
+	Outcome   string         `db:"outcome"`
+	Details   sql.NullString `db:"details"`
+	CreatedAt time.Time      `db:"created_at"`
+	PrevHash  sql.NullString `db:"prev_hash"`
+	Hash      sql.NullString `db:"hash"`
+}
+
+// toEntry converts EntryDto to Entry.
+func (dto *EntryDto) toEntry() *audit.Entry {
+	entry := &audit.Entry{
+		ID:        dto.ID,
+		Actor:     dto.Actor,
+		IP:        dto.IP.String,
+		Action:    dto.Action,
+		Target:    dto.Target.String,
+		Outcome:   dto.Outcome,
+		Details:   dto.Details.String,
+		CreatedAt: dto.CreatedAt,
+		PrevHash:  dto.PrevHash.String,
+		Hash:      dto.Hash.String,
+	}
+	if dto.UserID.Valid {
+		userID := int(dto.UserID.Int64)
+		entry.UserID = &userID
+	}
+	return entry
+}
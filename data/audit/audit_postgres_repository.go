@@ -0,0 +1,189 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
+	"github.com/pkg/errors"
+)
+
+// chainLockKey is a key of the advisory lock which serializes appending of hash-chained entries,
+// so two entries are never chained to the same previous entry, even if more instances are running.
+// The lock is held only for reading the last hash and inserting the entry, entries which are not chained don't take it.
+const chainLockKey = 0x61756469746c6f67 // "auditlog"
+
+const (
+	postgresLockChain = `
+	SELECT pg_advisory_xact_lock($1)
+	`
+
+	postgresGetLastHash = `
+	SELECT hash
+	FROM audit_log
+	WHERE hash IS NOT NULL
+	ORDER BY id DESC
+	LIMIT 1
+	`
+
+	postgresInsertEntry = `
+	INSERT INTO audit_log(user_id, actor, ip, action, target, outcome, details, created_at, prev_hash, hash)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	RETURNING id
+	`
+
+	postgresGetUserEntries = `
+	SELECT id, user_id, actor, ip, action, target, outcome, details, created_at, prev_hash, hash
+	FROM audit_log
+	WHERE user_id = $1
+	ORDER BY id DESC
+	LIMIT $2 OFFSET $3
+	`
+
+	postgresGetEntries = `
+	SELECT id, user_id, actor, ip, action, target, outcome, details, created_at, prev_hash, hash
+	FROM audit_log
+	WHERE $1::INTEGER IS NULL OR user_id = $1
+	ORDER BY id DESC
+	LIMIT $2 OFFSET $3
+	`
+
+	postgresGetEntriesAfter = `
+	SELECT id, user_id, actor, ip, action, target, outcome, details, created_at, prev_hash, hash
+	FROM audit_log
+	WHERE id > $1
+	ORDER BY id
+	LIMIT $2
+	`
+)
+
+// rowQuerier is implemented by both *sql.DB and *sql.Tx.
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Repository is a repository for the security audit log.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewAuditRepository creates a new audit repository.
+func NewAuditRepository(db *sql.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// InsertEntry appends entry to the audit log and sets its id. If chained is true, entry hash is computed
+// from the hash of the last chained entry and both are stored with it. Chained entries are appended one at a time,
+// entries which are not chained are inserted concurrently.
+func (r *Repository) InsertEntry(ctx context.Context, entry *audit.Entry, chained bool) error {
+	if !chained {
+		return r.insertEntry(ctx, r.db, entry, sql.NullString{}, sql.NullString{})
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = tx.ExecContext(ctx, postgresLockChain, chainLockKey); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+
+	prevHash := sql.NullString{}
+	err = tx.QueryRowContext(ctx, postgresGetLastHash).Scan(&prevHash)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return errors.Wrap(err, "internal error")
+	}
+
+	entry.PrevHash = prevHash.String
+	entry.Hash = entry.ComputeHash(entry.PrevHash)
+	if err = r.insertEntry(ctx, tx, entry, sql.NullString{String: entry.PrevHash, Valid: true}, sql.NullString{String: entry.Hash, Valid: true}); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// insertEntry inserts entry with given hashes and sets its id.
+func (r *Repository) insertEntry(ctx context.Context, q rowQuerier, entry *audit.Entry, prevHash, hash sql.NullString) error {
+	row := q.QueryRowContext(ctx, postgresInsertEntry,
+		toNullInt64(entry.UserID),
+		entry.Actor,
+		toNullString(entry.IP),
+		entry.Action,
+		toNullString(entry.Target),
+		entry.Outcome,
+		toNullString(entry.Details),
+		entry.CreatedAt,
+		prevHash,
+		hash,
+	)
+	return errors.Wrap(row.Scan(&entry.ID), "internal error")
+}
+
+// GetUserEntries returns entries of actions performed by the user, newest first.
+func (r *Repository) GetUserEntries(ctx context.Context, userID, limit, offset int) ([]*audit.Entry, error) {
+	return r.getEntries(ctx, postgresGetUserEntries, userID, limit, offset)
+}
+
+// GetEntries returns entries, newest first. If userID is not nil, only entries of actions performed by the user are returned.
+func (r *Repository) GetEntries(ctx context.Context, userID *int, limit, offset int) ([]*audit.Entry, error) {
+	return r.getEntries(ctx, postgresGetEntries, toNullInt64(userID), limit, offset)
+}
+
+// GetEntriesAfter returns entries with id greater than afterID, oldest first.
+func (r *Repository) GetEntriesAfter(ctx context.Context, afterID int64, limit int) ([]*audit.Entry, error) {
+	return r.getEntries(ctx, postgresGetEntriesAfter, afterID, limit)
+}
+
+func (r *Repository) getEntries(ctx context.Context, query string, args ...any) ([]*audit.Entry, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:all
+
+	entries := make([]*audit.Entry, 0)
+	for rows.Next() {
+		var dto EntryDto
+		if err = rows.Scan(
+			&dto.ID,
+			&dto.UserID,
+			&dto.Actor,
+			&dto.IP,
+			&dto.Action,
+			&dto.Target,
+			&dto.Outcome,
+			&dto.Details,
+			&dto.CreatedAt,
+			&dto.PrevHash,
+			&dto.Hash,
+		); err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		entries = append(entries, dto.toEntry())
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return entries, nil
+}
+
+func toNullInt64(v *int) sql.NullInt64 {
+	if v == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*v), Valid: true}
+}
+
+func toNullString(v string) sql.NullString {
+	return sql.NullString{String: v, Valid: v != ""}
+}
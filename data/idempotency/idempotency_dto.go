@@ -0,0 +1,36 @@
+package idempotency
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/idempotency"
+)
+
+// KeyDto is a struct that represent idempotency key database record.
+type KeyDto struct {
+	UserID        int            `db:"user_id"`
+	Key           string         `db:"key"`
+	Fingerprint   string         `db:"fingerprint"`
+	PreviewToken  string         `db:"preview_token"`
+	DraftID       sql.NullString `db:"draft_id"`
+	TransactionID sql.NullString `db:"transaction_id"`
+	CreatedAt     time.Time      `db:"created_at"`
+	ExpiresAt     time.Time      `db:"expires_at"`
+	LockedUntil   time.Time      `db:"locked_until"`
+}
+
+// toKey converts KeyDto to Key.
+func (k *KeyDto) toKey() *idempotency.Key {
+	return &idempotency.Key{
+		UserID:        k.UserID,
+		Key:           k.Key,
+		Fingerprint:   k.Fingerprint,
+		PreviewToken:  k.PreviewToken,
+		DraftID:       k.DraftID.String,
+		TransactionID: k.TransactionID.String,
+		CreatedAt:     k.CreatedAt,
+		ExpiresAt:     k.ExpiresAt,
+		LockedUntil:   k.LockedUntil,
+	}
+}
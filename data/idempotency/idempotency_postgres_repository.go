@@ -0,0 +1,108 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/idempotency"
+	"github.com/pkg/errors"
+)
+
+const (
+	postgresReserveKey = `
+	INSERT INTO idempotency_keys(user_id, key, fingerprint, preview_token, created_at, expires_at, locked_until)
+	VALUES($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT (user_id, key) DO UPDATE
+	SET fingerprint = EXCLUDED.fingerprint, preview_token = EXCLUDED.preview_token, draft_id = NULL, transaction_id = NULL,
+		created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at, locked_until = EXCLUDED.locked_until
+	WHERE idempotency_keys.expires_at < EXCLUDED.created_at
+		OR (idempotency_keys.draft_id IS NULL AND idempotency_keys.locked_until < EXCLUDED.created_at
+			AND idempotency_keys.fingerprint = EXCLUDED.fingerprint
+			AND EXISTS (
+				SELECT 1
+				FROM transaction_previews
+				WHERE token = idempotency_keys.preview_token AND user_id = idempotency_keys.user_id
+					AND NOT sending AND expires_at > EXCLUDED.created_at
+			))
+	`
+
+	postgresGetKey = `
+	SELECT user_id, key, fingerprint, preview_token, draft_id, transaction_id, created_at, expires_at, locked_until
+	FROM idempotency_keys
+	WHERE user_id = $1 AND key = $2
+	`
+
+	postgresCompleteKey = `
+	UPDATE idempotency_keys
+	SET draft_id = $3, transaction_id = $4
+	WHERE user_id = $1 AND key = $2
+	`
+
+	postgresDeleteKey = `
+	DELETE FROM idempotency_keys
+	WHERE user_id = $1 AND key = $2 AND draft_id IS NULL
+	`
+
+	postgresDeleteExpiredKeys = `
+	DELETE FROM idempotency_keys
+	WHERE expires_at < $1
+	`
+)
+
+// Repository is a repository for idempotency keys.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewIdempotencyRepository creates a new idempotency keys repository.
+func NewIdempotencyRepository(db *sql.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// ReserveKey inserts the key, replaces the expired one or takes over the key of the same request whose lease ended
+// while its preview can still be sent. It returns false if the key exists and it's still valid.
+func (r *Repository) ReserveKey(ctx context.Context, key *idempotency.Key) (bool, error) {
+	result, err := r.db.ExecContext(ctx, postgresReserveKey, key.UserID, key.Key, key.Fingerprint, key.PreviewToken, key.CreatedAt, key.ExpiresAt, key.LockedUntil)
+	if err != nil {
+		return false, errors.Wrap(err, "internal error")
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "internal error")
+	}
+	return affected == 1, nil
+}
+
+// GetKey returns key of the user. Can return nil key without an error - if no rows found.
+func (r *Repository) GetKey(ctx context.Context, userID int, key string) (*idempotency.Key, error) {
+	var dto KeyDto
+	row := r.db.QueryRowContext(ctx, postgresGetKey, userID, key)
+	if err := row.Scan(&dto.UserID, &dto.Key, &dto.Fingerprint, &dto.PreviewToken, &dto.DraftID, &dto.TransactionID, &dto.CreatedAt, &dto.ExpiresAt, &dto.LockedUntil); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return dto.toKey(), nil
+}
+
+// CompleteKey sets draft and transaction id of the sent transaction.
+func (r *Repository) CompleteKey(ctx context.Context, userID int, key, draftID, transactionID string) error {
+	_, err := r.db.ExecContext(ctx, postgresCompleteKey, userID, key, draftID, transactionID)
+	return errors.Wrap(err, "internal error")
+}
+
+// DeleteKey deletes the key which is still in progress, completed key is kept until it expires.
+func (r *Repository) DeleteKey(ctx context.Context, userID int, key string) error {
+	_, err := r.db.ExecContext(ctx, postgresDeleteKey, userID, key)
+	return errors.Wrap(err, "internal error")
+}
+
+// DeleteExpiredKeys deletes keys which expired before now.
+func (r *Repository) DeleteExpiredKeys(ctx context.Context, now time.Time) error {
+	_, err := r.db.ExecContext(ctx, postgresDeleteExpiredKeys, now)
+	return errors.Wrap(err, "internal error")
+}
@@ -0,0 +1,44 @@
+package identities
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/identities"
+)
+
+// IdentityDto is a struct that represent user identity database record.
+type IdentityDto struct {
+	ID          int            `db:"id"`
+	UserID      int            `db:"user_id"`
+	Issuer      string         `db:"issuer"`
+	Subject     string         `db:"subject"`
+	Email       sql.NullString `db:"email"`
+	AccessKeyID string         `db:"access_key_id"`
+	AccessKey   string         `db:"access_key"`
+	CreatedAt   time.Time      `db:"created_at"`
+	LastUsedAt  sql.NullTime   `db:"last_used_at"`
+}
+
+// toIdentity converts IdentityDto to Identity.
+func (i *IdentityDto) toIdentity() *identities.Identity {
+	identity := &identities.Identity{
+		ID:          i.ID,
+		UserID:      i.UserID,
+		Issuer:      i.Issuer,
+		Subject:     i.Subject,
+		Email:       i.Email.String,
+		AccessKeyID: i.AccessKeyID,
+		AccessKey:   i.AccessKey,
+		CreatedAt:   i.CreatedAt,
+	}
+	if i.LastUsedAt.Valid {
+		identity.LastUsedAt = &i.LastUsedAt.Time
+	}
+	return identity
+}
+
+// scan reads the identity from the row, columns must be selected in the order of identityColumns.
+func (i *IdentityDto) scan(row interface{ Scan(dest ...any) error }) error {
+	return row.Scan(&i.ID, &i.UserID, &i.Issuer, &i.Subject, &i.Email, &i.AccessKeyID, &i.AccessKey, &i.CreatedAt, &i.LastUsedAt) //nolint:wrapcheck // error wrapped higher in call stack
+}
@@ -0,0 +1,138 @@
+package identities
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/identities"
+	"github.com/pkg/errors"
+)
+
+const identityColumns = `id, user_id, issuer, subject, email, access_key_id, access_key, created_at, last_used_at`
+
+const (
+	postgresInsertIdentity = `
+	INSERT INTO user_identities(user_id, issuer, subject, email, access_key_id, access_key, created_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7)
+	RETURNING id
+	`
+
+	postgresGetIdentity = `
+	SELECT ` + identityColumns + `
+	FROM user_identities
+	WHERE issuer = $1 AND subject = $2
+	`
+
+	postgresGetUserIdentities = `
+	SELECT ` + identityColumns + `
+	FROM user_identities
+	WHERE user_id = $1
+	ORDER BY created_at
+	`
+
+	postgresUpdateIdentityAccessKey = `
+	UPDATE user_identities
+	SET access_key_id = $2, access_key = $3
+	WHERE id = $1
+	`
+
+	postgresTouchIdentity = `
+	UPDATE user_identities
+	SET last_used_at = $2
+	WHERE id = $1
+	`
+
+	postgresDeleteIdentity = `
+	DELETE FROM user_identities
+	WHERE user_id = $1 AND id = $2
+	RETURNING ` + identityColumns
+)
+
+// Repository is a repository for user identities.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewIdentitiesRepository creates a new identities repository.
+func NewIdentitiesRepository(db *sql.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// InsertIdentity inserts an identity to db and sets its id.
+func (r *Repository) InsertIdentity(ctx context.Context, identity *identities.Identity) error {
+	row := r.db.QueryRowContext(ctx, postgresInsertIdentity,
+		identity.UserID,
+		identity.Issuer,
+		identity.Subject,
+		sql.NullString{String: identity.Email, Valid: identity.Email != ""},
+		identity.AccessKeyID,
+		identity.AccessKey,
+		identity.CreatedAt,
+	)
+	if err := row.Scan(&identity.ID); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// GetIdentity returns identity by issuer and subject. Can return nil identity without an error - if no rows found.
+func (r *Repository) GetIdentity(ctx context.Context, issuer, subject string) (*identities.Identity, error) {
+	return r.getIdentity(r.db.QueryRowContext(ctx, postgresGetIdentity, issuer, subject))
+}
+
+// GetUserIdentities returns all identities of the user.
+func (r *Repository) GetUserIdentities(ctx context.Context, userID int) ([]*identities.Identity, error) {
+	rows, err := r.db.QueryContext(ctx, postgresGetUserIdentities, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:all
+
+	result := make([]*identities.Identity, 0)
+	for rows.Next() {
+		var identity IdentityDto
+		if err = identity.scan(rows); err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		result = append(result, identity.toIdentity())
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return result, nil
+}
+
+// UpdateIdentityAccessKey replaces access key of the identity.
+func (r *Repository) UpdateIdentityAccessKey(ctx context.Context, id int, accessKeyID, accessKey string) error {
+	if _, err := r.db.ExecContext(ctx, postgresUpdateIdentityAccessKey, id, accessKeyID, accessKey); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// TouchIdentity updates time of the last sign-in with the identity.
+func (r *Repository) TouchIdentity(ctx context.Context, id int, usedAt time.Time) error {
+	if _, err := r.db.ExecContext(ctx, postgresTouchIdentity, id, usedAt); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// DeleteIdentity deletes identity of the user and returns it. Can return nil identity without an error - if no rows found.
+func (r *Repository) DeleteIdentity(ctx context.Context, userID, id int) (*identities.Identity, error) {
+	return r.getIdentity(r.db.QueryRowContext(ctx, postgresDeleteIdentity, userID, id))
+}
+
+func (r *Repository) getIdentity(row *sql.Row) (*identities.Identity, error) {
+	var identity IdentityDto
+	if err := identity.scan(row); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return identity.toIdentity(), nil
+}
@@ -0,0 +1,88 @@
+package lockout
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	postgresGetLockedUntil = `
+	SELECT locked_until
+	FROM sign_in_attempts
+	WHERE key = $1
+	`
+
+	postgresRecordFailure = `
+	INSERT INTO sign_in_attempts(key, failures, last_failure_at)
+	VALUES($1, 1, $2)
+	ON CONFLICT (key) DO UPDATE
+	SET failures = CASE WHEN sign_in_attempts.last_failure_at < $3 THEN 1 ELSE sign_in_attempts.failures + 1 END,
+		last_failure_at = $2
+	RETURNING failures
+	`
+
+	postgresLock = `
+	UPDATE sign_in_attempts
+	SET locked_until = GREATEST(COALESCE(locked_until, $2), $2)
+	WHERE key = $1
+	`
+
+	postgresReset = `
+	DELETE FROM sign_in_attempts
+	WHERE key = $1
+	`
+)
+
+// Repository is a postgres repository for failed sign-in attempts counters.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewLockoutRepository creates a new lockout repository.
+func NewLockoutRepository(db *sql.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// GetLockedUntil returns time until which the key is locked, zero time if it's not locked.
+func (r *Repository) GetLockedUntil(ctx context.Context, key string) (time.Time, error) {
+	var lockedUntil sql.NullTime
+	row := r.db.QueryRowContext(ctx, postgresGetLockedUntil, key)
+	if err := row.Scan(&lockedUntil); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, errors.Wrap(err, "internal error")
+	}
+	return lockedUntil.Time, nil
+}
+
+// RecordFailure increments failures counter of the key and returns its new value.
+func (r *Repository) RecordFailure(ctx context.Context, key string, now, resetBefore time.Time) (int, error) {
+	var failures int
+	row := r.db.QueryRowContext(ctx, postgresRecordFailure, key, now, resetBefore)
+	if err := row.Scan(&failures); err != nil {
+		return 0, errors.Wrap(err, "internal error")
+	}
+	return failures, nil
+}
+
+// Lock locks the key until given time. Longer existing lock is kept.
+func (r *Repository) Lock(ctx context.Context, key string, until time.Time) error {
+	if _, err := r.db.ExecContext(ctx, postgresLock, key, until); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// Reset removes counter and lock of the key.
+func (r *Repository) Reset(ctx context.Context, key string) error {
+	if _, err := r.db.ExecContext(ctx, postgresReset, key); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package operators
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/operators"
+)
+
+// OperatorDto is a struct that represent operator database record.
+type OperatorDto struct {
+	ID           int          `db:"id"`
+	Email        string       `db:"email"`
+	PasswordHash string       `db:"password_hash"`
+	Role         string       `db:"role"`
+	CreatedAt    time.Time    `db:"created_at"`
+	DisabledAt   sql.NullTime `db:"disabled_at"`
+}
+
+// toOperator converts OperatorDto to Operator.
+func (dto *OperatorDto) toOperator() *operators.Operator {
+	operator := &operators.Operator{
+		ID:           dto.ID,
+		Email:        dto.Email,
+		PasswordHash: dto.PasswordHash,
+		Role:         dto.Role,
+		CreatedAt:    dto.CreatedAt,
+	}
+	if dto.DisabledAt.Valid {
+		operator.DisabledAt = &dto.DisabledAt.Time
+	}
+	return operator
+}
+
+// AuditEntryDto is a struct that represent admin audit log database record.
+type AuditEntryDto struct {
+	ID            int64          `db:"id"`
+	OperatorID    sql.NullInt64  `db:"operator_id"`
+	OperatorEmail string         `db:"operator_email"`
+	IP            sql.NullString `db:"ip"`
+	Action        string         `db:"action"`
+	TargetUserID  sql.NullInt64  `db:"target_user_id"`
+	Outcome       string         `db:"outcome"`
+	Details       sql.NullString `db:"details"`
+	CreatedAt     time.Time      `db:"created_at"`
+}
+
+// toAuditEntry converts AuditEntryDto to AuditEntry.
+func (dto *AuditEntryDto) toAuditEntry() *operators.AuditEntry {
+	entry := &operators.AuditEntry{
+		ID:            dto.ID,
+		OperatorID:    int(dto.OperatorID.Int64),
+		OperatorEmail: dto.OperatorEmail,
+		IP:            dto.IP.String,
+		Action:        dto.Action,
+		Outcome:       dto.Outcome,
+		Details:       dto.Details.String,
+		CreatedAt:     dto.CreatedAt,
+	}
+	if dto.TargetUserID.Valid {
+		targetUserID := int(dto.TargetUserID.Int64)
+		entry.TargetUserID = &targetUserID
+	}
+	return entry
+}
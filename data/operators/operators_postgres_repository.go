@@ -0,0 +1,202 @@
+package operators
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/operators"
+	"github.com/pkg/errors"
+)
+
+const (
+	postgresInsertOperator = `
+	INSERT INTO operators(email, password_hash, role, created_at)
+	VALUES($1, $2, $3, $4)
+	RETURNING id
+	`
+
+	postgresGetOperatorByEmail = `
+	SELECT id, email, password_hash, role, created_at, disabled_at
+	FROM operators
+	WHERE email = $1
+	`
+
+	postgresGetRoles = `
+	SELECT name, description
+	FROM operator_roles
+	ORDER BY name
+	`
+
+	postgresInsertSession = `
+	INSERT INTO operator_sessions(token_hash, operator_id, ip, created_at, expires_at)
+	VALUES($1, $2, $3, $4, $5)
+	`
+
+	postgresGetOperatorBySession = `
+	SELECT o.id, o.email, o.password_hash, o.role, o.created_at, o.disabled_at
+	FROM operator_sessions s
+	JOIN operators o ON o.id = s.operator_id
+	WHERE s.token_hash = $1 AND s.expires_at > $2
+	`
+
+	postgresDeleteSession = `
+	DELETE FROM operator_sessions
+	WHERE token_hash = $1
+	`
+
+	postgresDeleteExpiredSessions = `
+	DELETE FROM operator_sessions
+	WHERE expires_at <= $1
+	`
+
+	postgresInsertAuditEntry = `
+	INSERT INTO admin_audit_log(operator_id, operator_email, ip, action, target_user_id, outcome, details, created_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING id
+	`
+
+	postgresGetAuditEntries = `
+	SELECT id, operator_id, operator_email, ip, action, target_user_id, outcome, details, created_at
+	FROM admin_audit_log
+	WHERE $1::INTEGER IS NULL OR target_user_id = $1
+	ORDER BY id DESC
+	LIMIT $2 OFFSET $3
+	`
+)
+
+// Repository is a repository for operators, their sessions and the admin audit log.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewOperatorsRepository creates a new operators repository.
+func NewOperatorsRepository(db *sql.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// InsertOperator inserts an operator to db and sets its id.
+func (r *Repository) InsertOperator(ctx context.Context, operator *operators.Operator) error {
+	row := r.db.QueryRowContext(ctx, postgresInsertOperator, operator.Email, operator.PasswordHash, operator.Role, operator.CreatedAt)
+	if err := row.Scan(&operator.ID); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// GetOperatorByEmail returns operator by email. Can return nil operator without an error - if no rows found.
+func (r *Repository) GetOperatorByEmail(ctx context.Context, email string) (*operators.Operator, error) {
+	return r.getOperator(ctx, postgresGetOperatorByEmail, email)
+}
+
+// GetRoles returns all operator roles.
+func (r *Repository) GetRoles(ctx context.Context) ([]*operators.Role, error) {
+	rows, err := r.db.QueryContext(ctx, postgresGetRoles)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:all
+
+	roles := make([]*operators.Role, 0)
+	for rows.Next() {
+		var role operators.Role
+		if err = rows.Scan(&role.Name, &role.Description); err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		roles = append(roles, &role)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return roles, nil
+}
+
+// InsertSession inserts operator session identified by hash of its token.
+func (r *Repository) InsertSession(ctx context.Context, tokenHash string, operatorID int, ip string, createdAt, expiresAt time.Time) error {
+	if _, err := r.db.ExecContext(ctx, postgresInsertSession, tokenHash, operatorID, ip, createdAt, expiresAt); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// GetOperatorBySession returns operator of not expired session. Can return nil operator without an error - if no rows found.
+func (r *Repository) GetOperatorBySession(ctx context.Context, tokenHash string, now time.Time) (*operators.Operator, error) {
+	return r.getOperator(ctx, postgresGetOperatorBySession, tokenHash, now)
+}
+
+// DeleteSession deletes operator session.
+func (r *Repository) DeleteSession(ctx context.Context, tokenHash string) error {
+	if _, err := r.db.ExecContext(ctx, postgresDeleteSession, tokenHash); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// DeleteExpiredSessions deletes operator sessions which expired.
+func (r *Repository) DeleteExpiredSessions(ctx context.Context, now time.Time) error {
+	if _, err := r.db.ExecContext(ctx, postgresDeleteExpiredSessions, now); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// InsertAuditEntry appends entry to the admin audit log and sets its id.
+func (r *Repository) InsertAuditEntry(ctx context.Context, entry *operators.AuditEntry) error {
+	row := r.db.QueryRowContext(ctx, postgresInsertAuditEntry,
+		sql.NullInt64{Int64: int64(entry.OperatorID), Valid: entry.OperatorID != 0},
+		entry.OperatorEmail,
+		sql.NullString{String: entry.IP, Valid: entry.IP != ""},
+		entry.Action,
+		toNullInt64(entry.TargetUserID),
+		entry.Outcome,
+		sql.NullString{String: entry.Details, Valid: entry.Details != ""},
+		entry.CreatedAt,
+	)
+	if err := row.Scan(&entry.ID); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// GetAuditEntries returns page of the admin audit log, newest first. Entries are limited to target user if it's given.
+func (r *Repository) GetAuditEntries(ctx context.Context, targetUserID *int, limit, offset int) ([]*operators.AuditEntry, error) {
+	rows, err := r.db.QueryContext(ctx, postgresGetAuditEntries, toNullInt64(targetUserID), limit, offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:all
+
+	entries := make([]*operators.AuditEntry, 0)
+	for rows.Next() {
+		var entry AuditEntryDto
+		if err = rows.Scan(&entry.ID, &entry.OperatorID, &entry.OperatorEmail, &entry.IP, &entry.Action, &entry.TargetUserID, &entry.Outcome, &entry.Details, &entry.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		entries = append(entries, entry.toAuditEntry())
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return entries, nil
+}
+
+func (r *Repository) getOperator(ctx context.Context, query string, args ...any) (*operators.Operator, error) {
+	var operator OperatorDto
+	row := r.db.QueryRowContext(ctx, query, args...)
+	if err := row.Scan(&operator.ID, &operator.Email, &operator.PasswordHash, &operator.Role, &operator.CreatedAt, &operator.DisabledAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return operator.toOperator(), nil
+}
+
+func toNullInt64(v *int) sql.NullInt64 {
+	if v == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*v), Valid: true}
+}
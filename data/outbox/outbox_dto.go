@@ -0,0 +1,64 @@
+package outbox
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/outbox"
+)
+
+// EntryDto is a struct that represent transaction outbox database record.
+type EntryDto struct {
+	ID            int            `db:"id"`
+	UserID        int            `db:"user_id"`
+	DraftID       string         `db:"draft_id"`
+	TransactionID string         `db:"transaction_id"`
+	Hex           string         `db:"hex"`
+	Metadata      []byte         `db:"metadata"`
+	TotalValue    int64          `db:"total_value"`
+	Fee           int64          `db:"fee"`
+	AccessKeyID   string         `db:"access_key_id"`
+	AccessKey     string         `db:"access_key"`
+	Status        string         `db:"status"`
+	Attempts      int            `db:"attempts"`
+	LastError     sql.NullString `db:"last_error"`
+	NextAttemptAt time.Time      `db:"next_attempt_at"`
+	ExpiresAt     time.Time      `db:"expires_at"`
+	CreatedAt     time.Time      `db:"created_at"`
+	UpdatedAt     time.Time      `db:"updated_at"`
+}
+
+// toEntry converts EntryDto to Entry.
+func (e *EntryDto) toEntry() (*outbox.Entry, error) {
+	var metadata map[string]any
+	if err := json.Unmarshal(e.Metadata, &metadata); err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	return &outbox.Entry{
+		ID:            e.ID,
+		UserID:        e.UserID,
+		DraftID:       e.DraftID,
+		TransactionID: e.TransactionID,
+		Hex:           e.Hex,
+		Metadata:      metadata,
+		TotalValue:    uint64(e.TotalValue),
+		Fee:           uint64(e.Fee),
+		AccessKeyID:   e.AccessKeyID,
+		AccessKey:     e.AccessKey,
+		Status:        e.Status,
+		Attempts:      e.Attempts,
+		LastError:     e.LastError.String,
+		NextAttemptAt: e.NextAttemptAt,
+		ExpiresAt:     e.ExpiresAt,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}, nil
+}
+
+// scan reads the entry from the row, columns must be selected in the order of entryColumns.
+func (e *EntryDto) scan(row interface{ Scan(dest ...any) error }) error {
+	return row.Scan(&e.ID, &e.UserID, &e.DraftID, &e.TransactionID, &e.Hex, &e.Metadata, &e.TotalValue, &e.Fee, &e.AccessKeyID, &e.AccessKey, //nolint:wrapcheck // error wrapped higher in call stack
+		&e.Status, &e.Attempts, &e.LastError, &e.NextAttemptAt, &e.ExpiresAt, &e.CreatedAt, &e.UpdatedAt)
+}
@@ -0,0 +1,153 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/outbox"
+	"github.com/pkg/errors"
+)
+
+const entryColumns = `id, user_id, draft_id, transaction_id, hex, metadata, total_value, fee, access_key_id, access_key,
+	status, attempts, last_error, next_attempt_at, expires_at, created_at, updated_at`
+
+const (
+	postgresInsertEntry = `
+	INSERT INTO transaction_outbox(user_id, draft_id, transaction_id, hex, metadata, total_value, fee, access_key_id, access_key,
+		status, attempts, next_attempt_at, expires_at, created_at, updated_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	RETURNING id
+	`
+
+	postgresClaimDueEntries = `
+	UPDATE transaction_outbox
+	SET attempts = attempts + 1, next_attempt_at = $2, updated_at = $1
+	WHERE id IN (
+		SELECT id
+		FROM transaction_outbox
+		WHERE status = 'pending' AND next_attempt_at <= $1
+		ORDER BY next_attempt_at
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING ` + entryColumns
+
+	postgresUpdateEntry = `
+	UPDATE transaction_outbox
+	SET status = $2, last_error = $3, next_attempt_at = $4, updated_at = $5
+	WHERE id = $1
+	`
+
+	postgresGetUserEntries = `
+	SELECT ` + entryColumns + `
+	FROM transaction_outbox
+	WHERE user_id = $1 AND status = $2
+	ORDER BY created_at DESC
+	`
+
+	postgresGetUserEntry = `
+	SELECT ` + entryColumns + `
+	FROM transaction_outbox
+	WHERE user_id = $1 AND transaction_id = $2
+	`
+)
+
+// Repository is a repository for transaction outbox.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewOutboxRepository creates a new transaction outbox repository.
+func NewOutboxRepository(db *sql.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// InsertEntry inserts the entry and sets its id.
+func (r *Repository) InsertEntry(ctx context.Context, entry *outbox.Entry) error {
+	metadata, err := json.Marshal(entry.Metadata)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+
+	row := r.db.QueryRowContext(ctx, postgresInsertEntry,
+		entry.UserID,
+		entry.DraftID,
+		entry.TransactionID,
+		entry.Hex,
+		metadata,
+		int64(entry.TotalValue),
+		int64(entry.Fee),
+		entry.AccessKeyID,
+		entry.AccessKey,
+		entry.Status,
+		entry.Attempts,
+		entry.NextAttemptAt,
+		entry.ExpiresAt,
+		entry.CreatedAt,
+		entry.UpdatedAt,
+	)
+	err = row.Scan(&entry.ID)
+	return errors.Wrap(err, "internal error")
+}
+
+// ClaimDueEntries increments attempts of pending entries whose next attempt is due and postpones their next attempt by lease.
+// Entries claimed at the same time by another instance are skipped.
+func (r *Repository) ClaimDueEntries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*outbox.Entry, error) {
+	return r.queryEntries(ctx, postgresClaimDueEntries, now, now.Add(lease), limit)
+}
+
+// UpdateEntry sets status, last error and next attempt of the entry.
+func (r *Repository) UpdateEntry(ctx context.Context, entry *outbox.Entry) error {
+	lastError := sql.NullString{String: entry.LastError, Valid: entry.LastError != ""}
+	_, err := r.db.ExecContext(ctx, postgresUpdateEntry, entry.ID, entry.Status, lastError, entry.NextAttemptAt, entry.UpdatedAt)
+	return errors.Wrap(err, "internal error")
+}
+
+// GetUserEntries returns entries of the user with given status, the newest first.
+func (r *Repository) GetUserEntries(ctx context.Context, userID int, status string) ([]*outbox.Entry, error) {
+	return r.queryEntries(ctx, postgresGetUserEntries, userID, status)
+}
+
+// GetUserEntry returns entry of the user by transaction id. Can return nil entry without an error - if no rows found.
+func (r *Repository) GetUserEntry(ctx context.Context, userID int, transactionID string) (*outbox.Entry, error) {
+	var dto EntryDto
+	if err := dto.scan(r.db.QueryRowContext(ctx, postgresGetUserEntry, userID, transactionID)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+
+	entry, err := dto.toEntry()
+	return entry, errors.Wrap(err, "internal error")
+}
+
+func (r *Repository) queryEntries(ctx context.Context, query string, args ...any) ([]*outbox.Entry, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:all
+
+	entries := make([]*outbox.Entry, 0)
+	for rows.Next() {
+		var dto EntryDto
+		if err = dto.scan(rows); err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		entry, err := dto.toEntry()
+		if err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		entries = append(entries, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,52 @@
+package passkeys
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/passkeys"
+	"github.com/lib/pq"
+)
+
+// PasskeyDto is a struct that represent passkey database record.
+type PasskeyDto struct {
+	ID           int            `db:"id"`
+	UserID       int            `db:"user_id"`
+	Name         string         `db:"name"`
+	CredentialID []byte         `db:"credential_id"`
+	PublicKey    []byte         `db:"public_key"`
+	SignCount    int64          `db:"sign_count"`
+	UserHandle   string         `db:"user_handle"`
+	Transports   pq.StringArray `db:"transports"`
+	Xpriv        string         `db:"xpriv"`
+	CreatedAt    time.Time      `db:"created_at"`
+	LastUsedAt   sql.NullTime   `db:"last_used_at"`
+}
+
+// toPasskey converts PasskeyDto to Passkey.
+func (p *PasskeyDto) toPasskey() *passkeys.Passkey {
+	passkey := &passkeys.Passkey{
+		ID:           p.ID,
+		UserID:       p.UserID,
+		Name:         p.Name,
+		CredentialID: p.CredentialID,
+		PublicKey:    p.PublicKey,
+		SignCount:    uint32(p.SignCount), //nolint:gosec // sign count is stored from uint32
+		UserHandle:   p.UserHandle,
+		Transports:   p.Transports,
+		Xpriv:        p.Xpriv,
+		CreatedAt:    p.CreatedAt,
+	}
+	if passkey.Transports == nil {
+		passkey.Transports = []string{}
+	}
+	if p.LastUsedAt.Valid {
+		passkey.LastUsedAt = &p.LastUsedAt.Time
+	}
+	return passkey
+}
+
+// scan reads the passkey from the row, columns must be selected in the order of passkeyColumns.
+func (p *PasskeyDto) scan(row interface{ Scan(dest ...any) error }) error {
+	return row.Scan(&p.ID, &p.UserID, &p.Name, &p.CredentialID, &p.PublicKey, &p.SignCount, &p.UserHandle, &p.Transports, &p.Xpriv, &p.CreatedAt, &p.LastUsedAt) //nolint:wrapcheck // error wrapped higher in call stack
+}
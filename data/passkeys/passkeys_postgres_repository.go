@@ -0,0 +1,131 @@
+package passkeys
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/passkeys"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+const passkeyColumns = `id, user_id, name, credential_id, public_key, sign_count, user_handle, transports, xpriv, created_at, last_used_at`
+
+const (
+	postgresInsertPasskey = `
+	INSERT INTO webauthn_credentials(user_id, name, credential_id, public_key, sign_count, user_handle, transports, xpriv, created_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	ON CONFLICT (credential_id) DO NOTHING
+	RETURNING id
+	`
+
+	postgresGetPasskeyByCredentialID = `
+	SELECT ` + passkeyColumns + `
+	FROM webauthn_credentials
+	WHERE credential_id = $1
+	`
+
+	postgresGetUserPasskeys = `
+	SELECT ` + passkeyColumns + `
+	FROM webauthn_credentials
+	WHERE user_id = $1
+	ORDER BY created_at
+	`
+
+	postgresUpdatePasskeyUse = `
+	UPDATE webauthn_credentials
+	SET sign_count = $2, last_used_at = $3
+	WHERE id = $1
+	`
+
+	postgresDeletePasskey = `
+	DELETE FROM webauthn_credentials
+	WHERE user_id = $1 AND id = $2
+	RETURNING ` + passkeyColumns
+)
+
+// Repository is a repository for passkeys.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewPasskeysRepository creates a new passkeys repository.
+func NewPasskeysRepository(db *sql.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// InsertPasskey inserts a passkey to db and sets its id. False is returned if the credential is already registered.
+func (r *Repository) InsertPasskey(ctx context.Context, passkey *passkeys.Passkey) (bool, error) {
+	row := r.db.QueryRowContext(ctx, postgresInsertPasskey,
+		passkey.UserID,
+		passkey.Name,
+		passkey.CredentialID,
+		passkey.PublicKey,
+		int64(passkey.SignCount),
+		passkey.UserHandle,
+		pq.StringArray(passkey.Transports),
+		passkey.Xpriv,
+		passkey.CreatedAt,
+	)
+	if err := row.Scan(&passkey.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "internal error")
+	}
+	return true, nil
+}
+
+// GetPasskeyByCredentialID returns passkey by id of its credential. Can return nil passkey without an error - if no rows found.
+func (r *Repository) GetPasskeyByCredentialID(ctx context.Context, credentialID []byte) (*passkeys.Passkey, error) {
+	return r.getPasskey(r.db.QueryRowContext(ctx, postgresGetPasskeyByCredentialID, credentialID))
+}
+
+// GetUserPasskeys returns all passkeys of the user.
+func (r *Repository) GetUserPasskeys(ctx context.Context, userID int) ([]*passkeys.Passkey, error) {
+	rows, err := r.db.QueryContext(ctx, postgresGetUserPasskeys, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:all
+
+	result := make([]*passkeys.Passkey, 0)
+	for rows.Next() {
+		var passkey PasskeyDto
+		if err = passkey.scan(rows); err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		result = append(result, passkey.toPasskey())
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return result, nil
+}
+
+// UpdatePasskeyUse updates signature counter and time of the last use of the passkey.
+func (r *Repository) UpdatePasskeyUse(ctx context.Context, id int, signCount uint32, usedAt time.Time) error {
+	if _, err := r.db.ExecContext(ctx, postgresUpdatePasskeyUse, id, int64(signCount), usedAt); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// DeletePasskey deletes passkey of the user and returns it. Can return nil passkey without an error - if no rows found.
+func (r *Repository) DeletePasskey(ctx context.Context, userID, id int) (*passkeys.Passkey, error) {
+	return r.getPasskey(r.db.QueryRowContext(ctx, postgresDeletePasskey, userID, id))
+}
+
+func (r *Repository) getPasskey(row *sql.Row) (*passkeys.Passkey, error) {
+	var passkey PasskeyDto
+	if err := passkey.scan(row); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return passkey.toPasskey(), nil
+}
@@ -0,0 +1,27 @@
+package paymails
+
+import (
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/paymails"
+)
+
+// PaymailDto is a struct that represent user paymail database record.
+type PaymailDto struct {
+	ID        int       `db:"id"`
+	UserID    int       `db:"user_id"`
+	Address   string    `db:"address"`
+	Primary   bool      `db:"is_primary"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// toPaymail converts PaymailDto to Paymail.
+func (p *PaymailDto) toPaymail() *paymails.Paymail {
+	return &paymails.Paymail{
+		ID:        p.ID,
+		UserID:    p.UserID,
+		Address:   p.Address,
+		Primary:   p.Primary,
+		CreatedAt: p.CreatedAt,
+	}
+}
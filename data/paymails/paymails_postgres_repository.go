@@ -0,0 +1,152 @@
+package paymails
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/paymails"
+	"github.com/pkg/errors"
+)
+
+const (
+	postgresInsertPaymail = `
+	INSERT INTO user_paymails(user_id, address, is_primary, created_at)
+	VALUES($1, $2, $3, $4)
+	RETURNING id
+	`
+
+	postgresGetUserPaymails = `
+	SELECT id, user_id, address, is_primary, created_at
+	FROM user_paymails
+	WHERE user_id = $1
+	ORDER BY is_primary DESC, created_at
+	`
+
+	postgresGetUserPaymail = `
+	SELECT id, user_id, address, is_primary, created_at
+	FROM user_paymails
+	WHERE user_id = $1 AND id = $2
+	`
+
+	postgresUnsetPrimaryPaymail = `
+	UPDATE user_paymails
+	SET is_primary = false
+	WHERE user_id = $1 AND is_primary
+	`
+
+	postgresSetPrimaryPaymail = `
+	UPDATE user_paymails
+	SET is_primary = true
+	WHERE user_id = $1 AND id = $2
+	`
+
+	// users.paymail always holds the primary paymail, so the rest of the app keeps using it.
+	postgresUpdateUserPaymail = `
+	UPDATE users
+	SET paymail = (SELECT address FROM user_paymails WHERE user_id = $1 AND id = $2)
+	WHERE id = $1
+	`
+
+	postgresDeletePaymail = `
+	DELETE FROM user_paymails
+	WHERE user_id = $1 AND id = $2 AND NOT is_primary
+	`
+)
+
+// Repository is a repository for user paymails.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewPaymailsRepository creates a new paymails repository.
+func NewPaymailsRepository(db *sql.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// InsertPaymail inserts a paymail to db and sets its id.
+func (r *Repository) InsertPaymail(ctx context.Context, paymail *paymails.Paymail) error {
+	row := r.db.QueryRowContext(ctx, postgresInsertPaymail, paymail.UserID, paymail.Address, paymail.Primary, paymail.CreatedAt)
+	if err := row.Scan(&paymail.ID); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// GetUserPaymails returns all paymails of the user.
+func (r *Repository) GetUserPaymails(ctx context.Context, userID int) ([]*paymails.Paymail, error) {
+	rows, err := r.db.QueryContext(ctx, postgresGetUserPaymails, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:all
+
+	result := make([]*paymails.Paymail, 0)
+	for rows.Next() {
+		var paymail PaymailDto
+		if err = rows.Scan(&paymail.ID, &paymail.UserID, &paymail.Address, &paymail.Primary, &paymail.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		result = append(result, paymail.toPaymail())
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return result, nil
+}
+
+// GetUserPaymail returns paymail of the user by id. Can return nil paymail without an error - if no rows found.
+func (r *Repository) GetUserPaymail(ctx context.Context, userID, id int) (*paymails.Paymail, error) {
+	var paymail PaymailDto
+	row := r.db.QueryRowContext(ctx, postgresGetUserPaymail, userID, id)
+	if err := row.Scan(&paymail.ID, &paymail.UserID, &paymail.Address, &paymail.Primary, &paymail.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return paymail.toPaymail(), nil
+}
+
+// SetPrimaryPaymail makes the paymail primary instead of the current one and stores it as the user paymail.
+func (r *Repository) SetPrimaryPaymail(ctx context.Context, userID, id int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = tx.ExecContext(ctx, postgresUnsetPrimaryPaymail, userID); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+
+	res, err := tx.ExecContext(ctx, postgresSetPrimaryPaymail, userID, id)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	if affected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "internal error")
+	}
+
+	if _, err = tx.ExecContext(ctx, postgresUpdateUserPaymail, userID, id); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+
+	err = tx.Commit()
+	return errors.Wrap(err, "internal error")
+}
+
+// DeletePaymail deletes not primary paymail of the user.
+func (r *Repository) DeletePaymail(ctx context.Context, userID, id int) error {
+	if _, err := r.db.ExecContext(ctx, postgresDeletePaymail, userID, id); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
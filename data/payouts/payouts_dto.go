@@ -0,0 +1,75 @@
+package payouts
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/payouts"
+)
+
+// BatchDto is a struct that represent payout batch database record.
+type BatchDto struct {
+	ID            int       `db:"id"`
+	UserID        int       `db:"user_id"`
+	Status        string    `db:"status"`
+	TotalSatoshis int64     `db:"total_satoshis"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
+
+// toBatch converts BatchDto to Batch.
+func (b *BatchDto) toBatch() *payouts.Batch {
+	return &payouts.Batch{
+		ID:            b.ID,
+		UserID:        b.UserID,
+		Status:        b.Status,
+		TotalSatoshis: uint64(b.TotalSatoshis),
+		CreatedAt:     b.CreatedAt,
+		UpdatedAt:     b.UpdatedAt,
+		Rows:          []*payouts.Row{},
+	}
+}
+
+// scan reads the batch from the row, columns must be selected in the order of batchColumns.
+func (b *BatchDto) scan(row interface{ Scan(dest ...any) error }) error {
+	return row.Scan(&b.ID, &b.UserID, &b.Status, &b.TotalSatoshis, &b.CreatedAt, &b.UpdatedAt) //nolint:wrapcheck // error wrapped higher in call stack
+}
+
+// RowDto is a struct that represent payout row database record.
+type RowDto struct {
+	ID            int            `db:"id"`
+	Number        int            `db:"row_number"`
+	Paymail       string         `db:"paymail"`
+	Satoshis      int64          `db:"satoshis"`
+	Memo          string         `db:"memo"`
+	Status        string         `db:"status"`
+	Reference     sql.NullString `db:"reference"`
+	TransactionID sql.NullString `db:"transaction_id"`
+	Error         sql.NullString `db:"error"`
+	UpdatedAt     time.Time      `db:"updated_at"`
+}
+
+// toRow converts RowDto to Row.
+func (r *RowDto) toRow() *payouts.Row {
+	return &payouts.Row{
+		ID:            r.ID,
+		Number:        r.Number,
+		Paymail:       r.Paymail,
+		Satoshis:      uint64(r.Satoshis),
+		Memo:          r.Memo,
+		Status:        r.Status,
+		Reference:     r.Reference.String,
+		TransactionID: r.TransactionID.String,
+		Error:         r.Error.String,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
+
+// scan reads the payout row from the row, columns must be selected in the order of rowColumns.
+func (r *RowDto) scan(row interface{ Scan(dest ...any) error }) error {
+	return row.Scan(&r.ID, &r.Number, &r.Paymail, &r.Satoshis, &r.Memo, &r.Status, &r.Reference, &r.TransactionID, &r.Error, &r.UpdatedAt) //nolint:wrapcheck // error wrapped higher in call stack
+}
+
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,169 @@
+package payouts
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/payouts"
+	"github.com/pkg/errors"
+)
+
+const (
+	batchColumns = `id, user_id, status, total_satoshis, created_at, updated_at`
+	rowColumns   = `id, row_number, paymail, satoshis, memo, status, reference, transaction_id, error, updated_at`
+)
+
+const (
+	postgresInsertBatch = `
+	INSERT INTO payout_batches(user_id, status, total_satoshis, created_at, updated_at)
+	VALUES($1, $2, $3, $4, $5)
+	RETURNING id
+	`
+
+	postgresInsertRow = `
+	INSERT INTO payout_rows(batch_id, row_number, paymail, satoshis, memo, status, updated_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7)
+	RETURNING id
+	`
+
+	postgresGetBatch = `
+	SELECT ` + batchColumns + `
+	FROM payout_batches
+	WHERE user_id = $1 AND id = $2
+	`
+
+	postgresGetBatchRows = `
+	SELECT ` + rowColumns + `
+	FROM payout_rows
+	WHERE batch_id = $1
+	ORDER BY row_number
+	`
+
+	postgresClaimBatch = `
+	UPDATE payout_batches
+	SET status = 'running', updated_at = $3
+	WHERE user_id = $1 AND id = $2
+	AND (status IN ('draft', 'failed') OR (status = 'running' AND updated_at < $4))
+	`
+
+	postgresUpdateBatchStatus = `
+	UPDATE payout_batches
+	SET status = $2, updated_at = $3
+	WHERE id = $1
+	`
+
+	postgresUpdateRow = `
+	UPDATE payout_rows
+	SET status = $2, reference = $3, transaction_id = $4, error = $5, updated_at = $6
+	WHERE id = $1
+	`
+)
+
+// Repository is a repository for payout batches.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewPayoutsRepository creates a new payout batches repository.
+func NewPayoutsRepository(db *sql.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// InsertBatch inserts the batch with its rows and sets their ids.
+func (r *Repository) InsertBatch(ctx context.Context, batch *payouts.Batch) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	row := tx.QueryRowContext(ctx, postgresInsertBatch, batch.UserID, batch.Status, int64(batch.TotalSatoshis), batch.CreatedAt, batch.UpdatedAt)
+	if err = row.Scan(&batch.ID); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+
+	for _, payout := range batch.Rows {
+		row = tx.QueryRowContext(ctx, postgresInsertRow, batch.ID, payout.Number, payout.Paymail, int64(payout.Satoshis), payout.Memo, payout.Status, payout.UpdatedAt)
+		if err = row.Scan(&payout.ID); err != nil {
+			return errors.Wrap(err, "internal error")
+		}
+	}
+
+	err = tx.Commit()
+	return errors.Wrap(err, "internal error")
+}
+
+// GetBatch returns batch of the user with its rows. Can return nil batch without an error - if no rows found.
+func (r *Repository) GetBatch(ctx context.Context, userID, id int) (*payouts.Batch, error) {
+	var dto BatchDto
+	if err := dto.scan(r.db.QueryRowContext(ctx, postgresGetBatch, userID, id)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	batch := dto.toBatch()
+
+	rows, err := r.db.QueryContext(ctx, postgresGetBatchRows, batch.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:all
+
+	for rows.Next() {
+		var rowDto RowDto
+		if err = rowDto.scan(rows); err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		batch.Rows = append(batch.Rows, rowDto.toRow())
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+
+	return batch, nil
+}
+
+// ClaimBatch sets running status of the batch if it's a draft, it failed or it's running for longer than staleAfter.
+func (r *Repository) ClaimBatch(ctx context.Context, userID, id int, now time.Time, staleAfter time.Duration) (bool, error) {
+	result, err := r.db.ExecContext(ctx, postgresClaimBatch, userID, id, now, now.Add(-staleAfter))
+	if err != nil {
+		return false, errors.Wrap(err, "internal error")
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "internal error")
+	}
+	return affected == 1, nil
+}
+
+// UpdateBatchStatus sets status of the batch.
+func (r *Repository) UpdateBatchStatus(ctx context.Context, id int, status string, updatedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, postgresUpdateBatchStatus, id, status, updatedAt)
+	return errors.Wrap(err, "internal error")
+}
+
+// UpdateRows sets status, reference, transaction id and error of the rows in one db transaction.
+func (r *Repository) UpdateRows(ctx context.Context, rows []*payouts.Row) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, row := range rows {
+		if _, err = tx.ExecContext(ctx, postgresUpdateRow, row.ID, row.Status, toNullString(row.Reference), toNullString(row.TransactionID), toNullString(row.Error), row.UpdatedAt); err != nil {
+			return errors.Wrap(err, "internal error")
+		}
+	}
+
+	err = tx.Commit()
+	return errors.Wrap(err, "internal error")
+}
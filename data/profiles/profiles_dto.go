@@ -0,0 +1,26 @@
+package profiles
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/profiles"
+)
+
+// ProfileDto is a struct that represent user profile database record.
+type ProfileDto struct {
+	UserID     int            `db:"user_id"`
+	PublicName sql.NullString `db:"public_name"`
+	AvatarKey  sql.NullString `db:"avatar_key"`
+	UpdatedAt  time.Time      `db:"updated_at"`
+}
+
+// toProfile converts ProfileDto to Profile.
+func (dto *ProfileDto) toProfile() *profiles.Profile {
+	return &profiles.Profile{
+		UserID:     dto.UserID,
+		PublicName: dto.PublicName.String,
+		AvatarKey:  dto.AvatarKey.String,
+		UpdatedAt:  dto.UpdatedAt,
+	}
+}
@@ -0,0 +1,63 @@
+package profiles
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/profiles"
+	"github.com/pkg/errors"
+)
+
+const (
+	postgresGetProfile = `
+	SELECT user_id, public_name, avatar_key, updated_at
+	FROM user_profiles
+	WHERE user_id = $1
+	`
+
+	postgresUpsertProfile = `
+	INSERT INTO user_profiles(user_id, public_name, avatar_key, updated_at)
+	VALUES($1, $2, $3, $4)
+	ON CONFLICT (user_id) DO UPDATE
+	SET public_name = EXCLUDED.public_name, avatar_key = EXCLUDED.avatar_key, updated_at = EXCLUDED.updated_at
+	`
+)
+
+// Repository is a repository for user profiles.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewProfilesRepository creates a new profiles repository.
+func NewProfilesRepository(db *sql.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// GetProfile returns profile of the user. Can return nil without an error - if no rows found.
+func (r *Repository) GetProfile(ctx context.Context, userID int) (*profiles.Profile, error) {
+	var dto ProfileDto
+	row := r.db.QueryRowContext(ctx, postgresGetProfile, userID)
+	if err := row.Scan(&dto.UserID, &dto.PublicName, &dto.AvatarKey, &dto.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return dto.toProfile(), nil
+}
+
+// UpsertProfile stores profile of the user, replacing the previous one.
+func (r *Repository) UpsertProfile(ctx context.Context, profile *profiles.Profile) error {
+	_, err := r.db.ExecContext(ctx, postgresUpsertProfile,
+		profile.UserID,
+		sql.NullString{String: profile.PublicName, Valid: profile.PublicName != ""},
+		sql.NullString{String: profile.AvatarKey, Valid: profile.AvatarKey != ""},
+		profile.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
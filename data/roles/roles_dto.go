@@ -0,0 +1,26 @@
+package roles
+
+import (
+	"database/sql"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+)
+
+// RolePermissionDto is a struct that represent role joined with one of its permissions.
+// Permission is null if the role has no permissions.
+type RolePermissionDto struct {
+	Name        string         `db:"name"`
+	Description string         `db:"description"`
+	IsDefault   bool           `db:"is_default"`
+	Permission  sql.NullString `db:"permission"`
+}
+
+// toRole converts RolePermissionDto to Role without permissions.
+func (dto *RolePermissionDto) toRole() *roles.Role {
+	return &roles.Role{
+		Name:        dto.Name,
+		Description: dto.Description,
+		Default:     dto.IsDefault,
+		Permissions: make([]string, 0),
+	}
+}
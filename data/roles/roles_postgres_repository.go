@@ -0,0 +1,137 @@
+package roles
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+	"github.com/pkg/errors"
+)
+
+const (
+	postgresGetRoles = `
+	SELECT r.name, r.description, r.is_default, rp.permission
+	FROM roles r
+	LEFT JOIN role_permissions rp ON rp.role = r.name
+	ORDER BY r.name, rp.permission
+	`
+
+	postgresGetUserRoles = `
+	SELECT role
+	FROM user_roles
+	WHERE user_id = $1
+	ORDER BY role
+	`
+
+	// Permissions of the default role are granted only to users without any assigned role.
+	postgresGetUserPermissions = `
+	SELECT DISTINCT rp.permission
+	FROM role_permissions rp
+	JOIN roles r ON r.name = rp.role
+	WHERE r.name IN (SELECT role FROM user_roles WHERE user_id = $1)
+		OR (r.is_default AND NOT EXISTS (SELECT 1 FROM user_roles WHERE user_id = $1))
+	ORDER BY rp.permission
+	`
+
+	postgresInsertUserRole = `
+	INSERT INTO user_roles(user_id, role, created_at)
+	VALUES($1, $2, $3)
+	ON CONFLICT (user_id, role) DO NOTHING
+	`
+
+	postgresDeleteUserRole = `
+	DELETE FROM user_roles
+	WHERE user_id = $1 AND role = $2
+	`
+)
+
+// Repository is a repository for roles, their permissions and roles assigned to users.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRolesRepository creates a new roles repository.
+func NewRolesRepository(db *sql.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// GetRoles returns all roles with their permissions.
+func (r *Repository) GetRoles(ctx context.Context) ([]*roles.Role, error) {
+	rows, err := r.db.QueryContext(ctx, postgresGetRoles)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:all
+
+	result := make([]*roles.Role, 0)
+	var current *roles.Role
+	for rows.Next() {
+		var dto RolePermissionDto
+		if err = rows.Scan(&dto.Name, &dto.Description, &dto.IsDefault, &dto.Permission); err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+
+		// Rows are ordered by role, so permissions of one role are next to each other.
+		if current == nil || current.Name != dto.Name {
+			current = dto.toRole()
+			result = append(result, current)
+		}
+		if dto.Permission.Valid {
+			current.Permissions = append(current.Permissions, dto.Permission.String)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return result, nil
+}
+
+// GetUserRoles returns roles assigned to the user. Default role is not included.
+func (r *Repository) GetUserRoles(ctx context.Context, userID int) ([]string, error) {
+	return r.getNames(ctx, postgresGetUserRoles, userID)
+}
+
+// GetUserPermissions returns permissions granted to the user by assigned roles, or by the default role if the user has none.
+func (r *Repository) GetUserPermissions(ctx context.Context, userID int) ([]string, error) {
+	return r.getNames(ctx, postgresGetUserPermissions, userID)
+}
+
+// InsertUserRole assigns role to the user. Nothing is changed if the user already has the role.
+func (r *Repository) InsertUserRole(ctx context.Context, userID int, role string, createdAt time.Time) error {
+	if _, err := r.db.ExecContext(ctx, postgresInsertUserRole, userID, role, createdAt); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// DeleteUserRole removes role from the user.
+func (r *Repository) DeleteUserRole(ctx context.Context, userID int, role string) error {
+	if _, err := r.db.ExecContext(ctx, postgresDeleteUserRole, userID, role); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+func (r *Repository) getNames(ctx context.Context, query string, userID int) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:all
+
+	names := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		names = append(names, name)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return names, nil
+}
@@ -0,0 +1,41 @@
+package sessions
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/sessions"
+)
+
+// SessionDto is a struct that represent user session database record.
+type SessionDto struct {
+	ID          int            `db:"id"`
+	UserID      int            `db:"user_id"`
+	AccessKeyID string         `db:"access_key_id"`
+	AccessKey   sql.NullString `db:"access_key"`
+	IP          sql.NullString `db:"ip"`
+	UserAgent   sql.NullString `db:"user_agent"`
+	CreatedAt   time.Time      `db:"created_at"`
+	LastSeenAt  sql.NullTime   `db:"last_seen_at"`
+	ExpiresAt   time.Time      `db:"expires_at"`
+}
+
+// toSession converts SessionDto to Session.
+func (s *SessionDto) toSession() *sessions.Session {
+	lastSeenAt := s.CreatedAt
+	if s.LastSeenAt.Valid {
+		lastSeenAt = s.LastSeenAt.Time
+	}
+
+	return &sessions.Session{
+		ID:          s.ID,
+		UserID:      s.UserID,
+		AccessKeyID: s.AccessKeyID,
+		AccessKey:   s.AccessKey.String,
+		IP:          s.IP.String,
+		UserAgent:   s.UserAgent.String,
+		CreatedAt:   s.CreatedAt,
+		LastSeenAt:  lastSeenAt,
+		ExpiresAt:   s.ExpiresAt,
+	}
+}
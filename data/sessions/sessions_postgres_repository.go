@@ -0,0 +1,167 @@
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/sessions"
+	"github.com/pkg/errors"
+)
+
+const (
+	postgresInsertSession = `
+	INSERT INTO user_sessions(user_id, access_key_id, access_key, ip, user_agent, created_at, last_seen_at, expires_at)
+	VALUES($1, $2, $3, $4, $5, $6, $6, $7)
+	RETURNING id
+	`
+
+	postgresGetActiveUserSessions = `
+	SELECT id, user_id, access_key_id, access_key, ip, user_agent, created_at, last_seen_at, expires_at
+	FROM user_sessions
+	WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+	ORDER BY created_at DESC
+	`
+
+	postgresGetActiveUserSession = `
+	SELECT id, user_id, access_key_id, access_key, ip, user_agent, created_at, last_seen_at, expires_at
+	FROM user_sessions
+	WHERE user_id = $1 AND id = $2 AND revoked_at IS NULL
+	`
+
+	postgresGetExpiredSessions = `
+	SELECT id, user_id, access_key_id, access_key, ip, user_agent, created_at, last_seen_at, expires_at
+	FROM user_sessions
+	WHERE revoked_at IS NULL AND expires_at <= $1
+	ORDER BY expires_at
+	LIMIT $2
+	`
+
+	postgresExtendSession = `
+	UPDATE user_sessions
+	SET expires_at = $2
+	WHERE access_key_id = $1 AND revoked_at IS NULL
+	`
+
+	postgresTouchSession = `
+	UPDATE user_sessions
+	SET last_seen_at = $3
+	WHERE id = $1 AND access_key_id = $2 AND revoked_at IS NULL AND expires_at > $3
+	`
+
+	// Access key is not needed anymore once it's revoked, so it's removed from the record.
+	postgresMarkSessionRevoked = `
+	UPDATE user_sessions
+	SET revoked_at = $2, access_key = NULL
+	WHERE access_key_id = $1 AND revoked_at IS NULL
+	`
+
+	postgresMarkUserSessionsRevoked = `
+	UPDATE user_sessions
+	SET revoked_at = $3, access_key = NULL
+	WHERE user_id = $1 AND access_key_id <> $2 AND revoked_at IS NULL
+	`
+)
+
+// Repository is a repository for user sessions.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewSessionsRepository creates a new sessions repository.
+func NewSessionsRepository(db *sql.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// InsertSession inserts a session to db and sets its id.
+func (r *Repository) InsertSession(ctx context.Context, session *sessions.Session) error {
+	row := r.db.QueryRowContext(ctx, postgresInsertSession,
+		session.UserID, session.AccessKeyID, session.AccessKey, session.IP, session.UserAgent, session.CreatedAt, session.ExpiresAt)
+	if err := row.Scan(&session.ID); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// GetActiveUserSessions returns not revoked and not expired sessions of the user.
+func (r *Repository) GetActiveUserSessions(ctx context.Context, userID int) ([]*sessions.Session, error) {
+	return r.querySessions(ctx, postgresGetActiveUserSessions, userID, time.Now())
+}
+
+// GetActiveUserSession returns not revoked session of the user by id. Can return nil session without an error - if no rows found.
+func (r *Repository) GetActiveUserSession(ctx context.Context, userID, id int) (*sessions.Session, error) {
+	var session SessionDto
+	row := r.db.QueryRowContext(ctx, postgresGetActiveUserSession, userID, id)
+	if err := row.Scan(&session.ID, &session.UserID, &session.AccessKeyID, &session.AccessKey, &session.IP, &session.UserAgent, &session.CreatedAt, &session.LastSeenAt, &session.ExpiresAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return session.toSession(), nil
+}
+
+// GetExpiredSessions returns up to limit sessions which expired before now but weren't revoked yet.
+func (r *Repository) GetExpiredSessions(ctx context.Context, now time.Time, limit int) ([]*sessions.Session, error) {
+	return r.querySessions(ctx, postgresGetExpiredSessions, now, limit)
+}
+
+// ExtendSession moves expiration time of not revoked session.
+func (r *Repository) ExtendSession(ctx context.Context, accessKeyID string, expiresAt time.Time) error {
+	if _, err := r.db.ExecContext(ctx, postgresExtendSession, accessKeyID, expiresAt); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// TouchSession updates last seen time of the session. It returns false if the session is revoked or expired.
+func (r *Repository) TouchSession(ctx context.Context, id int, accessKeyID string, lastSeenAt time.Time) (bool, error) {
+	res, err := r.db.ExecContext(ctx, postgresTouchSession, id, accessKeyID, lastSeenAt)
+	if err != nil {
+		return false, errors.Wrap(err, "internal error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "internal error")
+	}
+	return affected > 0, nil
+}
+
+// MarkSessionRevoked marks session with given access key id as revoked.
+func (r *Repository) MarkSessionRevoked(ctx context.Context, accessKeyID string, revokedAt time.Time) error {
+	if _, err := r.db.ExecContext(ctx, postgresMarkSessionRevoked, accessKeyID, revokedAt); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// MarkUserSessionsRevoked marks all sessions of the user except the one with keepAccessKeyID as revoked.
+func (r *Repository) MarkUserSessionsRevoked(ctx context.Context, userID int, keepAccessKeyID string, revokedAt time.Time) error {
+	if _, err := r.db.ExecContext(ctx, postgresMarkUserSessionsRevoked, userID, keepAccessKeyID, revokedAt); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+func (r *Repository) querySessions(ctx context.Context, query string, args ...any) ([]*sessions.Session, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:all
+
+	result := make([]*sessions.Session, 0)
+	for rows.Next() {
+		var session SessionDto
+		if err = rows.Scan(&session.ID, &session.UserID, &session.AccessKeyID, &session.AccessKey, &session.IP, &session.UserAgent, &session.CreatedAt, &session.LastSeenAt, &session.ExpiresAt); err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		result = append(result, session.toSession())
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return result, nil
+}
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS user_sessions (
+    id serial PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    access_key_id VARCHAR(255) UNIQUE NOT NULL,
+    access_key TEXT,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS user_sessions_user_id_idx ON user_sessions (user_id) WHERE revoked_at IS NULL;
+CREATE INDEX IF NOT EXISTS user_sessions_expires_at_idx ON user_sessions (expires_at) WHERE revoked_at IS NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE user_sessions ADD COLUMN ip VARCHAR(45);
+ALTER TABLE user_sessions ADD COLUMN user_agent VARCHAR(512);
+ALTER TABLE user_sessions ADD COLUMN last_seen_at TIMESTAMP;
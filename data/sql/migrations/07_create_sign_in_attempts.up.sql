@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS sign_in_attempts (
+    key VARCHAR(320) PRIMARY KEY,
+    failures INTEGER NOT NULL,
+    last_failure_at TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP
+);
@@ -0,0 +1,10 @@
+ALTER TABLE users ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active';
+ALTER TABLE users ADD COLUMN xpub VARCHAR(255);
+-- Email verification registers xPub and then paymail in SPV Wallet, the flag records that the xPub is already registered,
+-- so verification can be retried when the paymail registration or the user activation fails.
+ALTER TABLE users ADD COLUMN xpub_registered BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE users ADD COLUMN verification_token_hash VARCHAR(64);
+ALTER TABLE users ADD COLUMN verification_expires_at TIMESTAMP;
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_verification_token_hash_idx ON users (verification_token_hash);
+CREATE INDEX IF NOT EXISTS users_pending_expires_at_idx ON users (verification_expires_at) WHERE status = 'pending';
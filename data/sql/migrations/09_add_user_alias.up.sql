@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN alias VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_pending_alias_idx ON users (alias) WHERE status = 'pending';
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS user_paymails (
+    id serial PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    address VARCHAR(320) UNIQUE NOT NULL,
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS user_paymails_user_id_idx ON user_paymails (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS user_paymails_primary_idx ON user_paymails (user_id) WHERE is_primary;
+
+INSERT INTO user_paymails (user_id, address, is_primary, created_at)
+SELECT id, paymail, true, created_at
+FROM users
+WHERE paymail IS NOT NULL AND paymail <> ''
+ON CONFLICT (address) DO NOTHING;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS user_profiles (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    public_name VARCHAR(100),
+    avatar_key VARCHAR(100),
+    updated_at TIMESTAMP NOT NULL
+);
@@ -0,0 +1,47 @@
+CREATE TABLE IF NOT EXISTS operator_roles (
+    name VARCHAR(50) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL
+);
+
+INSERT INTO operator_roles (name, description) VALUES
+    ('admin', 'Full access to the admin API'),
+    ('support', 'Can view users, lock and unlock accounts and terminate sessions'),
+    ('auditor', 'Read-only access to users and the admin audit log')
+ON CONFLICT (name) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS operators (
+    id serial PRIMARY KEY,
+    email VARCHAR(320) UNIQUE NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    role VARCHAR(50) NOT NULL REFERENCES operator_roles(name),
+    created_at TIMESTAMP NOT NULL,
+    disabled_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS operator_sessions (
+    token_hash VARCHAR(64) PRIMARY KEY,
+    operator_id INTEGER NOT NULL REFERENCES operators(id) ON DELETE CASCADE,
+    ip VARCHAR(45),
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS operator_sessions_expires_at_idx ON operator_sessions (expires_at);
+
+-- Entries outlive operators and users they refer to, so there are no foreign keys.
+CREATE TABLE IF NOT EXISTS admin_audit_log (
+    id bigserial PRIMARY KEY,
+    operator_id INTEGER,
+    operator_email VARCHAR(320) NOT NULL,
+    ip VARCHAR(45),
+    action VARCHAR(50) NOT NULL,
+    target_user_id INTEGER,
+    outcome VARCHAR(20) NOT NULL,
+    details TEXT,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS admin_audit_log_target_user_id_idx ON admin_audit_log (target_user_id);
+CREATE INDEX IF NOT EXISTS admin_audit_log_created_at_idx ON admin_audit_log (created_at);
+
+-- Lock set by an operator is kept with the user, apart from the sign-in lockout caused by failed attempts,
+-- so failed attempts neither shorten nor remove it.
+ALTER TABLE users ADD COLUMN locked_until TIMESTAMP;
@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS roles (
+    name VARCHAR(50) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT false
+);
+
+CREATE TABLE IF NOT EXISTS permissions (
+    name VARCHAR(50) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role VARCHAR(50) NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    permission VARCHAR(50) NOT NULL REFERENCES permissions(name) ON DELETE CASCADE,
+    PRIMARY KEY (role, permission)
+);
+
+-- Users without any assigned role have the default role, so existing users don't need a row here.
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(50) NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, role)
+);
+
+-- Staff access to other users is given only by operator roles of the admin API, so roles of users grant access to the own wallet only.
+INSERT INTO roles (name, description, is_default) VALUES
+    ('user', 'Wallet owner, can use and manage own wallet', true),
+    ('auditor', 'Read-only access to own wallet', false)
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO permissions (name, description) VALUES
+    ('wallet:read', 'View own balance, transactions and contacts'),
+    ('wallet:spend', 'Send transactions and sweep funds'),
+    ('contacts:write', 'Add, accept, reject and confirm contacts'),
+    ('account:manage', 'Manage own password, paymails, profile, two-factor authentication, sessions and account')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('user', 'wallet:read'),
+    ('user', 'wallet:spend'),
+    ('user', 'contacts:write'),
+    ('user', 'account:manage'),
+    ('auditor', 'wallet:read'),
+    ('auditor', 'account:manage')
+ON CONFLICT (role, permission) DO NOTHING;
@@ -0,0 +1,35 @@
+-- Security audit log of actions performed through the wallet API. Actions of operators are kept in admin_audit_log.
+-- Entries outlive users they refer to, so there are no foreign keys.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id bigserial PRIMARY KEY,
+    user_id INTEGER,
+    actor VARCHAR(320) NOT NULL,
+    ip VARCHAR(45),
+    action VARCHAR(50) NOT NULL,
+    target VARCHAR(320),
+    outcome VARCHAR(20) NOT NULL,
+    details TEXT,
+    created_at TIMESTAMP NOT NULL,
+    -- Hash of the previous hash-chained entry and hash of this entry, both are null if hash chain was disabled.
+    prev_hash VARCHAR(64),
+    hash VARCHAR(64)
+);
+CREATE INDEX IF NOT EXISTS audit_log_user_id_idx ON audit_log (user_id, id);
+-- Last hash-chained entry is read on every chained insert, it's found directly even after the hash chain was disabled for a while.
+CREATE INDEX IF NOT EXISTS audit_log_chained_idx ON audit_log (id) WHERE hash IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_log_no_update ON audit_log;
+CREATE TRIGGER audit_log_no_update
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+DROP TRIGGER IF EXISTS audit_log_no_truncate ON audit_log;
+CREATE TRIGGER audit_log_no_truncate
+    BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
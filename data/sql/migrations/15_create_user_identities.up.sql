@@ -0,0 +1,19 @@
+-- Identities of users at OpenID Connect providers. Access key of the identity is used only to create
+-- access keys of sessions signed in with the provider, it's encrypted because it lets to read the wallet.
+CREATE TABLE IF NOT EXISTS user_identities (
+    id serial PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    issuer VARCHAR(255) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(320),
+    access_key_id VARCHAR(64) NOT NULL,
+    access_key TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMP,
+    UNIQUE (issuer, subject)
+);
+CREATE INDEX IF NOT EXISTS user_identities_user_id_idx ON user_identities (user_id);
+
+-- xPriv of the user encrypted with the wallet passphrase, which is separate from the password of the account.
+-- It unlocks the wallet of users who sign in with single sign-on and don't enter the password.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS passphrase_xpriv TEXT;
@@ -0,0 +1,16 @@
+-- Passkeys of users. xPriv of the user is encrypted with the secret derived by the credential with the PRF extension,
+-- so the passkey both authenticates the user and unlocks the wallet without the password.
+CREATE TABLE IF NOT EXISTS webauthn_credentials (
+    id serial PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL,
+    credential_id BYTEA NOT NULL UNIQUE,
+    public_key BYTEA NOT NULL,
+    sign_count BIGINT NOT NULL DEFAULT 0,
+    user_handle VARCHAR(64) NOT NULL,
+    transports TEXT[] NOT NULL DEFAULT '{}',
+    xpriv TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS webauthn_credentials_user_id_idx ON webauthn_credentials (user_id);
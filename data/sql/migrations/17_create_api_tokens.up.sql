@@ -0,0 +1,16 @@
+-- Personal API tokens of users. Only sha256 hash of the token is stored, prefix is kept to recognize the token in the list.
+-- Access key of the token is encrypted because it lets to read the wallet.
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id serial PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+    access_key_id VARCHAR(64) NOT NULL,
+    access_key TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP,
+    last_used_at TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS api_tokens_user_id_idx ON api_tokens (user_id);
@@ -0,0 +1,27 @@
+-- Payout batches uploaded by users as CSV, every row is paid once. Rows are marked as sending with the reference
+-- written to metadata of the transaction before it's sent, so retried rows can be matched with transactions already sent.
+CREATE TABLE IF NOT EXISTS payout_batches (
+    id serial PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL,
+    total_satoshis BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS payout_batches_user_id_idx ON payout_batches (user_id);
+
+CREATE TABLE IF NOT EXISTS payout_rows (
+    id serial PRIMARY KEY,
+    batch_id INTEGER NOT NULL REFERENCES payout_batches(id) ON DELETE CASCADE,
+    row_number INTEGER NOT NULL,
+    paymail VARCHAR(320) NOT NULL,
+    satoshis BIGINT NOT NULL,
+    memo TEXT NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL,
+    reference VARCHAR(64),
+    transaction_id VARCHAR(64),
+    error TEXT,
+    updated_at TIMESTAMP NOT NULL,
+    UNIQUE (batch_id, paymail)
+);
+CREATE INDEX IF NOT EXISTS payout_rows_batch_id_idx ON payout_rows (batch_id);
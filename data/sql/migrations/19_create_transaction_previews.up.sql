@@ -0,0 +1,19 @@
+-- Previewed transactions which can be sent by their token until they expire. Draft is the unsigned transaction drafted by SPV Wallet,
+-- it's signed when the preview is sent. Sending is set while the preview is being sent, so it's not sent twice at the same time.
+CREATE TABLE IF NOT EXISTS transaction_previews (
+    token CHAR(64) PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    amount BIGINT NOT NULL,
+    fee BIGINT NOT NULL,
+    change BIGINT NOT NULL,
+    inputs JSONB NOT NULL,
+    outputs JSONB NOT NULL,
+    usd JSONB,
+    recipients JSONB NOT NULL,
+    draft JSONB NOT NULL,
+    metadata JSONB NOT NULL,
+    sending BOOLEAN NOT NULL DEFAULT FALSE,
+    expires_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS transaction_previews_user_id_idx ON transaction_previews (user_id);
+CREATE INDEX IF NOT EXISTS transaction_previews_expires_at_idx ON transaction_previews (expires_at);
@@ -0,0 +1,24 @@
+-- Signed transactions which are not recorded in SPV Wallet yet. The transaction is written here before it's recorded,
+-- so it's retried by the worker after SPV Wallet failure or restart until it's recorded or its draft expires.
+-- Access key is created for every transaction to record it without xPriv, it's encrypted and revoked when the entry is done.
+CREATE TABLE IF NOT EXISTS transaction_outbox (
+    id serial PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    draft_id VARCHAR(64) NOT NULL UNIQUE,
+    transaction_id CHAR(64) NOT NULL,
+    hex TEXT NOT NULL,
+    metadata JSONB NOT NULL,
+    total_value BIGINT NOT NULL,
+    fee BIGINT NOT NULL,
+    access_key_id VARCHAR(64) NOT NULL,
+    access_key TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS transaction_outbox_user_id_idx ON transaction_outbox (user_id, transaction_id);
+CREATE INDEX IF NOT EXISTS transaction_outbox_pending_idx ON transaction_outbox (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,18 @@
+-- Idempotency keys of sent transactions. Fingerprint is sha256 of the request without secrets, so the key can't be reused
+-- with a different request. Draft and transaction ids are set once the transaction is sent, before that the key is in progress.
+-- Request in progress holds its idempotency key only until locked_until, then a repeated request can take the key over,
+-- so a key of the request which never completed nor released it isn't blocked until it expires. The key is taken over only
+-- while its preview can still be sent, if the preview is being sent or it's gone, the transaction may have been sent already.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key VARCHAR(255) NOT NULL,
+    fingerprint CHAR(64) NOT NULL,
+    preview_token TEXT NOT NULL,
+    draft_id VARCHAR(64),
+    transaction_id CHAR(64),
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
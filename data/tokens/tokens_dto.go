@@ -0,0 +1,54 @@
+package tokens
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/tokens"
+	"github.com/lib/pq"
+)
+
+// TokenDto is a struct that represent API token database record.
+type TokenDto struct {
+	ID          int            `db:"id"`
+	UserID      int            `db:"user_id"`
+	Name        string         `db:"name"`
+	Prefix      string         `db:"prefix"`
+	Hash        string         `db:"token_hash"`
+	Scopes      pq.StringArray `db:"scopes"`
+	AccessKeyID string         `db:"access_key_id"`
+	AccessKey   string         `db:"access_key"`
+	CreatedAt   time.Time      `db:"created_at"`
+	ExpiresAt   sql.NullTime   `db:"expires_at"`
+	LastUsedAt  sql.NullTime   `db:"last_used_at"`
+}
+
+// toToken converts TokenDto to Token.
+func (t *TokenDto) toToken() *tokens.Token {
+	token := &tokens.Token{
+		ID:          t.ID,
+		UserID:      t.UserID,
+		Name:        t.Name,
+		Prefix:      t.Prefix,
+		Hash:        t.Hash,
+		Scopes:      t.Scopes,
+		AccessKeyID: t.AccessKeyID,
+		AccessKey:   t.AccessKey,
+		CreatedAt:   t.CreatedAt,
+	}
+	if token.Scopes == nil {
+		token.Scopes = []string{}
+	}
+	if t.ExpiresAt.Valid {
+		token.ExpiresAt = &t.ExpiresAt.Time
+	}
+	if t.LastUsedAt.Valid {
+		token.LastUsedAt = &t.LastUsedAt.Time
+	}
+	return token
+}
+
+// scan reads the token from the row, columns must be selected in the order of tokenColumns.
+func (t *TokenDto) scan(row interface{ Scan(dest ...any) error }) error {
+	return row.Scan(&t.ID, &t.UserID, &t.Name, &t.Prefix, &t.Hash, &t.Scopes, &t.AccessKeyID, &t.AccessKey, &t.CreatedAt, &t.ExpiresAt, &t.LastUsedAt) //nolint:wrapcheck // error wrapped higher in call stack
+}
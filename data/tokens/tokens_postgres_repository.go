@@ -0,0 +1,146 @@
+package tokens
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/tokens"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+const tokenColumns = `id, user_id, name, prefix, token_hash, scopes, access_key_id, access_key, created_at, expires_at, last_used_at`
+
+const (
+	postgresInsertToken = `
+	INSERT INTO api_tokens(user_id, name, prefix, token_hash, scopes, access_key_id, access_key, created_at, expires_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	RETURNING id
+	`
+
+	postgresGetTokenByHash = `
+	SELECT ` + tokenColumns + `
+	FROM api_tokens
+	WHERE token_hash = $1
+	`
+
+	postgresGetUserTokens = `
+	SELECT ` + tokenColumns + `
+	FROM api_tokens
+	WHERE user_id = $1
+	ORDER BY created_at
+	`
+
+	postgresTouchToken = `
+	UPDATE api_tokens
+	SET last_used_at = $2
+	WHERE id = $1
+	`
+
+	postgresDeleteToken = `
+	DELETE FROM api_tokens
+	WHERE user_id = $1 AND id = $2
+	RETURNING ` + tokenColumns
+
+	postgresDeleteUserTokens = `
+	DELETE FROM api_tokens
+	WHERE user_id = $1
+	RETURNING ` + tokenColumns
+)
+
+// Repository is a repository for API tokens.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewTokensRepository creates a new API tokens repository.
+func NewTokensRepository(db *sql.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// InsertToken inserts a token to db and sets its id.
+func (r *Repository) InsertToken(ctx context.Context, token *tokens.Token) error {
+	expiresAt := sql.NullTime{}
+	if token.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: *token.ExpiresAt, Valid: true}
+	}
+
+	row := r.db.QueryRowContext(ctx, postgresInsertToken,
+		token.UserID,
+		token.Name,
+		token.Prefix,
+		token.Hash,
+		pq.StringArray(token.Scopes),
+		token.AccessKeyID,
+		token.AccessKey,
+		token.CreatedAt,
+		expiresAt,
+	)
+	if err := row.Scan(&token.ID); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// GetTokenByHash returns token by hash of its value. Can return nil token without an error - if no rows found.
+func (r *Repository) GetTokenByHash(ctx context.Context, hash string) (*tokens.Token, error) {
+	return r.getToken(r.db.QueryRowContext(ctx, postgresGetTokenByHash, hash))
+}
+
+// GetUserTokens returns all tokens of the user.
+func (r *Repository) GetUserTokens(ctx context.Context, userID int) ([]*tokens.Token, error) {
+	return r.getTokens(ctx, postgresGetUserTokens, userID)
+}
+
+// TouchToken updates time of the last request authenticated by the token.
+func (r *Repository) TouchToken(ctx context.Context, id int, usedAt time.Time) error {
+	if _, err := r.db.ExecContext(ctx, postgresTouchToken, id, usedAt); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// DeleteToken deletes token of the user and returns it. Can return nil token without an error - if no rows found.
+func (r *Repository) DeleteToken(ctx context.Context, userID, id int) (*tokens.Token, error) {
+	return r.getToken(r.db.QueryRowContext(ctx, postgresDeleteToken, userID, id))
+}
+
+// DeleteUserTokens deletes all tokens of the user and returns them.
+func (r *Repository) DeleteUserTokens(ctx context.Context, userID int) ([]*tokens.Token, error) {
+	return r.getTokens(ctx, postgresDeleteUserTokens, userID)
+}
+
+func (r *Repository) getTokens(ctx context.Context, query string, args ...any) ([]*tokens.Token, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:all
+
+	result := make([]*tokens.Token, 0)
+	for rows.Next() {
+		var token TokenDto
+		if err = token.scan(rows); err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		result = append(result, token.toToken())
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return result, nil
+}
+
+func (r *Repository) getToken(row *sql.Row) (*tokens.Token, error) {
+	var token TokenDto
+	if err := token.scan(row); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return token.toToken(), nil
+}
@@ -0,0 +1,76 @@
+package transactions
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/transactions"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/spvwallet"
+)
+
+// PreviewDto is a struct that represent transaction preview database record.
+type PreviewDto struct {
+	Token      string    `db:"token"`
+	UserID     int       `db:"user_id"`
+	Amount     int64     `db:"amount"`
+	Fee        int64     `db:"fee"`
+	Change     int64     `db:"change"`
+	Inputs     []byte    `db:"inputs"`
+	Outputs    []byte    `db:"outputs"`
+	USD        []byte    `db:"usd"`
+	Recipients []byte    `db:"recipients"`
+	Draft      []byte    `db:"draft"`
+	Metadata   []byte    `db:"metadata"`
+	ExpiresAt  time.Time `db:"expires_at"`
+}
+
+// jsonColumn is a JSON column of the preview which is decoded into dest.
+type jsonColumn struct {
+	data []byte
+	dest any
+}
+
+// toPreview converts PreviewDto to Preview. The draft is decoded as the draft of SPV Wallet, so it can be signed.
+func (p *PreviewDto) toPreview() (*transactions.Preview, error) {
+	preview := &transactions.Preview{
+		Token:     p.Token,
+		ExpiresAt: p.ExpiresAt,
+		Amount:    uint64(p.Amount), //nolint:gosec // stored from uint64
+		Fee:       uint64(p.Fee),    //nolint:gosec // stored from uint64
+		Change:    uint64(p.Change), //nolint:gosec // stored from uint64
+		UserID:    p.UserID,
+	}
+
+	var inputs []users.DraftInput
+	var outputs []users.DraftOutput
+	var draft spvwallet.DraftTransaction
+	columns := []jsonColumn{
+		{p.Inputs, &inputs},
+		{p.Outputs, &outputs},
+		{p.Recipients, &preview.Recipients},
+		{p.Draft, &draft},
+		{p.Metadata, &preview.Metadata},
+		{p.USD, &preview.USD},
+	}
+	for _, c := range columns {
+		// USD is NULL if the exchange rate was not available.
+		if c.data == nil {
+			continue
+		}
+		if err := json.Unmarshal(c.data, c.dest); err != nil {
+			return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+		}
+	}
+
+	preview.Inputs = inputs
+	preview.Outputs = outputs
+	preview.Draft = &draft
+	return preview, nil
+}
+
+// scan reads the preview from the row, columns must be selected in the order of previewColumns.
+func (p *PreviewDto) scan(row interface{ Scan(dest ...any) error }) error {
+	return row.Scan(&p.Token, &p.UserID, &p.Amount, &p.Fee, &p.Change, &p.Inputs, &p.Outputs, &p.USD, &p.Recipients, &p.Draft, //nolint:wrapcheck // error wrapped higher in call stack
+		&p.Metadata, &p.ExpiresAt)
+}
@@ -0,0 +1,147 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/transactions"
+	"github.com/pkg/errors"
+)
+
+const previewColumns = `token, user_id, amount, fee, change, inputs, outputs, usd, recipients, draft, metadata, expires_at`
+
+const (
+	postgresDeleteReplacedPreviews = `
+	DELETE FROM transaction_previews
+	WHERE (user_id = $1 AND NOT sending) OR expires_at <= $2
+	`
+
+	postgresInsertPreview = `
+	INSERT INTO transaction_previews(` + previewColumns + `)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	postgresGetPreview = `
+	SELECT ` + previewColumns + `
+	FROM transaction_previews
+	WHERE token = $2 AND user_id = $1
+	`
+
+	postgresClaimPreview = `
+	UPDATE transaction_previews
+	SET sending = TRUE
+	WHERE token = $2 AND user_id = $1 AND NOT sending AND expires_at > $3
+	RETURNING ` + previewColumns
+
+	postgresReleasePreview = `
+	UPDATE transaction_previews
+	SET sending = FALSE
+	WHERE token = $1
+	`
+
+	postgresDeletePreview = `
+	DELETE FROM transaction_previews
+	WHERE token = $1
+	`
+)
+
+// Repository is a repository for transaction previews.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewTransactionsRepository creates a new transaction previews repository.
+func NewTransactionsRepository(db *sql.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// InsertPreview inserts the preview and deletes other previews of the user which are not being sent, the user has one preview at a time.
+// Expired previews of all users are deleted too.
+func (r *Repository) InsertPreview(ctx context.Context, preview *transactions.Preview, now time.Time) error {
+	args, err := previewArgs(preview)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = tx.ExecContext(ctx, postgresDeleteReplacedPreviews, preview.UserID, now); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	if _, err = tx.ExecContext(ctx, postgresInsertPreview, args...); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+
+	return errors.Wrap(tx.Commit(), "internal error")
+}
+
+// GetPreview returns preview of the user. Can return nil preview without an error - if no rows found.
+func (r *Repository) GetPreview(ctx context.Context, userID int, token string) (*transactions.Preview, error) {
+	return r.queryPreview(ctx, postgresGetPreview, userID, token)
+}
+
+// ClaimPreview marks the preview of the user as being sent and returns it.
+// Can return nil preview without an error - if the preview doesn't exist, expired or it's being sent already.
+func (r *Repository) ClaimPreview(ctx context.Context, userID int, token string, now time.Time) (*transactions.Preview, error) {
+	return r.queryPreview(ctx, postgresClaimPreview, userID, token, now)
+}
+
+// ReleasePreview clears the mark set by ClaimPreview.
+func (r *Repository) ReleasePreview(ctx context.Context, token string) error {
+	_, err := r.db.ExecContext(ctx, postgresReleasePreview, token)
+	return errors.Wrap(err, "internal error")
+}
+
+// DeletePreview deletes the preview.
+func (r *Repository) DeletePreview(ctx context.Context, token string) error {
+	_, err := r.db.ExecContext(ctx, postgresDeletePreview, token)
+	return errors.Wrap(err, "internal error")
+}
+
+func (r *Repository) queryPreview(ctx context.Context, query string, args ...any) (*transactions.Preview, error) {
+	var dto PreviewDto
+	if err := dto.scan(r.db.QueryRowContext(ctx, query, args...)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+
+	preview, err := dto.toPreview()
+	return preview, errors.Wrap(err, "internal error")
+}
+
+// previewArgs returns values of the preview in the order of previewColumns.
+func previewArgs(preview *transactions.Preview) ([]any, error) {
+	args := []any{
+		preview.Token,
+		preview.UserID,
+		int64(preview.Amount), //nolint:gosec // satoshis fit in int64
+		int64(preview.Fee),    //nolint:gosec // satoshis fit in int64
+		int64(preview.Change), //nolint:gosec // satoshis fit in int64
+	}
+
+	for _, v := range []any{preview.Inputs, preview.Outputs, preview.USD, preview.Recipients, preview.Draft, preview.Metadata} {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+		}
+		args = append(args, data)
+	}
+	// USD is NULL if the exchange rate is not available.
+	if preview.USD == nil {
+		args[7] = nil
+	}
+
+	return append(args, preview.ExpiresAt), nil
+}
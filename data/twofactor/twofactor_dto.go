@@ -0,0 +1,34 @@
+package twofactor
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/twofactor"
+)
+
+// TwoFactorDto is a struct that represent user second factor database record.
+type TwoFactorDto struct {
+	UserID               int           `db:"user_id"`
+	Secret               string        `db:"secret"`
+	Enabled              bool          `db:"enabled"`
+	TransactionThreshold sql.NullInt64 `db:"transaction_threshold"`
+	LastUsedStep         int64         `db:"last_used_step"`
+	CreatedAt            time.Time     `db:"created_at"`
+}
+
+// toTwoFactor converts TwoFactorDto to TwoFactor.
+func (dto *TwoFactorDto) toTwoFactor() *twofactor.TwoFactor {
+	tf := &twofactor.TwoFactor{
+		UserID:       dto.UserID,
+		Secret:       dto.Secret,
+		Enabled:      dto.Enabled,
+		LastUsedStep: dto.LastUsedStep,
+		CreatedAt:    dto.CreatedAt,
+	}
+	if dto.TransactionThreshold.Valid {
+		threshold := uint64(dto.TransactionThreshold.Int64) //nolint:gosec // threshold is never negative
+		tf.TransactionThreshold = &threshold
+	}
+	return tf
+}
@@ -0,0 +1,187 @@
+package twofactor
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/twofactor"
+	"github.com/pkg/errors"
+)
+
+const (
+	postgresGetTwoFactor = `
+	SELECT user_id, secret, enabled, transaction_threshold, last_used_step, created_at
+	FROM user_two_factor
+	WHERE user_id = $1
+	`
+
+	postgresUpsertTwoFactor = `
+	INSERT INTO user_two_factor(user_id, secret, enabled, transaction_threshold, last_used_step, created_at)
+	VALUES($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (user_id) DO UPDATE
+	SET secret = EXCLUDED.secret, enabled = EXCLUDED.enabled, transaction_threshold = EXCLUDED.transaction_threshold,
+		last_used_step = EXCLUDED.last_used_step, created_at = EXCLUDED.created_at
+	`
+
+	postgresInsertRecoveryCode = `
+	INSERT INTO user_recovery_codes(user_id, code_hash)
+	VALUES($1, $2)
+	`
+
+	postgresDeleteRecoveryCodes = `
+	DELETE FROM user_recovery_codes
+	WHERE user_id = $1
+	`
+
+	postgresEnableTwoFactor = `
+	UPDATE user_two_factor
+	SET enabled = TRUE
+	WHERE user_id = $1
+	`
+
+	postgresDeleteTwoFactor = `
+	DELETE FROM user_two_factor
+	WHERE user_id = $1
+	`
+
+	// Step is updated only if it's newer than the last used one, so the same code cannot be used twice.
+	postgresUpdateLastUsedStep = `
+	UPDATE user_two_factor
+	SET last_used_step = $2
+	WHERE user_id = $1 AND last_used_step < $2
+	`
+
+	postgresUseRecoveryCode = `
+	UPDATE user_recovery_codes
+	SET used_at = $3
+	WHERE id = (
+		SELECT id FROM user_recovery_codes
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+		LIMIT 1
+	)
+	`
+
+	postgresUpdateTransactionThreshold = `
+	UPDATE user_two_factor
+	SET transaction_threshold = $2
+	WHERE user_id = $1
+	`
+)
+
+// Repository is a repository for two-factor authentication.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewTwoFactorRepository creates a new two-factor repository.
+func NewTwoFactorRepository(db *sql.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// GetTwoFactor returns second factor of the user. Can return nil without an error - if no rows found.
+func (r *Repository) GetTwoFactor(ctx context.Context, userID int) (*twofactor.TwoFactor, error) {
+	var dto TwoFactorDto
+	row := r.db.QueryRowContext(ctx, postgresGetTwoFactor, userID)
+	if err := row.Scan(&dto.UserID, &dto.Secret, &dto.Enabled, &dto.TransactionThreshold, &dto.LastUsedStep, &dto.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return dto.toTwoFactor(), nil
+}
+
+// ReplaceTwoFactor stores second factor of the user together with its recovery codes, replacing the previous ones.
+func (r *Repository) ReplaceTwoFactor(ctx context.Context, tf *twofactor.TwoFactor, recoveryCodeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	threshold := sql.NullInt64{}
+	if tf.TransactionThreshold != nil {
+		threshold = sql.NullInt64{Int64: int64(*tf.TransactionThreshold), Valid: true} //nolint:gosec // satoshis fit in int64
+	}
+
+	if _, err = tx.ExecContext(ctx, postgresUpsertTwoFactor, tf.UserID, tf.Secret, tf.Enabled, threshold, tf.LastUsedStep, tf.CreatedAt); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	if _, err = tx.ExecContext(ctx, postgresDeleteRecoveryCodes, tf.UserID); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	for _, codeHash := range recoveryCodeHashes {
+		if _, err = tx.ExecContext(ctx, postgresInsertRecoveryCode, tf.UserID, codeHash); err != nil {
+			return errors.Wrap(err, "internal error")
+		}
+	}
+
+	return errors.Wrap(tx.Commit(), "internal error")
+}
+
+// EnableTwoFactor enables second factor of the user.
+func (r *Repository) EnableTwoFactor(ctx context.Context, userID int) error {
+	if _, err := r.db.ExecContext(ctx, postgresEnableTwoFactor, userID); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// DeleteTwoFactor removes second factor of the user together with its recovery codes.
+func (r *Repository) DeleteTwoFactor(ctx context.Context, userID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = tx.ExecContext(ctx, postgresDeleteRecoveryCodes, userID); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	if _, err = tx.ExecContext(ctx, postgresDeleteTwoFactor, userID); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+
+	return errors.Wrap(tx.Commit(), "internal error")
+}
+
+// UpdateLastUsedStep stores TOTP time step of the accepted code. It returns false if the same or newer step was already used.
+func (r *Repository) UpdateLastUsedStep(ctx context.Context, userID int, step int64) (bool, error) {
+	return r.execAffected(ctx, postgresUpdateLastUsedStep, userID, step)
+}
+
+// UseRecoveryCode marks unused recovery code as used. It returns false if there is no such unused code.
+func (r *Repository) UseRecoveryCode(ctx context.Context, userID int, codeHash string, usedAt time.Time) (bool, error) {
+	return r.execAffected(ctx, postgresUseRecoveryCode, userID, codeHash, usedAt)
+}
+
+// UpdateTransactionThreshold sets value in satoshis above which transactions require the code, nil disables it.
+func (r *Repository) UpdateTransactionThreshold(ctx context.Context, userID int, threshold *uint64) error {
+	value := sql.NullInt64{}
+	if threshold != nil {
+		value = sql.NullInt64{Int64: int64(*threshold), Valid: true} //nolint:gosec // satoshis fit in int64
+	}
+	if _, err := r.db.ExecContext(ctx, postgresUpdateTransactionThreshold, userID, value); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+func (r *Repository) execAffected(ctx context.Context, query string, args ...any) (bool, error) {
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, errors.Wrap(err, "internal error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "internal error")
+	}
+	return affected > 0, nil
+}
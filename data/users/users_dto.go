@@ -1,6 +1,7 @@
 package users
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
@@ -8,20 +9,33 @@ import (
 
 // UserDto is a struct that represent user database record.
 type UserDto struct {
-	ID        int       `db:"id"`
-	Email     string    `db:"email"`
-	Xpriv     string    `db:"xpriv"`
-	Paymail   string    `db:"paymail"`
-	CreatedAt time.Time `db:"created_at"`
+	ID             int            `db:"id"`
+	Email          string         `db:"email"`
+	Xpriv          string         `db:"xpriv"`
+	Xpub           sql.NullString `db:"xpub"`
+	Alias          sql.NullString `db:"alias"`
+	Paymail        string         `db:"paymail"`
+	Status         string         `db:"status"`
+	CreatedAt      time.Time      `db:"created_at"`
+	XpubRegistered bool           `db:"xpub_registered"` // read only for users waiting for email verification
+	LockedUntil    sql.NullTime   `db:"locked_until"`
 }
 
 // toUser converts UserDto to User.
 func (user *UserDto) toUser() *users.User {
-	return &users.User{
-		ID:        user.ID,
-		Email:     user.Email,
-		Xpriv:     user.Xpriv,
-		Paymail:   user.Paymail,
-		CreatedAt: user.CreatedAt,
+	result := &users.User{
+		ID:             user.ID,
+		Email:          user.Email,
+		Xpriv:          user.Xpriv,
+		Xpub:           user.Xpub.String,
+		Alias:          user.Alias.String,
+		Paymail:        user.Paymail,
+		Status:         user.Status,
+		CreatedAt:      user.CreatedAt,
+		XpubRegistered: user.XpubRegistered,
 	}
+	if user.LockedUntil.Valid {
+		result.LockedUntil = &user.LockedUntil.Time
+	}
+	return result
 }
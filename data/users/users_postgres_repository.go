@@ -3,6 +3,7 @@ package users
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
 	"github.com/pkg/errors"
@@ -10,21 +11,132 @@ import (
 
 const (
 	postgresInsertUser = `
-	INSERT INTO users(email, xpriv, paymail, created_at)
-	VALUES($1, $2, $3, $4)
+	INSERT INTO users(email, xpriv, xpub, paymail, status, created_at)
+	VALUES($1, $2, $3, $4, $5, $6)
+	`
+
+	postgresInsertPendingUser = `
+	INSERT INTO users(email, xpriv, xpub, alias, paymail, status, created_at, verification_token_hash, verification_expires_at)
+	VALUES($1, $2, $3, $4, '', 'pending', $5, $6, $7)
+	RETURNING id
 	`
 
 	postgresGetUserByEmail = `
-	SELECT id, email, xpriv, paymail, created_at
+	SELECT id, email, xpriv, xpub, alias, paymail, status, created_at, locked_until
 	FROM users
 	WHERE email = $1
 	`
 
 	postgresGetUserByID = `
-	SELECT id, email, xpriv, paymail, created_at
+	SELECT id, email, xpriv, xpub, alias, paymail, status, created_at, locked_until
+	FROM users
+	WHERE id = $1
+	`
+
+	postgresGetPendingUserByVerificationToken = `
+	SELECT id, email, xpriv, xpub, alias, paymail, status, created_at, xpub_registered
 	FROM users
+	WHERE verification_token_hash = $1 AND status = 'pending' AND verification_expires_at > $2
+	`
+
+	postgresIsAliasPending = `
+	SELECT EXISTS(
+		SELECT 1
+		FROM users
+		WHERE alias = $1 AND status = 'pending'
+	)
+	`
+
+	postgresUpdateUserXpriv = `
+	UPDATE users
+	SET xpriv = $2
+	WHERE id = $1
+	`
+
+	postgresResetUserXpriv = `
+	UPDATE users
+	SET xpriv = $2, passphrase_xpriv = NULL
+	WHERE id = $1
+	`
+
+	postgresGetUserPassphraseXpriv = `
+	SELECT passphrase_xpriv
+	FROM users
+	WHERE id = $1
+	`
+
+	postgresUpdateUserPassphraseXpriv = `
+	UPDATE users
+	SET passphrase_xpriv = $2
+	WHERE id = $1
+	`
+
+	postgresDeleteUserPasskeys = `
+	DELETE FROM webauthn_credentials
+	WHERE user_id = $1
+	`
+
+	postgresMarkXpubRegistered = `
+	UPDATE users
+	SET xpub_registered = true
+	WHERE id = $1 AND status = 'pending'
+	`
+
+	postgresLockUser = `
+	UPDATE users
+	SET locked_until = $2
+	WHERE id = $1
+	`
+
+	postgresUnlockUser = `
+	UPDATE users
+	SET locked_until = NULL
+	WHERE id = $1
+	`
+
+	postgresActivateUser = `
+	UPDATE users
+	SET status = 'active', paymail = $2, verification_token_hash = NULL, verification_expires_at = NULL
+	WHERE id = $1 AND status = 'pending'
+	`
+
+	postgresInsertPrimaryPaymail = `
+	INSERT INTO user_paymails(user_id, address, is_primary, created_at)
+	VALUES($1, $2, true, $3)
+	`
+
+	// Query matches email or any paymail of the user, empty query matches all users.
+	postgresSearchUsers = `
+	SELECT id, email, xpriv, xpub, alias, paymail, status, created_at, locked_until
+	FROM users u
+	WHERE $1 = '' OR u.email ILIKE $1 OR EXISTS(
+		SELECT 1
+		FROM user_paymails p
+		WHERE p.user_id = u.id AND p.address ILIKE $1
+	)
+	ORDER BY id
+	LIMIT $2 OFFSET $3
+	`
+
+	postgresCountUsers = `
+	SELECT COUNT(*)
+	FROM users u
+	WHERE $1 = '' OR u.email ILIKE $1 OR EXISTS(
+		SELECT 1
+		FROM user_paymails p
+		WHERE p.user_id = u.id AND p.address ILIKE $1
+	)
+	`
+
+	postgresDeleteUser = `
+	DELETE FROM users
 	WHERE id = $1
 	`
+
+	postgresDeleteExpiredPendingUsers = `
+	DELETE FROM users
+	WHERE status = 'pending' AND verification_expires_at <= $1
+	`
 )
 
 // Repository is a repository for users.
@@ -53,18 +165,27 @@ func (r *Repository) InsertUser(ctx context.Context, user *users.User) error {
 		return errors.Wrap(err, "internal error")
 	}
 	defer stmt.Close() //nolint:all
-	if _, err = stmt.Exec(user.Email, user.Xpriv, user.Paymail, user.CreatedAt); err != nil {
+	if _, err = stmt.Exec(user.Email, user.Xpriv, sql.NullString{String: user.Xpub, Valid: user.Xpub != ""}, user.Paymail, user.Status, user.CreatedAt); err != nil {
 		return errors.Wrap(err, "internal error")
 	}
 	err = tx.Commit()
 	return errors.Wrap(err, "internal error")
 }
 
+// InsertPendingUser inserts a user waiting for email verification to db and sets its id.
+func (r *Repository) InsertPendingUser(ctx context.Context, user *users.User, verificationTokenHash string, verificationExpiresAt time.Time) error {
+	row := r.db.QueryRowContext(ctx, postgresInsertPendingUser, user.Email, user.Xpriv, user.Xpub, sql.NullString{String: user.Alias, Valid: user.Alias != ""}, user.CreatedAt, verificationTokenHash, verificationExpiresAt)
+	if err := row.Scan(&user.ID); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
 // GetUserByEmail returns user by email. Can return nil user without an error - if no rows found.
 func (r *Repository) GetUserByEmail(ctx context.Context, email string) (*users.User, error) {
 	var user UserDto
 	row := r.db.QueryRowContext(ctx, postgresGetUserByEmail, email)
-	if err := row.Scan(&user.ID, &user.Email, &user.Xpriv, &user.Paymail, &user.CreatedAt); err != nil {
+	if err := row.Scan(&user.ID, &user.Email, &user.Xpriv, &user.Xpub, &user.Alias, &user.Paymail, &user.Status, &user.CreatedAt, &user.LockedUntil); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
@@ -73,12 +194,213 @@ func (r *Repository) GetUserByEmail(ctx context.Context, email string) (*users.U
 	return user.toUser(), nil
 }
 
-// GetUserByID returns user by id.
+// GetUserByID returns user by id. Can return nil user without an error - if no rows found.
 func (r *Repository) GetUserByID(ctx context.Context, id int) (*users.User, error) {
 	var user UserDto
 	row := r.db.QueryRowContext(ctx, postgresGetUserByID, id)
-	if err := row.Scan(&user.ID, &user.Email, &user.Xpriv, &user.Paymail, &user.CreatedAt); err != nil {
+	if err := row.Scan(&user.ID, &user.Email, &user.Xpriv, &user.Xpub, &user.Alias, &user.Paymail, &user.Status, &user.CreatedAt, &user.LockedUntil); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
 		return nil, errors.Wrap(err, "internal error")
 	}
 	return user.toUser(), nil
 }
+
+// GetPendingUserByVerificationToken returns user waiting for email verification by hash of not expired verification token.
+// Can return nil user without an error - if no rows found.
+func (r *Repository) GetPendingUserByVerificationToken(ctx context.Context, verificationTokenHash string, now time.Time) (*users.User, error) {
+	var user UserDto
+	row := r.db.QueryRowContext(ctx, postgresGetPendingUserByVerificationToken, verificationTokenHash, now)
+	if err := row.Scan(&user.ID, &user.Email, &user.Xpriv, &user.Xpub, &user.Alias, &user.Paymail, &user.Status, &user.CreatedAt, &user.XpubRegistered); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return user.toUser(), nil
+}
+
+// IsAliasPending checks if the alias was chosen by a user who didn't verify email yet.
+func (r *Repository) IsAliasPending(ctx context.Context, alias string) (bool, error) {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, postgresIsAliasPending, alias).Scan(&exists); err != nil {
+		return false, errors.Wrap(err, "internal error")
+	}
+	return exists, nil
+}
+
+// UpdateUserXpriv replaces encrypted xpriv of the user with given id.
+func (r *Repository) UpdateUserXpriv(ctx context.Context, id int, xpriv string) error {
+	res, err := r.db.ExecContext(ctx, postgresUpdateUserXpriv, id, xpriv)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	if affected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "internal error")
+	}
+	return nil
+}
+
+// ResetUserXpriv replaces encrypted xpriv of the user with given id, removes its wallet passphrase and deletes passkeys of the user.
+func (r *Repository) ResetUserXpriv(ctx context.Context, id int, xpriv string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	res, err := tx.ExecContext(ctx, postgresResetUserXpriv, id, xpriv)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	if affected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "internal error")
+	}
+
+	if _, err = tx.ExecContext(ctx, postgresDeleteUserPasskeys, id); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+
+	err = tx.Commit()
+	return errors.Wrap(err, "internal error")
+}
+
+// GetUserPassphraseXpriv returns xpriv of the user with given id encrypted with the wallet passphrase.
+// Empty string is returned if the user has no wallet passphrase.
+func (r *Repository) GetUserPassphraseXpriv(ctx context.Context, id int) (string, error) {
+	var xpriv sql.NullString
+	if err := r.db.QueryRowContext(ctx, postgresGetUserPassphraseXpriv, id).Scan(&xpriv); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", errors.Wrap(err, "internal error")
+	}
+	return xpriv.String, nil
+}
+
+// UpdateUserPassphraseXpriv replaces xpriv of the user with given id encrypted with the wallet passphrase.
+func (r *Repository) UpdateUserPassphraseXpriv(ctx context.Context, id int, xpriv string) error {
+	res, err := r.db.ExecContext(ctx, postgresUpdateUserPassphraseXpriv, id, xpriv)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	if affected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "internal error")
+	}
+	return nil
+}
+
+// MarkXpubRegistered records that xPub of the user waiting for email verification is registered in SPV Wallet.
+func (r *Repository) MarkXpubRegistered(ctx context.Context, id int) error {
+	_, err := r.db.ExecContext(ctx, postgresMarkXpubRegistered, id)
+	return errors.Wrap(err, "internal error")
+}
+
+// LockUser locks the account of the user until given time.
+func (r *Repository) LockUser(ctx context.Context, id int, until time.Time) error {
+	_, err := r.db.ExecContext(ctx, postgresLockUser, id, until)
+	return errors.Wrap(err, "internal error")
+}
+
+// UnlockUser removes the lock of the user account.
+func (r *Repository) UnlockUser(ctx context.Context, id int) error {
+	_, err := r.db.ExecContext(ctx, postgresUnlockUser, id)
+	return errors.Wrap(err, "internal error")
+}
+
+// ActivateUser marks user with verified email as active, sets its paymail as primary and removes verification token.
+func (r *Repository) ActivateUser(ctx context.Context, id int, paymail string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	res, err := tx.ExecContext(ctx, postgresActivateUser, id, paymail)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	if affected == 0 {
+		return errors.Wrap(sql.ErrNoRows, "internal error")
+	}
+
+	if _, err = tx.ExecContext(ctx, postgresInsertPrimaryPaymail, id, paymail, time.Now()); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+
+	err = tx.Commit()
+	return errors.Wrap(err, "internal error")
+}
+
+// SearchUsers returns users whose email or paymail matches the ILIKE pattern, empty pattern matches all users.
+func (r *Repository) SearchUsers(ctx context.Context, pattern string, limit, offset int) ([]*users.User, error) {
+	rows, err := r.db.QueryContext(ctx, postgresSearchUsers, pattern, limit, offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:all
+
+	result := make([]*users.User, 0)
+	for rows.Next() {
+		var user UserDto
+		if err = rows.Scan(&user.ID, &user.Email, &user.Xpriv, &user.Xpub, &user.Alias, &user.Paymail, &user.Status, &user.CreatedAt, &user.LockedUntil); err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		result = append(result, user.toUser())
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return result, nil
+}
+
+// CountUsers returns number of users whose email or paymail matches the ILIKE pattern.
+func (r *Repository) CountUsers(ctx context.Context, pattern string) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, postgresCountUsers, pattern).Scan(&count); err != nil {
+		return 0, errors.Wrap(err, "internal error")
+	}
+	return count, nil
+}
+
+// DeleteUser deletes user with given id.
+func (r *Repository) DeleteUser(ctx context.Context, id int) error {
+	if _, err := r.db.ExecContext(ctx, postgresDeleteUser, id); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// DeleteExpiredPendingUsers deletes users who didn't verify email before the verification token expired.
+func (r *Repository) DeleteExpiredPendingUsers(ctx context.Context, now time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, postgresDeleteExpiredPendingUsers, now)
+	if err != nil {
+		return 0, errors.Wrap(err, "internal error")
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "internal error")
+	}
+	return deleted, nil
+}
@@ -15,169 +15,191 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/config": {
+        "/api/admin/v1/audit-log": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sharedconfig"
+                    "admin"
+                ],
+                "summary": "Get actions performed by operators, newest first",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get config returns config fields exposed to clients",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/transports_http_endpoints_api_config.PublicConfig"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_operators.AuditEntry"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/v1/contact/accepted/{paymail}": {
-            "patch": {
-                "produces": [
-                    "application/json"
+        "/api/admin/v1/operators": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
-                "tags": [
-                    "contact"
+                "consumes": [
+                    "application/json"
                 ],
-                "summary": "Accept a contact",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/api/v1/contact/confirmed": {
-            "patch": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "contact"
+                    "admin"
                 ],
-                "summary": "Confirm a contact",
+                "summary": "Create operator of the admin API",
                 "parameters": [
                     {
-                        "description": "Confirm contact data",
+                        "description": "Operator data",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/transports_http_endpoints_api_contacts.ConfirmContact"
+                            "$ref": "#/definitions/transports_http_endpoints_api_admin.CreateOperator"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_operators.Operator"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/contact/rejected/{paymail}": {
-            "patch": {
+        "/api/admin/v1/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "contact"
+                    "admin"
                 ],
-                "summary": "Reject a contact",
+                "summary": "Get roles which can be assigned to operators",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_operators.Role"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/api/v1/contact/totp": {
-            "post": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "contact"
-                ],
-                "summary": "Generate TOTP for contact.",
-                "parameters": [
+        "/api/admin/v1/security-log": {
+            "get": {
+                "security": [
                     {
-                        "description": "Contact details",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Contact"
-                        }
+                        "BearerAuth": []
                     }
                 ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/api/v1/contact/{paymail}": {
-            "put": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "contact"
+                    "admin"
                 ],
-                "summary": "Create or update a contact.",
+                "summary": "Get actions performed by wallet users, newest first",
                 "parameters": [
                     {
-                        "description": "Upsert contact data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/transports_http_endpoints_api_contacts.UpsertContact"
-                        }
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_audit.Entry"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/api/v1/contacts/search": {
-            "post": {
+        "/api/admin/v1/security-log/verify": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Recomputes hash chain of the security log to check that no entry was changed or removed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "contact"
-                ],
-                "summary": "Get all contacts.",
-                "parameters": [
-                    {
-                        "description": "Conditions for filtering contacts",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/transports_http_endpoints_api_contacts.SearchContact"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "Verify hash chain of the security log",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SearchContactsResponse"
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_audit.ChainVerification"
                         }
                     }
                 }
             }
         },
-        "/api/v1/sign-in": {
+        "/api/admin/v1/sign-in": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -186,17 +208,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "admin"
                 ],
-                "summary": "Sign in user",
+                "summary": "Sign in operator to the admin API",
                 "parameters": [
                     {
-                        "description": "User sign in data",
+                        "description": "Operator sign in data",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/transports_http_endpoints_api_access.SignInUser"
+                            "$ref": "#/definitions/transports_http_endpoints_api_admin.SignInOperator"
                         }
                     }
                 ],
@@ -204,24 +226,26 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/transports_http_endpoints_api_access.SignInResponse"
+                            "$ref": "#/definitions/transports_http_endpoints_api_admin.SignInResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/sign-out": {
+        "/api/admin/v1/sign-out": {
             "post": {
-                "consumes": [
-                    "*/*"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "admin"
                 ],
-                "summary": "Sign out user",
+                "summary": "Sign out operator from the admin API",
                 "responses": {
                     "200": {
                         "description": "OK"
@@ -229,68 +253,95 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/transaction": {
-            "post": {
+        "/api/admin/v1/user-roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transaction"
-                ],
-                "summary": "Create transaction.",
-                "parameters": [
-                    {
-                        "description": "Create transaction data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/transports_http_endpoints_api_transactions.CreateTransaction"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "Get roles which can be assigned to users in the wallet",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/transports_http_endpoints_api_transactions.FullTransaction"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_roles.Role"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/v1/transaction/search": {
-            "post": {
+        "/api/admin/v1/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transaction"
+                    "admin"
+                ],
+                "summary": "Search users by email or paymail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Part of email or paymail",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all transactions.",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_transactions.PaginatedTransactions"
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_admin.UsersPage"
                         }
                     }
                 }
             }
         },
-        "/api/v1/transaction/{id}": {
+        "/api/admin/v1/users/{id}": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transaction"
+                    "admin"
                 ],
-                "summary": "Get transaction by id.",
+                "summary": "Get user with its xPub ID, paymails and balance",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Transaction id",
+                        "type": "integer",
+                        "description": "User id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -300,15 +351,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/transports_http_endpoints_api_transactions.FullTransaction"
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_admin.UserDetails"
                         }
                     }
                 }
             }
         },
-        "/api/v1/user": {
+        "/api/admin/v1/users/{id}/lock": {
             "post": {
-                "description": "Register new user with given data, paymail is created based on username from sended email.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Locked user can't sign in with password, single sign-on or passkey, use API tokens or recover the wallet.\nThe lock replaces previous lock set by operator, failed sign-in attempts don't change it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -316,17 +372,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "admin"
                 ],
-                "summary": "Register new user",
+                "summary": "Lock sign-in to the user account and sign the user out of all sessions",
                 "parameters": [
                     {
-                        "description": "User data",
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Lock data",
                         "name": "data",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/transports_http_endpoints_api_users.RegisterUser"
+                            "$ref": "#/definitions/transports_http_endpoints_api_admin.LockUser"
                         }
                     }
                 ],
@@ -334,400 +396,3582 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/transports_http_endpoints_api_users.RegisterResponse"
+                            "$ref": "#/definitions/transports_http_endpoints_api_admin.LockUserResponse"
                         }
                     }
                 }
             }
         },
-        "/status": {
+        "/api/admin/v1/users/{id}/roles": {
             "get": {
-                "consumes": [
-                    "*/*"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "status"
+                    "admin"
+                ],
+                "summary": "Get roles of the user in the wallet and permissions they grant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Check the status of the server",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_roles.UserRoles"
+                        }
                     }
                 }
             }
         },
-        "/user": {
-            "get": {
-                "consumes": [
-                    "*/*"
+        "/api/admin/v1/users/{id}/roles/{role}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Assigned roles replace the default role, so it must be assigned too if the user should keep it.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "admin"
+                ],
+                "summary": "Assign role in the wallet to the user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role name",
+                        "name": "role",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get user information",
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/transports_http_endpoints_api_users.UserResponse"
-                        }
+                        "description": "OK"
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "filter.QueryParams": {
-            "type": "object",
-            "properties": {
-                "order_by_field": {
-                    "type": "string"
-                },
-                "page": {
-                    "type": "integer"
-                },
-                "page_size": {
-                    "type": "integer"
-                },
-                "sort_direction": {
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "User without any role has the default role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Remove role in the wallet from the user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role name",
+                        "name": "role",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/admin/v1/users/{id}/sessions": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Sign the user out of all sessions and revoke their access keys, signing grants and API tokens",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_admin.TerminateSessionsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/v1/users/{id}/transactions/search": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get transaction history of the user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Query params",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_admin.SearchTransactions"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_transactions.PaginatedTransactions"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/v1/users/{id}/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes the lock set by operator and resets failed sign-in attempts of the user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Unlock sign-in to the user account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/avatars/{key}": {
+            "get": {
+                "produces": [
+                    "image/png",
+                    "image/jpeg",
+                    "image/gif",
+                    "image/webp"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Get avatar image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Avatar key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/config": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sharedconfig"
+                ],
+                "summary": "Get config returns config fields exposed to clients",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_config.PublicConfig"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/contact/accepted/{paymail}": {
+            "patch": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contact"
+                ],
+                "summary": "Accept a contact",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/contact/confirmed": {
+            "patch": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contact"
+                ],
+                "summary": "Confirm a contact",
+                "parameters": [
+                    {
+                        "description": "Confirm contact data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_contacts.ConfirmContact"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/contact/rejected/{paymail}": {
+            "patch": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contact"
+                ],
+                "summary": "Reject a contact",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/contact/totp": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contact"
+                ],
+                "summary": "Generate TOTP for contact.",
+                "parameters": [
+                    {
+                        "description": "Contact details",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Contact"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/contact/{paymail}": {
+            "put": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contact"
+                ],
+                "summary": "Create or update a contact.",
+                "parameters": [
+                    {
+                        "description": "Upsert contact data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_contacts.UpsertContact"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/contacts/search": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contact"
+                ],
+                "summary": "Get all contacts.",
+                "parameters": [
+                    {
+                        "description": "Conditions for filtering contacts",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_contacts.SearchContact"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchContactsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/oidc/callback": {
+            "get": {
+                "description": "Signs in the user of the identity linked to the wallet account. If the identity isn't linked yet\nor it must be unlocked again, it's kept in session and /api/v1/oidc/link must be called with the wallet passphrase.\nIf frontend url is configured, user is redirected to it with result or error query parameter.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sso"
+                ],
+                "summary": "Finish sign-in with the OpenID Connect provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State of the sign-in",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_sso.SignInResponse"
+                        }
+                    },
+                    "302": {
+                        "description": "Found"
+                    }
+                }
+            }
+        },
+        "/api/v1/oidc/link": {
+            "post": {
+                "description": "Links the identity signed in with the OpenID Connect provider to the wallet account and signs the user in.\nWallet is unlocked with its passphrase, which is set by the signed-in user and is separate from the password of the account,\nso signing grant is created as on sign-in with password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sso"
+                ],
+                "summary": "Link identity to the wallet account",
+                "parameters": [
+                    {
+                        "description": "Wallet passphrase",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_sso.LinkIdentity"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_sso.SignInResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/oidc/sign-in": {
+            "get": {
+                "description": "Redirects to the sign-in page of the OpenID Connect provider, which redirects back to the callback.",
+                "tags": [
+                    "sso"
+                ],
+                "summary": "Start sign-in with the OpenID Connect provider",
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    }
+                }
+            }
+        },
+        "/api/v1/passkeys/sign-in": {
+            "post": {
+                "description": "Passkey unlocks the wallet, so signing grant is created as on sign-in with password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "passkeys"
+                ],
+                "summary": "Sign in with passkey",
+                "parameters": [
+                    {
+                        "description": "Result of navigator.credentials.get",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webauthn.AssertionResponse"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_passkeys.SignInResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/passkeys/sign-in/options": {
+            "post": {
+                "description": "Returns options for navigator.credentials.get, the user chooses one of passkeys registered for the wallet.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "passkeys"
+                ],
+                "summary": "Start sign-in with passkey",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webauthn.RequestOptions"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/paymail/available": {
+            "get": {
+                "description": "Check if paymail alias is valid and not taken. If it can't be registered, free variants of it are suggested.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Check paymail alias availability",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Paymail alias",
+                        "name": "alias",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.AliasAvailabilityResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/sessions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Get active sessions of the user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/transports_http_endpoints_api_sessions.Session"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/sessions/{id}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Revoke session and its access key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/sign-in": {
+            "post": {
+                "description": "If the user has two-factor authentication enabled and code is not provided, error-2fa-required is returned and request must be repeated with the code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Sign in user",
+                "parameters": [
+                    {
+                        "description": "User sign in data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_access.SignInUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_access.SignInResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/sign-out": {
+            "post": {
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Sign out user",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/signing-grant": {
+            "post": {
+                "description": "Unlock signing with xPriv for a short time, so actions like contact confirmation don't require the password.\nWallet is unlocked with the password or, if it's empty, with the wallet passphrase.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Create signing grant",
+                "parameters": [
+                    {
+                        "description": "User password or wallet passphrase",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_access.CreateSigningGrant"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_access.SigningGrantResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/signing-grant/passkey": {
+            "post": {
+                "description": "Unlock signing with xPriv for a short time with a passkey instead of the password. The signing grant is used\nby endpoints which sign with xPriv, e.g. sending transactions, when the request has no password or wallet passphrase.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "passkeys"
+                ],
+                "summary": "Create signing grant with passkey",
+                "parameters": [
+                    {
+                        "description": "Result of navigator.credentials.get",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webauthn.AssertionResponse"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_passkeys.SigningGrantResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/signing-grant/passkey/options": {
+            "post": {
+                "description": "Returns options for navigator.credentials.get, only passkeys of the signed-in user are allowed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "passkeys"
+                ],
+                "summary": "Start unlock with passkey",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webauthn.RequestOptions"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transaction": {
+            "post": {
+                "description": "Sends the previewed transaction, so the user pays exactly the previewed fee. The preview can be sent only once.\nWallet is unlocked with the password, the wallet passphrase or the signing grant of the session, so sessions signed in\nwith single sign-on or passkey can spend too. Two-factor code is required if total amount is above the user threshold.\nRequest repeated with the same Idempotency-Key returns the originally sent transaction, the key can't be reused with a different request.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "Create transaction.",
+                "parameters": [
+                    {
+                        "description": "Create transaction data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_transactions.CreateTransaction"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key which makes repeated request return the original outcome",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_transactions.SentTransaction"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transaction/batch": {
+            "post": {
+                "description": "Validates CSV with rows of paymail,satoshis,memo against the balance. Nothing is paid, the batch is saved\nonly if all rows are valid, so it can be executed. CSV is sent as file form field or as text/csv body.",
+                "consumes": [
+                    "multipart/form-data",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "Upload payout batch.",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV with rows of paymail,satoshis,memo",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_payouts.Preview"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transaction/batch/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "Get payout batch with status of its rows.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payout batch id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_payouts.Batch"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transaction/batch/{id}/execute": {
+            "post": {
+                "description": "Pays rows of the batch which are not paid yet, in chunks paid by one transaction each. Progress is sent\nover websocket. Failed batch can be executed again, rows already paid are never paid twice.\nWallet is unlocked with the password, the wallet passphrase or the signing grant of the session.\nTwo-factor code is required if unpaid total is above the user threshold.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "Execute payout batch.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payout batch id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Execute payout batch data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_transactions.ExecuteBatch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_payouts.Batch"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transaction/preview": {
+            "post": {
+                "description": "Drafts transaction paying all recipients without sending it and returns its fee, inputs, outputs, change\nand value in USD. The transaction is sent by its preview token until the preview expires.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "Preview transaction.",
+                "parameters": [
+                    {
+                        "description": "Preview transaction data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_transactions.PreviewTransaction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_transactions.Preview"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transaction/search": {
+            "post": {
+                "description": "Sent transactions which are not recorded in SPV Wallet yet are listed first on the first page with \"pending record\" status.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "Get all transactions.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_transactions.PaginatedTransactions"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/transaction/{id}": {
+            "get": {
+                "description": "Sent transaction which is not recorded in SPV Wallet yet has \"pending record\" status, \"record failed\" if it was given up.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "Get transaction by id.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_transactions.FullTransaction"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user": {
+            "post": {
+                "description": "Register new user with given data. Verification link is sent to the email, paymail is created after it's opened.\nPaymail alias is optional, if it's not provided it's based on username from sended email.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Register new user",
+                "parameters": [
+                    {
+                        "description": "User data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.RegisterUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.RegisterResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete user and its data. If sweepTo paymail is provided, all funds are sent to it first and the user isn't deleted if it fails.\nAccess keys are revoked, paymails are removed from SPV Wallet and the user is deleted together with its sessions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "description": "User deletion data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.DeleteUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.DeleteUserResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/2fa": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "2fa"
+                ],
+                "summary": "Get two-factor authentication settings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_twofactor.Status"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "2fa"
+                ],
+                "summary": "Disable two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "User password and TOTP or recovery code",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_twofactor.Disable"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/user/2fa/confirm": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "2fa"
+                ],
+                "summary": "Confirm two-factor enrollment with the code from authenticator app",
+                "parameters": [
+                    {
+                        "description": "TOTP code",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_twofactor.Confirm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/user/2fa/enroll": {
+            "post": {
+                "description": "Generates TOTP secret and recovery codes. Recovery codes are returned only once and each can be used instead of the code one time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "2fa"
+                ],
+                "summary": "Enroll two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "User password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_twofactor.Enroll"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_twofactor.Enrollment"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/2fa/transaction-threshold": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "2fa"
+                ],
+                "summary": "Set value in satoshis above which transactions require two-factor code",
+                "parameters": [
+                    {
+                        "description": "Threshold and TOTP or recovery code",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_twofactor.SetTransactionThreshold"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/user/activity": {
+            "get": {
+                "description": "Sign-ins, password changes, sent transactions and other actions recorded in the audit log, also failed ones.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get activity of the user, newest first",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_audit.Entry"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/export": {
+            "get": {
+                "description": "Export user record, profile, paymails, contacts and transaction history as JSON or zip archive with export.json and uploaded avatar.",
+                "produces": [
+                    "application/json",
+                    "application/zip"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Export user data",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "zip"
+                        ],
+                        "type": "string",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_exports.Export"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/identities": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sso"
+                ],
+                "summary": "Get identities linked to the wallet account",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_identities.Identity"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/identities/{id}": {
+            "delete": {
+                "description": "Identity can't be used to sign in anymore, sessions already signed in with it are kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sso"
+                ],
+                "summary": "Unlink identity from the wallet account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Identity id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/user/passkeys": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "passkeys"
+                ],
+                "summary": "Get passkeys of the user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_passkeys.Passkey"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Verifies the created credential and encrypts the xPriv with the secret derived by the passkey with the PRF extension.\nIf the authenticator doesn't return PRF results on creation, the frontend gets them with navigator.credentials.get\nwith the same extension inputs and sets them in clientExtensionResults of the credential.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "passkeys"
+                ],
+                "summary": "Register passkey",
+                "parameters": [
+                    {
+                        "description": "Created credential and wallet password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_passkeys.RegisterPasskey"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_passkeys.Passkey"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/passkeys/options": {
+            "post": {
+                "description": "Returns options for navigator.credentials.create, they request the PRF extension which is required to unlock the wallet.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "passkeys"
+                ],
+                "summary": "Start passkey registration",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webauthn.CreationOptions"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/passkeys/{id}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "passkeys"
+                ],
+                "summary": "Delete passkey",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Passkey id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/user/password": {
+            "put": {
+                "description": "Change user password. All other user sessions and API tokens are terminated first, if it fails the password isn't changed\nand the request can be repeated. Then xPriv is re-encrypted with the new password, wallet passphrase is removed and passkeys are deleted,\nso they must be set up again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Change user password",
+                "parameters": [
+                    {
+                        "description": "Password change data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.ChangePassword"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/user/paymails": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "paymails"
+                ],
+                "summary": "Get paymails of the user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_paymails.Paymail"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "paymails"
+                ],
+                "summary": "Add paymail on any domain served by SPV Wallet",
+                "parameters": [
+                    {
+                        "description": "Paymail data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_paymails.AddPaymail"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_paymails.Paymail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/paymails/{id}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "paymails"
+                ],
+                "summary": "Remove paymail which is not primary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Paymail id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/user/paymails/{id}/primary": {
+            "put": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "paymails"
+                ],
+                "summary": "Set primary paymail used as sender of transactions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Paymail id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_paymails.Paymail"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/profile": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Get public name and avatar of the user paymails",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_profiles.Profile"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Update public name of the user paymails",
+                "parameters": [
+                    {
+                        "description": "Profile data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_profiles.UpdateProfile"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_profiles.Profile"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/profile/avatar": {
+            "post": {
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Upload avatar of the user paymails",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "PNG, JPEG, GIF or WebP image",
+                        "name": "avatar",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Password, signing grant from session is used if it's empty",
+                        "name": "password",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_profiles.Profile"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/recover": {
+            "post": {
+                "description": "Recover access to the wallet with mnemonic returned on registration. xPriv is encrypted with the new password, wallet passphrase is removed, passkeys are deleted and must be registered again,\nand all user sessions, signing grants and API tokens are terminated. Failed attempts are counted as failed sign-in attempts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Recover user wallet",
+                "parameters": [
+                    {
+                        "description": "User recovery data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.RecoverUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/user/roles": {
+            "get": {
+                "description": "Frontend can use permissions to hide actions which are not allowed to the user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Get roles and permissions of the signed-in user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_roles.UserRoles"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/tokens": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tokens"
+                ],
+                "summary": "Get API tokens of the user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_tokens.Token"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Token is returned only once, it's sent in Authorization header as Bearer token instead of session cookie.\nScopes limit what the token can do: read-only, send and contacts. Sending still requires the wallet password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tokens"
+                ],
+                "summary": "Create API token",
+                "parameters": [
+                    {
+                        "description": "Token name, scopes and wallet password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_tokens.CreateToken"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_tokens.CreatedToken"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/tokens/{id}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tokens"
+                ],
+                "summary": "Revoke API token and its access key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Token id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/user/verify": {
+            "get": {
+                "description": "Verify user email with the token from verification email. User xPub and paymail are registered in SPV Wallet.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Verify user email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Verification token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.VerifyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/wallet-passphrase": {
+            "put": {
+                "description": "Set wallet passphrase, which unlocks the wallet independently of the password, e.g. to link identity signed in with single sign-on\nand to spend in sessions signed in with it. Passphrase must be different from the password, the password is required to unlock the wallet.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Set wallet passphrase",
+                "parameters": [
+                    {
+                        "description": "Wallet passphrase data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.SetWalletPassphrase"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/status": {
+            "get": {
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "status"
+                ],
+                "summary": "Check the status of the server",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/user": {
+            "get": {
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get user information",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.UserResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "filter.QueryParams": {
+            "type": "object",
+            "properties": {
+                "order_by_field": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "sort_direction": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_admin.User": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "paymail": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_admin.UserDetails": {
+            "type": "object",
+            "properties": {
+                "activeSessions": {
+                    "type": "integer"
+                },
+                "balance": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lockedUntil": {
+                    "description": "LockedUntil is set while the account is locked by an operator.",
+                    "type": "string"
+                },
+                "paymail": {
+                    "type": "string"
+                },
+                "paymails": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "signInLockedUntil": {
+                    "description": "SignInLockedUntil is set while sign-in is locked because of failed attempts.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "xpubId": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_admin.UsersPage": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_admin.User"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_audit.ChainVerification": {
+            "type": "object",
+            "properties": {
+                "brokenAtId": {
+                    "description": "BrokenAtID is id of the first entry which doesn't match its hash or the previous entry.",
+                    "type": "integer"
+                },
+                "checked": {
+                    "description": "Checked is the number of hash-chained entries which were checked.",
+                    "type": "integer"
+                },
+                "unchained": {
+                    "description": "Unchained is the number of entries recorded while hash chain was disabled, they can't be verified.",
+                    "type": "integer"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_audit.Entry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_exports.Export": {
+            "type": "object",
+            "properties": {
+                "contacts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Contact"
+                    }
+                },
+                "exportedAt": {
+                    "type": "string"
+                },
+                "paymails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_paymails.Paymail"
+                    }
+                },
+                "profile": {
+                    "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_profiles.Profile"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {}
+                },
+                "user": {
+                    "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_exports.User"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_exports.User": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "paymail": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_identities.Identity": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issuer": {
+                    "type": "string"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_operators.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "operatorEmail": {
+                    "type": "string"
+                },
+                "operatorId": {
+                    "type": "integer"
+                },
+                "outcome": {
+                    "type": "string"
+                },
+                "targetUserId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_operators.Operator": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "disabledAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_operators.Role": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_passkeys.Passkey": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "transports": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_paymails.Paymail": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "primary": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_payouts.Batch": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_payouts.Row"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "totalSatoshis": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_payouts.InvalidRow": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_payouts.Preview": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer"
+                },
+                "batch": {
+                    "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_payouts.Batch"
+                },
+                "error": {
+                    "description": "Error explains why the batch with valid rows wasn't saved.",
+                    "type": "string"
+                },
+                "invalidRows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_payouts.InvalidRow"
+                    }
+                },
+                "totalSatoshis": {
+                    "type": "integer"
+                },
+                "transactions": {
+                    "description": "Transactions is the number of transactions which pay the batch.",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_payouts.Row": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "memo": {
+                    "description": "Memo is kept only with the row, it's not written to the blockchain.",
+                    "type": "string"
+                },
+                "paymail": {
+                    "type": "string"
+                },
+                "row": {
+                    "description": "Number is the line of the row in uploaded CSV.",
+                    "type": "integer"
+                },
+                "satoshis": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transactionId": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_profiles.Profile": {
+            "type": "object",
+            "properties": {
+                "avatarUrl": {
+                    "type": "string"
+                },
+                "publicName": {
+                    "description": "PublicName is empty if the alias is used as public name.",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_roles.Role": {
+            "type": "object",
+            "properties": {
+                "default": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_roles.UserRoles": {
+            "type": "object",
+            "properties": {
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_tokens.Token": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "Prefix is the beginning of the token, it lets the user recognize the token, which itself is shown only once.",
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_transactions.PaginatedTransactions": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "pages": {
+                    "type": "integer"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {}
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_transactions.Preview": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount is the total value paid to recipients.",
+                    "type": "integer"
+                },
+                "change": {
+                    "type": "integer"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "fee": {
+                    "type": "integer"
+                },
+                "inputs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_users.DraftInput"
+                    }
+                },
+                "outputs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_users.DraftOutput"
+                    }
+                },
+                "token": {
+                    "type": "string"
+                },
+                "usd": {
+                    "description": "USD is omitted if the exchange rate is not available.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_transactions.PreviewUSD"
+                        }
+                    ]
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_transactions.PreviewUSD": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "fee": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_transactions.SentTransaction": {
+            "type": "object",
+            "properties": {
+                "draftId": {
+                    "type": "string"
+                },
+                "transactionId": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_twofactor.Enrollment": {
+            "type": "object",
+            "properties": {
+                "recoveryCodes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "uri": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_twofactor.Status": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "transactionThreshold": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_users.Balance": {
+            "type": "object",
+            "properties": {
+                "bsv": {
+                    "type": "number"
+                },
+                "satoshis": {
+                    "type": "integer"
+                },
+                "usd": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_users.DraftInput": {
+            "type": "object",
+            "properties": {
+                "outputIndex": {
+                    "type": "integer"
+                },
+                "satoshis": {
+                    "type": "integer"
+                },
+                "transactionId": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_users.DraftOutput": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "description": "Change is true for outputs which return the change to the wallet.",
+                    "type": "boolean"
+                },
+                "satoshis": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Contact": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt is a time when outer model was created.",
+                    "type": "string",
+                    "example": "2024-02-26T11:00:28.069911Z"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt is a time when outer model was deleted.",
+                    "type": "string",
+                    "example": "2024-02-26T11:02:28.069911Z"
+                },
+                "fullName": {
+                    "description": "FullName is name which could be shown instead of whole paymail address.",
+                    "type": "string",
+                    "example": "Test User"
+                },
+                "id": {
+                    "description": "ID is a unique identifier of contact.",
+                    "type": "string",
+                    "example": "68af358bde7d8641621c7dd3de1a276c9a62cfa9e2d0740494519f1ba61e2f4a"
+                },
+                "metadata": {
+                    "description": "Metadata is a metadata map of outer model.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "key": "value",
+                        "key2": "value2"
+                    }
+                },
+                "paymail": {
+                    "description": "Paymail is a paymail address related to contact.",
+                    "type": "string",
+                    "example": "test@spv-wallet.com"
+                },
+                "pubKey": {
+                    "description": "PubKey is a public key related to contact (receiver).",
+                    "type": "string",
+                    "example": "xpub661MyMwAqRbcGpZVrSHU..."
+                },
+                "status": {
+                    "description": "Status is a contact's current status.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.ContactStatus"
+                        }
+                    ],
+                    "example": "unconfirmed"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt is a time when outer model was updated.",
+                    "type": "string",
+                    "example": "2024-02-26T11:01:28.069911Z"
+                }
+            }
+        },
+        "models.Metadata": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "models.Page": {
+            "type": "object",
+            "properties": {
+                "number": {
+                    "description": "Page number",
+                    "type": "integer"
+                },
+                "orderByField": {
+                    "description": "Field by which to order the results",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "Size of the page",
+                    "type": "integer"
+                },
+                "sortDirection": {
+                    "description": "Direction in which to order the results ASC/DSC",
+                    "type": "string"
+                },
+                "totalElements": {
+                    "description": "Total count of elements",
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "description": "Total number of possible pages",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SearchContactsResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "List of records for the response",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Contact"
+                    }
+                },
+                "page": {
+                    "description": "Pagination details",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Page"
+                        }
+                    ]
+                }
+            }
+        },
+        "response.ContactStatus": {
+            "type": "string",
+            "enum": [
+                "unconfirmed",
+                "awaiting",
+                "confirmed",
+                "rejected"
+            ],
+            "x-enum-varnames": [
+                "ContactNotConfirmed",
+                "ContactAwaitAccept",
+                "ContactConfirmed",
+                "ContactRejected"
+            ]
+        },
+        "transports_http_endpoints_api_access.CreateSigningGrant": {
+            "type": "object",
+            "properties": {
+                "passphrase": {
+                    "description": "Passphrase is the wallet passphrase, it's used if password is empty, e.g. in sessions signed in with single sign-on.",
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_access.SignInResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_users.Balance"
+                },
+                "paymail": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_access.SignInUser": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is TOTP or recovery code, required only if the user has two-factor authentication enabled.",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_access.SigningGrantResponse": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_admin.CreateOperator": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_admin.LockUser": {
+            "type": "object",
+            "properties": {
+                "until": {
+                    "description": "Until is time until which sign-in is locked, account is locked until it's unlocked by operator if it's empty.",
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_admin.LockUserResponse": {
+            "type": "object",
+            "properties": {
+                "lockedUntil": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_admin.SearchTransactions": {
+            "type": "object",
+            "properties": {
+                "params": {
+                    "$ref": "#/definitions/filter.QueryParams"
+                }
+            }
+        },
+        "transports_http_endpoints_api_admin.SignInOperator": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_admin.SignInResponse": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "description": "ExpiresAt is time after which operator needs to sign in again.",
+                    "type": "string"
+                },
+                "operator": {
+                    "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_operators.Operator"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_admin.TerminateSessionsResponse": {
+            "type": "object",
+            "properties": {
+                "terminated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "transports_http_endpoints_api_config.PublicConfig": {
+            "type": "object",
+            "properties": {
+                "experimental_features": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "paymail_domain": {
+                    "type": "string"
+                },
+                "paymail_domains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "transports_http_endpoints_api_contacts.ConfirmContact": {
+            "type": "object",
+            "properties": {
+                "contact": {
+                    "$ref": "#/definitions/models.Contact"
+                },
+                "passcode": {
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Password is optional, signing grant from session is used if it's empty.",
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_contacts.SearchContact": {
+            "type": "object",
+            "properties": {
+                "conditions": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "metadata": {
+                    "$ref": "#/definitions/models.Metadata"
+                },
+                "params": {
+                    "$ref": "#/definitions/filter.QueryParams"
+                }
+            }
+        },
+        "transports_http_endpoints_api_contacts.UpsertContact": {
+            "type": "object",
+            "properties": {
+                "fullName": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "password": {
+                    "description": "Password is optional, signing grant from session is used if it's empty.",
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_passkeys.RegisterPasskey": {
+            "type": "object",
+            "properties": {
+                "credential": {
+                    "description": "Credential is the result of navigator.credentials.create serialized with PublicKeyCredential.toJSON.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/webauthn.RegistrationResponse"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "Name is shown in the list of passkeys, e.g. name of the device.",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Password unlocks the wallet, so its xPriv can be encrypted with the secret derived by the passkey.",
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_passkeys.SignInResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_users.Balance"
+                },
+                "paymail": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_passkeys.SigningGrantResponse": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_paymails.AddPaymail": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "domain": {
+                    "description": "Domain is optional, configured paymail domain is used if it's empty.",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Password is optional, signing grant from session is used if it's empty.",
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_profiles.UpdateProfile": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "description": "Password is optional, signing grant from session is used if it's empty.",
+                    "type": "string"
+                },
+                "publicName": {
+                    "description": "PublicName is shown by wallets for the user paymails, empty value resets it to the paymail alias.",
                     "type": "string"
                 }
             }
         },
-        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_transactions.PaginatedTransactions": {
+        "transports_http_endpoints_api_sessions.Session": {
             "type": "object",
             "properties": {
-                "count": {
+                "accessKeyId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "boolean"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "pages": {
+                "ip": {
+                    "type": "string"
+                },
+                "lastSeenAt": {
+                    "type": "string"
+                },
+                "userAgent": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_sso.LinkIdentity": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is TOTP or recovery code, required only if the user has two-factor authentication enabled.",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "Email is optional, verified email of the identity is used if it's empty.",
+                    "type": "string"
+                },
+                "passphrase": {
+                    "description": "Passphrase is the wallet passphrase, it's separate from the password of the account.",
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_sso.SignInResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_users.Balance"
+                },
+                "paymail": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_tokens.CreateToken": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "description": "ExpiresAt is optional, the token is valid until it's revoked if it's not set.",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name is shown in the list of tokens, e.g. name of the integration using the token.",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Password unlocks the wallet, so access key of the token can be created.",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "Scopes are any of read-only, send and contacts.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "transports_http_endpoints_api_tokens.CreatedToken": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "transactions": {
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "Prefix is the beginning of the token, it lets the user recognize the token, which itself is shown only once.",
+                    "type": "string"
+                },
+                "scopes": {
                     "type": "array",
-                    "items": {}
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token": {
+                    "description": "Value is the token itself, it isn't stored and can't be shown again.",
+                    "type": "string"
                 }
             }
         },
-        "github_com_bitcoin-sv_spv-wallet-web-backend_domain_users.Balance": {
+        "transports_http_endpoints_api_transactions.CreateTransaction": {
             "type": "object",
             "properties": {
-                "bsv": {
-                    "type": "number"
+                "code": {
+                    "description": "Code is TOTP or recovery code, required only above the user two-factor transaction threshold.",
+                    "type": "string"
+                },
+                "passphrase": {
+                    "description": "Passphrase is the wallet passphrase, it's used if password is empty, e.g. in sessions signed in with single sign-on.",
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "previewToken": {
+                    "description": "PreviewToken is the token of the preview which is sent.",
+                    "type": "string"
+                },
+                "recipient": {
+                    "description": "Recipient and Satoshis pay a single recipient, they're used only if Recipients are not set.",
+                    "type": "string"
+                },
+                "recipients": {
+                    "description": "Recipients are paid by one transaction, every recipient can be listed only once.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/transports_http_endpoints_api_transactions.Recipient"
+                    }
                 },
                 "satoshis": {
                     "type": "integer"
+                }
+            }
+        },
+        "transports_http_endpoints_api_transactions.ExecuteBatch": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is TOTP or recovery code, required only if unpaid total of the batch is above the user two-factor transaction threshold.",
+                    "type": "string"
                 },
-                "usd": {
-                    "type": "number"
+                "passphrase": {
+                    "description": "Passphrase is the wallet passphrase, it's used if password is empty, e.g. in sessions signed in with single sign-on.",
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
                 }
             }
         },
-        "models.Contact": {
+        "transports_http_endpoints_api_transactions.FullTransaction": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "description": "CreatedAt is a time when outer model was created.",
-                    "type": "string",
-                    "example": "2024-02-26T11:00:28.069911Z"
+                "blockHash": {
+                    "type": "string"
                 },
-                "deleted_at": {
-                    "description": "DeletedAt is a time when outer model was deleted.",
-                    "type": "string",
-                    "example": "2024-02-26T11:02:28.069911Z"
+                "blockHeight": {
+                    "type": "integer"
                 },
-                "fullName": {
-                    "description": "FullName is name which could be shown instead of whole paymail address.",
-                    "type": "string",
-                    "example": "Test User"
+                "createdAt": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "fee": {
+                    "type": "integer"
                 },
                 "id": {
-                    "description": "ID is a unique identifier of contact.",
-                    "type": "string",
-                    "example": "68af358bde7d8641621c7dd3de1a276c9a62cfa9e2d0740494519f1ba61e2f4a"
+                    "type": "string"
                 },
-                "metadata": {
-                    "description": "Metadata is a metadata map of outer model.",
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    },
-                    "example": {
-                        "key": "value",
-                        "key2": "value2"
-                    }
+                "numberOfInputs": {
+                    "type": "integer"
                 },
-                "paymail": {
-                    "description": "Paymail is a paymail address related to contact.",
-                    "type": "string",
-                    "example": "test@spv-wallet.com"
+                "numberOfOutputs": {
+                    "type": "integer"
                 },
-                "pubKey": {
-                    "description": "PubKey is a public key related to contact (receiver).",
-                    "type": "string",
-                    "example": "xpub661MyMwAqRbcGpZVrSHU..."
+                "receiver": {
+                    "type": "string"
+                },
+                "sender": {
+                    "type": "string"
                 },
                 "status": {
-                    "description": "Status is a contact's current status.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/response.ContactStatus"
-                        }
-                    ],
-                    "example": "unconfirmed"
+                    "type": "string"
+                },
+                "totalValue": {
+                    "type": "integer"
+                }
+            }
+        },
+        "transports_http_endpoints_api_transactions.PreviewTransaction": {
+            "type": "object",
+            "properties": {
+                "recipient": {
+                    "description": "Recipient and Satoshis pay a single recipient, they're used only if Recipients are not set.",
+                    "type": "string"
+                },
+                "recipients": {
+                    "description": "Recipients are paid by one transaction, every recipient can be listed only once.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/transports_http_endpoints_api_transactions.Recipient"
+                    }
+                },
+                "satoshis": {
+                    "type": "integer"
+                }
+            }
+        },
+        "transports_http_endpoints_api_transactions.Recipient": {
+            "type": "object",
+            "properties": {
+                "opReturn": {
+                    "description": "OpReturn is optional text attached to the output.",
+                    "type": "string"
+                },
+                "recipient": {
+                    "type": "string"
+                },
+                "satoshis": {
+                    "type": "integer"
+                }
+            }
+        },
+        "transports_http_endpoints_api_twofactor.Confirm": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_twofactor.Disable": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_twofactor.Enroll": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_twofactor.SetTransactionThreshold": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "satoshis": {
+                    "description": "Satoshis is a threshold value, null disables the code requirement for transactions.",
+                    "type": "integer"
+                }
+            }
+        },
+        "transports_http_endpoints_api_users.AliasAvailabilityResponse": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "available": {
+                    "type": "boolean"
+                },
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "transports_http_endpoints_api_users.ChangePassword": {
+            "type": "object",
+            "properties": {
+                "newPassword": {
+                    "type": "string"
+                },
+                "newPasswordConfirmation": {
+                    "type": "string"
+                },
+                "oldPassword": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_users.DeleteUser": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
                 },
-                "updated_at": {
-                    "description": "UpdatedAt is a time when outer model was updated.",
-                    "type": "string",
-                    "example": "2024-02-26T11:01:28.069911Z"
+                "sweepTo": {
+                    "description": "SweepTo is optional, if it's provided all funds are sent to this paymail before the user is deleted.",
+                    "type": "string"
                 }
             }
         },
-        "models.Metadata": {
+        "transports_http_endpoints_api_users.DeleteUserResponse": {
             "type": "object",
-            "additionalProperties": true
+            "properties": {
+                "sweepTransactionId": {
+                    "description": "SweepTransactionID is empty if funds weren't sent.",
+                    "type": "string"
+                }
+            }
         },
-        "models.Page": {
+        "transports_http_endpoints_api_users.RecoverUser": {
             "type": "object",
             "properties": {
-                "number": {
-                    "description": "Page number",
-                    "type": "integer"
-                },
-                "orderByField": {
-                    "description": "Field by which to order the results",
+                "email": {
                     "type": "string"
                 },
-                "size": {
-                    "description": "Size of the page",
-                    "type": "integer"
-                },
-                "sortDirection": {
-                    "description": "Direction in which to order the results ASC/DSC",
+                "mnemonic": {
                     "type": "string"
                 },
-                "totalElements": {
-                    "description": "Total count of elements",
-                    "type": "integer"
+                "password": {
+                    "type": "string"
                 },
-                "totalPages": {
-                    "description": "Total number of possible pages",
-                    "type": "integer"
+                "passwordConfirmation": {
+                    "type": "string"
                 }
             }
         },
-        "models.SearchContactsResponse": {
+        "transports_http_endpoints_api_users.RegisterResponse": {
             "type": "object",
             "properties": {
-                "content": {
-                    "description": "List of records for the response",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Contact"
-                    }
+                "mnemonic": {
+                    "type": "string"
                 },
-                "page": {
-                    "description": "Pagination details",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.Page"
-                        }
-                    ]
+                "paymail": {
+                    "description": "Paymail is empty until the user verifies email.",
+                    "type": "string"
                 }
             }
         },
-        "response.ContactStatus": {
-            "type": "string",
-            "enum": [
-                "unconfirmed",
-                "awaiting",
-                "confirmed",
-                "rejected"
-            ],
-            "x-enum-varnames": [
-                "ContactNotConfirmed",
-                "ContactAwaitAccept",
-                "ContactConfirmed",
-                "ContactRejected"
-            ]
-        },
-        "transports_http_endpoints_api_access.SignInResponse": {
+        "transports_http_endpoints_api_users.RegisterUser": {
             "type": "object",
             "properties": {
-                "balance": {
-                    "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_users.Balance"
+                "alias": {
+                    "description": "Alias is optional, paymail alias is based on email username if it's empty.",
+                    "type": "string"
                 },
-                "paymail": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "passwordConfirmation": {
                     "type": "string"
                 }
             }
         },
-        "transports_http_endpoints_api_access.SignInUser": {
+        "transports_http_endpoints_api_users.SetWalletPassphrase": {
             "type": "object",
             "properties": {
-                "email": {
+                "passphrase": {
+                    "type": "string"
+                },
+                "passphraseConfirmation": {
                     "type": "string"
                 },
                 "password": {
+                    "description": "Password unlocks the wallet, so its xPriv can be encrypted with the passphrase.",
                     "type": "string"
                 }
             }
         },
-        "transports_http_endpoints_api_config.PublicConfig": {
+        "transports_http_endpoints_api_users.UserResponse": {
             "type": "object",
             "properties": {
-                "experimental_features": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "boolean"
-                    }
+                "balance": {
+                    "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_users.Balance"
                 },
-                "paymail_domain": {
+                "email": {
+                    "type": "string"
+                },
+                "paymail": {
                     "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
                 }
             }
         },
-        "transports_http_endpoints_api_contacts.ConfirmContact": {
+        "transports_http_endpoints_api_users.VerifyResponse": {
             "type": "object",
             "properties": {
-                "contact": {
-                    "$ref": "#/definitions/models.Contact"
-                },
-                "passcode": {
+                "paymail": {
                     "type": "string"
                 }
             }
         },
-        "transports_http_endpoints_api_contacts.SearchContact": {
+        "webauthn.AssertionResponse": {
             "type": "object",
             "properties": {
-                "conditions": {
-                    "type": "object",
-                    "additionalProperties": true
+                "clientExtensionResults": {
+                    "$ref": "#/definitions/webauthn.ClientExtensionResults"
                 },
-                "metadata": {
-                    "$ref": "#/definitions/models.Metadata"
+                "id": {
+                    "type": "string"
                 },
-                "params": {
-                    "$ref": "#/definitions/filter.QueryParams"
+                "rawId": {
+                    "type": "string"
+                },
+                "response": {
+                    "$ref": "#/definitions/webauthn.AuthenticatorAssertion"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "transports_http_endpoints_api_contacts.UpsertContact": {
+        "webauthn.AuthenticatorAssertion": {
             "type": "object",
             "properties": {
-                "fullName": {
+                "authenticatorData": {
                     "type": "string"
                 },
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": {}
+                "clientDataJSON": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "userHandle": {
+                    "type": "string"
                 }
             }
         },
-        "transports_http_endpoints_api_transactions.CreateTransaction": {
+        "webauthn.AuthenticatorAttestation": {
             "type": "object",
             "properties": {
-                "password": {
+                "attestationObject": {
                     "type": "string"
                 },
-                "recipient": {
+                "clientDataJSON": {
                     "type": "string"
                 },
-                "satoshis": {
-                    "type": "integer"
+                "transports": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "transports_http_endpoints_api_transactions.FullTransaction": {
+        "webauthn.AuthenticatorSelection": {
             "type": "object",
             "properties": {
-                "blockHash": {
+                "residentKey": {
                     "type": "string"
                 },
-                "blockHeight": {
-                    "type": "integer"
-                },
-                "createdAt": {
+                "userVerification": {
+                    "type": "string"
+                }
+            }
+        },
+        "webauthn.ClientExtensionResults": {
+            "type": "object",
+            "properties": {
+                "prf": {
+                    "$ref": "#/definitions/webauthn.PRFOutputs"
+                }
+            }
+        },
+        "webauthn.CreationOptions": {
+            "type": "object",
+            "properties": {
+                "attestation": {
                     "type": "string"
                 },
-                "direction": {
+                "authenticatorSelection": {
+                    "$ref": "#/definitions/webauthn.AuthenticatorSelection"
+                },
+                "challenge": {
                     "type": "string"
                 },
-                "fee": {
+                "excludeCredentials": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/webauthn.CredentialDescriptor"
+                    }
+                },
+                "extensions": {
+                    "$ref": "#/definitions/webauthn.Extensions"
+                },
+                "pubKeyCredParams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/webauthn.CredentialParameter"
+                    }
+                },
+                "rp": {
+                    "$ref": "#/definitions/webauthn.RelyingPartyEntity"
+                },
+                "timeout": {
                     "type": "integer"
                 },
+                "user": {
+                    "$ref": "#/definitions/webauthn.UserEntity"
+                }
+            }
+        },
+        "webauthn.CredentialDescriptor": {
+            "type": "object",
+            "properties": {
                 "id": {
                     "type": "string"
                 },
-                "numberOfInputs": {
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "webauthn.CredentialParameter": {
+            "type": "object",
+            "properties": {
+                "alg": {
                     "type": "integer"
                 },
-                "numberOfOutputs": {
-                    "type": "integer"
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "webauthn.Extensions": {
+            "type": "object",
+            "properties": {
+                "prf": {
+                    "$ref": "#/definitions/webauthn.PRFInputs"
+                }
+            }
+        },
+        "webauthn.PRFInputs": {
+            "type": "object",
+            "properties": {
+                "eval": {
+                    "$ref": "#/definitions/webauthn.PRFValues"
+                }
+            }
+        },
+        "webauthn.PRFOutputs": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
                 },
-                "receiver": {
+                "results": {
+                    "$ref": "#/definitions/webauthn.PRFValues"
+                }
+            }
+        },
+        "webauthn.PRFValues": {
+            "type": "object",
+            "properties": {
+                "first": {
                     "type": "string"
+                }
+            }
+        },
+        "webauthn.RegistrationResponse": {
+            "type": "object",
+            "properties": {
+                "clientExtensionResults": {
+                    "$ref": "#/definitions/webauthn.ClientExtensionResults"
                 },
-                "sender": {
+                "id": {
                     "type": "string"
                 },
-                "status": {
+                "rawId": {
                     "type": "string"
                 },
-                "totalValue": {
-                    "type": "integer"
+                "response": {
+                    "$ref": "#/definitions/webauthn.AuthenticatorAttestation"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "transports_http_endpoints_api_users.RegisterResponse": {
+        "webauthn.RelyingPartyEntity": {
             "type": "object",
             "properties": {
-                "mnemonic": {
+                "id": {
                     "type": "string"
                 },
-                "paymail": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "transports_http_endpoints_api_users.RegisterUser": {
+        "webauthn.RequestOptions": {
             "type": "object",
             "properties": {
-                "email": {
+                "allowCredentials": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/webauthn.CredentialDescriptor"
+                    }
+                },
+                "challenge": {
                     "type": "string"
                 },
-                "password": {
+                "extensions": {
+                    "$ref": "#/definitions/webauthn.Extensions"
+                },
+                "rpId": {
                     "type": "string"
                 },
-                "passwordConfirmation": {
+                "timeout": {
+                    "type": "integer"
+                },
+                "userVerification": {
                     "type": "string"
                 }
             }
         },
-        "transports_http_endpoints_api_users.UserResponse": {
+        "webauthn.UserEntity": {
             "type": "object",
             "properties": {
-                "balance": {
-                    "$ref": "#/definitions/github_com_bitcoin-sv_spv-wallet-web-backend_domain_users.Balance"
-                },
-                "email": {
+                "displayName": {
                     "type": "string"
                 },
-                "paymail": {
+                "id": {
                     "type": "string"
                 },
-                "userId": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Operator token of the admin API prefixed with \"Bearer \".",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -0,0 +1,47 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+)
+
+// User represents user shown to operators, without the encrypted xPriv.
+type User struct {
+	ID        int       `json:"id"`
+	Email     string    `json:"email"`
+	Paymail   string    `json:"paymail"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// UsersPage represents page of users matching the search query.
+type UsersPage struct {
+	Content  []*User `json:"content"`
+	Total    int     `json:"total"`
+	Page     int     `json:"page"`
+	PageSize int     `json:"pageSize"`
+}
+
+// UserDetails represents user together with its SPV Wallet xPub and state of its account in the backend.
+type UserDetails struct {
+	User
+	Paymails []string `json:"paymails"`
+	XpubID   string   `json:"xpubId,omitempty"`
+	Balance  uint64   `json:"balance"`
+	// LockedUntil is set while the account is locked by an operator.
+	LockedUntil *time.Time `json:"lockedUntil,omitempty"`
+	// SignInLockedUntil is set while sign-in is locked because of failed attempts.
+	SignInLockedUntil *time.Time `json:"signInLockedUntil,omitempty"`
+	ActiveSessions    int        `json:"activeSessions"`
+}
+
+func toUser(user *users.User) *User {
+	return &User{
+		ID:        user.ID,
+		Email:     user.Email,
+		Paymail:   user.Paymail,
+		Status:    user.Status,
+		CreatedAt: user.CreatedAt,
+	}
+}
@@ -0,0 +1,178 @@
+package admin
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/grants"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/lockout"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/paymails"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/sessions"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/tokens"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/rs/zerolog"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// Service provides operators with access to users and their accounts.
+type Service struct {
+	usersService      *users.UserService
+	paymailsService   *paymails.Service
+	lockoutService    *lockout.Service
+	sessionsService   *sessions.Service
+	tokensService     *tokens.Service
+	grantsService     *grants.Service
+	adminWalletClient users.AdminWalletClient
+	log               *zerolog.Logger
+}
+
+// NewAdminService creates a new admin service.
+func NewAdminService(usersService *users.UserService, paymailsService *paymails.Service, lockoutService *lockout.Service, sessionsService *sessions.Service, tokensService *tokens.Service, grantsService *grants.Service, adminWalletClient users.AdminWalletClient, log *zerolog.Logger) *Service {
+	adminServiceLogger := log.With().Str("service", "admin-service").Logger()
+	return &Service{
+		usersService:      usersService,
+		paymailsService:   paymailsService,
+		lockoutService:    lockoutService,
+		sessionsService:   sessionsService,
+		tokensService:     tokensService,
+		grantsService:     grantsService,
+		adminWalletClient: adminWalletClient,
+		log:               &adminServiceLogger,
+	}
+}
+
+// SearchUsers returns page of users whose email or paymail contains the query. Empty query returns all users.
+func (s *Service) SearchUsers(query string, page, pageSize int) (*UsersPage, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > maxPageSize {
+		pageSize = defaultPageSize
+	}
+
+	found, total, err := s.usersService.SearchUsers(query, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	content := make([]*User, 0, len(found))
+	for _, user := range found {
+		content = append(content, toUser(user))
+	}
+
+	return &UsersPage{Content: content, Total: total, Page: page, PageSize: pageSize}, nil
+}
+
+// GetUserDetails returns user with its paymails, xPub ID and balance from SPV Wallet, lock set by operator,
+// sign-in lock caused by failed attempts and number of active sessions.
+func (s *Service) GetUserDetails(userID int) (*UserDetails, error) {
+	user, err := s.usersService.GetUserByID(userID)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	userPaymails, err := s.paymailsService.GetPaymails(userID)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	activeSessions, err := s.sessionsService.GetActiveSessions(userID)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	signInLockedUntil, err := s.lockoutService.LockedUntil(user.Email)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	details := &UserDetails{
+		User:           *toUser(user),
+		Paymails:       make([]string, 0, len(userPaymails)),
+		ActiveSessions: len(activeSessions),
+	}
+	for _, paymail := range userPaymails {
+		details.Paymails = append(details.Paymails, paymail.Address)
+	}
+	if user.Locked() {
+		details.LockedUntil = user.LockedUntil
+	}
+	if !signInLockedUntil.IsZero() {
+		details.SignInLockedUntil = &signInLockedUntil
+	}
+
+	// Users who didn't verify email yet have nothing registered in SPV Wallet.
+	if user.Paymail == "" {
+		return details, nil
+	}
+
+	xpub, err := s.adminWalletClient.GetPaymailXPub(user.Paymail)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting xPub: %v", err.Error())
+		return nil, spverrors.ErrGetXPub
+	}
+	details.XpubID = xpub.GetID()
+	details.Balance = xpub.GetCurrentBalance()
+
+	return details, nil
+}
+
+// LockUser locks the user account until given time and signs the user out of all sessions,
+// so the lock applies to the user who is already signed in too.
+func (s *Service) LockUser(userID int, until time.Time) error {
+	if _, err := s.usersService.GetUserByID(userID); err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	if err := s.usersService.LockUser(userID, until); err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	_, err := s.signOut(userID)
+	return err
+}
+
+// UnlockUser removes the lock of the user account set by operator and resets failed sign-in attempts of the user.
+func (s *Service) UnlockUser(userID int) error {
+	user, err := s.usersService.GetUserByID(userID)
+	if err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	if err = s.usersService.UnlockUser(userID); err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	return s.lockoutService.Unlock(user.Email) //nolint:wrapcheck // error wrapped higher in call stack
+}
+
+// TerminateSessions signs the user out of all sessions and returns number of terminated sessions.
+func (s *Service) TerminateSessions(userID int) (int, error) {
+	if _, err := s.usersService.GetUserByID(userID); err != nil {
+		return 0, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	return s.signOut(userID)
+}
+
+// signOut terminates sessions of the user, revokes its signing grants and API tokens and returns number of terminated sessions.
+func (s *Service) signOut(userID int) (int, error) {
+	terminated, err := s.sessionsService.TerminateUserSessions(userID)
+	if err != nil {
+		return 0, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	s.grantsService.RevokeUserGrants(userID)
+
+	if _, err = s.tokensService.RevokeUserTokens(userID); err != nil {
+		return 0, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+	return terminated, nil
+}
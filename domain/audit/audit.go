@@ -0,0 +1,139 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Outcomes of recorded actions.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// Actions recorded in the security audit log.
+const (
+	ActionSignIn             = "sign-in"
+	ActionSignOut            = "sign-out"
+	ActionRegister           = "register"
+	ActionRecover            = "recover"
+	ActionVerifyEmail        = "verify-email"
+	ActionCheckAlias         = "check-alias"
+	ActionCreateSigningGrant = "create-signing-grant"
+
+	ActionSSOSignIn      = "sso-sign-in"
+	ActionLinkIdentity   = "link-identity"
+	ActionViewIdentities = "view-identities"
+	ActionUnlinkIdentity = "unlink-identity"
+
+	ActionPasskeySignIn   = "passkey-sign-in"
+	ActionPasskeyUnlock   = "passkey-unlock"
+	ActionRegisterPasskey = "register-passkey"
+	ActionViewPasskeys    = "view-passkeys"
+	ActionDeletePasskey   = "delete-passkey"
+
+	ActionCreateAPIToken = "create-api-token"
+	ActionViewAPITokens  = "view-api-tokens"
+	ActionRevokeAPIToken = "revoke-api-token"
+
+	ActionViewAccount         = "view-account"
+	ActionChangePassword      = "change-password"
+	ActionSetWalletPassphrase = "set-wallet-passphrase"
+	ActionDeleteAccount       = "delete-account"
+	ActionExportAccount       = "export-account"
+	ActionViewActivity        = "view-activity"
+
+	ActionViewTransactions   = "view-transactions"
+	ActionViewTransaction    = "view-transaction"
+	ActionSendTransaction    = "send-transaction"
+	ActionPreviewTransaction = "preview-transaction"
+
+	ActionUploadPayoutBatch  = "upload-payout-batch"
+	ActionViewPayoutBatch    = "view-payout-batch"
+	ActionExecutePayoutBatch = "execute-payout-batch"
+
+	ActionViewContacts   = "view-contacts"
+	ActionUpsertContact  = "upsert-contact"
+	ActionAcceptContact  = "accept-contact"
+	ActionRejectContact  = "reject-contact"
+	ActionConfirmContact = "confirm-contact"
+	ActionGenerateTotp   = "generate-totp"
+
+	ActionViewProfile   = "view-profile"
+	ActionUpdateProfile = "update-profile"
+	ActionUploadAvatar  = "upload-avatar"
+
+	ActionViewPaymails      = "view-paymails"
+	ActionAddPaymail        = "add-paymail"
+	ActionSetPrimaryPaymail = "set-primary-paymail"
+	ActionRemovePaymail     = "remove-paymail"
+
+	ActionViewSessions  = "view-sessions"
+	ActionRevokeSession = "revoke-session"
+
+	ActionViewTwoFactor           = "view-2fa"
+	ActionEnrollTwoFactor         = "enroll-2fa"
+	ActionConfirmTwoFactor        = "confirm-2fa"
+	ActionDisableTwoFactor        = "disable-2fa"
+	ActionSetTransactionThreshold = "set-transaction-threshold"
+
+	ActionViewOwnRoles = "view-own-roles"
+)
+
+// Actor represents who performed the action. UserID is nil if the actor isn't signed in, e.g. on failed sign-in.
+type Actor struct {
+	UserID *int
+	Name   string
+	IP     string
+}
+
+// Entry represents an action recorded in the security audit log.
+type Entry struct {
+	ID        int64     `json:"id"`
+	UserID    *int      `json:"userId,omitempty"`
+	Actor     string    `json:"actor"`
+	IP        string    `json:"ip"`
+	Action    string    `json:"action"`
+	Target    string    `json:"target,omitempty"`
+	Outcome   string    `json:"outcome"`
+	Details   string    `json:"details,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	PrevHash  string    `json:"-"`
+	Hash      string    `json:"-"`
+}
+
+// ChainVerification represents result of the hash chain verification.
+type ChainVerification struct {
+	// Checked is the number of hash-chained entries which were checked.
+	Checked int `json:"checked"`
+	// Unchained is the number of entries recorded while hash chain was disabled, they can't be verified.
+	Unchained int  `json:"unchained"`
+	Valid     bool `json:"valid"`
+	// BrokenAtID is id of the first entry which doesn't match its hash or the previous entry.
+	BrokenAtID *int64 `json:"brokenAtId,omitempty"`
+}
+
+// ComputeHash returns hash of the entry chained to the hash of the previous entry. ID is not part of the hash,
+// so gaps in ids left by rolled back inserts don't break the chain.
+func (e *Entry) ComputeHash(prevHash string) string {
+	userID := ""
+	if e.UserID != nil {
+		userID = strconv.Itoa(*e.UserID)
+	}
+
+	hash := sha256.Sum256([]byte(strings.Join([]string{
+		prevHash,
+		userID,
+		e.Actor,
+		e.IP,
+		e.Action,
+		e.Target,
+		e.Outcome,
+		e.Details,
+		e.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}, "\x1f")))
+	return hex.EncodeToString(hash[:])
+}
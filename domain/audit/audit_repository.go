@@ -0,0 +1,13 @@
+package audit
+
+import "context"
+
+// Repository is an interface which defines methods for the security audit log. Entries can only be appended.
+type Repository interface {
+	// InsertEntry appends the entry. If chained is true, its hash is computed from hash of the last chained entry.
+	InsertEntry(ctx context.Context, entry *Entry, chained bool) error
+	GetUserEntries(ctx context.Context, userID, limit, offset int) ([]*Entry, error)
+	GetEntries(ctx context.Context, userID *int, limit, offset int) ([]*Entry, error)
+	// GetEntriesAfter returns entries with id greater than afterID in the order they were appended.
+	GetEntriesAfter(ctx context.Context, afterID int64, limit int) ([]*Entry, error)
+}
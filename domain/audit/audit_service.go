@@ -0,0 +1,160 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/models"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+	// verifyBatchSize number of entries loaded at once when hash chain is verified.
+	verifyBatchSize = 1000
+	// maxNameLength is the length of actor and target columns. Actor can be any email which the user tried to sign in with,
+	// so longer names are truncated instead of the entry being refused.
+	maxNameLength = 320
+)
+
+// Service records security-relevant actions of users to the append-only audit log.
+type Service struct {
+	repo      Repository
+	hashChain bool
+	log       *zerolog.Logger
+}
+
+// NewAuditService creates a new audit service.
+func NewAuditService(repo Repository, log *zerolog.Logger) *Service {
+	auditServiceLogger := log.With().Str("service", "audit-service").Logger()
+	return &Service{
+		repo:      repo,
+		hashChain: viper.GetBool(config.EnvAuditHashChain),
+		log:       &auditServiceLogger,
+	}
+}
+
+// Record appends the action to the audit log, err is the error which the action failed with or nil. Only code of the error
+// is stored, so internal error messages are never shown to users. Errors are only logged, the action already happened.
+func (s *Service) Record(actor Actor, action, target string, err error) {
+	entry := &Entry{
+		UserID:  actor.UserID,
+		Actor:   truncate(actor.Name, maxNameLength),
+		IP:      actor.IP,
+		Action:  action,
+		Target:  truncate(target, maxNameLength),
+		Outcome: OutcomeSuccess,
+		// Postgres keeps microseconds only, hash must be computed from the stored value.
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	if err != nil {
+		entry.Outcome = OutcomeFailure
+		entry.Details = errorCode(err)
+	}
+
+	if err = s.repo.InsertEntry(context.Background(), entry, s.hashChain); err != nil {
+		logEvent := s.log.Error().
+			Str("actor", entry.Actor).
+			Str("action", entry.Action).
+			Str("outcome", entry.Outcome)
+		if entry.UserID != nil {
+			logEvent = logEvent.Str("userID", strconv.Itoa(*entry.UserID))
+		}
+		logEvent.Msgf("Error while recording action: %v", err.Error())
+	}
+}
+
+// GetUserActivity returns page of actions performed by the user, newest first.
+func (s *Service) GetUserActivity(userID, page, pageSize int) ([]*Entry, error) {
+	limit, offset := paging(page, pageSize)
+	entries, err := s.repo.GetUserEntries(context.Background(), userID, limit, offset)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting user activity: %v", err.Error())
+		return nil, spverrors.ErrGetAuditLog
+	}
+	return entries, nil
+}
+
+// GetEntries returns page of the audit log, newest first. Entries can be limited to actions performed by one user.
+func (s *Service) GetEntries(userID *int, page, pageSize int) ([]*Entry, error) {
+	limit, offset := paging(page, pageSize)
+	entries, err := s.repo.GetEntries(context.Background(), userID, limit, offset)
+	if err != nil {
+		s.log.Error().Msgf("Error while getting audit log: %v", err.Error())
+		return nil, spverrors.ErrGetAuditLog
+	}
+	return entries, nil
+}
+
+// VerifyChain checks that no hash-chained entry was changed or removed. Removal of the newest entries can't be detected,
+// because there is no later entry chained to them.
+func (s *Service) VerifyChain() (*ChainVerification, error) {
+	result := &ChainVerification{Valid: true}
+	lastHash := ""
+	afterID := int64(0)
+
+	for {
+		entries, err := s.repo.GetEntriesAfter(context.Background(), afterID, verifyBatchSize)
+		if err != nil {
+			s.log.Error().Msgf("Error while getting audit log entries: %v", err.Error())
+			return nil, spverrors.ErrGetAuditLog
+		}
+
+		for _, entry := range entries {
+			afterID = entry.ID
+			if entry.Hash == "" {
+				result.Unchained++
+				continue
+			}
+
+			result.Checked++
+			if entry.PrevHash != lastHash || entry.ComputeHash(entry.PrevHash) != entry.Hash {
+				result.Valid = false
+				result.BrokenAtID = &entry.ID
+				return result, nil
+			}
+			lastHash = entry.Hash
+		}
+
+		if len(entries) < verifyBatchSize {
+			return result, nil
+		}
+	}
+}
+
+func paging(page, pageSize int) (limit, offset int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > maxPageSize {
+		pageSize = defaultPageSize
+	}
+	return pageSize, (page - 1) * pageSize
+}
+
+func errorCode(err error) string {
+	var extendedErr models.ExtendedError
+	if errors.As(err, &extendedErr) {
+		return extendedErr.GetCode()
+	}
+	return models.UnknownErrorCode
+}
+
+// truncate returns the value cut to maxLength characters. Invalid UTF-8 is replaced, so the value is accepted by the database.
+func truncate(value string, maxLength int) string {
+	value = strings.ToValidUTF8(value, string(utf8.RuneError))
+	if utf8.RuneCountInString(value) <= maxLength {
+		return value
+	}
+	return string([]rune(value)[:maxLength])
+}
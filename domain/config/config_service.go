@@ -76,6 +76,7 @@ func (s *Service) makePublicConfig(shared *models.SharedConfig) *PublicConfig {
 
 	return &PublicConfig{
 		PaymailDomain:        configuredPaymailDomain,
+		PaymailDomains:       shared.PaymailDomains,
 		ExperimentalFeatures: shared.ExperimentalFeatures,
 	}
 }
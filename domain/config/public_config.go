@@ -3,5 +3,6 @@ package config
 // PublicConfig represents a config that is exposed to the public.
 type PublicConfig struct {
 	PaymailDomain        string          `json:"paymail_domain"`
+	PaymailDomains       []string        `json:"paymail_domains"`
 	ExperimentalFeatures map[string]bool `json:"experimental_features"`
 }
@@ -0,0 +1,29 @@
+package exports
+
+import (
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/paymails"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/profiles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet/models"
+)
+
+// Export contains all data kept about the user by the backend and SPV Wallet.
+type Export struct {
+	ExportedAt   time.Time           `json:"exportedAt"`
+	User         *User               `json:"user"`
+	Profile      *profiles.Profile   `json:"profile"`
+	Paymails     []*paymails.Paymail `json:"paymails"`
+	Contacts     []*models.Contact   `json:"contacts"`
+	Transactions []users.Transaction `json:"transactions"`
+}
+
+// User is the exported user record. Encrypted xPriv isn't part of it, the mnemonic is the backup of the wallet.
+type User struct {
+	ID        int       `json:"id"`
+	Email     string    `json:"email"`
+	Paymail   string    `json:"paymail"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,189 @@
+package exports
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/paymails"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/profiles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/models"
+	"github.com/bitcoin-sv/spv-wallet/models/filter"
+	"github.com/rs/zerolog"
+)
+
+const (
+	pageSize = 100
+	// maxPages limits the number of fetched pages, so a misbehaving SPV Wallet cannot make the export run forever.
+	maxPages = 1000
+)
+
+// Service collects user data from the backend and SPV Wallet.
+type Service struct {
+	usersService        *users.UserService
+	paymailsService     *paymails.Service
+	profilesService     *profiles.Service
+	walletClientFactory users.WalletClientFactory
+	log                 *zerolog.Logger
+}
+
+// NewExportsService creates a new exports service.
+func NewExportsService(usersService *users.UserService, paymailsService *paymails.Service, profilesService *profiles.Service, walletClientFactory users.WalletClientFactory, log *zerolog.Logger) *Service {
+	exportsServiceLogger := log.With().Str("service", "exports-service").Logger()
+	return &Service{
+		usersService:        usersService,
+		paymailsService:     paymailsService,
+		profilesService:     profilesService,
+		walletClientFactory: walletClientFactory,
+		log:                 &exportsServiceLogger,
+	}
+}
+
+// Export returns user record, profile, paymails, contacts and transaction history of the user.
+func (s *Service) Export(userID int, accessKey string) (*Export, error) {
+	user, err := s.usersService.GetUserByID(userID)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	profile, err := s.profilesService.GetProfile(userID)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	userPaymails, err := s.paymailsService.GetPaymails(userID)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	userWalletClient, err := s.walletClientFactory.CreateWithAccessKey(accessKey)
+	if err != nil {
+		return nil, spverrors.ErrExportUser.Wrap(err)
+	}
+
+	contacts, err := s.getContacts(userWalletClient)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting contacts: %v", err.Error())
+		return nil, spverrors.ErrExportUser
+	}
+
+	transactions, err := s.getTransactions(userWalletClient, user.Paymail)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting transactions: %v", err.Error())
+		return nil, spverrors.ErrExportUser
+	}
+
+	return &Export{
+		ExportedAt: time.Now(),
+		User: &User{
+			ID:        user.ID,
+			Email:     user.Email,
+			Paymail:   user.Paymail,
+			Status:    user.Status,
+			CreatedAt: user.CreatedAt,
+		},
+		Profile:      profile,
+		Paymails:     userPaymails,
+		Contacts:     contacts,
+		Transactions: transactions,
+	}, nil
+}
+
+// ExportZip returns the export as export.json in a zip archive together with the uploaded avatar.
+func (s *Service) ExportZip(userID int, accessKey string) ([]byte, error) {
+	export, err := s.Export(userID, accessKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	if err = writeJSON(archive, "export.json", export); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while writing export archive: %v", err.Error())
+		return nil, spverrors.ErrExportUser
+	}
+
+	if export.Profile.AvatarKey != "" {
+		avatar, _, err := s.profilesService.GetAvatar(export.Profile.AvatarKey)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+		}
+		if err = writeFile(archive, "avatar"+path.Ext(export.Profile.AvatarKey), avatar); err != nil {
+			s.log.Error().
+				Str("userID", strconv.Itoa(userID)).
+				Msgf("Error while writing export archive: %v", err.Error())
+			return nil, spverrors.ErrExportUser
+		}
+	}
+
+	if err = archive.Close(); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while writing export archive: %v", err.Error())
+		return nil, spverrors.ErrExportUser
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (s *Service) getContacts(userWalletClient users.UserWalletClient) ([]*models.Contact, error) {
+	contacts := make([]*models.Contact, 0)
+	for page := 1; page <= maxPages; page++ {
+		result, err := userWalletClient.GetContacts(context.Background(), &filter.ContactFilter{}, nil, &filter.QueryParams{Page: page, PageSize: pageSize})
+		if err != nil {
+			return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+		}
+
+		contacts = append(contacts, result.Content...)
+		if len(result.Content) < pageSize {
+			break
+		}
+	}
+	return contacts, nil
+}
+
+func (s *Service) getTransactions(userWalletClient users.UserWalletClient, userPaymail string) ([]users.Transaction, error) {
+	transactions := make([]users.Transaction, 0)
+	for page := 1; page <= maxPages; page++ {
+		result, err := userWalletClient.GetTransactions(&filter.QueryParams{Page: page, PageSize: pageSize}, userPaymail)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+		}
+
+		transactions = append(transactions, result...)
+		if len(result) < pageSize {
+			break
+		}
+	}
+	return transactions, nil
+}
+
+func writeJSON(archive *zip.Writer, name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+	return writeFile(archive, name, data)
+}
+
+func writeFile(archive *zip.Writer, name string, data []byte) error {
+	w, err := archive.Create(name)
+	if err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+	_, err = w.Write(data)
+	return err //nolint:wrapcheck // error wrapped higher in call stack
+}
@@ -0,0 +1,124 @@
+package grants
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+)
+
+const grantIDLength = 32
+
+// Grant is a short-lived permission to sign with the user xPriv without asking for the password again.
+type Grant struct {
+	ID        string    `json:"-"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type signingGrant struct {
+	userID    int
+	xpriv     string
+	expiresAt time.Time
+}
+
+// Service keeps signing grants in memory only. Grants are never persisted,
+// so they're lost on restart and the user has to unlock signing with password again.
+type Service struct {
+	ttl    time.Duration
+	grants map[string]*signingGrant
+	mutex  sync.Mutex
+	log    *zerolog.Logger
+}
+
+// NewGrantsService creates a new signing grants service.
+func NewGrantsService(log *zerolog.Logger) *Service {
+	grantsServiceLogger := log.With().Str("service", "grants-service").Logger()
+	return &Service{
+		ttl:    viper.GetDuration(config.EnvHTTPServerSessionSigningGrantTTL),
+		grants: make(map[string]*signingGrant),
+		log:    &grantsServiceLogger,
+	}
+}
+
+// CreateGrant creates a new signing grant for the user xpriv.
+func (s *Service) CreateGrant(userID int, xpriv string) (*Grant, error) {
+	b := make([]byte, grantIDLength)
+	if _, err := rand.Read(b); err != nil {
+		s.log.Error().Msgf("Error while generating signing grant id: %v", err.Error())
+		return nil, spverrors.ErrCreateSigningGrant
+	}
+
+	grant := &Grant{
+		ID:        hex.EncodeToString(b),
+		ExpiresAt: time.Now().Add(s.ttl),
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.removeExpired()
+	s.grants[grant.ID] = &signingGrant{
+		userID:    userID,
+		xpriv:     xpriv,
+		expiresAt: grant.ExpiresAt,
+	}
+
+	return grant, nil
+}
+
+// GetXpriv returns xpriv held by the signing grant if the grant belongs to the user and didn't expire.
+func (s *Service) GetXpriv(grantID string, userID int) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	grant, ok := s.grants[grantID]
+	if !ok || grant.userID != userID {
+		return "", spverrors.ErrSigningGrantRequired
+	}
+
+	if time.Now().After(grant.expiresAt) {
+		delete(s.grants, grantID)
+		s.log.Debug().
+			Str("userID", strconv.Itoa(userID)).
+			Msg("Signing grant expired")
+		return "", spverrors.ErrSigningGrantRequired
+	}
+
+	return grant.xpriv, nil
+}
+
+// RevokeGrant removes the signing grant.
+func (s *Service) RevokeGrant(grantID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.grants, grantID)
+}
+
+// RevokeUserGrants removes all signing grants of the user.
+func (s *Service) RevokeUserGrants(userID int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for id, grant := range s.grants {
+		if grant.userID == userID {
+			delete(s.grants, id)
+		}
+	}
+}
+
+// removeExpired removes expired grants. It must be called with mutex locked.
+func (s *Service) removeExpired() {
+	now := time.Now()
+	for id, grant := range s.grants {
+		if now.After(grant.expiresAt) {
+			delete(s.grants, id)
+		}
+	}
+}
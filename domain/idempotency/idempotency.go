@@ -0,0 +1,30 @@
+package idempotency
+
+import (
+	"time"
+)
+
+// MaxKeyLength is the maximum length of the idempotency key.
+const MaxKeyLength = 255
+
+// Key is the idempotency key of the request which sends a transaction.
+type Key struct {
+	UserID int
+	Key    string
+	// Fingerprint identifies the request, the key can't be reused with a different request.
+	Fingerprint string
+	// PreviewToken is the token of the preview sent by the request, the key is taken over only while the preview can be sent.
+	PreviewToken string
+	// DraftID and TransactionID are set when the transaction is sent.
+	DraftID       string
+	TransactionID string
+	CreatedAt     time.Time
+	ExpiresAt     time.Time
+	// LockedUntil is the end of the lease of the request in progress, then a repeated request can take the key over.
+	LockedUntil time.Time
+}
+
+// Completed returns true if the transaction was sent, otherwise the request with the key is still in progress.
+func (k *Key) Completed() bool {
+	return k.DraftID != ""
+}
@@ -0,0 +1,18 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+)
+
+// Repository is an interface which defines methods for idempotency keys Repository.
+type Repository interface {
+	// ReserveKey inserts the key if the user has no such key, it expired or the same request in progress didn't complete it
+	// before its lease ended and its preview is neither being sent nor gone. It returns false if the key is already reserved.
+	ReserveKey(ctx context.Context, key *Key) (bool, error)
+	// GetKey returns key of the user, nil is returned if the user has no such key.
+	GetKey(ctx context.Context, userID int, key string) (*Key, error)
+	CompleteKey(ctx context.Context, userID int, key, draftID, transactionID string) error
+	DeleteKey(ctx context.Context, userID int, key string) error
+	DeleteExpiredKeys(ctx context.Context, now time.Time) error
+}
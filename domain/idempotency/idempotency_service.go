@@ -0,0 +1,132 @@
+package idempotency
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+)
+
+const (
+	// reaperInterval is how often expired idempotency keys are removed.
+	reaperInterval = time.Hour
+	// reserveAttempts is how many times the key is reserved when it's released by other request in the meantime.
+	reserveAttempts = 3
+)
+
+// Service keeps idempotency keys of requests sending transactions, so a repeated request returns the original outcome
+// instead of sending the transaction again.
+type Service struct {
+	repo  Repository
+	ttl   time.Duration
+	lease time.Duration
+	log   *zerolog.Logger
+}
+
+// NewIdempotencyService creates a new idempotency keys service.
+func NewIdempotencyService(repo Repository, log *zerolog.Logger) *Service {
+	idempotencyServiceLogger := log.With().Str("service", "idempotency-service").Logger()
+	return &Service{
+		repo:  repo,
+		ttl:   viper.GetDuration(config.EnvTransactionsIdempotencyKeyTTL),
+		lease: viper.GetDuration(config.EnvTransactionsIdempotencyKeyLease),
+		log:   &idempotencyServiceLogger,
+	}
+}
+
+// Begin reserves the key for the request with given fingerprint. Nil is returned if the key is reserved and the request
+// should be processed, it must be followed by Complete or Release then. If the key was used by the same request which
+// already sent the transaction, the key is returned, so the original outcome can be returned again.
+// The request in progress holds the key only for the lease, then the same request can take the key over, but only if
+// its preview can still be sent. The preview which is being sent or which is gone may have been sent already,
+// so the key is kept in progress until it expires.
+func (s *Service) Begin(userID int, key, fingerprint, previewToken string) (*Key, error) {
+	if len(key) > MaxKeyLength {
+		return nil, spverrors.ErrInvalidIdempotencyKey
+	}
+
+	for range reserveAttempts {
+		now := time.Now()
+		reserved, err := s.repo.ReserveKey(context.Background(), &Key{
+			UserID:       userID,
+			Key:          key,
+			Fingerprint:  fingerprint,
+			PreviewToken: previewToken,
+			CreatedAt:    now,
+			ExpiresAt:    now.Add(s.ttl),
+			LockedUntil:  now.Add(s.lease),
+		})
+		if err != nil {
+			s.log.Error().
+				Str("userID", strconv.Itoa(userID)).
+				Msgf("Error while reserving idempotency key: %v", err.Error())
+			return nil, spverrors.ErrIdempotencyKey
+		}
+		if reserved {
+			return nil, nil
+		}
+
+		existing, err := s.repo.GetKey(context.Background(), userID, key)
+		if err != nil {
+			s.log.Error().
+				Str("userID", strconv.Itoa(userID)).
+				Msgf("Error while getting idempotency key: %v", err.Error())
+			return nil, spverrors.ErrIdempotencyKey
+		}
+
+		switch {
+		case existing == nil:
+			// The key was released in the meantime, so it's reserved again.
+			continue
+		case existing.Fingerprint != fingerprint:
+			return nil, spverrors.ErrIdempotencyKeyReused
+		case !existing.Completed():
+			return nil, spverrors.ErrIdempotencyKeyInProgress
+		default:
+			return existing, nil
+		}
+	}
+
+	// The key keeps being reserved and released by concurrent requests.
+	return nil, spverrors.ErrIdempotencyKeyInProgress
+}
+
+// Complete stores the sent transaction as the outcome of the request with the key.
+func (s *Service) Complete(userID int, key, draftID, transactionID string) {
+	if err := s.repo.CompleteKey(context.Background(), userID, key, draftID, transactionID); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Str("draftTxID", draftID).
+			Msgf("Error while completing idempotency key: %v", err.Error())
+	}
+}
+
+// Release removes the key of the request which failed before the transaction was sent, so the request can be repeated.
+func (s *Service) Release(userID int, key string) {
+	if err := s.repo.DeleteKey(context.Background(), userID, key); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while releasing idempotency key: %v", err.Error())
+	}
+}
+
+// StartReaper periodically removes expired idempotency keys until ctx is done.
+func (s *Service) StartReaper(ctx context.Context) {
+	ticker := time.NewTicker(reaperInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.repo.DeleteExpiredKeys(ctx, time.Now()); err != nil {
+				s.log.Error().Msgf("Error while removing expired idempotency keys: %v", err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package identities
+
+import "time"
+
+// Identity is a user identity at an OpenID Connect provider linked to the wallet account.
+type Identity struct {
+	ID      int    `json:"id"`
+	UserID  int    `json:"-"`
+	Issuer  string `json:"issuer"`
+	Subject string `json:"subject"`
+	Email   string `json:"email"`
+	// AccessKeyID and AccessKey are of the access key which creates access keys of sessions signed in with the identity.
+	AccessKeyID string     `json:"-"`
+	AccessKey   string     `json:"-"` // access key sealed by the server sealer
+	CreatedAt   time.Time  `json:"createdAt"`
+	LastUsedAt  *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// SignInRequest is a started sign-in with the OpenID Connect provider. State and nonce must be kept
+// until the user is redirected back to the callback, so it can be checked that the sign-in was started by the same client.
+type SignInRequest struct {
+	URL   string
+	State string
+	Nonce string
+}
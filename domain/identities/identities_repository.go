@@ -0,0 +1,17 @@
+package identities
+
+import (
+	"context"
+	"time"
+)
+
+// Repository is an interface which defines methods for user identities Repository.
+type Repository interface {
+	InsertIdentity(ctx context.Context, identity *Identity) error
+	GetIdentity(ctx context.Context, issuer, subject string) (*Identity, error)
+	GetUserIdentities(ctx context.Context, userID int) ([]*Identity, error)
+	UpdateIdentityAccessKey(ctx context.Context, id int, accessKeyID, accessKey string) error
+	TouchIdentity(ctx context.Context, id int, usedAt time.Time) error
+	// DeleteIdentity deletes identity of the user and returns it, nil is returned if the user has no such identity.
+	DeleteIdentity(ctx context.Context, userID, id int) (*Identity, error)
+}
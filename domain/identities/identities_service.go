@@ -0,0 +1,295 @@
+package identities
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/oidc"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/rs/zerolog"
+)
+
+const randomValueLength = 32
+
+// Service signs users in with an OpenID Connect provider. The xPriv stays encrypted with the wallet passphrase,
+// which is asked only when the identity is linked and when the user spends. Sessions get access keys created
+// with the access key of the identity instead, it lets to read the wallet but never to sign.
+type Service struct {
+	repo                Repository
+	provider            oidc.Provider
+	uService            *users.UserService
+	walletClientFactory users.WalletClientFactory
+	sealer              *encryption.Sealer
+	log                 *zerolog.Logger
+}
+
+// NewIdentitiesService creates a new identities service.
+// If provider is nil, single sign-on is disabled, but already linked identities can be still listed and unlinked.
+// Access keys are encrypted by sealer.
+func NewIdentitiesService(repo Repository, provider oidc.Provider, uService *users.UserService, walletClientFactory users.WalletClientFactory, sealer *encryption.Sealer, log *zerolog.Logger) *Service {
+	identitiesServiceLogger := log.With().Str("service", "identities-service").Logger()
+	return &Service{
+		repo:                repo,
+		provider:            provider,
+		uService:            uService,
+		walletClientFactory: walletClientFactory,
+		sealer:              sealer,
+		log:                 &identitiesServiceLogger,
+	}
+}
+
+// StartSignIn returns the url of the provider sign-in page together with state and nonce of the sign-in.
+func (s *Service) StartSignIn() (*SignInRequest, error) {
+	if s.provider == nil {
+		return nil, spverrors.ErrSSODisabled
+	}
+
+	state, err := randomValue()
+	if err != nil {
+		s.log.Error().Msgf("Error while generating state: %v", err.Error())
+		return nil, spverrors.ErrSSOAuthentication
+	}
+
+	nonce, err := randomValue()
+	if err != nil {
+		s.log.Error().Msgf("Error while generating nonce: %v", err.Error())
+		return nil, spverrors.ErrSSOAuthentication
+	}
+
+	url, err := s.provider.AuthCodeURL(context.Background(), state, nonce)
+	if err != nil {
+		s.log.Error().Msgf("Error while creating sign-in url: %v", err.Error())
+		return nil, spverrors.ErrSSOAuthentication
+	}
+
+	return &SignInRequest{
+		URL:   url,
+		State: state,
+		Nonce: nonce,
+	}, nil
+}
+
+// Authenticate exchanges the code returned to the callback for verified claims of the user.
+func (s *Service) Authenticate(code, nonce string) (*oidc.Claims, error) {
+	if s.provider == nil {
+		return nil, spverrors.ErrSSODisabled
+	}
+
+	claims, err := s.provider.Exchange(context.Background(), code, nonce)
+	if err != nil {
+		s.log.Warn().Msgf("Error while exchanging code: %v", err.Error())
+		return nil, spverrors.ErrSSOAuthentication
+	}
+
+	return claims, nil
+}
+
+// GetIdentity returns identity linked to the provider user.
+func (s *Service) GetIdentity(claims *oidc.Claims) (*Identity, error) {
+	identity, err := s.repo.GetIdentity(context.Background(), claims.Issuer, claims.Subject)
+	if err != nil {
+		s.log.Error().
+			Str("subject", claims.Subject).
+			Msgf("Error while getting identity: %v", err.Error())
+		return nil, spverrors.ErrGetIdentities
+	}
+
+	if identity == nil {
+		return nil, spverrors.ErrIdentityNotLinked
+	}
+
+	return identity, nil
+}
+
+// SignIn signs in the user of the linked identity. Access key of the session is created with the access key of the identity,
+// so returned user has no xPriv and the wallet passphrase is needed to spend.
+func (s *Service) SignIn(identity *Identity) (*users.AuthenticatedUser, error) {
+	user, err := s.uService.GetUserByID(identity.UserID)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	if user.Locked() {
+		return nil, spverrors.ErrAccountSuspended
+	}
+
+	identityAccessKey, err := s.sealer.Open(context.Background(), identity.AccessKey)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(identity.UserID)).
+			Msgf("Error while decrypting identity access key: %v", err.Error())
+		return nil, spverrors.ErrIdentityUnlockRequired
+	}
+
+	userWalletClient, err := s.walletClientFactory.CreateWithAccessKey(identityAccessKey)
+	if err != nil {
+		return nil, spverrors.ErrIdentityUnlockRequired.Wrap(err)
+	}
+
+	// Access key of the identity is revoked together with other access keys on wallet recovery.
+	accessKey, err := userWalletClient.CreateAccessKey()
+	if err != nil {
+		s.log.Warn().
+			Str("userID", strconv.Itoa(identity.UserID)).
+			Msgf("Error while creating access key with identity access key: %v", err.Error())
+		return nil, spverrors.ErrIdentityUnlockRequired
+	}
+
+	balance, err := s.uService.GetUserBalance(accessKey.GetAccessKey())
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	if err = s.repo.TouchIdentity(context.Background(), identity.ID, time.Now()); err != nil {
+		s.log.Warn().
+			Str("userID", strconv.Itoa(identity.UserID)).
+			Msgf("Error while updating identity last use: %v", err.Error())
+	}
+
+	return &users.AuthenticatedUser{
+		User: user,
+		AccessKey: users.AccessKey{
+			ID:  accessKey.GetAccessKeyID(),
+			Key: accessKey.GetAccessKey(),
+		},
+		Balance: *balance,
+	}, nil
+}
+
+// Link links the provider user to the wallet of the user. The xPriv, unlocked with the wallet passphrase, creates
+// a new access key of the identity. If the identity is already linked to the user, its access key is replaced.
+func (s *Service) Link(userID int, claims *oidc.Claims, xpriv string) (*Identity, error) {
+	identity, err := s.repo.GetIdentity(context.Background(), claims.Issuer, claims.Subject)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting identity: %v", err.Error())
+		return nil, spverrors.ErrLinkIdentity
+	}
+
+	if identity != nil && identity.UserID != userID {
+		return nil, spverrors.ErrIdentityLinkedToAnotherUser
+	}
+
+	userWalletClient, err := s.walletClientFactory.CreateWithXpriv(xpriv)
+	if err != nil {
+		return nil, spverrors.ErrLinkIdentity.Wrap(err)
+	}
+
+	accessKey, err := userWalletClient.CreateAccessKey()
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while creating identity access key: %v", err.Error())
+		return nil, spverrors.ErrCreateAccessKey
+	}
+
+	encryptedAccessKey, err := s.sealer.Seal(context.Background(), accessKey.GetAccessKey())
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while encrypting identity access key: %v", err.Error())
+		return nil, spverrors.ErrLinkIdentity
+	}
+
+	if identity != nil {
+		previousAccessKeyID := identity.AccessKeyID
+		if err = s.repo.UpdateIdentityAccessKey(context.Background(), identity.ID, accessKey.GetAccessKeyID(), encryptedAccessKey); err != nil {
+			s.log.Error().
+				Str("userID", strconv.Itoa(userID)).
+				Msgf("Error while updating identity access key: %v", err.Error())
+			return nil, spverrors.ErrLinkIdentity
+		}
+		identity.AccessKeyID, identity.AccessKey = accessKey.GetAccessKeyID(), encryptedAccessKey
+
+		// Previous key is usually already revoked, it's revoked here in case the user relinked the identity without it.
+		if _, err = userWalletClient.RevokeAccessKey(previousAccessKeyID); err != nil {
+			s.log.Debug().
+				Str("userID", strconv.Itoa(userID)).
+				Msgf("Previous identity access key wasn't revoked: %v", err.Error())
+		}
+		return identity, nil
+	}
+
+	identity = &Identity{
+		UserID:      userID,
+		Issuer:      claims.Issuer,
+		Subject:     claims.Subject,
+		Email:       claims.Email,
+		AccessKeyID: accessKey.GetAccessKeyID(),
+		AccessKey:   encryptedAccessKey,
+		CreatedAt:   time.Now(),
+	}
+	if err = s.repo.InsertIdentity(context.Background(), identity); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while inserting identity: %v", err.Error())
+		return nil, spverrors.ErrLinkIdentity
+	}
+
+	return identity, nil
+}
+
+// GetUserIdentities returns identities linked to the user.
+func (s *Service) GetUserIdentities(userID int) ([]*Identity, error) {
+	result, err := s.repo.GetUserIdentities(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting identities: %v", err.Error())
+		return nil, spverrors.ErrGetIdentities
+	}
+	return result, nil
+}
+
+// Unlink removes the identity of the user and revokes its access key, sessions signed in with it are kept.
+func (s *Service) Unlink(userID, id int) error {
+	identity, err := s.repo.DeleteIdentity(context.Background(), userID, id)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while deleting identity: %v", err.Error())
+		return spverrors.ErrLinkIdentity
+	}
+
+	if identity == nil {
+		return spverrors.ErrIdentityNotFound
+	}
+
+	// Identity is already removed, so its access key can't be used to sign in even if it's not revoked.
+	if err = s.revokeAccessKey(identity); err != nil {
+		s.log.Warn().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while revoking identity access key: %v", err.Error())
+	}
+
+	return nil
+}
+
+func (s *Service) revokeAccessKey(identity *Identity) error {
+	accessKey, err := s.sealer.Open(context.Background(), identity.AccessKey)
+	if err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	userWalletClient, err := s.walletClientFactory.CreateWithAccessKey(accessKey)
+	if err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	_, err = userWalletClient.RevokeAccessKey(identity.AccessKeyID)
+	return err //nolint:wrapcheck // error wrapped higher in call stack
+}
+
+func randomValue() (string, error) {
+	b := make([]byte, randomValueLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,20 @@
+package lockout
+
+import (
+	"context"
+	"time"
+)
+
+// Repository is an interface which defines methods for failed sign-in attempts counters.
+// Counters are shared by all backend replicas, so implementations must update them atomically.
+type Repository interface {
+	// GetLockedUntil returns time until which the key is locked, zero time if it's not locked.
+	GetLockedUntil(ctx context.Context, key string) (time.Time, error)
+	// RecordFailure increments failures counter of the key and returns its new value.
+	// Counter starts from scratch if the previous failure happened before resetBefore.
+	RecordFailure(ctx context.Context, key string, now, resetBefore time.Time) (int, error)
+	// Lock locks the key until given time.
+	Lock(ctx context.Context, key string, until time.Time) error
+	// Reset removes counter and lock of the key.
+	Reset(ctx context.Context, key string) error
+}
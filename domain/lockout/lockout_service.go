@@ -0,0 +1,170 @@
+package lockout
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+)
+
+const (
+	emailKeyPrefix = "email:"
+	ipKeyPrefix    = "ip:"
+)
+
+// Service protects sign-in from password guessing. Failed attempts are counted per email and per client ip.
+// After a few failures each next attempt is delayed exponentially and after max attempts the key is locked for lock duration.
+type Service struct {
+	repo          Repository
+	backoffAfter  int
+	backoffBase   time.Duration
+	maxAttempts   int
+	ipMaxAttempts int
+	lockDuration  time.Duration
+	log           *zerolog.Logger
+}
+
+// NewLockoutService creates a new lockout service.
+func NewLockoutService(repo Repository, log *zerolog.Logger) *Service {
+	lockoutServiceLogger := log.With().Str("service", "lockout-service").Logger()
+	return &Service{
+		repo:          repo,
+		backoffAfter:  viper.GetInt(config.EnvSignInLockoutBackoffAfter),
+		backoffBase:   viper.GetDuration(config.EnvSignInLockoutBackoffBase),
+		maxAttempts:   viper.GetInt(config.EnvSignInLockoutMaxAttempts),
+		ipMaxAttempts: viper.GetInt(config.EnvSignInLockoutIPMaxAttempts),
+		lockDuration:  viper.GetDuration(config.EnvSignInLockoutDuration),
+		log:           &lockoutServiceLogger,
+	}
+}
+
+// CheckSignIn returns an error and time after which sign-in can be retried if the email or ip is locked.
+func (s *Service) CheckSignIn(email, ip string) (time.Duration, error) {
+	now := time.Now()
+
+	if retryAfter := s.lockedFor(emailKey(email), now); retryAfter > 0 {
+		return retryAfter, spverrors.ErrAccountLocked
+	}
+
+	if retryAfter := s.lockedFor(ipKey(ip), now); retryAfter > 0 {
+		return retryAfter, spverrors.ErrTooManySignInAttempts
+	}
+
+	return 0, nil
+}
+
+// RecordFailure counts failed sign-in attempt and locks the email and ip if needed.
+func (s *Service) RecordFailure(email, ip string) {
+	now := time.Now()
+	s.recordFailure(emailKey(email), s.maxAttempts, now)
+	s.recordFailure(ipKey(ip), s.ipMaxAttempts, now)
+}
+
+// RecordSuccess resets failed attempts of the email. Counter of the ip is kept,
+// so signing in to own account doesn't allow to continue guessing passwords of others.
+func (s *Service) RecordSuccess(email string) {
+	if err := s.repo.Reset(context.Background(), emailKey(email)); err != nil {
+		s.log.Error().
+			Str("userEmail", email).
+			Msgf("Error while resetting failed sign-in attempts: %v", err.Error())
+	}
+}
+
+// LockedUntil returns time until which sign-in to the account with the email is locked because of failed attempts,
+// zero time if it's not locked.
+func (s *Service) LockedUntil(email string) (time.Time, error) {
+	lockedUntil, err := s.repo.GetLockedUntil(context.Background(), emailKey(email))
+	if err != nil {
+		s.log.Error().
+			Str("userEmail", email).
+			Msgf("Error while checking sign-in lock: %v", err.Error())
+		return time.Time{}, spverrors.ErrGetUser
+	}
+
+	if !lockedUntil.After(time.Now()) {
+		return time.Time{}, nil
+	}
+	return lockedUntil, nil
+}
+
+// Unlock resets failed attempts of the email, so its lock is removed before it expires, e.g. by admin.
+func (s *Service) Unlock(email string) error {
+	if err := s.repo.Reset(context.Background(), emailKey(email)); err != nil {
+		s.log.Error().
+			Str("userEmail", email).
+			Msgf("Error while unlocking account: %v", err.Error())
+		return spverrors.ErrUnlockAccount
+	}
+
+	s.log.Info().
+		Str("userEmail", email).
+		Msg("Account unlocked")
+	return nil
+}
+
+// lockedFor returns for how long the key is still locked.
+// Lookup errors are only logged - sign-in itself will fail if the database is unavailable.
+func (s *Service) lockedFor(key string, now time.Time) time.Duration {
+	lockedUntil, err := s.repo.GetLockedUntil(context.Background(), key)
+	if err != nil {
+		s.log.Error().Msgf("Error while checking sign-in lock: %v", err.Error())
+		return 0
+	}
+
+	if lockedUntil.After(now) {
+		return lockedUntil.Sub(now)
+	}
+	return 0
+}
+
+func (s *Service) recordFailure(key string, maxAttempts int, now time.Time) {
+	failures, err := s.repo.RecordFailure(context.Background(), key, now, now.Add(-s.lockDuration))
+	if err != nil {
+		s.log.Error().Msgf("Error while recording failed sign-in attempt: %v", err.Error())
+		return
+	}
+
+	delay := s.delay(failures, maxAttempts)
+	if delay == 0 {
+		return
+	}
+
+	if failures >= maxAttempts {
+		s.log.Warn().
+			Str("key", key).
+			Msgf("Sign-in locked after %d failed attempts", failures)
+	}
+
+	if err = s.repo.Lock(context.Background(), key, now.Add(delay)); err != nil {
+		s.log.Error().Msgf("Error while locking sign-in: %v", err.Error())
+	}
+}
+
+// delay returns for how long the next attempt should be blocked after given number of failures.
+func (s *Service) delay(failures, maxAttempts int) time.Duration {
+	if failures >= maxAttempts {
+		return s.lockDuration
+	}
+
+	if failures <= s.backoffAfter {
+		return 0
+	}
+
+	delay := s.backoffBase << (failures - s.backoffAfter - 1)
+	if delay <= 0 || delay > s.lockDuration {
+		return s.lockDuration
+	}
+	return delay
+}
+
+func emailKey(email string) string {
+	return emailKeyPrefix + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ip string) string {
+	return ipKeyPrefix + ip
+}
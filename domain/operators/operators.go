@@ -0,0 +1,71 @@
+package operators
+
+import "time"
+
+// Operator roles defined in operator_roles table.
+const (
+	RoleAdmin   = "admin"
+	RoleSupport = "support"
+	RoleAuditor = "auditor"
+)
+
+// Outcomes of actions recorded in the admin audit log.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// Actions recorded in the admin audit log.
+const (
+	ActionSignIn               = "sign-in"
+	ActionSignOut              = "sign-out"
+	ActionCreateOperator       = "create-operator"
+	ActionSearchUsers          = "search-users"
+	ActionViewUser             = "view-user"
+	ActionViewUserTransactions = "view-user-transactions"
+	ActionLockUser             = "lock-user"
+	ActionUnlockUser           = "unlock-user"
+	ActionTerminateSessions    = "terminate-sessions"
+	ActionAssignRole           = "assign-role"
+	ActionRemoveRole           = "remove-role"
+	ActionViewAuditLog         = "view-audit-log"
+	ActionViewSecurityLog      = "view-security-log"
+	ActionVerifySecurityLog    = "verify-security-log"
+	// ActionForbidden is recorded when operator role doesn't allow the requested action.
+	ActionForbidden = "forbidden"
+)
+
+// Operator represents a person operating the backend through the admin API.
+type Operator struct {
+	ID           int        `json:"id"`
+	Email        string     `json:"email"`
+	PasswordHash string     `json:"-"`
+	Role         string     `json:"role"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	DisabledAt   *time.Time `json:"disabledAt,omitempty"`
+}
+
+// Role represents operator role.
+type Role struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Session represents signed in operator. Only hash of the token is stored.
+type Session struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// AuditEntry represents an action performed by an operator.
+type AuditEntry struct {
+	ID            int64     `json:"id"`
+	OperatorID    int       `json:"operatorId"`
+	OperatorEmail string    `json:"operatorEmail"`
+	IP            string    `json:"ip"`
+	Action        string    `json:"action"`
+	TargetUserID  *int      `json:"targetUserId,omitempty"`
+	Outcome       string    `json:"outcome"`
+	Details       string    `json:"details,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
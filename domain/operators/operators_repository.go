@@ -0,0 +1,19 @@
+package operators
+
+import (
+	"context"
+	"time"
+)
+
+// Repository is an interface which defines methods for operators, their sessions and the admin audit log.
+type Repository interface {
+	InsertOperator(ctx context.Context, operator *Operator) error
+	GetOperatorByEmail(ctx context.Context, email string) (*Operator, error)
+	GetRoles(ctx context.Context) ([]*Role, error)
+	InsertSession(ctx context.Context, tokenHash string, operatorID int, ip string, createdAt, expiresAt time.Time) error
+	GetOperatorBySession(ctx context.Context, tokenHash string, now time.Time) (*Operator, error)
+	DeleteSession(ctx context.Context, tokenHash string) error
+	DeleteExpiredSessions(ctx context.Context, now time.Time) error
+	InsertAuditEntry(ctx context.Context, entry *AuditEntry) error
+	GetAuditEntries(ctx context.Context, targetUserID *int, limit, offset int) ([]*AuditEntry, error)
+}
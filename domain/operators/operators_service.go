@@ -0,0 +1,276 @@
+package operators
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+)
+
+const (
+	sessionTokenLength = 32
+	defaultPageSize    = 50
+	maxPageSize        = 500
+	// sessionReaperInterval expired sessions are rejected anyway, so they're removed only occasionally.
+	sessionReaperInterval = time.Hour
+)
+
+// Service manages operators of the admin API, their sessions and the admin audit log.
+// Operators are separate from wallet users and are signed in with a bearer token instead of session cookie.
+type Service struct {
+	repo       Repository
+	sessionTTL time.Duration
+	log        *zerolog.Logger
+}
+
+// NewOperatorsService creates a new operators service.
+func NewOperatorsService(repo Repository, log *zerolog.Logger) *Service {
+	operatorsServiceLogger := log.With().Str("service", "operators-service").Logger()
+	return &Service{
+		repo:       repo,
+		sessionTTL: viper.GetDuration(config.EnvAdminSessionTTL),
+		log:        &operatorsServiceLogger,
+	}
+}
+
+// EnsureConfiguredOperator creates the admin operator configured by admin.operator.* if it doesn't exist yet,
+// so the first operator can sign in to a fresh installation. Existing operator is never changed.
+func (s *Service) EnsureConfiguredOperator() error {
+	email := viper.GetString(config.EnvAdminOperatorEmail)
+	if email == "" {
+		return nil
+	}
+
+	_, err := s.CreateOperator(email, viper.GetString(config.EnvAdminOperatorPassword), RoleAdmin)
+	if err != nil && !errors.Is(err, spverrors.ErrOperatorAlreadyExists) {
+		return err
+	}
+	return nil
+}
+
+// CreateOperator creates operator with given role.
+func (s *Service) CreateOperator(email, password, role string) (*Operator, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return nil, spverrors.ErrIncorrectEmail
+	}
+	if password == "" {
+		return nil, spverrors.ErrEmptyPassword
+	}
+	if err := s.checkRole(role); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetOperatorByEmail(context.Background(), email)
+	if err != nil {
+		s.log.Error().
+			Str("operatorEmail", email).
+			Msgf("Error while getting operator: %v", err.Error())
+		return nil, spverrors.ErrGetOperator
+	}
+	if existing != nil {
+		return nil, spverrors.ErrOperatorAlreadyExists
+	}
+
+	passwordHash, err := encryption.HashPassword(password)
+	if err != nil {
+		s.log.Error().
+			Str("operatorEmail", email).
+			Msgf("Error while hashing operator password: %v", err.Error())
+		return nil, spverrors.ErrCreateOperator
+	}
+
+	operator := &Operator{
+		Email:        email,
+		PasswordHash: passwordHash,
+		Role:         role,
+		CreatedAt:    time.Now(),
+	}
+	if err = s.repo.InsertOperator(context.Background(), operator); err != nil {
+		s.log.Error().
+			Str("operatorEmail", email).
+			Msgf("Error while inserting operator: %v", err.Error())
+		return nil, spverrors.ErrCreateOperator
+	}
+
+	s.log.Info().
+		Str("operatorEmail", email).
+		Str("role", role).
+		Msg("Operator created")
+	return operator, nil
+}
+
+// GetRoles returns roles which can be assigned to operators.
+func (s *Service) GetRoles() ([]*Role, error) {
+	roles, err := s.repo.GetRoles(context.Background())
+	if err != nil {
+		s.log.Error().Msgf("Error while getting operator roles: %v", err.Error())
+		return nil, spverrors.ErrGetOperator
+	}
+	return roles, nil
+}
+
+// SignIn checks operator credentials and creates a session. Only hash of the returned token is stored.
+func (s *Service) SignIn(email, password, ip string) (*Operator, *Session, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	operator, err := s.repo.GetOperatorByEmail(context.Background(), email)
+	if err != nil {
+		s.log.Error().
+			Str("operatorEmail", email).
+			Msgf("Error while getting operator: %v", err.Error())
+		return nil, nil, spverrors.ErrGetOperator
+	}
+
+	if operator == nil || operator.DisabledAt != nil {
+		return nil, nil, spverrors.ErrInvalidCredentials
+	}
+
+	valid, err := encryption.VerifyPassword(password, operator.PasswordHash)
+	if err != nil {
+		s.log.Error().
+			Str("operatorEmail", email).
+			Msgf("Error while verifying operator password: %v", err.Error())
+		return nil, nil, spverrors.ErrInvalidCredentials
+	}
+	if !valid {
+		return nil, nil, spverrors.ErrInvalidCredentials
+	}
+
+	token, err := generateSessionToken()
+	if err != nil {
+		s.log.Error().
+			Str("operatorEmail", email).
+			Msgf("Error while generating operator session token: %v", err.Error())
+		return nil, nil, spverrors.ErrOperatorSession
+	}
+
+	now := time.Now()
+	session := &Session{Token: token, ExpiresAt: now.Add(s.sessionTTL)}
+	if err = s.repo.InsertSession(context.Background(), hashSessionToken(token), operator.ID, ip, now, session.ExpiresAt); err != nil {
+		s.log.Error().
+			Str("operatorEmail", email).
+			Msgf("Error while inserting operator session: %v", err.Error())
+		return nil, nil, spverrors.ErrOperatorSession
+	}
+
+	return operator, session, nil
+}
+
+// Authenticate returns operator signed in with the session token.
+func (s *Service) Authenticate(token string) (*Operator, error) {
+	if token == "" {
+		return nil, spverrors.ErrUnauthorized
+	}
+
+	operator, err := s.repo.GetOperatorBySession(context.Background(), hashSessionToken(token), time.Now())
+	if err != nil {
+		s.log.Error().Msgf("Error while getting operator by session: %v", err.Error())
+		return nil, spverrors.ErrUnauthorized
+	}
+
+	if operator == nil || operator.DisabledAt != nil {
+		return nil, spverrors.ErrUnauthorized
+	}
+	return operator, nil
+}
+
+// SignOut removes the operator session.
+func (s *Service) SignOut(token string) error {
+	if err := s.repo.DeleteSession(context.Background(), hashSessionToken(token)); err != nil {
+		s.log.Error().Msgf("Error while deleting operator session: %v", err.Error())
+		return spverrors.ErrOperatorSession
+	}
+	return nil
+}
+
+// StartSessionReaper periodically removes expired operator sessions until ctx is done.
+func (s *Service) StartSessionReaper(ctx context.Context) {
+	ticker := time.NewTicker(sessionReaperInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RemoveExpiredSessions(ctx)
+		}
+	}
+}
+
+// RemoveExpiredSessions removes operator sessions which expired.
+func (s *Service) RemoveExpiredSessions(ctx context.Context) {
+	if err := s.repo.DeleteExpiredSessions(ctx, time.Now()); err != nil {
+		s.log.Error().Msgf("Error while deleting expired operator sessions: %v", err.Error())
+	}
+}
+
+// RecordAction appends the action to the admin audit log. Errors are only logged, the action already happened.
+func (s *Service) RecordAction(entry *AuditEntry) {
+	entry.CreatedAt = time.Now()
+	if err := s.repo.InsertAuditEntry(context.Background(), entry); err != nil {
+		logEvent := s.log.Error().
+			Str("operatorEmail", entry.OperatorEmail).
+			Str("action", entry.Action).
+			Str("outcome", entry.Outcome)
+		if entry.TargetUserID != nil {
+			logEvent = logEvent.Str("userID", strconv.Itoa(*entry.TargetUserID))
+		}
+		logEvent.Msgf("Error while recording admin action: %v", err.Error())
+	}
+}
+
+// GetAuditLog returns page of the admin audit log, newest first. Entries can be limited to actions on one user.
+func (s *Service) GetAuditLog(targetUserID *int, page, pageSize int) ([]*AuditEntry, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > maxPageSize {
+		pageSize = defaultPageSize
+	}
+
+	entries, err := s.repo.GetAuditEntries(context.Background(), targetUserID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		s.log.Error().Msgf("Error while getting admin audit log: %v", err.Error())
+		return nil, spverrors.ErrGetAuditLog
+	}
+	return entries, nil
+}
+
+func (s *Service) checkRole(role string) error {
+	roles, err := s.GetRoles()
+	if err != nil {
+		return err
+	}
+	for _, r := range roles {
+		if r.Name == role {
+			return nil
+		}
+	}
+	return spverrors.ErrInvalidOperatorRole
+}
+
+// generateSessionToken generates random operator session token.
+func generateSessionToken() (string, error) {
+	b := make([]byte, sessionTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashSessionToken hashes session token, only the hash is stored so a database dump can't be used to sign in.
+func hashSessionToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,164 @@
+package outbox
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"time"
+)
+
+// Statuses of outbox entries.
+const (
+	// StatusPending is a status of the signed transaction which isn't recorded in SPV Wallet yet.
+	StatusPending = "pending"
+	// StatusRecorded is a status of the transaction recorded in SPV Wallet, it's broadcast by SPV Wallet then.
+	StatusRecorded = "recorded"
+	// StatusFailed is a status of the transaction which couldn't be recorded until its draft expired, it was never broadcast.
+	StatusFailed = "failed"
+)
+
+// Statuses of outbox entries shown as transaction statuses.
+const (
+	TransactionStatusPendingRecord = "pending record"
+	TransactionStatusRecordFailed  = "record failed"
+)
+
+// Entry is a signed transaction written to the outbox before it's recorded in SPV Wallet.
+type Entry struct {
+	ID            int
+	UserID        int
+	DraftID       string
+	TransactionID string
+	Hex           string
+	Metadata      map[string]any
+	TotalValue    uint64
+	Fee           uint64
+	AccessKeyID   string
+	// AccessKey is encrypted access key used to record the transaction.
+	AccessKey     string
+	Status        string
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	// ExpiresAt is the expiration of the draft, the transaction can't be recorded after it.
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Transaction is the transaction of the outbox entry shown together with transactions read from SPV Wallet.
+// It implements both users.Transaction and users.FullTransaction.
+type Transaction struct {
+	ID              string    `json:"id"`
+	BlockHash       string    `json:"blockHash"`
+	BlockHeight     uint64    `json:"blockHeight"`
+	TotalValue      uint64    `json:"totalValue"`
+	Direction       string    `json:"direction"`
+	Status          string    `json:"status"`
+	Fee             uint64    `json:"fee"`
+	NumberOfInputs  uint32    `json:"numberOfInputs"`
+	NumberOfOutputs uint32    `json:"numberOfOutputs"`
+	CreatedAt       time.Time `json:"createdAt"`
+	Sender          string    `json:"sender"`
+	Receiver        string    `json:"receiver"`
+}
+
+// Sender returns paymail of the sender kept in metadata of the transaction.
+func (e *Entry) Sender() string {
+	sender, _ := e.Metadata["sender"].(string)
+	return sender
+}
+
+// ToTransaction returns the transaction of the entry.
+func (e *Entry) ToTransaction() *Transaction {
+	status := TransactionStatusPendingRecord
+	if e.Status == StatusFailed {
+		status = TransactionStatusRecordFailed
+	}
+	receiver, _ := e.Metadata["receiver"].(string)
+
+	return &Transaction{
+		ID:         e.TransactionID,
+		TotalValue: e.TotalValue,
+		Direction:  "outgoing",
+		Status:     status,
+		Fee:        e.Fee,
+		CreatedAt:  e.CreatedAt,
+		Sender:     e.Sender(),
+		Receiver:   receiver,
+	}
+}
+
+// GetTransactionID returns transaction ID.
+func (t *Transaction) GetTransactionID() string {
+	return t.ID
+}
+
+// GetTransactionBlockHash returns transaction block hash.
+func (t *Transaction) GetTransactionBlockHash() string {
+	return t.BlockHash
+}
+
+// GetTransactionBlockHeight returns transaction block height.
+func (t *Transaction) GetTransactionBlockHeight() uint64 {
+	return t.BlockHeight
+}
+
+// GetTransactionTotalValue returns transaction total value.
+func (t *Transaction) GetTransactionTotalValue() uint64 {
+	return t.TotalValue
+}
+
+// GetTransactionDirection returns transaction direction.
+func (t *Transaction) GetTransactionDirection() string {
+	return t.Direction
+}
+
+// GetTransactionStatus returns transaction status.
+func (t *Transaction) GetTransactionStatus() string {
+	return t.Status
+}
+
+// GetTransactionFee returns transaction fee.
+func (t *Transaction) GetTransactionFee() uint64 {
+	return t.Fee
+}
+
+// GetTransactionNumberOfInputs returns transaction number of inputs.
+func (t *Transaction) GetTransactionNumberOfInputs() uint32 {
+	return t.NumberOfInputs
+}
+
+// GetTransactionNumberOfOutputs returns transaction number of outputs.
+func (t *Transaction) GetTransactionNumberOfOutputs() uint32 {
+	return t.NumberOfOutputs
+}
+
+// GetTransactionCreatedDate returns transaction created date.
+func (t *Transaction) GetTransactionCreatedDate() time.Time {
+	return t.CreatedAt
+}
+
+// GetTransactionSender returns transaction sender.
+func (t *Transaction) GetTransactionSender() string {
+	return t.Sender
+}
+
+// GetTransactionReceiver returns transaction receiver.
+func (t *Transaction) GetTransactionReceiver() string {
+	return t.Receiver
+}
+
+// transactionID returns ID of the signed transaction, it's double sha256 of the transaction in reversed byte order.
+func transactionID(txHex string) (string, error) {
+	tx, err := hex.DecodeString(txHex)
+	if err != nil {
+		return "", err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	first := sha256.Sum256(tx)
+	second := sha256.Sum256(first[:])
+	id := second[:]
+	slices.Reverse(id)
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,19 @@
+package outbox
+
+import (
+	"context"
+	"time"
+)
+
+// Repository is an interface which defines methods for transaction outbox Repository.
+type Repository interface {
+	InsertEntry(ctx context.Context, entry *Entry) error
+	// ClaimDueEntries returns pending entries whose next attempt is due. Attempts of the claimed entries are incremented
+	// and their next attempt is postponed by lease, so the entry isn't recorded twice at the same time.
+	ClaimDueEntries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Entry, error)
+	// UpdateEntry sets status, last error and next attempt of the entry.
+	UpdateEntry(ctx context.Context, entry *Entry) error
+	GetUserEntries(ctx context.Context, userID int, status string) ([]*Entry, error)
+	// GetUserEntry returns entry of the user by transaction id, nil is returned if the user has no such entry.
+	GetUserEntry(ctx context.Context, userID int, transactionID string) (*Entry, error)
+}
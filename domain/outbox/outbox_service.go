@@ -0,0 +1,283 @@
+package outbox
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/bitcoin-sv/spv-wallet/models"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+)
+
+const (
+	// workerBatchSize is a max number of entries recorded in a single worker run.
+	workerBatchSize = 100
+	// attemptLease postpones the next attempt of the entry being recorded, so the entry is retried
+	// only if the attempt didn't finish, e.g. because of restart.
+	attemptLease = time.Minute
+	// initialBackoff is a delay before the first retry, it's doubled with every failed attempt.
+	initialBackoff = 5 * time.Second
+)
+
+// Service records signed transactions in SPV Wallet. The transaction is written to the outbox before it's recorded,
+// so it's retried with exponential backoff after SPV Wallet failure or restart until it's recorded or its draft expires.
+type Service struct {
+	repo                Repository
+	walletClientFactory users.WalletClientFactory
+	sealer              *encryption.Sealer
+	interval            time.Duration
+	maxBackoff          time.Duration
+	log                 *zerolog.Logger
+}
+
+// NewOutboxService creates a new transaction outbox service.
+// Access keys are encrypted by sealer.
+func NewOutboxService(repo Repository, walletClientFactory users.WalletClientFactory, sealer *encryption.Sealer, log *zerolog.Logger) *Service {
+	outboxServiceLogger := log.With().Str("service", "outbox-service").Logger()
+	return &Service{
+		repo:                repo,
+		walletClientFactory: walletClientFactory,
+		sealer:              sealer,
+		interval:            viper.GetDuration(config.EnvTransactionsOutboxInterval),
+		maxBackoff:          viper.GetDuration(config.EnvTransactionsOutboxMaxBackoff),
+		log:                 &outboxServiceLogger,
+	}
+}
+
+// Enqueue writes the signed transaction to the outbox. The wallet client must be created with xPriv, it creates access key
+// which lets the worker record the transaction. The entry is claimed for the first attempt, which should follow with Record.
+func (s *Service) Enqueue(userID int, userWalletClient users.UserWalletClient, draft users.DraftTransaction, metadata map[string]any, totalValue uint64, expiresAt time.Time) (*Entry, error) {
+	txID, err := transactionID(draft.GetDraftTransactionHex())
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Str("draftTxID", draft.GetDraftTransactionID()).
+			Msgf("Error while reading signed transaction: %v", err.Error())
+		return nil, spverrors.ErrCreateTransaction
+	}
+
+	accessKey, err := userWalletClient.CreateAccessKey()
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while creating access key of the transaction: %v", err.Error())
+		return nil, spverrors.ErrCreateTransaction
+	}
+
+	encryptedAccessKey, err := s.sealer.Seal(context.Background(), accessKey.GetAccessKey())
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while encrypting access key of the transaction: %v", err.Error())
+		return nil, spverrors.ErrCreateTransaction
+	}
+
+	now := time.Now()
+	entry := &Entry{
+		UserID:        userID,
+		DraftID:       draft.GetDraftTransactionID(),
+		TransactionID: txID,
+		Hex:           draft.GetDraftTransactionHex(),
+		Metadata:      metadata,
+		TotalValue:    totalValue,
+		Fee:           draft.GetDraftTransactionFee(),
+		AccessKeyID:   accessKey.GetAccessKeyID(),
+		AccessKey:     encryptedAccessKey,
+		Status:        StatusPending,
+		Attempts:      1,
+		NextAttemptAt: now.Add(attemptLease),
+		ExpiresAt:     expiresAt,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err = s.repo.InsertEntry(context.Background(), entry); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Str("draftTxID", entry.DraftID).
+			Msgf("Error while inserting outbox entry: %v", err.Error())
+		return nil, spverrors.ErrCreateTransaction
+	}
+
+	return entry, nil
+}
+
+// Record records the transaction of the claimed entry. If recording fails, the entry is retried later and nil transaction
+// is returned without an error. Error is returned only if the entry is given up because its draft expired.
+func (s *Service) Record(entry *Entry, userWalletClient users.UserWalletClient) (*models.Transaction, error) {
+	tx, err := userWalletClient.RecordTransaction(entry.Hex, entry.DraftID, entry.Metadata)
+	if err != nil {
+		// The previous attempt could record the transaction without its response being received.
+		if _, getErr := userWalletClient.GetTransaction(entry.TransactionID, entry.Sender()); getErr == nil {
+			err = nil
+		}
+	}
+
+	now := time.Now()
+	entry.UpdatedAt = now
+	switch {
+	case err == nil:
+		entry.Status = StatusRecorded
+		entry.LastError = ""
+		s.revoke(entry, userWalletClient)
+	case now.Before(entry.ExpiresAt):
+		entry.LastError = err.Error()
+		entry.NextAttemptAt = now.Add(s.backoff(entry.Attempts))
+		s.log.Warn().
+			Str("userID", strconv.Itoa(entry.UserID)).
+			Str("draftTxID", entry.DraftID).
+			Msgf("Error while recording transaction, attempt %d will be retried at %s: %v", entry.Attempts, entry.NextAttemptAt.Format(time.RFC3339), err.Error())
+	default:
+		entry.Status = StatusFailed
+		entry.LastError = err.Error()
+		s.log.Error().
+			Str("userID", strconv.Itoa(entry.UserID)).
+			Str("draftTxID", entry.DraftID).
+			Msgf("Giving up recording transaction after %d attempts, its draft expired: %v", entry.Attempts, err.Error())
+		s.revoke(entry, userWalletClient)
+	}
+
+	if updateErr := s.repo.UpdateEntry(context.Background(), entry); updateErr != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(entry.UserID)).
+			Str("draftTxID", entry.DraftID).
+			Msgf("Error while updating outbox entry: %v", updateErr.Error())
+	}
+
+	if entry.Status == StatusFailed {
+		return nil, spverrors.ErrRecordTransaction
+	}
+	return tx, nil
+}
+
+// StartWorker periodically records pending transactions until ctx is done.
+func (s *Service) StartWorker(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RecordPending(ctx)
+		}
+	}
+}
+
+// RecordPending records pending transactions whose next attempt is due.
+func (s *Service) RecordPending(ctx context.Context) {
+	entries, err := s.repo.ClaimDueEntries(ctx, time.Now(), attemptLease, workerBatchSize)
+	if err != nil {
+		s.log.Error().Msgf("Error while claiming outbox entries: %v", err.Error())
+		return
+	}
+
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return
+		}
+
+		userWalletClient, err := s.walletClient(entry)
+		if err != nil {
+			// The entry is retried when its lease ends.
+			s.log.Error().
+				Str("userID", strconv.Itoa(entry.UserID)).
+				Str("draftTxID", entry.DraftID).
+				Msgf("Error while creating wallet client of outbox entry: %v", err.Error())
+			continue
+		}
+
+		if tx, err := s.Record(entry, userWalletClient); err == nil && tx != nil {
+			s.log.Info().
+				Str("userID", strconv.Itoa(entry.UserID)).
+				Str("draftTxID", entry.DraftID).
+				Msgf("Transaction recorded after %d attempts", entry.Attempts)
+		}
+	}
+}
+
+// GetPendingTransactions returns transactions of the user which are not recorded yet.
+func (s *Service) GetPendingTransactions(userID int) ([]*Transaction, error) {
+	entries, err := s.repo.GetUserEntries(context.Background(), userID, StatusPending)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting outbox entries: %v", err.Error())
+		return nil, spverrors.ErrGetTransactions
+	}
+
+	transactions := make([]*Transaction, 0, len(entries))
+	for _, entry := range entries {
+		transactions = append(transactions, entry.ToTransaction())
+	}
+	return transactions, nil
+}
+
+// GetTransaction returns transaction of the user which is not recorded yet or couldn't be recorded.
+// Nil is returned if the transaction was never written to the outbox or it's already recorded.
+func (s *Service) GetTransaction(userID int, transactionID string) (*Transaction, error) {
+	entry, err := s.repo.GetUserEntry(context.Background(), userID, transactionID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting outbox entry: %v", err.Error())
+		return nil, spverrors.ErrGetTransaction
+	}
+	if entry == nil || entry.Status == StatusRecorded {
+		return nil, nil
+	}
+
+	return entry.ToTransaction(), nil
+}
+
+// GetKeptAccessKeyIDs returns IDs of access keys of the user's pending transactions. They're needed until the transactions
+// are recorded, so they're not revoked when other sessions of the user are terminated.
+func (s *Service) GetKeptAccessKeyIDs(userID int) ([]string, error) {
+	entries, err := s.repo.GetUserEntries(context.Background(), userID, StatusPending)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting outbox entries: %v", err.Error())
+		return nil, spverrors.ErrGetTransactions
+	}
+
+	accessKeyIDs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		accessKeyIDs = append(accessKeyIDs, entry.AccessKeyID)
+	}
+	return accessKeyIDs, nil
+}
+
+// backoff returns the delay after the failed attempt, it's doubled with every attempt up to max backoff.
+func (s *Service) backoff(attempts int) time.Duration {
+	delay := initialBackoff
+	for i := 1; i < attempts && delay < s.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, s.maxBackoff)
+}
+
+// revoke revokes access key of the entry, it's not needed once the entry is recorded or given up.
+func (s *Service) revoke(entry *Entry, userWalletClient users.UserWalletClient) {
+	if _, err := userWalletClient.RevokeAccessKey(entry.AccessKeyID); err != nil {
+		s.log.Warn().
+			Str("userID", strconv.Itoa(entry.UserID)).
+			Str("draftTxID", entry.DraftID).
+			Msgf("Error while revoking access key of the transaction: %v", err.Error())
+	}
+}
+
+// walletClient creates wallet client with access key of the entry.
+func (s *Service) walletClient(entry *Entry) (users.UserWalletClient, error) {
+	accessKey, err := s.sealer.Open(context.Background(), entry.AccessKey)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	return s.walletClientFactory.CreateWithAccessKey(accessKey) //nolint:wrapcheck // error wrapped higher in call stack
+}
@@ -0,0 +1,38 @@
+package passkeys
+
+import (
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/webauthn"
+)
+
+// Passkey is a WebAuthn credential of the user which signs in and unlocks the wallet.
+type Passkey struct {
+	ID           int      `json:"id"`
+	UserID       int      `json:"-"`
+	Name         string   `json:"name"`
+	CredentialID []byte   `json:"-"`
+	PublicKey    []byte   `json:"-"` // COSE encoded public key
+	SignCount    uint32   `json:"-"`
+	UserHandle   string   `json:"-"`
+	Transports   []string `json:"transports"`
+	// Xpriv is xPriv encrypted with the secret derived by the passkey with the PRF extension.
+	Xpriv      string     `json:"-"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// Unlocked is a passkey which authenticated the user together with the xPriv it unlocked.
+type Unlocked struct {
+	Passkey *Passkey
+	Xpriv   string
+}
+
+func (p *Passkey) credential() *webauthn.Credential {
+	return &webauthn.Credential{
+		ID:         p.CredentialID,
+		PublicKey:  p.PublicKey,
+		SignCount:  p.SignCount,
+		Transports: p.Transports,
+	}
+}
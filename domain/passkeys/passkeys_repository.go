@@ -0,0 +1,17 @@
+package passkeys
+
+import (
+	"context"
+	"time"
+)
+
+// Repository is an interface which defines methods for passkeys Repository.
+type Repository interface {
+	// InsertPasskey inserts passkey and returns false if the credential is already registered.
+	InsertPasskey(ctx context.Context, passkey *Passkey) (bool, error)
+	GetPasskeyByCredentialID(ctx context.Context, credentialID []byte) (*Passkey, error)
+	GetUserPasskeys(ctx context.Context, userID int) ([]*Passkey, error)
+	UpdatePasskeyUse(ctx context.Context, id int, signCount uint32, usedAt time.Time) error
+	// DeletePasskey deletes passkey of the user and returns it, nil is returned if the user has no such passkey.
+	DeletePasskey(ctx context.Context, userID, id int) (*Passkey, error)
+}
@@ -0,0 +1,286 @@
+package passkeys
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/webauthn"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+)
+
+// Service registers passkeys and authenticates users with them. The xPriv is encrypted with the secret derived by
+// the passkey with the PRF extension, so a passkey both signs the user in and unlocks the wallet for spending.
+type Service struct {
+	repo         Repository
+	relyingParty *webauthn.RelyingParty
+	uService     *users.UserService
+	keyCustody   encryption.KeyCustody
+	log          *zerolog.Logger
+}
+
+// NewPasskeysService creates a new passkeys service for the relying party configured by webauthn settings.
+// If keyCustody is nil, encrypted xPrivs are not additionally wrapped with a data key.
+func NewPasskeysService(repo Repository, uService *users.UserService, keyCustody encryption.KeyCustody, log *zerolog.Logger) *Service {
+	passkeysServiceLogger := log.With().Str("service", "passkeys-service").Logger()
+	return &Service{
+		repo: repo,
+		relyingParty: webauthn.NewRelyingParty(
+			viper.GetString(config.EnvWebAuthnRPID),
+			viper.GetString(config.EnvWebAuthnRPName),
+			viper.GetStringSlice(config.EnvWebAuthnOrigins),
+			viper.GetDuration(config.EnvWebAuthnChallengeTTL),
+		),
+		uService:   uService,
+		keyCustody: keyCustody,
+		log:        &passkeysServiceLogger,
+	}
+}
+
+// StartRegistration returns options of the registration ceremony of a new passkey of the user.
+// Challenge and user handle of the options must be kept until the registration is finished.
+func (s *Service) StartRegistration(userID int) (*webauthn.CreationOptions, error) {
+	user, err := s.uService.GetUserByID(userID)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	passkeys, err := s.GetUserPasskeys(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := s.relyingParty.NewChallenge()
+	if err != nil {
+		s.log.Error().Msgf("Error while generating challenge: %v", err.Error())
+		return nil, spverrors.ErrSavePasskey
+	}
+
+	// All passkeys of the user share the handle, so the authenticator replaces discoverable credential of the user instead of adding another one.
+	var userHandle string
+	if len(passkeys) > 0 {
+		userHandle = passkeys[0].UserHandle
+	} else if userHandle, err = s.relyingParty.NewUserHandle(); err != nil {
+		s.log.Error().Msgf("Error while generating user handle: %v", err.Error())
+		return nil, spverrors.ErrSavePasskey
+	}
+
+	entity := webauthn.UserEntity{
+		ID:          userHandle,
+		Name:        user.Email,
+		DisplayName: user.Paymail,
+	}
+	return s.relyingParty.CreationOptions(challenge, entity, credentialIDs(passkeys)), nil
+}
+
+// Register verifies the result of the registration ceremony and saves the passkey. The wallet is unlocked with its password,
+// so its xPriv can be encrypted with the secret derived by the passkey.
+func (s *Service) Register(userID int, name, password, challenge, userHandle string, response *webauthn.RegistrationResponse) (*Passkey, error) {
+	credential, err := s.relyingParty.VerifyRegistration(challenge, response)
+	if err != nil {
+		s.log.Warn().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Invalid passkey registration: %v", err.Error())
+		return nil, spverrors.ErrPasskeyRegistration
+	}
+
+	secret, err := response.ClientExtensionResults.PRFSecret()
+	if err != nil || secret == nil {
+		return nil, spverrors.ErrPasskeyPRFNotSupported
+	}
+
+	xpriv, err := s.uService.GetUserXpriv(userID, password)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	encryptedXpriv, err := s.encryptXpriv(secret, xpriv)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while encrypting xPriv with passkey: %v", err.Error())
+		return nil, spverrors.ErrEncryptXPriv
+	}
+
+	passkey := &Passkey{
+		UserID:       userID,
+		Name:         name,
+		CredentialID: credential.ID,
+		PublicKey:    credential.PublicKey,
+		SignCount:    credential.SignCount,
+		UserHandle:   userHandle,
+		Transports:   credential.Transports,
+		Xpriv:        encryptedXpriv,
+		CreatedAt:    time.Now(),
+	}
+	inserted, err := s.repo.InsertPasskey(context.Background(), passkey)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while inserting passkey: %v", err.Error())
+		return nil, spverrors.ErrSavePasskey
+	}
+	if !inserted {
+		return nil, spverrors.ErrPasskeyAlreadyRegistered
+	}
+
+	return passkey, nil
+}
+
+// StartAssertion returns options of the authentication ceremony. If userID is nil, the user chooses from discoverable passkeys,
+// otherwise only passkeys of the user are allowed. Challenge of the options must be kept until the authentication is finished.
+func (s *Service) StartAssertion(userID *int) (*webauthn.RequestOptions, error) {
+	var allow [][]byte
+	if userID != nil {
+		passkeys, err := s.GetUserPasskeys(*userID)
+		if err != nil {
+			return nil, err
+		}
+		if len(passkeys) == 0 {
+			return nil, spverrors.ErrPasskeyNotFound
+		}
+		allow = credentialIDs(passkeys)
+	}
+
+	challenge, err := s.relyingParty.NewChallenge()
+	if err != nil {
+		s.log.Error().Msgf("Error while generating challenge: %v", err.Error())
+		return nil, spverrors.ErrPasskeyAuthentication
+	}
+
+	return s.relyingParty.RequestOptions(challenge, allow), nil
+}
+
+// Authenticate verifies the result of the authentication ceremony and unlocks xPriv of the user with the secret derived by the passkey.
+func (s *Service) Authenticate(challenge string, response *webauthn.AssertionResponse) (*Unlocked, error) {
+	credentialID, err := response.CredentialID()
+	if err != nil {
+		return nil, spverrors.ErrPasskeyAuthentication
+	}
+
+	passkey, err := s.repo.GetPasskeyByCredentialID(context.Background(), credentialID)
+	if err != nil {
+		s.log.Error().Msgf("Error while getting passkey: %v", err.Error())
+		return nil, spverrors.ErrGetPasskeys
+	}
+	if passkey == nil {
+		return nil, spverrors.ErrPasskeyAuthentication
+	}
+
+	if response.UserHandle() != "" && response.UserHandle() != passkey.UserHandle {
+		s.log.Warn().
+			Str("userID", strconv.Itoa(passkey.UserID)).
+			Msg("Passkey returned user handle of another user")
+		return nil, spverrors.ErrPasskeyAuthentication
+	}
+
+	signCount, err := s.relyingParty.VerifyAssertion(challenge, response, passkey.credential())
+	if err != nil {
+		s.log.Warn().
+			Str("userID", strconv.Itoa(passkey.UserID)).
+			Msgf("Invalid passkey assertion: %v", err.Error())
+		return nil, spverrors.ErrPasskeyAuthentication
+	}
+
+	secret, err := response.ClientExtensionResults.PRFSecret()
+	if err != nil || secret == nil {
+		return nil, spverrors.ErrPasskeyPRFNotSupported
+	}
+
+	xpriv, err := s.decryptXpriv(secret, passkey.Xpriv)
+	if err != nil {
+		s.log.Warn().
+			Str("userID", strconv.Itoa(passkey.UserID)).
+			Msgf("Error while decrypting xPriv with passkey: %v", err.Error())
+		return nil, spverrors.ErrPasskeyAuthentication
+	}
+
+	now := time.Now()
+	if err = s.repo.UpdatePasskeyUse(context.Background(), passkey.ID, signCount, now); err != nil {
+		// Signature counter must be stored, otherwise a cloned passkey wouldn't be detected.
+		s.log.Error().
+			Str("userID", strconv.Itoa(passkey.UserID)).
+			Msgf("Error while updating passkey use: %v", err.Error())
+		return nil, spverrors.ErrSavePasskey
+	}
+	passkey.SignCount, passkey.LastUsedAt = signCount, &now
+
+	return &Unlocked{
+		Passkey: passkey,
+		Xpriv:   xpriv,
+	}, nil
+}
+
+// GetUserPasskeys returns passkeys of the user.
+func (s *Service) GetUserPasskeys(userID int) ([]*Passkey, error) {
+	result, err := s.repo.GetUserPasskeys(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting passkeys: %v", err.Error())
+		return nil, spverrors.ErrGetPasskeys
+	}
+	return result, nil
+}
+
+// DeletePasskey deletes passkey of the user, it can't sign in or unlock the wallet anymore.
+func (s *Service) DeletePasskey(userID, id int) error {
+	passkey, err := s.repo.DeletePasskey(context.Background(), userID, id)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while deleting passkey: %v", err.Error())
+		return spverrors.ErrSavePasskey
+	}
+
+	if passkey == nil {
+		return spverrors.ErrPasskeyNotFound
+	}
+	return nil
+}
+
+// encryptXpriv encrypts xpriv with the passkey secret and wraps it with a data key if key custody is enabled.
+func (s *Service) encryptXpriv(secret []byte, xpriv string) (string, error) {
+	encryptedXpriv, err := encryption.Encrypt(hex.EncodeToString(secret), xpriv)
+	if err != nil {
+		return "", err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	if s.keyCustody == nil {
+		return encryptedXpriv, nil
+	}
+
+	return encryption.WrapWithDataKey(context.Background(), s.keyCustody, encryptedXpriv) //nolint:wrapcheck // error wrapped higher in call stack
+}
+
+// decryptXpriv unwraps xpriv if it was wrapped with a data key and decrypts it with the passkey secret.
+func (s *Service) decryptXpriv(secret []byte, encryptedXpriv string) (string, error) {
+	if encryption.IsWrapped(encryptedXpriv) {
+		if s.keyCustody == nil {
+			return "", fmt.Errorf("xPriv is wrapped with a data key but key custody is disabled")
+		}
+
+		unwrappedXpriv, err := encryption.UnwrapWithDataKey(context.Background(), s.keyCustody, encryptedXpriv)
+		if err != nil {
+			return "", fmt.Errorf("internal error: %w", err)
+		}
+		encryptedXpriv = unwrappedXpriv
+	}
+
+	return encryption.Decrypt(hex.EncodeToString(secret), encryptedXpriv) //nolint:wrapcheck // error wrapped higher in call stack
+}
+
+func credentialIDs(passkeys []*Passkey) [][]byte {
+	result := make([][]byte, 0, len(passkeys))
+	for _, passkey := range passkeys {
+		result = append(result, passkey.CredentialID)
+	}
+	return result
+}
@@ -0,0 +1,12 @@
+package paymails
+
+import "time"
+
+// Paymail represents a paymail address registered in SPV Wallet for the user xPub.
+type Paymail struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"-"`
+	Address   string    `json:"address"`
+	Primary   bool      `json:"primary"`
+	CreatedAt time.Time `json:"createdAt"`
+}
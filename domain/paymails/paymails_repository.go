@@ -0,0 +1,19 @@
+package paymails
+
+import (
+	"context"
+)
+
+// Repository is an interface which defines methods for user paymails Repository.
+type Repository interface {
+	InsertPaymail(ctx context.Context, paymail *Paymail) error
+	GetUserPaymails(ctx context.Context, userID int) ([]*Paymail, error)
+	GetUserPaymail(ctx context.Context, userID, id int) (*Paymail, error)
+	SetPrimaryPaymail(ctx context.Context, userID, id int) error
+	DeletePaymail(ctx context.Context, userID, id int) error
+}
+
+// PendingAliasChecker checks if the alias was chosen on registration by a user who didn't verify email yet.
+type PendingAliasChecker interface {
+	IsAliasPending(ctx context.Context, alias string) (bool, error)
+}
@@ -0,0 +1,256 @@
+package paymails
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	backendconfig "github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/libsv/go-bk/bip32"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+)
+
+// Service manages paymail addresses of the user on domains served by SPV Wallet.
+type Service struct {
+	repo              Repository
+	pendingAliases    PendingAliasChecker
+	adminWalletClient users.AdminWalletClient
+	configService     *config.Service
+	defaultDomain     string
+	log               *zerolog.Logger
+}
+
+// NewPaymailsService creates a new paymails service.
+func NewPaymailsService(repo Repository, pendingAliases PendingAliasChecker, adminWalletClient users.AdminWalletClient, configService *config.Service, log *zerolog.Logger) *Service {
+	paymailsServiceLogger := log.With().Str("service", "paymails-service").Logger()
+	return &Service{
+		repo:              repo,
+		pendingAliases:    pendingAliases,
+		adminWalletClient: adminWalletClient,
+		configService:     configService,
+		defaultDomain:     viper.GetString(backendconfig.EnvPaymailDomain),
+		log:               &paymailsServiceLogger,
+	}
+}
+
+// GetPaymails returns all paymails of the user, primary first.
+func (s *Service) GetPaymails(userID int) ([]*Paymail, error) {
+	paymails, err := s.repo.GetUserPaymails(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting paymails: %v", err.Error())
+		return nil, spverrors.ErrGetPaymails
+	}
+
+	return paymails, nil
+}
+
+// AddPaymail registers a new paymail with the alias on the domain for the xPub derived from xpriv.
+// If domain is empty, the configured paymail domain is used.
+func (s *Service) AddPaymail(userID int, xpriv, alias, domain string) (*Paymail, error) {
+	alias = users.NormalizeAlias(alias)
+	if err := users.ValidateAlias(alias); err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if domain == "" {
+		domain = s.defaultDomain
+	}
+	if !s.isDomainSupported(domain) {
+		return nil, spverrors.ErrPaymailDomainNotSupported
+	}
+
+	if err := s.checkAvailability(alias, domain); err != nil {
+		return nil, err
+	}
+
+	xpub, err := deriveXpub(xpriv)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while deriving xPub: %v", err.Error())
+		return nil, spverrors.ErrAddPaymail
+	}
+
+	address, err := s.adminWalletClient.CreatePaymail(alias, domain, xpub)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while creating paymail: %v", err.Error())
+		return nil, spverrors.ErrAddPaymail
+	}
+
+	paymail := &Paymail{
+		UserID:    userID,
+		Address:   address,
+		CreatedAt: time.Now(),
+	}
+
+	if err = s.repo.InsertPaymail(context.Background(), paymail); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Str("paymail", address).
+			Msgf("Paymail was created in SPV Wallet but it cannot be stored: %v", err.Error())
+		return nil, spverrors.ErrAddPaymail
+	}
+
+	return paymail, nil
+}
+
+// SetPrimaryPaymail makes the paymail primary. Primary paymail is used as the user paymail, e.g. as transaction sender.
+func (s *Service) SetPrimaryPaymail(userID, id int) (*Paymail, error) {
+	paymail, err := s.getUserPaymail(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if paymail.Primary {
+		return paymail, nil
+	}
+
+	if err = s.repo.SetPrimaryPaymail(context.Background(), userID, id); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while setting primary paymail: %v", err.Error())
+		return nil, spverrors.ErrUpdatePaymail
+	}
+
+	paymail.Primary = true
+	return paymail, nil
+}
+
+// RemovePaymail removes the paymail from SPV Wallet. Primary paymail cannot be removed.
+func (s *Service) RemovePaymail(userID, id int) error {
+	paymail, err := s.getUserPaymail(userID, id)
+	if err != nil {
+		return err
+	}
+
+	if paymail.Primary {
+		return spverrors.ErrRemovePrimaryPaymail
+	}
+
+	if err = s.adminWalletClient.DeletePaymail(paymail.Address); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while deleting paymail: %v", err.Error())
+		return spverrors.ErrRemovePaymail
+	}
+
+	if err = s.repo.DeletePaymail(context.Background(), userID, id); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while removing paymail: %v", err.Error())
+		return spverrors.ErrRemovePaymail
+	}
+
+	return nil
+}
+
+// UpdateProfile sets public name and avatar url of all paymails of the user in SPV Wallet.
+// Empty values reset them to the alias and the configured avatar.
+func (s *Service) UpdateProfile(userID int, xpriv, publicName, avatar string) error {
+	userPaymails, err := s.GetPaymails(userID)
+	if err != nil {
+		return err
+	}
+
+	xpub, err := deriveXpub(xpriv)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while deriving xPub: %v", err.Error())
+		return spverrors.ErrUpdatePaymail
+	}
+
+	for _, paymail := range userPaymails {
+		if err = s.adminWalletClient.UpdatePaymailProfile(paymail.Address, xpub, publicName, avatar); err != nil {
+			s.log.Error().
+				Str("userID", strconv.Itoa(userID)).
+				Str("paymail", paymail.Address).
+				Msgf("Error while updating paymail profile: %v", err.Error())
+			return spverrors.ErrUpdatePaymail
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) getUserPaymail(userID, id int) (*Paymail, error) {
+	paymail, err := s.repo.GetUserPaymail(context.Background(), userID, id)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting paymail: %v", err.Error())
+		return nil, spverrors.ErrGetPaymails
+	}
+
+	if paymail == nil {
+		return nil, spverrors.ErrPaymailNotFound
+	}
+
+	return paymail, nil
+}
+
+// checkAvailability checks if the alias is free on the domain.
+// On the configured domain it can also be held by a user who didn't verify email yet.
+func (s *Service) checkAvailability(alias, domain string) error {
+	if domain == s.defaultDomain {
+		pending, err := s.pendingAliases.IsAliasPending(context.Background(), alias)
+		if err != nil {
+			s.log.Error().
+				Str("alias", alias).
+				Msgf("Error while checking pending aliases: %v", err.Error())
+			return spverrors.ErrCheckAlias
+		}
+		if pending {
+			return spverrors.ErrAliasTaken
+		}
+	}
+
+	exists, err := s.adminWalletClient.PaymailExists(alias, domain)
+	if err != nil {
+		s.log.Error().
+			Str("alias", alias).
+			Str("domain", domain).
+			Msgf("Error while checking paymail: %v", err.Error())
+		return spverrors.ErrCheckAlias
+	}
+	if exists {
+		return spverrors.ErrAliasTaken
+	}
+
+	return nil
+}
+
+// isDomainSupported checks if the domain is served by SPV Wallet.
+func (s *Service) isDomainSupported(domain string) bool {
+	sharedConfig := s.configService.GetSharedConfig()
+	if sharedConfig == nil {
+		return domain == s.defaultDomain
+	}
+	return slices.Contains(sharedConfig.PaymailDomains, domain)
+}
+
+// deriveXpub returns xPub of the xpriv.
+func deriveXpub(xpriv string) (string, error) {
+	key, err := bip32.NewKeyFromString(xpriv)
+	if err != nil {
+		return "", err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	xpub, err := key.Neuter()
+	if err != nil {
+		return "", err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	return xpub.String(), nil
+}
@@ -0,0 +1,88 @@
+package payouts
+
+import (
+	"time"
+)
+
+// Statuses of payout batches.
+const (
+	// BatchStatusDraft is a status of the validated batch which wasn't executed yet.
+	BatchStatusDraft = "draft"
+	// BatchStatusRunning is a status of the batch whose rows are being paid.
+	BatchStatusRunning = "running"
+	// BatchStatusCompleted is a status of the batch whose all rows are paid.
+	BatchStatusCompleted = "completed"
+	// BatchStatusFailed is a status of the executed batch with failed rows, they can be retried.
+	BatchStatusFailed = "failed"
+)
+
+// Statuses of payout rows.
+const (
+	RowStatusPending = "pending"
+	// RowStatusSending is a status of the row whose transaction was sent but its result isn't known yet.
+	RowStatusSending = "sending"
+	RowStatusSent    = "sent"
+	RowStatusFailed  = "failed"
+)
+
+// Batch is a list of payouts uploaded by the user as CSV.
+type Batch struct {
+	ID            int       `json:"id"`
+	UserID        int       `json:"-"`
+	Status        string    `json:"status"`
+	TotalSatoshis uint64    `json:"totalSatoshis"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+	Rows          []*Row    `json:"rows"`
+}
+
+// Row is a payout to a single paymail.
+type Row struct {
+	ID int `json:"id"`
+	// Number is the line of the row in uploaded CSV.
+	Number   int    `json:"row"`
+	Paymail  string `json:"paymail"`
+	Satoshis uint64 `json:"satoshis"`
+	// Memo is kept only with the row, it's not written to the blockchain.
+	Memo   string `json:"memo,omitempty"`
+	Status string `json:"status"`
+	// Reference is written to metadata of the transaction which pays the row, so retry can find already sent transaction.
+	Reference     string    `json:"-"`
+	TransactionID string    `json:"transactionId,omitempty"`
+	Error         string    `json:"error,omitempty"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// Unpaid returns true if the row is not known to be paid, so it's paid when the batch is executed.
+func (r *Row) Unpaid() bool {
+	return r.Status != RowStatusSent
+}
+
+// UnpaidSatoshis returns total value of rows which are not known to be paid.
+func (b *Batch) UnpaidSatoshis() uint64 {
+	var total uint64
+	for _, row := range b.Rows {
+		if row.Unpaid() {
+			total += row.Satoshis
+		}
+	}
+	return total
+}
+
+// InvalidRow is a row of uploaded CSV which can't be paid.
+type InvalidRow struct {
+	Number int    `json:"row"`
+	Error  string `json:"error"`
+}
+
+// Preview is the result of the dry run of uploaded CSV. Batch is saved only if all rows are valid and total value doesn't exceed the balance.
+type Preview struct {
+	Batch       *Batch       `json:"batch,omitempty"`
+	InvalidRows []InvalidRow `json:"invalidRows"`
+	Total       uint64       `json:"totalSatoshis"`
+	Balance     uint64       `json:"balance"`
+	// Transactions is the number of transactions which pay the batch.
+	Transactions int `json:"transactions"`
+	// Error explains why the batch with valid rows wasn't saved.
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,93 @@
+package payouts
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+)
+
+// paymailPattern is a loose paymail syntax check, the paymail is resolved by SPV Wallet when the row is paid.
+var paymailPattern = regexp.MustCompile(`^[a-z0-9._+-]+@[a-z0-9-]+(\.[a-z0-9-]+)*\.[a-z]{2,}$`)
+
+// parseCSV reads rows of paymail,satoshis,memo. Memo is optional and the first line is skipped if it's a header.
+// Rows which can't be paid are returned as invalid rows, error is returned only if the CSV can't be read.
+func parseCSV(r io.Reader, maxRows int) ([]*Row, []InvalidRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows := make([]*Row, 0)
+	invalidRows := make([]InvalidRow, 0)
+	seen := make(map[string]int)
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, spverrors.ErrInvalidPayoutCSV.Wrap(err)
+		}
+		if first && isHeader(record) {
+			continue
+		}
+
+		if len(rows)+len(invalidRows) >= maxRows {
+			return nil, nil, spverrors.ErrPayoutBatchTooLarge
+		}
+
+		line, _ := reader.FieldPos(0)
+		row, invalid := parseRecord(line, record)
+		if invalid == "" {
+			if firstLine, ok := seen[row.Paymail]; ok {
+				invalid = fmt.Sprintf("paymail is already paid by row %d", firstLine)
+			} else {
+				seen[row.Paymail] = line
+			}
+		}
+
+		if invalid != "" {
+			invalidRows = append(invalidRows, InvalidRow{Number: line, Error: invalid})
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, invalidRows, nil
+}
+
+func parseRecord(line int, record []string) (*Row, string) {
+	if len(record) < 2 || len(record) > 3 {
+		return nil, "row must have paymail, satoshis and optional memo"
+	}
+
+	paymail := strings.ToLower(strings.TrimSpace(record[0]))
+	if !paymailPattern.MatchString(paymail) {
+		return nil, "invalid paymail"
+	}
+
+	satoshis, err := strconv.ParseUint(strings.TrimSpace(record[1]), 10, 64)
+	if err != nil || satoshis == 0 {
+		return nil, "satoshis must be a positive integer"
+	}
+
+	row := &Row{
+		Number:   line,
+		Paymail:  paymail,
+		Satoshis: satoshis,
+		Status:   RowStatusPending,
+	}
+	if len(record) == 3 {
+		row.Memo = strings.TrimSpace(record[2])
+	}
+	return row, ""
+}
+
+func isHeader(record []string) bool {
+	return strings.EqualFold(strings.TrimSpace(record[0]), "paymail")
+}
@@ -0,0 +1,20 @@
+package payouts
+
+import (
+	"context"
+	"time"
+)
+
+// Repository is an interface which defines methods for payout batches Repository.
+type Repository interface {
+	// InsertBatch inserts the batch together with its rows.
+	InsertBatch(ctx context.Context, batch *Batch) error
+	// GetBatch returns batch of the user with its rows, nil is returned if the user has no such batch.
+	GetBatch(ctx context.Context, userID, id int) (*Batch, error)
+	// ClaimBatch sets running status of the batch if it's not running already or it's running for longer than staleAfter,
+	// so the batch is never executed twice at the same time. It returns false if the batch can't be claimed.
+	ClaimBatch(ctx context.Context, userID, id int, now time.Time, staleAfter time.Duration) (bool, error)
+	UpdateBatchStatus(ctx context.Context, id int, status string, updatedAt time.Time) error
+	// UpdateRows sets status, reference, transaction id and error of the rows.
+	UpdateRows(ctx context.Context, rows []*Row) error
+}
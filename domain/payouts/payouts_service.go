@@ -0,0 +1,337 @@
+package payouts
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-go-client/commands"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/transactions"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/notification"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+)
+
+const (
+	// referenceMetadataKey is a key of transaction metadata with the reference of the paid rows.
+	referenceMetadataKey = "payoutReference"
+	// batchMetadataKey is a key of transaction metadata with the id of the paid batch.
+	batchMetadataKey = "payoutBatch"
+	// staleAfter is the time after which the running batch is considered abandoned, e.g. by restarted server, and it can be executed again.
+	staleAfter = 10 * time.Minute
+)
+
+// Service validates payout batches uploaded as CSV and pays them in chunks, one transaction per chunk.
+type Service struct {
+	repo                Repository
+	walletClientFactory users.WalletClientFactory
+	chunkSize           int
+	maxRows             int
+	log                 *zerolog.Logger
+}
+
+// NewPayoutsService creates a new payout batches service.
+func NewPayoutsService(repo Repository, walletClientFactory users.WalletClientFactory, log *zerolog.Logger) *Service {
+	payoutsServiceLogger := log.With().Str("service", "payouts-service").Logger()
+	return &Service{
+		repo:                repo,
+		walletClientFactory: walletClientFactory,
+		chunkSize:           max(viper.GetInt(config.EnvPayoutsChunkSize), 1),
+		maxRows:             max(viper.GetInt(config.EnvPayoutsMaxRows), 1),
+		log:                 &payoutsServiceLogger,
+	}
+}
+
+// Preview validates the uploaded CSV against the balance of the user. Nothing is paid, the batch is saved as a draft
+// only if all rows are valid and their total doesn't exceed the balance, so it can be executed later.
+func (s *Service) Preview(userID int, accessKey string, csv io.Reader) (*Preview, error) {
+	rows, invalidRows, err := parseCSV(csv, s.maxRows)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 && len(invalidRows) == 0 {
+		return nil, spverrors.ErrInvalidPayoutCSV
+	}
+
+	userWalletClient, err := s.walletClientFactory.CreateWithAccessKey(accessKey)
+	if err != nil {
+		return nil, spverrors.ErrSavePayoutBatch.Wrap(err)
+	}
+	xpub, err := userWalletClient.GetXPub()
+	if err != nil {
+		s.log.Error().Str("userID", strconv.Itoa(userID)).Msgf("Error while getting balance: %v", err.Error())
+		return nil, spverrors.ErrSavePayoutBatch
+	}
+
+	preview := &Preview{
+		InvalidRows:  invalidRows,
+		Balance:      xpub.GetCurrentBalance(),
+		Transactions: (len(rows) + s.chunkSize - 1) / s.chunkSize,
+	}
+	for _, row := range rows {
+		if preview.Total+row.Satoshis < preview.Total {
+			return nil, spverrors.ErrInvalidPayoutCSV
+		}
+		preview.Total += row.Satoshis
+	}
+
+	if len(invalidRows) > 0 || len(rows) == 0 {
+		return preview, nil
+	}
+	if preview.Total > preview.Balance {
+		preview.Error = spverrors.ErrInsufficientBalance.Message
+		return preview, nil
+	}
+
+	now := time.Now().UTC()
+	batch := &Batch{
+		UserID:        userID,
+		Status:        BatchStatusDraft,
+		TotalSatoshis: preview.Total,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		Rows:          rows,
+	}
+	for _, row := range rows {
+		row.UpdatedAt = now
+	}
+	if err = s.repo.InsertBatch(context.Background(), batch); err != nil {
+		s.log.Error().Str("userID", strconv.Itoa(userID)).Msgf("Error while saving payout batch: %v", err.Error())
+		return nil, spverrors.ErrSavePayoutBatch
+	}
+
+	preview.Batch = batch
+	return preview, nil
+}
+
+// GetBatch returns batch of the user with status of its rows.
+func (s *Service) GetBatch(userID, id int) (*Batch, error) {
+	batch, err := s.repo.GetBatch(context.Background(), userID, id)
+	if err != nil {
+		s.log.Error().Str("userID", strconv.Itoa(userID)).Msgf("Error while getting payout batch: %v", err.Error())
+		return nil, spverrors.ErrGetPayoutBatch
+	}
+	if batch == nil {
+		return nil, spverrors.ErrPayoutBatchNotFound
+	}
+	return batch, nil
+}
+
+// Execute pays rows of the batch which are not paid yet. Rows whose earlier payment result isn't known are first matched
+// with transactions of the user by reference, so no row is paid twice. Rows are paid in the background, progress is sent
+// to events which are closed when the batch is finished.
+func (s *Service) Execute(batch *Batch, senderPaymail, xpriv string, events chan notification.PayoutBatchEvent) error {
+	if batch.Status == BatchStatusCompleted {
+		return spverrors.ErrPayoutBatchCompleted
+	}
+
+	previousStatus := batch.Status
+	claimed, err := s.repo.ClaimBatch(context.Background(), batch.UserID, batch.ID, time.Now().UTC(), staleAfter)
+	if err != nil {
+		s.log.Error().Str("userID", strconv.Itoa(batch.UserID)).Msgf("Error while claiming payout batch: %v", err.Error())
+		return spverrors.ErrExecutePayoutBatch
+	}
+	if !claimed {
+		return spverrors.ErrPayoutBatchRunning
+	}
+	batch.Status = BatchStatusRunning
+
+	userWalletClient, err := s.walletClientFactory.CreateWithXpriv(xpriv)
+	if err != nil {
+		s.release(batch, previousStatus)
+		return spverrors.ErrExecutePayoutBatch.Wrap(err)
+	}
+
+	if err = s.reconcile(userWalletClient, batch, senderPaymail); err != nil {
+		s.log.Error().Str("userID", strconv.Itoa(batch.UserID)).Msgf("Error while matching payout batch with transactions: %v", err.Error())
+		s.release(batch, previousStatus)
+		return spverrors.ErrExecutePayoutBatch
+	}
+
+	xpub, err := userWalletClient.GetXPub()
+	if err != nil {
+		s.log.Error().Str("userID", strconv.Itoa(batch.UserID)).Msgf("Error while getting balance: %v", err.Error())
+		s.release(batch, previousStatus)
+		return spverrors.ErrExecutePayoutBatch
+	}
+	if batch.UnpaidSatoshis() > xpub.GetCurrentBalance() {
+		s.release(batch, previousStatus)
+		return spverrors.ErrInsufficientBalance
+	}
+
+	go s.pay(userWalletClient, batch, senderPaymail, events)
+
+	return nil
+}
+
+// reconcile marks rows as sent if the transaction with their reference was already sent. Rows with reference which
+// is not found are paid again with a new reference.
+func (s *Service) reconcile(userWalletClient users.UserWalletClient, batch *Batch, senderPaymail string) error {
+	references := make(map[string][]*Row)
+	for _, row := range batch.Rows {
+		if row.Unpaid() && row.Reference != "" {
+			references[row.Reference] = append(references[row.Reference], row)
+		}
+	}
+
+	changed := make([]*Row, 0)
+	for reference, rows := range references {
+		txs, err := userWalletClient.GetTransactionsWithMetadata(map[string]any{referenceMetadataKey: reference}, senderPaymail)
+		if err != nil {
+			return err //nolint:wrapcheck // error wrapped higher in call stack
+		}
+
+		now := time.Now().UTC()
+		for _, row := range rows {
+			if len(txs) > 0 {
+				row.Status = RowStatusSent
+				row.TransactionID = txs[0].GetTransactionID()
+				row.Error = ""
+			} else {
+				row.Status = RowStatusFailed
+				row.Reference = ""
+			}
+			row.UpdatedAt = now
+			changed = append(changed, row)
+		}
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+	return s.repo.UpdateRows(context.Background(), changed) //nolint:wrapcheck // error wrapped higher in call stack
+}
+
+func (s *Service) pay(userWalletClient users.UserWalletClient, batch *Batch, senderPaymail string, events chan notification.PayoutBatchEvent) {
+	defer close(events)
+
+	unpaid := make([]*Row, 0, len(batch.Rows))
+	for _, row := range batch.Rows {
+		if row.Unpaid() {
+			unpaid = append(unpaid, row)
+		}
+	}
+
+	for start := 0; start < len(unpaid); start += s.chunkSize {
+		chunk := unpaid[start:min(start+s.chunkSize, len(unpaid))]
+		if err := s.payChunk(userWalletClient, batch, chunk, senderPaymail); err != nil {
+			s.log.Error().Str("userID", strconv.Itoa(batch.UserID)).Msgf("Error while saving payout rows: %v", err.Error())
+			// The chunk may be paid, so the batch is left running and it can be retried once it's stale.
+			return
+		}
+		events <- notification.PreparePayoutBatchEvent(progress(batch, chunk))
+	}
+
+	batch.Status = BatchStatusCompleted
+	for _, row := range batch.Rows {
+		if row.Unpaid() {
+			batch.Status = BatchStatusFailed
+			break
+		}
+	}
+	batch.UpdatedAt = time.Now().UTC()
+	if err := s.repo.UpdateBatchStatus(context.Background(), batch.ID, batch.Status, batch.UpdatedAt); err != nil {
+		s.log.Error().Str("userID", strconv.Itoa(batch.UserID)).Msgf("Error while updating payout batch status: %v", err.Error())
+	}
+	events <- notification.PreparePayoutBatchEvent(progress(batch, nil))
+}
+
+// payChunk pays rows by one transaction. The rows are saved as sending with a reference before the transaction is sent,
+// so if the result is lost, the transaction can be found by the reference when the batch is retried.
+func (s *Service) payChunk(userWalletClient users.UserWalletClient, batch *Batch, rows []*Row, senderPaymail string) error {
+	reference, err := newReference()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	recipients := make([]transactions.Recipient, 0, len(rows))
+	outputs := make([]*commands.Recipients, 0, len(rows))
+	for _, row := range rows {
+		row.Status = RowStatusSending
+		row.Reference = reference
+		row.Error = ""
+		row.UpdatedAt = now
+		recipients = append(recipients, transactions.Recipient{Recipient: row.Paymail, Satoshis: row.Satoshis})
+		outputs = append(outputs, &commands.Recipients{To: row.Paymail, Satoshis: row.Satoshis})
+	}
+	if err = s.repo.UpdateRows(context.Background(), rows); err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+	if err = s.repo.UpdateBatchStatus(context.Background(), batch.ID, BatchStatusRunning, now); err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	metadata := transactions.RecipientsMetadata(senderPaymail, recipients)
+	metadata[referenceMetadataKey] = reference
+	metadata[batchMetadataKey] = batch.ID
+
+	tx, sendErr := userWalletClient.SendToRecipients(outputs, metadata)
+	now = time.Now().UTC()
+	for _, row := range rows {
+		row.UpdatedAt = now
+		if sendErr != nil {
+			// Reference is kept, the transaction may be recorded by SPV Wallet even if the response was lost.
+			row.Status = RowStatusFailed
+			row.Error = spverrors.ErrCreateTransaction.Message
+		} else {
+			row.Status = RowStatusSent
+			row.TransactionID = tx.GetTransactionID()
+		}
+	}
+	if sendErr != nil {
+		s.log.Error().Str("userID", strconv.Itoa(batch.UserID)).Msgf("Error while paying payout batch rows: %v", sendErr.Error())
+	}
+
+	return s.repo.UpdateRows(context.Background(), rows) //nolint:wrapcheck // error wrapped higher in call stack
+}
+
+// release returns the claimed batch to its previous status, so it can be executed again.
+func (s *Service) release(batch *Batch, status string) {
+	batch.Status = status
+	batch.UpdatedAt = time.Now().UTC()
+	if err := s.repo.UpdateBatchStatus(context.Background(), batch.ID, status, batch.UpdatedAt); err != nil {
+		s.log.Error().Str("userID", strconv.Itoa(batch.UserID)).Msgf("Error while updating payout batch status: %v", err.Error())
+	}
+}
+
+func progress(batch *Batch, changed []*Row) *notification.PayoutBatch {
+	p := &notification.PayoutBatch{
+		ID:     batch.ID,
+		Status: batch.Status,
+		Total:  len(batch.Rows),
+		Rows:   make([]notification.PayoutRow, 0, len(changed)),
+	}
+	for _, row := range batch.Rows {
+		switch row.Status {
+		case RowStatusSent:
+			p.Sent++
+		case RowStatusFailed:
+			p.Failed++
+		}
+	}
+	for _, row := range changed {
+		p.Rows = append(p.Rows, notification.PayoutRow{
+			Row:           row.Number,
+			Paymail:       row.Paymail,
+			Status:        row.Status,
+			TransactionID: row.TransactionID,
+			Error:         row.Error,
+		})
+	}
+	return p
+}
+
+func newReference() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,14 @@
+package profiles
+
+import "time"
+
+// Profile represents public name and avatar shown for the user paymails.
+type Profile struct {
+	UserID int `json:"-"`
+	// PublicName is empty if the alias is used as public name.
+	PublicName string `json:"publicName"`
+	// AvatarKey is empty if the configured avatar is used.
+	AvatarKey string    `json:"-"`
+	AvatarURL string    `json:"avatarUrl"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,16 @@
+package profiles
+
+import (
+	"context"
+)
+
+// Repository is an interface which defines methods for user profiles Repository.
+type Repository interface {
+	GetProfile(ctx context.Context, userID int) (*Profile, error)
+	UpsertProfile(ctx context.Context, profile *Profile) error
+}
+
+// PaymailsUpdater sets public name and avatar of the user paymails in SPV Wallet.
+type PaymailsUpdater interface {
+	UpdateProfile(userID int, xpriv, publicName, avatar string) error
+}
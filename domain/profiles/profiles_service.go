@@ -0,0 +1,217 @@
+package profiles
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/blobstore"
+	backendconfig "github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+)
+
+const (
+	maxPublicNameLength = 100
+	avatarKeyBytes      = 16
+)
+
+// avatarExtensions maps supported avatar content types to extensions of stored files.
+var avatarExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// Service manages public name and avatar shown for the user paymails.
+type Service struct {
+	repo          Repository
+	paymails      PaymailsUpdater
+	store         blobstore.Store
+	avatarURL     string
+	defaultAvatar string
+	maxAvatarSize int
+	log           *zerolog.Logger
+}
+
+// NewProfilesService creates a new profiles service.
+func NewProfilesService(repo Repository, paymails PaymailsUpdater, store blobstore.Store, log *zerolog.Logger) *Service {
+	profilesServiceLogger := log.With().Str("service", "profiles-service").Logger()
+	return &Service{
+		repo:          repo,
+		paymails:      paymails,
+		store:         store,
+		avatarURL:     strings.TrimSuffix(viper.GetString(backendconfig.EnvAvatarURL), "/"),
+		defaultAvatar: viper.GetString(backendconfig.EnvPaymailAvatar),
+		maxAvatarSize: viper.GetInt(backendconfig.EnvAvatarMaxSize),
+		log:           &profilesServiceLogger,
+	}
+}
+
+// GetProfile returns profile of the user.
+func (s *Service) GetProfile(userID int) (*Profile, error) {
+	profile, err := s.repo.GetProfile(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting profile: %v", err.Error())
+		return nil, spverrors.ErrGetProfile
+	}
+
+	if profile == nil {
+		profile = &Profile{UserID: userID}
+	}
+	profile.AvatarURL = s.getAvatarURL(profile.AvatarKey)
+
+	return profile, nil
+}
+
+// UpdatePublicName sets public name of the user paymails. Empty name resets it to the paymail alias.
+func (s *Service) UpdatePublicName(userID int, xpriv, publicName string) (*Profile, error) {
+	publicName = strings.TrimSpace(publicName)
+	if err := validatePublicName(publicName); err != nil {
+		return nil, err
+	}
+
+	profile, err := s.GetProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+	profile.PublicName = publicName
+
+	if err = s.saveProfile(profile, xpriv); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// UploadAvatar stores the image and sets it as avatar of the user paymails. Previous avatar is removed.
+func (s *Service) UploadAvatar(userID int, xpriv string, data []byte) (*Profile, error) {
+	if len(data) == 0 || len(data) > s.maxAvatarSize {
+		return nil, spverrors.ErrInvalidAvatar
+	}
+	ext, ok := avatarExtensions[http.DetectContentType(data)]
+	if !ok {
+		return nil, spverrors.ErrInvalidAvatar
+	}
+
+	profile, err := s.GetProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+	previousKey := profile.AvatarKey
+
+	key, err := newAvatarKey(ext)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while generating avatar key: %v", err.Error())
+		return nil, spverrors.ErrUpdateProfile
+	}
+
+	if err = s.store.Put(context.Background(), key, data); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while storing avatar: %v", err.Error())
+		return nil, spverrors.ErrUpdateProfile
+	}
+
+	profile.AvatarKey = key
+	profile.AvatarURL = s.getAvatarURL(key)
+
+	if err = s.saveProfile(profile, xpriv); err != nil {
+		s.RemoveAvatar(userID, key)
+		return nil, err
+	}
+
+	if previousKey != "" {
+		s.RemoveAvatar(userID, previousKey)
+	}
+
+	return profile, nil
+}
+
+// GetAvatar returns the avatar image and its content type.
+func (s *Service) GetAvatar(key string) ([]byte, string, error) {
+	data, err := s.store.Get(context.Background(), key)
+	if errors.Is(err, blobstore.ErrNotFound) || errors.Is(err, blobstore.ErrInvalidKey) {
+		return nil, "", spverrors.ErrAvatarNotFound
+	}
+	if err != nil {
+		s.log.Error().
+			Str("key", key).
+			Msgf("Error while getting avatar: %v", err.Error())
+		return nil, "", spverrors.ErrGetProfile
+	}
+
+	return data, http.DetectContentType(data), nil
+}
+
+// saveProfile pushes the profile to SPV Wallet and stores it.
+func (s *Service) saveProfile(profile *Profile, xpriv string) error {
+	avatar := ""
+	if profile.AvatarKey != "" {
+		avatar = profile.AvatarURL
+	}
+
+	if err := s.paymails.UpdateProfile(profile.UserID, xpriv, profile.PublicName, avatar); err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	profile.UpdatedAt = time.Now()
+	if err := s.repo.UpsertProfile(context.Background(), profile); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(profile.UserID)).
+			Msgf("Profile was updated in SPV Wallet but it cannot be stored: %v", err.Error())
+		return spverrors.ErrUpdateProfile
+	}
+
+	return nil
+}
+
+// RemoveAvatar removes the avatar image, errors are only logged.
+func (s *Service) RemoveAvatar(userID int, key string) {
+	if err := s.store.Delete(context.Background(), key); err != nil {
+		s.log.Warn().
+			Str("userID", strconv.Itoa(userID)).
+			Str("key", key).
+			Msgf("Error while removing avatar: %v", err.Error())
+	}
+}
+
+func (s *Service) getAvatarURL(key string) string {
+	if key == "" {
+		return s.defaultAvatar
+	}
+	return s.avatarURL + "/" + key
+}
+
+func validatePublicName(publicName string) error {
+	if !utf8.ValidString(publicName) || utf8.RuneCountInString(publicName) > maxPublicNameLength {
+		return spverrors.ErrInvalidPublicName
+	}
+	for _, r := range publicName {
+		if unicode.IsControl(r) {
+			return spverrors.ErrInvalidPublicName
+		}
+	}
+	return nil
+}
+
+func newAvatarKey(ext string) (string, error) {
+	b := make([]byte, avatarKeyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+	return hex.EncodeToString(b) + ext, nil
+}
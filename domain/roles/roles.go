@@ -0,0 +1,42 @@
+package roles
+
+// Roles defined in roles table. They grant access to the own wallet only, staff access to other users is given by operator roles.
+const (
+	RoleUser    = "user"
+	RoleAuditor = "auditor"
+)
+
+// Permissions defined in permissions table, they're required by API routes.
+const (
+	// PermissionWalletRead allows to view own balance, transactions and contacts.
+	PermissionWalletRead = "wallet:read"
+	// PermissionWalletSpend allows to send transactions and sweep funds.
+	PermissionWalletSpend = "wallet:spend"
+	// PermissionContactsWrite allows to add, accept, reject and confirm contacts.
+	PermissionContactsWrite = "contacts:write"
+	// PermissionAccountManage allows to manage own password, paymails, profile, two-factor authentication, sessions and account.
+	PermissionAccountManage = "account:manage"
+)
+
+// Role represents role with its permissions. Users without any assigned role have the default role.
+type Role struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Default     bool     `json:"default"`
+	Permissions []string `json:"permissions"`
+}
+
+// UserRoles represents roles of the user and permissions they grant.
+type UserRoles struct {
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+// Permissions is a set of permissions granted to the user.
+type Permissions map[string]struct{}
+
+// Has returns true if the permission is granted.
+func (p Permissions) Has(permission string) bool {
+	_, ok := p[permission]
+	return ok
+}
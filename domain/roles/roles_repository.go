@@ -0,0 +1,15 @@
+package roles
+
+import (
+	"context"
+	"time"
+)
+
+// Repository is an interface which defines methods for roles, their permissions and roles assigned to users.
+type Repository interface {
+	GetRoles(ctx context.Context) ([]*Role, error)
+	GetUserRoles(ctx context.Context, userID int) ([]string, error)
+	GetUserPermissions(ctx context.Context, userID int) ([]string, error)
+	InsertUserRole(ctx context.Context, userID int, role string, createdAt time.Time) error
+	DeleteUserRole(ctx context.Context, userID int, role string) error
+}
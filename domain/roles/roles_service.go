@@ -0,0 +1,147 @@
+package roles
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/rs/zerolog"
+)
+
+// Service manages roles of users and resolves permissions they grant.
+// Assigned roles replace the default role, so e.g. account which has only auditor role can't spend.
+type Service struct {
+	repo         Repository
+	usersService *users.UserService
+	log          *zerolog.Logger
+}
+
+// NewRolesService creates a new roles service.
+func NewRolesService(repo Repository, usersService *users.UserService, log *zerolog.Logger) *Service {
+	rolesServiceLogger := log.With().Str("service", "roles-service").Logger()
+	return &Service{
+		repo:         repo,
+		usersService: usersService,
+		log:          &rolesServiceLogger,
+	}
+}
+
+// GetRoles returns all roles with their permissions.
+func (s *Service) GetRoles() ([]*Role, error) {
+	roles, err := s.repo.GetRoles(context.Background())
+	if err != nil {
+		s.log.Error().Msgf("Error while getting roles: %v", err.Error())
+		return nil, spverrors.ErrGetRoles
+	}
+	return roles, nil
+}
+
+// GetPermissions returns permissions granted to the user by its roles.
+func (s *Service) GetPermissions(userID int) (Permissions, error) {
+	granted, err := s.repo.GetUserPermissions(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting user permissions: %v", err.Error())
+		return nil, spverrors.ErrGetRoles
+	}
+
+	permissions := make(Permissions, len(granted))
+	for _, permission := range granted {
+		permissions[permission] = struct{}{}
+	}
+	return permissions, nil
+}
+
+// GetUserRoles returns roles of the user and permissions they grant. Default role is returned if the user has no role assigned.
+func (s *Service) GetUserRoles(userID int) (*UserRoles, error) {
+	userRoles, err := s.repo.GetUserRoles(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting user roles: %v", err.Error())
+		return nil, spverrors.ErrGetRoles
+	}
+
+	permissions, err := s.repo.GetUserPermissions(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting user permissions: %v", err.Error())
+		return nil, spverrors.ErrGetRoles
+	}
+
+	if len(userRoles) == 0 {
+		if userRoles, err = s.defaultRoles(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &UserRoles{Roles: userRoles, Permissions: permissions}, nil
+}
+
+// AssignRole assigns the role to the user. Assigning a role which the user already has does nothing.
+func (s *Service) AssignRole(userID int, role string) error {
+	if err := s.checkUserAndRole(userID, role); err != nil {
+		return err
+	}
+
+	if err := s.repo.InsertUserRole(context.Background(), userID, role, time.Now()); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Str("role", role).
+			Msgf("Error while assigning user role: %v", err.Error())
+		return spverrors.ErrUpdateUserRoles
+	}
+	return nil
+}
+
+// RemoveRole removes the role from the user. User without any role has the default role again.
+func (s *Service) RemoveRole(userID int, role string) error {
+	if err := s.checkUserAndRole(userID, role); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteUserRole(context.Background(), userID, role); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Str("role", role).
+			Msgf("Error while removing user role: %v", err.Error())
+		return spverrors.ErrUpdateUserRoles
+	}
+	return nil
+}
+
+func (s *Service) checkUserAndRole(userID int, role string) error {
+	if _, err := s.usersService.GetUserByID(userID); err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	roles, err := s.GetRoles()
+	if err != nil {
+		return err
+	}
+	for _, r := range roles {
+		if r.Name == role {
+			return nil
+		}
+	}
+	return spverrors.ErrInvalidRole
+}
+
+func (s *Service) defaultRoles() ([]string, error) {
+	roles, err := s.GetRoles()
+	if err != nil {
+		return nil, err
+	}
+
+	defaultRoles := make([]string, 0, 1)
+	for _, r := range roles {
+		if r.Default {
+			defaultRoles = append(defaultRoles, r.Name)
+		}
+	}
+	return defaultRoles, nil
+}
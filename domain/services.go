@@ -1,12 +1,48 @@
 package domain
 
 import (
+	"github.com/bitcoin-sv/spv-wallet-web-backend/blobstore"
+	db_audit "github.com/bitcoin-sv/spv-wallet-web-backend/data/audit"
+	db_idempotency "github.com/bitcoin-sv/spv-wallet-web-backend/data/idempotency"
+	db_identities "github.com/bitcoin-sv/spv-wallet-web-backend/data/identities"
+	db_lockout "github.com/bitcoin-sv/spv-wallet-web-backend/data/lockout"
+	db_operators "github.com/bitcoin-sv/spv-wallet-web-backend/data/operators"
+	db_outbox "github.com/bitcoin-sv/spv-wallet-web-backend/data/outbox"
+	db_passkeys "github.com/bitcoin-sv/spv-wallet-web-backend/data/passkeys"
+	db_paymails "github.com/bitcoin-sv/spv-wallet-web-backend/data/paymails"
+	db_payouts "github.com/bitcoin-sv/spv-wallet-web-backend/data/payouts"
+	db_profiles "github.com/bitcoin-sv/spv-wallet-web-backend/data/profiles"
+	db_roles "github.com/bitcoin-sv/spv-wallet-web-backend/data/roles"
+	db_sessions "github.com/bitcoin-sv/spv-wallet-web-backend/data/sessions"
+	db_tokens "github.com/bitcoin-sv/spv-wallet-web-backend/data/tokens"
+	db_transactions "github.com/bitcoin-sv/spv-wallet-web-backend/data/transactions"
+	db_twofactor "github.com/bitcoin-sv/spv-wallet-web-backend/data/twofactor"
 	db_users "github.com/bitcoin-sv/spv-wallet-web-backend/data/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/admin"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/config"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/contacts"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/exports"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/grants"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/idempotency"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/identities"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/lockout"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/operators"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/outbox"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/passkeys"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/paymails"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/payouts"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/profiles"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/rates"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/sessions"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/tokens"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/transactions"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/twofactor"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/mailer"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/oidc"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/spvwallet"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -20,25 +56,113 @@ type Services struct {
 	WalletClientFactory users.WalletClientFactory
 	ConfigService       *config.Service
 	RatesService        *rates.Service
+	GrantsService       *grants.Service
+	SessionsService     *sessions.Service
+	TwoFactorService    *twofactor.Service
+	LockoutService      *lockout.Service
+	PaymailsService     *paymails.Service
+	ProfilesService     *profiles.Service
+	ExportsService      *exports.Service
+	OperatorsService    *operators.Service
+	AdminService        *admin.Service
+	RolesService        *roles.Service
+	AuditService        *audit.Service
+	IdentitiesService   *identities.Service
+	PasskeysService     *passkeys.Service
+	TokensService       *tokens.Service
+	PayoutsService      *payouts.Service
+	OutboxService       *outbox.Service
+	IdempotencyService  *idempotency.Service
+}
+
+// Repositories is a struct that contains all repositories used by services.
+type Repositories struct {
+	Users        *db_users.Repository
+	Sessions     *db_sessions.Repository
+	TwoFactor    *db_twofactor.Repository
+	Lockout      *db_lockout.Repository
+	Paymails     *db_paymails.Repository
+	Profiles     *db_profiles.Repository
+	Operators    *db_operators.Repository
+	Roles        *db_roles.Repository
+	Audit        *db_audit.Repository
+	Identities   *db_identities.Repository
+	Passkeys     *db_passkeys.Repository
+	Tokens       *db_tokens.Repository
+	Payouts      *db_payouts.Repository
+	Outbox       *db_outbox.Repository
+	Idempotency  *db_idempotency.Repository
+	Transactions *db_transactions.Repository
 }
 
 // NewServices creates services instance.
-func NewServices(usersRepo *db_users.Repository, log *zerolog.Logger) (*Services, error) {
+func NewServices(repos *Repositories, log *zerolog.Logger) (*Services, error) {
 	walletClientFactory := spvwallet.NewWalletClientFactory(log)
 	adminWalletClient, err := walletClientFactory.CreateAdminClient()
 	if err != nil {
 		return nil, errors.Wrap(err, "internal error")
 	}
 
+	keyCustody, err := encryption.NewKeyCustody()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create key custody")
+	}
+
+	sealer, err := encryption.NewServerSealer(keyCustody)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create sealer")
+	}
+
+	m, err := mailer.NewMailer(log)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create mailer")
+	}
+
+	oidcProvider, err := oidc.NewProvider()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create oidc provider")
+	}
+
+	blobStore, err := blobstore.NewBlobStore()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create blob store")
+	}
+
 	rService := rates.NewRatesService(log)
-	uService := users.NewUserService(usersRepo, adminWalletClient, walletClientFactory, rService, log)
+	cService := config.NewConfigService(adminWalletClient, log)
+	tfService := twofactor.NewTwoFactorService(repos.TwoFactor, sealer, log)
+	oService := outbox.NewOutboxService(repos.Outbox, walletClientFactory, sealer, log)
+	uService := users.NewUserService(repos.Users, adminWalletClient, walletClientFactory, rService, keyCustody, tfService, oService, m, log)
+	pService := paymails.NewPaymailsService(repos.Paymails, repos.Users, adminWalletClient, cService, log)
+	prService := profiles.NewProfilesService(repos.Profiles, pService, blobStore, log)
+	lService := lockout.NewLockoutService(repos.Lockout, log)
+	sService := sessions.NewSessionsService(repos.Sessions, walletClientFactory, sealer, log)
+	tkService := tokens.NewTokensService(repos.Tokens, uService, walletClientFactory, sealer, log)
+	gService := grants.NewGrantsService(log)
 
 	return &Services{
 		RatesService:        rService,
 		UsersService:        uService,
 		WalletClientFactory: walletClientFactory,
-		TransactionsService: transactions.NewTransactionService(adminWalletClient, walletClientFactory, log),
+		TransactionsService: transactions.NewTransactionService(repos.Transactions, adminWalletClient, walletClientFactory, rService, oService, log),
 		ContactsService:     contacts.NewContactsService(adminWalletClient, walletClientFactory, log),
-		ConfigService:       config.NewConfigService(adminWalletClient, log),
+		ConfigService:       cService,
+		GrantsService:       gService,
+		SessionsService:     sService,
+		TwoFactorService:    tfService,
+		LockoutService:      lService,
+		PaymailsService:     pService,
+		ProfilesService:     prService,
+		ExportsService:      exports.NewExportsService(uService, pService, prService, walletClientFactory, log),
+		OperatorsService:    operators.NewOperatorsService(repos.Operators, log),
+		AdminService:        admin.NewAdminService(uService, pService, lService, sService, tkService, gService, adminWalletClient, log),
+		RolesService:        roles.NewRolesService(repos.Roles, uService, log),
+		AuditService:        audit.NewAuditService(repos.Audit, log),
+		IdentitiesService:   identities.NewIdentitiesService(repos.Identities, oidcProvider, uService, walletClientFactory, sealer, log),
+		PasskeysService:     passkeys.NewPasskeysService(repos.Passkeys, uService, keyCustody, log),
+		TokensService:       tkService,
+		PayoutsService:      payouts.NewPayoutsService(repos.Payouts, walletClientFactory, log),
+		OutboxService:       oService,
+		IdempotencyService:  idempotency.NewIdempotencyService(repos.Idempotency, log),
 	}, nil
 }
@@ -0,0 +1,16 @@
+package sessions
+
+import "time"
+
+// Session represents a user login tracked together with SPV Wallet access key issued for it.
+type Session struct {
+	ID          int       `json:"id"`
+	UserID      int       `json:"-"`
+	AccessKeyID string    `json:"accessKeyId"`
+	AccessKey   string    `json:"-"`
+	IP          string    `json:"ip"`
+	UserAgent   string    `json:"userAgent"`
+	CreatedAt   time.Time `json:"createdAt"`
+	LastSeenAt  time.Time `json:"lastSeenAt"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
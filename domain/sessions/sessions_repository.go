@@ -0,0 +1,18 @@
+package sessions
+
+import (
+	"context"
+	"time"
+)
+
+// Repository is an interface which defines methods for sessions Repository.
+type Repository interface {
+	InsertSession(ctx context.Context, session *Session) error
+	GetActiveUserSessions(ctx context.Context, userID int) ([]*Session, error)
+	GetActiveUserSession(ctx context.Context, userID, id int) (*Session, error)
+	GetExpiredSessions(ctx context.Context, now time.Time, limit int) ([]*Session, error)
+	ExtendSession(ctx context.Context, accessKeyID string, expiresAt time.Time) error
+	TouchSession(ctx context.Context, id int, accessKeyID string, lastSeenAt time.Time) (bool, error)
+	MarkSessionRevoked(ctx context.Context, accessKeyID string, revokedAt time.Time) error
+	MarkUserSessionsRevoked(ctx context.Context, userID int, keepAccessKeyID string, revokedAt time.Time) error
+}
@@ -0,0 +1,267 @@
+package sessions
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+)
+
+const (
+	// reaperBatchSize is a max number of expired sessions revoked in a single reaper run.
+	reaperBatchSize = 100
+	// maxUserAgentLength is a length of the user_agent column.
+	maxUserAgentLength = 512
+)
+
+// Service tracks access keys issued for user sessions, so they can be revoked
+// in SPV Wallet on sign-out, on user request or when the session expires.
+type Service struct {
+	repo                Repository
+	walletClientFactory users.WalletClientFactory
+	sealer              *encryption.Sealer
+	maxAge              time.Duration
+	reaperInterval      time.Duration
+	log                 *zerolog.Logger
+}
+
+// NewSessionsService creates a new sessions service. Access keys of sessions are stored encrypted by sealer.
+func NewSessionsService(repo Repository, walletClientFactory users.WalletClientFactory, sealer *encryption.Sealer, log *zerolog.Logger) *Service {
+	sessionsServiceLogger := log.With().Str("service", "sessions-service").Logger()
+	return &Service{
+		repo:                repo,
+		walletClientFactory: walletClientFactory,
+		sealer:              sealer,
+		maxAge:              time.Duration(viper.GetInt(config.EnvHTTPServerSessionMaxAge)) * time.Second,
+		reaperInterval:      viper.GetDuration(config.EnvHTTPServerSessionReaperInterval),
+		log:                 &sessionsServiceLogger,
+	}
+}
+
+// CreateSession starts tracking the access key issued on user sign-in, together with the client ip and user agent.
+func (s *Service) CreateSession(userID int, accessKey users.AccessKey, ip, userAgent string) (*Session, error) {
+	sealedAccessKey, err := s.sealer.Seal(context.Background(), accessKey.Key)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while encrypting session access key: %v", err.Error())
+		return nil, spverrors.ErrCreateSession
+	}
+
+	now := time.Now()
+	session := &Session{
+		UserID:      userID,
+		AccessKeyID: accessKey.ID,
+		AccessKey:   sealedAccessKey,
+		IP:          ip,
+		UserAgent:   truncate(userAgent, maxUserAgentLength),
+		CreatedAt:   now,
+		LastSeenAt:  now,
+		ExpiresAt:   now.Add(s.maxAge),
+	}
+
+	if err = s.repo.InsertSession(context.Background(), session); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while inserting session: %v", err.Error())
+		return nil, spverrors.ErrCreateSession
+	}
+
+	return session, nil
+}
+
+// ExtendSession moves the session expiration, it should be called whenever the session cookie is saved again.
+func (s *Service) ExtendSession(accessKeyID string) error {
+	if err := s.repo.ExtendSession(context.Background(), accessKeyID, time.Now().Add(s.maxAge)); err != nil {
+		s.log.Error().Msgf("Error while extending session: %v", err.Error())
+		return spverrors.ErrSessionUpdate
+	}
+	return nil
+}
+
+// TouchSession checks if the session is still active and records the time it was last used.
+func (s *Service) TouchSession(sessionID int, accessKeyID string) error {
+	active, err := s.repo.TouchSession(context.Background(), sessionID, accessKeyID, time.Now())
+	if err != nil {
+		s.log.Error().Msgf("Error while updating session: %v", err.Error())
+		return spverrors.ErrGetSessions
+	}
+
+	if !active {
+		return spverrors.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// GetActiveSessions returns not expired sessions of the user.
+func (s *Service) GetActiveSessions(userID int) ([]*Session, error) {
+	sessions, err := s.repo.GetActiveUserSessions(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting sessions: %v", err.Error())
+		return nil, spverrors.ErrGetSessions
+	}
+	return sessions, nil
+}
+
+// RevokeSession revokes access key of the user session with given id and returns the revoked session.
+func (s *Service) RevokeSession(userID, sessionID int) (*Session, error) {
+	session, err := s.repo.GetActiveUserSession(context.Background(), userID, sessionID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting session: %v", err.Error())
+		return nil, spverrors.ErrGetSessions
+	}
+
+	if session == nil {
+		return nil, spverrors.ErrSessionNotFound
+	}
+
+	if err = s.revokeSession(session); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while revoking session: %v", err.Error())
+		return nil, spverrors.ErrSessionTerminate
+	}
+
+	return session, nil
+}
+
+// TerminateUserSessions revokes access keys of all active sessions of the user and returns number of terminated sessions.
+// Sessions whose access key cannot be revoked are still marked as revoked, so they're rejected by the backend.
+func (s *Service) TerminateUserSessions(userID int) (int, error) {
+	sessions, err := s.repo.GetActiveUserSessions(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting sessions: %v", err.Error())
+		return 0, spverrors.ErrGetSessions
+	}
+
+	for _, session := range sessions {
+		if err = s.revokeSession(session); err != nil {
+			s.log.Warn().
+				Str("userID", strconv.Itoa(userID)).
+				Msgf("Error while revoking session, it's only marked as revoked: %v", err.Error())
+		}
+	}
+
+	if err = s.repo.MarkUserSessionsRevoked(context.Background(), userID, "", time.Now()); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while marking sessions as revoked: %v", err.Error())
+		return 0, spverrors.ErrSessionTerminate
+	}
+
+	return len(sessions), nil
+}
+
+// SignOut revokes access key of the session which is being terminated.
+func (s *Service) SignOut(accessKeyID, accessKey string) error {
+	if err := s.revoke(accessKeyID, accessKey); err != nil {
+		s.log.Error().Msgf("Error while revoking access key on sign-out: %v", err.Error())
+		return spverrors.ErrSessionTerminate
+	}
+	return nil
+}
+
+// ForgetOtherSessions marks all user sessions except the current one as revoked,
+// it should be called when their access keys were already revoked in SPV Wallet by other means.
+func (s *Service) ForgetOtherSessions(userID int, currentAccessKeyID string) error {
+	if err := s.repo.MarkUserSessionsRevoked(context.Background(), userID, currentAccessKeyID, time.Now()); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while marking sessions as revoked: %v", err.Error())
+		return spverrors.ErrSessionTerminate
+	}
+	return nil
+}
+
+// StartReaper periodically revokes access keys of expired sessions until ctx is done.
+func (s *Service) StartReaper(ctx context.Context) {
+	ticker := time.NewTicker(s.reaperInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RevokeExpiredSessions(ctx)
+		}
+	}
+}
+
+// RevokeExpiredSessions revokes access keys of sessions which expired.
+// If the access key cannot be revoked for longer than the session max age (e.g. because it was already revoked
+// directly in SPV Wallet), the session is given up and marked as revoked anyway.
+func (s *Service) RevokeExpiredSessions(ctx context.Context) {
+	now := time.Now()
+	expired, err := s.repo.GetExpiredSessions(ctx, now, reaperBatchSize)
+	if err != nil {
+		s.log.Error().Msgf("Error while getting expired sessions: %v", err.Error())
+		return
+	}
+
+	for _, session := range expired {
+		err = s.revokeSession(session)
+		if err == nil {
+			continue
+		}
+
+		if session.ExpiresAt.Add(s.maxAge).After(now) {
+			s.log.Warn().
+				Str("userID", strconv.Itoa(session.UserID)).
+				Msgf("Error while revoking expired session, will retry: %v", err.Error())
+			continue
+		}
+
+		s.log.Warn().
+			Str("userID", strconv.Itoa(session.UserID)).
+			Msgf("Giving up revoking expired session: %v", err.Error())
+		if err = s.repo.MarkSessionRevoked(ctx, session.AccessKeyID, now); err != nil {
+			s.log.Error().Msgf("Error while marking session as revoked: %v", err.Error())
+		}
+	}
+}
+
+// revokeSession revokes the sealed access key stored with the session.
+func (s *Service) revokeSession(session *Session) error {
+	accessKey, err := s.sealer.Open(context.Background(), session.AccessKey)
+	if err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	return s.revoke(session.AccessKeyID, accessKey)
+}
+
+// revoke revokes the access key in SPV Wallet and marks the session as revoked.
+func (s *Service) revoke(accessKeyID, accessKey string) error {
+	userWalletClient, err := s.walletClientFactory.CreateWithAccessKey(accessKey)
+	if err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	if _, err = userWalletClient.RevokeAccessKey(accessKeyID); err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	return s.repo.MarkSessionRevoked(context.Background(), accessKeyID, time.Now()) //nolint:wrapcheck // error wrapped higher in call stack
+}
+
+func truncate(s string, length int) string {
+	if len(s) <= length {
+		return s
+	}
+	return strings.ToValidUTF8(s[:length], "")
+}
@@ -0,0 +1,71 @@
+package tokens
+
+import (
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+)
+
+// Scopes of API tokens, a token can have several of them.
+const (
+	// ScopeReadOnly allows to view balance, transactions and contacts.
+	ScopeReadOnly = "read-only"
+	// ScopeSend allows to send transactions, the wallet password is still required by every transaction.
+	ScopeSend = "send"
+	// ScopeContacts allows to add, accept, reject and confirm contacts.
+	ScopeContacts = "contacts"
+)
+
+// scopePermissions are permissions granted by scopes. Account management is never granted to API tokens.
+var scopePermissions = map[string]string{
+	ScopeReadOnly: roles.PermissionWalletRead,
+	ScopeSend:     roles.PermissionWalletSpend,
+	ScopeContacts: roles.PermissionContactsWrite,
+}
+
+// Token is a personal API token of the user, it authenticates API requests by the Authorization header instead of session cookie.
+type Token struct {
+	ID     int    `json:"id"`
+	UserID int    `json:"-"`
+	Name   string `json:"name"`
+	// Prefix is the beginning of the token, it lets the user recognize the token, which itself is shown only once.
+	Prefix string   `json:"prefix"`
+	Hash   string   `json:"-"` // sha256 hash of the token
+	Scopes []string `json:"scopes"`
+	// AccessKeyID and AccessKey are of the access key created for the token, requests authenticated by the token use it.
+	AccessKeyID string     `json:"-"`
+	AccessKey   string     `json:"-"` // access key sealed by the server sealer
+	CreatedAt   time.Time  `json:"createdAt"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt  *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// Expired returns true if the token has expiration and it passed.
+func (t *Token) Expired() bool {
+	return t.ExpiresAt != nil && time.Now().After(*t.ExpiresAt)
+}
+
+// Permissions returns permissions granted by scopes of the token. They're limited by roles of the user, so the token
+// never grants more than the user has.
+func (t *Token) Permissions() roles.Permissions {
+	result := make(roles.Permissions, len(t.Scopes))
+	for _, scope := range t.Scopes {
+		if permission, ok := scopePermissions[scope]; ok {
+			result[permission] = struct{}{}
+		}
+	}
+	return result
+}
+
+// CreatedToken is a new token together with its secret value, which isn't stored and can't be shown again.
+type CreatedToken struct {
+	Token  *Token
+	Secret string
+}
+
+// AuthenticatedToken is a token which authenticated the request together with the decrypted access key and primary paymail of the user.
+type AuthenticatedToken struct {
+	Token     *Token
+	AccessKey string
+	Paymail   string
+}
@@ -0,0 +1,18 @@
+package tokens
+
+import (
+	"context"
+	"time"
+)
+
+// Repository is an interface which defines methods for API tokens Repository.
+type Repository interface {
+	InsertToken(ctx context.Context, token *Token) error
+	GetTokenByHash(ctx context.Context, hash string) (*Token, error)
+	GetUserTokens(ctx context.Context, userID int) ([]*Token, error)
+	TouchToken(ctx context.Context, id int, usedAt time.Time) error
+	// DeleteToken deletes token of the user and returns it, nil is returned if the user has no such token.
+	DeleteToken(ctx context.Context, userID, id int) (*Token, error)
+	// DeleteUserTokens deletes all tokens of the user and returns them.
+	DeleteUserTokens(ctx context.Context, userID int) ([]*Token, error)
+}
@@ -0,0 +1,277 @@
+package tokens
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// secretPrefix marks API tokens, so they can be recognized e.g. by secret scanners.
+	secretPrefix = "spvw_"
+	secretLength = 32
+	// displayedPrefixLength is the length of the token beginning stored to recognize the token.
+	displayedPrefixLength = len(secretPrefix) + 8
+	// touchInterval limits how often last use of the token is stored, so not every request writes to db.
+	touchInterval = time.Minute
+)
+
+// Service creates personal API tokens and authenticates requests with them. Every token has its own access key,
+// created with xPriv when the token is created, so the token lets to read the wallet but never to sign.
+type Service struct {
+	repo                Repository
+	uService            *users.UserService
+	walletClientFactory users.WalletClientFactory
+	sealer              *encryption.Sealer
+	log                 *zerolog.Logger
+}
+
+// NewTokensService creates a new API tokens service. Access keys of tokens are encrypted by sealer.
+func NewTokensService(repo Repository, uService *users.UserService, walletClientFactory users.WalletClientFactory, sealer *encryption.Sealer, log *zerolog.Logger) *Service {
+	tokensServiceLogger := log.With().Str("service", "tokens-service").Logger()
+	return &Service{
+		repo:                repo,
+		uService:            uService,
+		walletClientFactory: walletClientFactory,
+		sealer:              sealer,
+		log:                 &tokensServiceLogger,
+	}
+}
+
+// CreateToken creates token of the user with given scopes. The xPriv, unlocked with the wallet password, creates access key of the token.
+// If expiresAt is nil, the token is valid until it's revoked. Like access keys of sessions, its access key is revoked on password change and wallet recovery.
+func (s *Service) CreateToken(userID int, name string, scopes []string, expiresAt *time.Time, xpriv string) (*CreatedToken, error) {
+	scopes, err := normalizeScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, spverrors.ErrInvalidAPITokenExpiration
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		s.log.Error().Msgf("Error while generating API token: %v", err.Error())
+		return nil, spverrors.ErrCreateAPIToken
+	}
+
+	userWalletClient, err := s.walletClientFactory.CreateWithXpriv(xpriv)
+	if err != nil {
+		return nil, spverrors.ErrCreateAPIToken.Wrap(err)
+	}
+
+	accessKey, err := userWalletClient.CreateAccessKey()
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while creating API token access key: %v", err.Error())
+		return nil, spverrors.ErrCreateAccessKey
+	}
+
+	encryptedAccessKey, err := s.sealer.Seal(context.Background(), accessKey.GetAccessKey())
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while encrypting API token access key: %v", err.Error())
+		return nil, spverrors.ErrCreateAPIToken
+	}
+
+	token := &Token{
+		UserID:      userID,
+		Name:        name,
+		Prefix:      secret[:displayedPrefixLength],
+		Hash:        hashSecret(secret),
+		Scopes:      scopes,
+		AccessKeyID: accessKey.GetAccessKeyID(),
+		AccessKey:   encryptedAccessKey,
+		CreatedAt:   time.Now(),
+		ExpiresAt:   expiresAt,
+	}
+	if err = s.repo.InsertToken(context.Background(), token); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while inserting API token: %v", err.Error())
+		return nil, spverrors.ErrCreateAPIToken
+	}
+
+	return &CreatedToken{
+		Token:  token,
+		Secret: secret,
+	}, nil
+}
+
+// Authenticate returns token with the secret value. Unknown, revoked and expired tokens are rejected with the same error.
+func (s *Service) Authenticate(secret string) (*AuthenticatedToken, error) {
+	token, err := s.repo.GetTokenByHash(context.Background(), hashSecret(secret))
+	if err != nil {
+		s.log.Error().Msgf("Error while getting API token: %v", err.Error())
+		return nil, spverrors.ErrGetAPITokens
+	}
+
+	if token == nil || token.Expired() {
+		return nil, spverrors.ErrInvalidAPIToken
+	}
+
+	accessKey, err := s.sealer.Open(context.Background(), token.AccessKey)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(token.UserID)).
+			Msgf("Error while decrypting API token access key: %v", err.Error())
+		return nil, spverrors.ErrInvalidAPIToken
+	}
+
+	// Primary paymail could be changed since the token was created, so it's taken from db.
+	user, err := s.uService.GetUserByID(token.UserID)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	// Tokens are revoked when the account is locked, the lock is checked in case revoking failed.
+	if user.Locked() {
+		return nil, spverrors.ErrAccountSuspended
+	}
+
+	now := time.Now()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= touchInterval {
+		if err = s.repo.TouchToken(context.Background(), token.ID, now); err != nil {
+			s.log.Warn().
+				Str("userID", strconv.Itoa(token.UserID)).
+				Msgf("Error while updating API token last use: %v", err.Error())
+		} else {
+			token.LastUsedAt = &now
+		}
+	}
+
+	return &AuthenticatedToken{
+		Token:     token,
+		AccessKey: accessKey,
+		Paymail:   user.Paymail,
+	}, nil
+}
+
+// GetUserTokens returns API tokens of the user.
+func (s *Service) GetUserTokens(userID int) ([]*Token, error) {
+	result, err := s.repo.GetUserTokens(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting API tokens: %v", err.Error())
+		return nil, spverrors.ErrGetAPITokens
+	}
+	return result, nil
+}
+
+// RevokeToken deletes token of the user and revokes its access key.
+func (s *Service) RevokeToken(userID, id int) error {
+	token, err := s.repo.DeleteToken(context.Background(), userID, id)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while deleting API token: %v", err.Error())
+		return spverrors.ErrRevokeAPIToken
+	}
+
+	if token == nil {
+		return spverrors.ErrAPITokenNotFound
+	}
+
+	// Token is already removed, so it can't authenticate requests even if its access key is not revoked.
+	if err = s.revokeAccessKey(token); err != nil {
+		s.log.Warn().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while revoking API token access key: %v", err.Error())
+	}
+
+	return nil
+}
+
+// RevokeUserTokens deletes all tokens of the user and revokes their access keys, it returns number of revoked tokens.
+// Tokens whose access key cannot be revoked are still deleted, so they can't authenticate requests.
+func (s *Service) RevokeUserTokens(userID int) (int, error) {
+	deleted, err := s.repo.DeleteUserTokens(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while deleting API tokens: %v", err.Error())
+		return 0, spverrors.ErrRevokeAPIToken
+	}
+
+	for _, token := range deleted {
+		if err = s.revokeAccessKey(token); err != nil {
+			s.log.Warn().
+				Str("userID", strconv.Itoa(userID)).
+				Msgf("Error while revoking API token access key, the token is only deleted: %v", err.Error())
+		}
+	}
+
+	return len(deleted), nil
+}
+
+// ForgetUserTokens deletes all tokens of the user,
+// it should be called when their access keys were already revoked in SPV Wallet by other means.
+func (s *Service) ForgetUserTokens(userID int) error {
+	if _, err := s.repo.DeleteUserTokens(context.Background(), userID); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while deleting API tokens: %v", err.Error())
+		return spverrors.ErrRevokeAPIToken
+	}
+	return nil
+}
+
+func (s *Service) revokeAccessKey(token *Token) error {
+	accessKey, err := s.sealer.Open(context.Background(), token.AccessKey)
+	if err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	userWalletClient, err := s.walletClientFactory.CreateWithAccessKey(accessKey)
+	if err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	_, err = userWalletClient.RevokeAccessKey(token.AccessKeyID)
+	return err //nolint:wrapcheck // error wrapped higher in call stack
+}
+
+// normalizeScopes checks that scopes are known and removes duplicates, at least one scope is required.
+func normalizeScopes(scopes []string) ([]string, error) {
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if _, ok := scopePermissions[scope]; !ok {
+			return nil, spverrors.ErrInvalidAPITokenScopes
+		}
+		if !slices.Contains(result, scope) {
+			result = append(result, scope)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, spverrors.ErrInvalidAPITokenScopes
+	}
+	return result, nil
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, secretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+	return secretPrefix + hex.EncodeToString(b), nil
+}
+
+// hashSecret returns sha256 hash of the token. Tokens are random, so they don't need a slow password hash.
+func hashSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
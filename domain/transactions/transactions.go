@@ -1,6 +1,12 @@
 package transactions
 
-import "github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+import (
+	"strings"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+)
 
 // PaginatedTransactions represents transactions with pagination details
 // like transactins count and number of pages.
@@ -9,3 +15,97 @@ type PaginatedTransactions struct {
 	Pages        int                 `json:"pages"`
 	Transactions []users.Transaction `json:"transactions"`
 }
+
+// Recipient is an output of the transaction sent by the user.
+type Recipient struct {
+	Recipient string
+	Satoshis  uint64
+	// OpReturn is optional data attached to the output.
+	OpReturn string
+}
+
+// Preview is the drafted transaction shown to the user before it's sent. Token must be presented to send the transaction,
+// the drafted transaction is signed then, so the user pays exactly the previewed fee.
+type Preview struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	// Amount is the total value paid to recipients.
+	Amount  uint64              `json:"amount"`
+	Fee     uint64              `json:"fee"`
+	Change  uint64              `json:"change"`
+	Inputs  []users.DraftInput  `json:"inputs"`
+	Outputs []users.DraftOutput `json:"outputs"`
+	// USD is omitted if the exchange rate is not available.
+	USD *PreviewUSD `json:"usd,omitempty"`
+
+	UserID     int         `json:"-"`
+	Recipients []Recipient `json:"-"`
+	// Draft is the unsigned transaction drafted by SPV Wallet, it's signed when the preview is sent.
+	Draft    users.DraftTransaction `json:"-"`
+	Metadata map[string]any         `json:"-"`
+}
+
+// SentTransaction is the signed transaction which is recorded in SPV Wallet. Until it's recorded, it has pending record status.
+type SentTransaction struct {
+	DraftID       string `json:"draftId"`
+	TransactionID string `json:"transactionId"`
+}
+
+// PreviewUSD is the value of the previewed transaction in USD.
+type PreviewUSD struct {
+	Amount float64 `json:"amount"`
+	Fee    float64 `json:"fee"`
+	Total  float64 `json:"total"`
+}
+
+// SameRecipients returns true if the recipients are the previewed ones, in any order.
+func (p *Preview) SameRecipients(recipients []Recipient) bool {
+	if len(recipients) != len(p.Recipients) {
+		return false
+	}
+
+	previewed := make(map[string]Recipient, len(p.Recipients))
+	for _, r := range p.Recipients {
+		previewed[normalizeRecipient(r.Recipient)] = r
+	}
+	for _, r := range recipients {
+		other, ok := previewed[normalizeRecipient(r.Recipient)]
+		if !ok || other.Satoshis != r.Satoshis || other.OpReturn != r.OpReturn {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateRecipients checks that there is at least one recipient, every recipient gets some satoshis
+// and no recipient is paid twice. It returns total value of the transaction.
+func ValidateRecipients(recipients []Recipient) (uint64, error) {
+	if len(recipients) == 0 {
+		return 0, spverrors.ErrInvalidRecipients
+	}
+
+	var total uint64
+	seen := make(map[string]struct{}, len(recipients))
+	for _, r := range recipients {
+		to := normalizeRecipient(r.Recipient)
+		if to == "" || r.Satoshis == 0 {
+			return 0, spverrors.ErrInvalidRecipients
+		}
+
+		if _, ok := seen[to]; ok {
+			return 0, spverrors.ErrDuplicateRecipient
+		}
+		seen[to] = struct{}{}
+
+		if total+r.Satoshis < total {
+			return 0, spverrors.ErrInvalidRecipients
+		}
+		total += r.Satoshis
+	}
+
+	return total, nil
+}
+
+func normalizeRecipient(recipient string) string {
+	return strings.ToLower(strings.TrimSpace(recipient))
+}
@@ -0,0 +1,21 @@
+package transactions
+
+import (
+	"context"
+	"time"
+)
+
+// Repository is an interface which defines methods for transaction previews Repository.
+type Repository interface {
+	// InsertPreview inserts the preview and deletes other previews of the user which are not being sent, the user has one preview at a time.
+	// Expired previews of all users are deleted too.
+	InsertPreview(ctx context.Context, preview *Preview, now time.Time) error
+	// GetPreview returns preview of the user, nil is returned if the user has no such preview.
+	GetPreview(ctx context.Context, userID int, token string) (*Preview, error)
+	// ClaimPreview marks the preview of the user as being sent and returns it, so it's not sent twice at the same time.
+	// Nil is returned if the user has no such preview, it expired or it's being sent already.
+	ClaimPreview(ctx context.Context, userID int, token string, now time.Time) (*Preview, error)
+	// ReleasePreview clears the mark set by ClaimPreview, so the preview can be sent again.
+	ReleasePreview(ctx context.Context, token string) error
+	DeletePreview(ctx context.Context, token string) error
+}
@@ -1,70 +1,225 @@
 package transactions
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"math"
+	"strconv"
 	"time"
 
-	"github.com/avast/retry-go/v4"
 	"github.com/bitcoin-sv/spv-wallet-go-client/commands"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/outbox"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/rates"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/notification"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
-	"github.com/bitcoin-sv/spv-wallet/models"
 	"github.com/bitcoin-sv/spv-wallet/models/filter"
+	"github.com/bitcoin-sv/spv-wallet/models/response"
 	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
 )
 
+const previewTokenLength = 32
+
 // TransactionService represents service whoch contains methods linked with transactions.
 type TransactionService struct {
+	repo                Repository
 	adminWalletClient   users.AdminWalletClient
 	walletClientFactory users.WalletClientFactory
+	ratesService        *rates.Service
+	outboxService       *outbox.Service
+	previewTTL          time.Duration
+	recordWindow        time.Duration
 	log                 *zerolog.Logger
 }
 
 // NewTransactionService creates new transaction service.
-func NewTransactionService(adminWalletClient users.AdminWalletClient, walletClientFactory users.WalletClientFactory, log *zerolog.Logger) *TransactionService {
+// If ratesService is nil, previews are not valued in USD.
+func NewTransactionService(repo Repository, adminWalletClient users.AdminWalletClient, walletClientFactory users.WalletClientFactory, ratesService *rates.Service, outboxService *outbox.Service, log *zerolog.Logger) *TransactionService {
 	transactionServiceLogger := log.With().Str("service", "transaction-service").Logger()
 	return &TransactionService{
+		repo:                repo,
 		adminWalletClient:   adminWalletClient,
 		walletClientFactory: walletClientFactory,
+		ratesService:        ratesService,
+		outboxService:       outboxService,
+		previewTTL:          viper.GetDuration(config.EnvTransactionsPreviewTTL),
+		recordWindow:        viper.GetDuration(config.EnvTransactionsRecordWindow),
 		log:                 &transactionServiceLogger,
 	}
 }
 
-// CreateTransaction creates transaction.
-func (s *TransactionService) CreateTransaction(userPaymail, xpriv, recipient string, satoshis uint64, events chan notification.TransactionEvent) error {
-	userWalletClient, err := s.walletClientFactory.CreateWithXpriv(xpriv)
+// PreviewTransaction drafts transaction paying all recipients without signing it. Total value of the transaction is checked
+// against the balance, the fee is calculated by SPV Wallet. Inputs of the draft are reserved until the draft expires.
+// Previews are stored in the database, so they can be sent through any instance. The user has one preview at a time,
+// the previous one is replaced unless it's being sent.
+// SPV Wallet client has no way to cancel a draft, so inputs of replaced and abandoned previews are released when their draft expires.
+func (s *TransactionService) PreviewTransaction(userID int, userPaymail, accessKey string, recipients []Recipient) (*Preview, error) {
+	total, err := ValidateRecipients(recipients)
 	if err != nil {
-		return spverrors.ErrCreateTransaction.Wrap(err)
+		return nil, err
 	}
 
-	recipients := []*commands.Recipients{{Satoshis: satoshis, To: recipient}}
-	metadata := map[string]any{"receiver": recipient, "sender": userPaymail}
+	userWalletClient, err := s.walletClientFactory.CreateWithAccessKey(accessKey)
+	if err != nil {
+		return nil, spverrors.ErrPreviewTransaction.Wrap(err)
+	}
 
-	draftTransaction, err := userWalletClient.CreateAndFinalizeTransaction(recipients, metadata)
+	xpub, err := userWalletClient.GetXPub()
 	if err != nil {
-		s.log.Debug().Msgf("Error during create transaction: %s", err.Error())
-		return spverrors.ErrCreateTransaction
+		s.log.Debug().Msgf("Error during get balance: %s", err.Error())
+		return nil, spverrors.ErrPreviewTransaction
+	}
+	if total > xpub.GetCurrentBalance() {
+		return nil, spverrors.ErrInsufficientBalance
 	}
 
-	go func() {
-		if err == nil {
-			return
+	outputs := make([]*commands.Recipients, 0, len(recipients))
+	for _, r := range recipients {
+		output := &commands.Recipients{Satoshis: r.Satoshis, To: r.Recipient}
+		if r.OpReturn != "" {
+			output.OpReturn = &response.OpReturn{StringParts: []string{r.OpReturn}}
 		}
+		outputs = append(outputs, output)
+	}
+	metadata := RecipientsMetadata(userPaymail, recipients)
 
-		tx, err := tryRecordTransaction(userWalletClient, draftTransaction, metadata, s.log)
-		if err != nil {
-			events <- notification.PrepareTransactionErrorEvent(err)
-		} else if tx != nil {
-			events <- notification.PrepareTransactionEvent(tx)
+	// The draft is kept valid after the preview expires, so the transaction sent just before can still be recorded.
+	draft, err := userWalletClient.DraftToRecipients(outputs, metadata, s.previewTTL+s.recordWindow)
+	if err != nil {
+		s.log.Debug().Msgf("Error during draft transaction: %s", err.Error())
+		return nil, spverrors.ErrPreviewTransaction
+	}
+
+	b := make([]byte, previewTokenLength)
+	if _, err = rand.Read(b); err != nil {
+		s.log.Error().Msgf("Error while generating preview token: %v", err.Error())
+		return nil, spverrors.ErrPreviewTransaction
+	}
+
+	preview := &Preview{
+		Token:      hex.EncodeToString(b),
+		ExpiresAt:  time.Now().Add(s.previewTTL),
+		Amount:     total,
+		Fee:        draft.GetDraftTransactionFee(),
+		Change:     draft.GetDraftTransactionChange(),
+		Inputs:     draft.GetDraftTransactionInputs(),
+		Outputs:    draft.GetDraftTransactionOutputs(),
+		USD:        s.valueInUSD(total, draft.GetDraftTransactionFee()),
+		UserID:     userID,
+		Recipients: recipients,
+		Draft:      draft,
+		Metadata:   metadata,
+	}
+
+	if err = s.repo.InsertPreview(context.Background(), preview, time.Now()); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while inserting transaction preview: %v", err.Error())
+		return nil, spverrors.ErrPreviewTransaction
+	}
+
+	return preview, nil
+}
+
+// GetPreview returns preview of the user which wasn't sent yet and didn't expire.
+func (s *TransactionService) GetPreview(userID int, token string) (*Preview, error) {
+	preview, err := s.repo.GetPreview(context.Background(), userID, token)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting transaction preview: %v", err.Error())
+		return nil, spverrors.ErrPreviewTransaction
+	}
+	if preview == nil || time.Now().After(preview.ExpiresAt) {
+		return nil, spverrors.ErrInvalidTransactionPreview
+	}
+
+	return preview, nil
+}
+
+// CreateTransaction signs the previewed transaction and records it. The preview is removed once the signed transaction
+// is written to the outbox, so it's never sent twice. If signing fails, the preview can be sent again.
+// The signed transaction is written to the outbox before it's recorded, so it's retried in background if recording fails.
+func (s *TransactionService) CreateTransaction(userID int, xpriv, previewToken string, events chan notification.TransactionEvent) (*SentTransaction, error) {
+	preview, err := s.repo.ClaimPreview(context.Background(), userID, previewToken, time.Now())
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while claiming transaction preview: %v", err.Error())
+		return nil, spverrors.ErrCreateTransaction
+	}
+	if preview == nil {
+		return nil, spverrors.ErrInvalidTransactionPreview
+	}
+
+	sent, err := s.send(userID, xpriv, preview, events)
+	if err != nil {
+		if releaseErr := s.repo.ReleasePreview(context.Background(), previewToken); releaseErr != nil {
+			s.log.Error().
+				Str("userID", strconv.Itoa(userID)).
+				Msgf("Error while releasing transaction preview: %v", releaseErr.Error())
+		}
+		return nil, err
+	}
+
+	// The preview which isn't deleted stays claimed until it expires, so it's not sent again anyway.
+	if err = s.repo.DeletePreview(context.Background(), previewToken); err != nil {
+		s.log.Warn().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while deleting sent transaction preview: %v", err.Error())
+	}
+
+	return sent, nil
+}
+
+// send signs the draft of the preview and writes it to the outbox, then it's recorded in background.
+func (s *TransactionService) send(userID int, xpriv string, preview *Preview, events chan notification.TransactionEvent) (*SentTransaction, error) {
+	userWalletClient, err := s.walletClientFactory.CreateWithXpriv(xpriv)
+	if err != nil {
+		return nil, spverrors.ErrCreateTransaction.Wrap(err)
+	}
+
+	draftTransaction, err := userWalletClient.FinalizeTransaction(preview.Draft)
+	if err != nil {
+		s.log.Debug().Msgf("Error during create transaction: %s", err.Error())
+		return nil, spverrors.ErrCreateTransaction
+	}
+
+	entry, err := s.outboxService.Enqueue(userID, userWalletClient, draftTransaction, preview.Metadata, preview.Amount, preview.ExpiresAt.Add(s.recordWindow))
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	// The draft is only signed, so it has to be recorded to be broadcast.
+	go func() {
+		tx, err := s.outboxService.Record(entry, userWalletClient)
+		switch {
+		case err != nil:
+			s.notify(events, notification.PrepareTransactionErrorEvent(err))
+		case tx != nil:
+			s.notify(events, notification.PrepareTransactionEvent(tx))
+		default:
+			s.notify(events, notification.PreparePendingTransactionEvent(entry.ToTransaction()))
 		}
 	}()
 
-	return nil
+	return &SentTransaction{DraftID: entry.DraftID, TransactionID: entry.TransactionID}, nil
 }
 
-// GetTransaction returns transaction by id.
-func (s *TransactionService) GetTransaction(accessKey, id, userPaymail string) (users.FullTransaction, error) {
+// notify sends the event without blocking, the event is dropped if the channel isn't read and its buffer is full.
+func (s *TransactionService) notify(events chan notification.TransactionEvent, event notification.TransactionEvent) {
+	select {
+	case events <- event:
+	default:
+		s.log.Warn().Msgf("Transaction event %s was dropped, nobody is waiting for it", event.Status)
+	}
+}
+
+// GetTransaction returns transaction by id. Sent transaction which isn't recorded yet is returned with pending record status.
+func (s *TransactionService) GetTransaction(userID int, accessKey, id, userPaymail string) (users.FullTransaction, error) {
 	// Try to generate user-client with decrypted xpriv.
 	userWalletClient, err := s.walletClientFactory.CreateWithAccessKey(accessKey)
 	if err != nil {
@@ -74,14 +229,20 @@ func (s *TransactionService) GetTransaction(accessKey, id, userPaymail string) (
 	transaction, err := userWalletClient.GetTransaction(id, userPaymail)
 	if err != nil {
 		s.log.Debug().Msgf("Error during get transaction: %s", err.Error())
-		return nil, spverrors.ErrGetTransaction
+
+		pending, outboxErr := s.outboxService.GetTransaction(userID, id)
+		if outboxErr != nil || pending == nil {
+			return nil, spverrors.ErrGetTransaction
+		}
+		return pending, nil
 	}
 
 	return transaction, nil
 }
 
-// GetTransactions returns transactions by access key.
-func (s *TransactionService) GetTransactions(accessKey, userPaymail string, queryParam *filter.QueryParams) (*PaginatedTransactions, error) {
+// GetTransactions returns transactions by access key. Sent transactions which are not recorded yet
+// are prepended to the first page with pending record status.
+func (s *TransactionService) GetTransactions(userID int, accessKey, userPaymail string, queryParam *filter.QueryParams) (*PaginatedTransactions, error) {
 	// Try to generate user-client with decrypted xpriv.
 	userWalletClient, err := s.walletClientFactory.CreateWithAccessKey(accessKey)
 	if err != nil {
@@ -100,6 +261,14 @@ func (s *TransactionService) GetTransactions(accessKey, userPaymail string, quer
 		return nil, spverrors.ErrGetTransactions.Wrap(err)
 	}
 
+	if queryParam.Page <= 1 {
+		pending, err := s.outboxService.GetPendingTransactions(userID)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+		}
+		transactions = withPending(transactions, pending)
+	}
+
 	// Calculate pages.
 	pages := int(math.Ceil(float64(count) / float64(queryParam.PageSize)))
 
@@ -112,42 +281,78 @@ func (s *TransactionService) GetTransactions(accessKey, userPaymail string, quer
 	return pTransactions, nil
 }
 
-func tryRecordTransaction(userWalletClient users.UserWalletClient, draftTx users.DraftTransaction, metadata map[string]any, log *zerolog.Logger) (*models.Transaction, error) {
-	retries := uint(3)
-	tx, recordErr := tryRecord(userWalletClient, draftTx, metadata, log, retries)
+// GetPaymailTransactions returns transactions of the xPub which the paymail belongs to.
+// It uses admin client, so history of other users can be read without their access key.
+func (s *TransactionService) GetPaymailTransactions(paymail string, queryParam *filter.QueryParams) (*PaginatedTransactions, error) {
+	xpub, err := s.adminWalletClient.GetPaymailXPub(paymail)
+	if err != nil {
+		s.log.Error().Str("paymail", paymail).Msgf("Error while getting xPub: %v", err.Error())
+		return nil, spverrors.ErrGetTransactions
+	}
 
-	if recordErr != nil {
-		log.Error().
-			Str("draftTxID", draftTx.GetDraftTransactionID()).
-			Msgf("record transaction failed: %s", recordErr.Error())
-		return nil, spverrors.ErrRecordTransaction
+	transactions, count, err := s.adminWalletClient.GetXPubTransactions(xpub.GetID(), queryParam, paymail)
+	if err != nil {
+		s.log.Error().Str("paymail", paymail).Msgf("Error while getting transactions: %v", err.Error())
+		return nil, spverrors.ErrGetTransactions
+	}
+
+	return &PaginatedTransactions{
+		Count:        count,
+		Pages:        int(math.Ceil(float64(count) / float64(queryParam.PageSize))),
+		Transactions: transactions,
+	}, nil
+}
+
+// valueInUSD returns the amount and fee in USD, nil is returned if the exchange rate is not available.
+func (s *TransactionService) valueInUSD(amount, fee uint64) *PreviewUSD {
+	if s.ratesService == nil {
+		return nil
+	}
+
+	exchangeRate, err := s.ratesService.GetExchangeRate()
+	if err != nil || exchangeRate == nil {
+		s.log.Warn().Msgf("Exchange rate not found, preview is not valued in USD: %v", err)
+		return nil
+	}
+
+	toUSD := func(satoshis uint64) float64 {
+		return float64(satoshis) / 100000000 * *exchangeRate
+	}
+	return &PreviewUSD{
+		Amount: toUSD(amount),
+		Fee:    toUSD(fee),
+		Total:  toUSD(amount + fee),
+	}
+}
+
+// RecipientsMetadata returns metadata with sender and all recipients of the transaction. Receiver is kept
+// with the first recipient, so the transaction is shown by readers which don't know the list of receivers.
+func RecipientsMetadata(userPaymail string, recipients []Recipient) map[string]any {
+	receivers := make([]string, 0, len(recipients))
+	for _, r := range recipients {
+		receivers = append(receivers, r.Recipient)
 	}
 
-	log.Debug().
-		Str("draftTxID", draftTx.GetDraftTransactionID()).
-		Msg("transaction successfully recorded")
-	return tx, nil
+	return map[string]any{
+		"sender":    userPaymail,
+		"receiver":  receivers[0],
+		"receivers": receivers,
+	}
 }
 
-func tryRecord(userWalletClient users.UserWalletClient, draftTx users.DraftTransaction, metadata map[string]any, log *zerolog.Logger, retries uint) (*models.Transaction, error) {
-	log.Debug().
-		Str("draftTxID", draftTx.GetDraftTransactionID()).
-		Msg("record transaction")
-
-	tx := &models.Transaction{}
-	err := retry.Do(
-		func() error {
-			var err error
-			tx, err = userWalletClient.RecordTransaction(draftTx.GetDraftTransactionHex(), draftTx.GetDraftTransactionID(), metadata)
-			return err //nolint:wrapcheck // error wrapped higher in call stack
-		},
-		retry.Attempts(retries),
-		retry.Delay(1*time.Second),
-		retry.OnRetry(func(n uint, err error) {
-			log.Warn().
-				Str("draftTxID", draftTx.GetDraftTransactionID()).
-				Msgf("%d retry RecordTransaction after error: %v", n, err.Error())
-		}),
-	)
-	return tx, err //nolint:wrapcheck // error wrapped higher in call stack
+// withPending prepends pending transactions which are not in the list already, the previous attempt could record them
+// without its response being received.
+func withPending(transactions []users.Transaction, pending []*outbox.Transaction) []users.Transaction {
+	listed := make(map[string]bool, len(transactions))
+	for _, tx := range transactions {
+		listed[tx.GetTransactionID()] = true
+	}
+
+	result := make([]users.Transaction, 0, len(pending)+len(transactions))
+	for _, tx := range pending {
+		if !listed[tx.ID] {
+			result = append(result, tx)
+		}
+	}
+	return append(result, transactions...)
 }
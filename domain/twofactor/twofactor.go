@@ -0,0 +1,29 @@
+package twofactor
+
+import "time"
+
+// TwoFactor represents TOTP second factor of the user.
+type TwoFactor struct {
+	UserID int
+	// Secret is the TOTP shared secret sealed by the server sealer.
+	Secret  string
+	Enabled bool
+	// TransactionThreshold is a value in satoshis above which transactions require the code, nil if not required.
+	TransactionThreshold *uint64
+	// LastUsedStep is a TOTP time step of the last accepted code, codes from the same or earlier steps are rejected.
+	LastUsedStep int64
+	CreatedAt    time.Time
+}
+
+// Enrollment represents data required to set up authenticator app.
+type Enrollment struct {
+	URI           string   `json:"uri"`
+	Secret        string   `json:"secret"`
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// Status represents two-factor authentication settings of the user.
+type Status struct {
+	Enabled              bool    `json:"enabled"`
+	TransactionThreshold *uint64 `json:"transactionThreshold"`
+}
@@ -0,0 +1,17 @@
+package twofactor
+
+import (
+	"context"
+	"time"
+)
+
+// Repository is an interface which defines methods for two-factor Repository.
+type Repository interface {
+	GetTwoFactor(ctx context.Context, userID int) (*TwoFactor, error)
+	ReplaceTwoFactor(ctx context.Context, twoFactor *TwoFactor, recoveryCodeHashes []string) error
+	EnableTwoFactor(ctx context.Context, userID int) error
+	DeleteTwoFactor(ctx context.Context, userID int) error
+	UpdateLastUsedStep(ctx context.Context, userID int, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userID int, codeHash string, usedAt time.Time) (bool, error)
+	UpdateTransactionThreshold(ctx context.Context, userID int, threshold *uint64) error
+}
@@ -0,0 +1,337 @@
+package twofactor
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+)
+
+const (
+	totpPeriod         = 30
+	totpSkew           = 1
+	recoveryCodesCount = 10
+	// recoveryCodeLength is a number of random bytes of recovery code, it's formatted as two groups of hex characters.
+	recoveryCodeLength = 5
+)
+
+var totpOpts = totp.ValidateOpts{
+	Period:    totpPeriod,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+// Service provides RFC 6238 (TOTP) two-factor authentication of users.
+// TOTP secrets are sealed by the server sealer, so they can't be used to generate codes if the database leaks.
+type Service struct {
+	repo   Repository
+	sealer *encryption.Sealer
+	issuer string
+	log    *zerolog.Logger
+}
+
+// NewTwoFactorService creates a new two-factor service.
+func NewTwoFactorService(repo Repository, sealer *encryption.Sealer, log *zerolog.Logger) *Service {
+	twoFactorServiceLogger := log.With().Str("service", "two-factor-service").Logger()
+	return &Service{
+		repo:   repo,
+		sealer: sealer,
+		issuer: viper.GetString(config.EnvTwoFactorIssuer),
+		log:    &twoFactorServiceLogger,
+	}
+}
+
+// GetStatus returns two-factor authentication settings of the user.
+func (s *Service) GetStatus(userID int) (*Status, error) {
+	tf, err := s.get(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tf == nil || !tf.Enabled {
+		return &Status{}, nil
+	}
+
+	return &Status{Enabled: true, TransactionThreshold: tf.TransactionThreshold}, nil
+}
+
+// Enroll generates a new TOTP secret and recovery codes for the user.
+// Second factor is not required until enrollment is confirmed with the code from authenticator app.
+func (s *Service) Enroll(userID int, accountName string) (*Enrollment, error) {
+	tf, err := s.get(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tf != nil && tf.Enabled {
+		return nil, spverrors.ErrTwoFactorAlreadyEnabled
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      s.issuer,
+		AccountName: accountName,
+		Period:      totpPeriod,
+		Digits:      totpOpts.Digits,
+		Algorithm:   totpOpts.Algorithm,
+	})
+	if err != nil {
+		s.log.Error().Msgf("Error while generating TOTP key: %v", err.Error())
+		return nil, spverrors.ErrTwoFactor
+	}
+
+	recoveryCodes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		s.log.Error().Msgf("Error while generating recovery codes: %v", err.Error())
+		return nil, spverrors.ErrTwoFactor
+	}
+
+	sealedSecret, err := s.sealer.Seal(context.Background(), key.Secret())
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while sealing TOTP secret: %v", err.Error())
+		return nil, spverrors.ErrTwoFactor
+	}
+
+	tf = &TwoFactor{
+		UserID:    userID,
+		Secret:    sealedSecret,
+		CreatedAt: time.Now(),
+	}
+	if err = s.repo.ReplaceTwoFactor(context.Background(), tf, hashes); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while storing two-factor enrollment: %v", err.Error())
+		return nil, spverrors.ErrTwoFactor
+	}
+
+	return &Enrollment{
+		URI:           key.URL(),
+		Secret:        key.Secret(),
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+// Confirm enables second factor of the user if the code from authenticator app is valid.
+func (s *Service) Confirm(userID int, code string) error {
+	tf, err := s.get(userID)
+	if err != nil {
+		return err
+	}
+
+	if tf == nil {
+		return spverrors.ErrTwoFactorNotEnrolled
+	}
+	if tf.Enabled {
+		return spverrors.ErrTwoFactorAlreadyEnabled
+	}
+
+	// Recovery codes cannot be used to confirm enrollment.
+	if err = s.verifyTotp(tf, code); err != nil {
+		return err
+	}
+
+	if err = s.repo.EnableTwoFactor(context.Background(), userID); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while enabling two-factor: %v", err.Error())
+		return spverrors.ErrTwoFactor
+	}
+
+	return nil
+}
+
+// Disable removes second factor of the user if the code is valid.
+func (s *Service) Disable(userID int, code string) error {
+	tf, err := s.getEnabled(userID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.verify(tf, code); err != nil {
+		return err
+	}
+
+	if err = s.repo.DeleteTwoFactor(context.Background(), userID); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while disabling two-factor: %v", err.Error())
+		return spverrors.ErrTwoFactor
+	}
+
+	return nil
+}
+
+// SetTransactionThreshold sets value in satoshis above which transactions require the code, nil disables it.
+func (s *Service) SetTransactionThreshold(userID int, threshold *uint64, code string) error {
+	tf, err := s.getEnabled(userID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.verify(tf, code); err != nil {
+		return err
+	}
+
+	if err = s.repo.UpdateTransactionThreshold(context.Background(), userID, threshold); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while updating transaction threshold: %v", err.Error())
+		return spverrors.ErrTwoFactor
+	}
+
+	return nil
+}
+
+// VerifySignIn checks the code if the user has second factor enabled.
+func (s *Service) VerifySignIn(userID int, code string) error {
+	tf, err := s.get(userID)
+	if err != nil {
+		return err
+	}
+
+	if tf == nil || !tf.Enabled {
+		return nil
+	}
+
+	return s.verify(tf, code)
+}
+
+// VerifyTransaction checks the code if the user has second factor enabled and transaction value is above the threshold.
+func (s *Service) VerifyTransaction(userID int, satoshis uint64, code string) error {
+	tf, err := s.get(userID)
+	if err != nil {
+		return err
+	}
+
+	if tf == nil || !tf.Enabled || tf.TransactionThreshold == nil || satoshis <= *tf.TransactionThreshold {
+		return nil
+	}
+
+	return s.verify(tf, code)
+}
+
+func (s *Service) get(userID int) (*TwoFactor, error) {
+	tf, err := s.repo.GetTwoFactor(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting two-factor: %v", err.Error())
+		return nil, spverrors.ErrTwoFactor
+	}
+	return tf, nil
+}
+
+func (s *Service) getEnabled(userID int) (*TwoFactor, error) {
+	tf, err := s.get(userID)
+	if err != nil {
+		return nil, err
+	}
+	if tf == nil || !tf.Enabled {
+		return nil, spverrors.ErrTwoFactorNotEnrolled
+	}
+	return tf, nil
+}
+
+// verify accepts either TOTP code or one of unused recovery codes.
+func (s *Service) verify(tf *TwoFactor, code string) error {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return spverrors.ErrTwoFactorRequired
+	}
+
+	if len(code) == int(totpOpts.Digits) {
+		return s.verifyTotp(tf, code)
+	}
+
+	used, err := s.repo.UseRecoveryCode(context.Background(), tf.UserID, hashRecoveryCode(code), time.Now())
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(tf.UserID)).
+			Msgf("Error while using recovery code: %v", err.Error())
+		return spverrors.ErrTwoFactor
+	}
+	if !used {
+		return spverrors.ErrInvalidTwoFactorCode
+	}
+
+	s.log.Info().
+		Str("userID", strconv.Itoa(tf.UserID)).
+		Msg("Recovery code used")
+	return nil
+}
+
+// verifyTotp checks the code against current time step and adjacent ones. Accepted step is stored, so the code cannot be replayed.
+func (s *Service) verifyTotp(tf *TwoFactor, code string) error {
+	secret, err := s.sealer.Open(context.Background(), tf.Secret)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(tf.UserID)).
+			Msgf("Error while opening TOTP secret: %v", err.Error())
+		return spverrors.ErrTwoFactor
+	}
+
+	now := time.Now()
+	for i := -totpSkew; i <= totpSkew; i++ {
+		t := now.Add(time.Duration(i*totpPeriod) * time.Second)
+		expected, err := totp.GenerateCodeCustom(secret, t, totpOpts)
+		if err != nil {
+			s.log.Error().
+				Str("userID", strconv.Itoa(tf.UserID)).
+				Msgf("Error while generating TOTP code: %v", err.Error())
+			return spverrors.ErrTwoFactor
+		}
+		if expected != code {
+			continue
+		}
+
+		step := t.Unix() / totpPeriod
+		accepted, err := s.repo.UpdateLastUsedStep(context.Background(), tf.UserID, step)
+		if err != nil {
+			s.log.Error().
+				Str("userID", strconv.Itoa(tf.UserID)).
+				Msgf("Error while storing TOTP step: %v", err.Error())
+			return spverrors.ErrTwoFactor
+		}
+		if !accepted {
+			return spverrors.ErrInvalidTwoFactorCode
+		}
+		return nil
+	}
+
+	return spverrors.ErrInvalidTwoFactorCode
+}
+
+func generateRecoveryCodes() (codes, hashes []string, err error) {
+	codes = make([]string, 0, recoveryCodesCount)
+	hashes = make([]string, 0, recoveryCodesCount)
+	for range recoveryCodesCount {
+		b := make([]byte, recoveryCodeLength)
+		if _, err = rand.Read(b); err != nil {
+			return nil, nil, err //nolint:wrapcheck // error wrapped higher in call stack
+		}
+		h := hex.EncodeToString(b)
+		code := h[:recoveryCodeLength] + "-" + h[recoveryCodeLength:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode normalizes the code, so it can be entered without the dash and in any case.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	hash := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(hash[:])
+}
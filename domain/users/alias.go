@@ -0,0 +1,195 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/pkg/errors"
+)
+
+const (
+	maxAliasLength     = 64
+	aliasSuggestions   = 3
+	maxSuggestionTries = 10
+	// suggestionSuffixMax is the exclusive upper bound of the number appended to suggested aliases.
+	suggestionSuffixMax    = 10000
+	suggestionSuffixLength = 4
+)
+
+// aliasPattern allows lowercase letters, digits, dots, dashes and underscores, starting and ending with a letter or digit.
+var aliasPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9._-]*[a-z0-9])?$`)
+
+// reservedAliases can't be registered by users because they could be mistaken for the operator or system addresses.
+var reservedAliases = []string{
+	"abuse", "admin", "administrator", "api", "billing", "contact", "help", "hostmaster", "info",
+	"mailer-daemon", "no-reply", "noreply", "operator", "paymail", "postmaster", "root", "security",
+	"server", "spv", "spv-wallet", "spvwallet", "support", "system", "wallet", "webmaster",
+}
+
+// NormalizeAlias returns alias in the form in which it's registered - trimmed and lowercase.
+func NormalizeAlias(alias string) string {
+	return strings.ToLower(strings.TrimSpace(alias))
+}
+
+// ValidateAlias checks if the normalized alias follows paymail alias rules and isn't reserved.
+func ValidateAlias(alias string) error {
+	if len(alias) > maxAliasLength || !aliasPattern.MatchString(alias) || strings.Contains(alias, "..") {
+		return spverrors.ErrInvalidAlias
+	}
+
+	if slices.Contains(reservedAliases, alias) {
+		return spverrors.ErrReservedAlias
+	}
+
+	return nil
+}
+
+// CheckAliasAvailability checks if the alias can be registered. If it can't, free variants of it are suggested.
+func (s *UserService) CheckAliasAvailability(alias string) (*AliasAvailability, error) {
+	alias = NormalizeAlias(alias)
+
+	err := ValidateAlias(alias)
+	if err != nil && !errors.Is(err, spverrors.ErrReservedAlias) {
+		return nil, err
+	}
+
+	result := &AliasAvailability{Alias: alias}
+
+	if err == nil {
+		if result.Available, err = s.isAliasAvailable(alias); err != nil {
+			return nil, err
+		}
+	}
+
+	if !result.Available {
+		if result.Suggestions, err = s.suggestAliases(alias); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// chooseAlias returns the alias to register for the new user. If alias wasn't provided, it's derived from the email
+// and replaced with a free variant if it's invalid or already taken.
+func (s *UserService) chooseAlias(alias, email string) (string, error) {
+	if !emptyString(alias) {
+		alias = NormalizeAlias(alias)
+		if err := ValidateAlias(alias); err != nil {
+			return "", err
+		}
+
+		available, err := s.isAliasAvailable(alias)
+		if err != nil {
+			return "", err
+		}
+		if !available {
+			return "", spverrors.ErrAliasTaken
+		}
+		return alias, nil
+	}
+
+	username, _ := splitEmail(email)
+	alias = sanitizeAlias(username)
+
+	if ValidateAlias(alias) == nil {
+		available, err := s.isAliasAvailable(alias)
+		if err != nil {
+			return "", err
+		}
+		if available {
+			return alias, nil
+		}
+	}
+
+	suggestions, err := s.suggestAliases(alias)
+	if err != nil {
+		return "", err
+	}
+	if len(suggestions) == 0 {
+		return "", spverrors.ErrAliasTaken
+	}
+
+	return suggestions[0], nil
+}
+
+// isAliasAvailable checks if the alias isn't chosen by a user waiting for email verification or registered in SPV Wallet.
+func (s *UserService) isAliasAvailable(alias string) (bool, error) {
+	pending, err := s.repo.IsAliasPending(context.Background(), alias)
+	if err != nil {
+		s.log.Error().
+			Str("alias", alias).
+			Msgf("Error while checking pending aliases: %v", err.Error())
+		return false, spverrors.ErrCheckAlias
+	}
+	if pending {
+		return false, nil
+	}
+
+	exists, err := s.adminWalletClient.PaymailExists(alias, s.paymailDomain)
+	if err != nil {
+		s.log.Error().
+			Str("alias", alias).
+			Msgf("Error while checking paymail: %v", err.Error())
+		return false, spverrors.ErrCheckAlias
+	}
+
+	return !exists, nil
+}
+
+// suggestAliases returns up to aliasSuggestions free variants of the alias with a random number appended.
+func (s *UserService) suggestAliases(alias string) ([]string, error) {
+	base := sanitizeAlias(alias)
+	if base == "" {
+		base = "user"
+	}
+	if len(base) > maxAliasLength-suggestionSuffixLength {
+		base = strings.TrimRight(base[:maxAliasLength-suggestionSuffixLength], "._-")
+	}
+
+	suggestions := make([]string, 0, aliasSuggestions)
+	for i := 0; i < maxSuggestionTries && len(suggestions) < aliasSuggestions; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(suggestionSuffixMax))
+		if err != nil {
+			s.log.Error().Msgf("Error while generating alias suggestion: %v", err.Error())
+			return nil, spverrors.ErrCheckAlias
+		}
+
+		candidate := fmt.Sprintf("%s%d", base, n.Int64())
+		if slices.Contains(suggestions, candidate) || ValidateAlias(candidate) != nil {
+			continue
+		}
+
+		available, err := s.isAliasAvailable(candidate)
+		if err != nil {
+			return nil, err
+		}
+		if available {
+			suggestions = append(suggestions, candidate)
+		}
+	}
+
+	return suggestions, nil
+}
+
+// sanitizeAlias lowercases the value and removes characters which aren't allowed in alias.
+func sanitizeAlias(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(value) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '-' || r == '_' {
+			b.WriteRune(r)
+		}
+	}
+
+	alias := strings.Trim(b.String(), "._-")
+	for strings.Contains(alias, "..") {
+		alias = strings.ReplaceAll(alias, "..", ".")
+	}
+	return alias
+}
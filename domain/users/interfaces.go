@@ -54,6 +54,10 @@ type (
 	DraftTransaction interface {
 		GetDraftTransactionHex() string
 		GetDraftTransactionID() string
+		GetDraftTransactionFee() uint64
+		GetDraftTransactionChange() uint64
+		GetDraftTransactionInputs() []DraftInput
+		GetDraftTransactionOutputs() []DraftOutput
 	}
 
 	// UserWalletClient defines methods which are available for a user with access key.
@@ -61,15 +65,23 @@ type (
 		// Access Key methods
 		CreateAccessKey() (AccKey, error)
 		GetAccessKey(accessKeyID string) (AccKey, error)
+		GetAccessKeys() ([]AccKey, error)
 		RevokeAccessKey(accessKeyID string) (AccKey, error)
 		// XPub Key methods
 		GetXPub() (PubKey, error)
+		// Paymail methods
+		GetPaymails() ([]string, error)
 		// Transaction methods
-		SendToRecipients(recipients []*commands.Recipients, senderPaymail string) (Transaction, error)
+		SendToRecipients(recipients []*commands.Recipients, metadata map[string]any) (Transaction, error)
+		SendAllTo(recipient, senderPaymail string) (Transaction, error)
 		GetTransactions(queryParam *filter.QueryParams, userPaymail string) ([]Transaction, error)
 		GetTransaction(transactionID, userPaymail string) (FullTransaction, error)
+		GetTransactionsWithMetadata(metadata map[string]any, userPaymail string) ([]Transaction, error)
 		GetTransactionsCount() (int64, error)
-		CreateAndFinalizeTransaction(recipients []*commands.Recipients, metadata map[string]any) (DraftTransaction, error)
+		// DraftToRecipients drafts the transaction without signing it, its inputs are reserved until expiresIn passes.
+		DraftToRecipients(recipients []*commands.Recipients, metadata map[string]any, expiresIn time.Duration) (DraftTransaction, error)
+		// FinalizeTransaction signs the draft created by DraftToRecipients, it requires the client created with xPriv.
+		FinalizeTransaction(draft DraftTransaction) (DraftTransaction, error)
 		RecordTransaction(hex, draftTxID string, metadata map[string]any) (*models.Transaction, error)
 		// Contacts methods
 		UpsertContact(ctx context.Context, paymail, fullName, requesterPaymail string, metadata map[string]any) (*models.Contact, error)
@@ -84,9 +96,26 @@ type (
 	AdminWalletClient interface {
 		RegisterXpub(xpriv *bip32.ExtendedKey) (string, error)
 		RegisterPaymail(alias, xpub string) (string, error)
+		CreatePaymail(alias, domain, xpub string) (string, error)
+		DeletePaymail(address string) error
+		UpdatePaymailProfile(address, xpub, publicName, avatar string) error
+		PaymailExists(alias, domain string) (bool, error)
+		GetPaymailXPub(address string) (PubKey, error)
+		GetXPubTransactions(xpubID string, queryParam *filter.QueryParams, userPaymail string) ([]Transaction, int64, error)
 		GetSharedConfig() (*models.SharedConfig, error)
 	}
 
+	// SecondFactorVerifier verifies second authentication factor if the user has it enabled.
+	SecondFactorVerifier interface {
+		VerifySignIn(userID int, code string) error
+	}
+
+	// AccessKeysKeeper returns IDs of user access keys which are used by the server in background, e.g. to record sent transactions.
+	// They're not revoked when other sessions of the user are terminated.
+	AccessKeysKeeper interface {
+		GetKeptAccessKeyIDs(userID int) ([]string, error)
+	}
+
 	// WalletClientFactory defines methods to create user and admin clients.
 	WalletClientFactory interface {
 		CreateWithXpriv(xpriv string) (UserWalletClient, error)
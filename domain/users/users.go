@@ -4,13 +4,31 @@ import (
 	"time"
 )
 
+// User statuses.
+const (
+	// StatusPending is a status of the user who didn't verify email yet. User xPub and paymail aren't registered in SPV Wallet.
+	StatusPending = "pending"
+	// StatusActive is a status of the user with verified email.
+	StatusActive = "active"
+)
+
 // User is a struct that contains user data.
 type User struct {
-	ID        int       `json:"id"`
-	Email     string    `json:"email"`
-	Xpriv     string    `json:"-"` // xPriv encrypted with user password
-	Paymail   string    `json:"paymail"`
-	CreatedAt time.Time `json:"created_at"`
+	ID             int        `json:"id"`
+	Email          string     `json:"email"`
+	Xpriv          string     `json:"-"` // xPriv encrypted with user password
+	Xpub           string     `json:"-"` // xPub kept until it's registered in SPV Wallet on email verification
+	Alias          string     `json:"-"` // paymail alias chosen on registration, kept until paymail is registered
+	Paymail        string     `json:"paymail"`
+	Status         string     `json:"status"`
+	CreatedAt      time.Time  `json:"created_at"`
+	XpubRegistered bool       `json:"-"` // set for pending user whose xPub is already registered in SPV Wallet
+	LockedUntil    *time.Time `json:"-"` // set while the account is locked by an operator
+}
+
+// Locked returns true if the account is locked by an operator.
+func (u *User) Locked() bool {
+	return u.LockedUntil != nil && u.LockedUntil.After(time.Now())
 }
 
 // CreatedUser is a struct that contains new user information used to create http response.
@@ -19,6 +37,13 @@ type CreatedUser struct {
 	Mnemonic string
 }
 
+// AliasAvailability is a struct that contains result of paymail alias availability check.
+type AliasAvailability struct {
+	Alias       string
+	Available   bool
+	Suggestions []string
+}
+
 // AuthenticatedUser is a struct that contains authenticated user data.
 type AuthenticatedUser struct {
 	User      *User
@@ -44,3 +69,18 @@ type Balance struct {
 	Bsv      float64 `json:"bsv"`
 	Satoshis uint64  `json:"satoshis"`
 }
+
+// DraftInput is an output of earlier transaction spent by the drafted transaction.
+type DraftInput struct {
+	TransactionID string `json:"transactionId"`
+	OutputIndex   uint32 `json:"outputIndex"`
+	Satoshis      uint64 `json:"satoshis"`
+}
+
+// DraftOutput is an output of the drafted transaction.
+type DraftOutput struct {
+	To       string `json:"to"`
+	Satoshis uint64 `json:"satoshis"`
+	// Change is true for outputs which return the change to the wallet.
+	Change bool `json:"change"`
+}
@@ -2,11 +2,30 @@ package users
 
 import (
 	"context"
+	"time"
 )
 
 // Repository is an interface which defines methods for Repository.
 type Repository interface {
 	InsertUser(ctx context.Context, user *User) error
+	InsertPendingUser(ctx context.Context, user *User, verificationTokenHash string, verificationExpiresAt time.Time) error
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
 	GetUserByID(ctx context.Context, id int) (*User, error)
+	GetPendingUserByVerificationToken(ctx context.Context, verificationTokenHash string, now time.Time) (*User, error)
+	IsAliasPending(ctx context.Context, alias string) (bool, error)
+	UpdateUserXpriv(ctx context.Context, id int, xpriv string) error
+	// ResetUserXpriv replaces encrypted xpriv of the user, removes its wallet passphrase and deletes passkeys of the user,
+	// which keep their own copies of the xpriv, so they must be set up again.
+	ResetUserXpriv(ctx context.Context, id int, xpriv string) error
+	// GetUserPassphraseXpriv returns xpriv encrypted with the wallet passphrase, it's empty if the user has no wallet passphrase.
+	GetUserPassphraseXpriv(ctx context.Context, id int) (string, error)
+	UpdateUserPassphraseXpriv(ctx context.Context, id int, xpriv string) error
+	MarkXpubRegistered(ctx context.Context, id int) error
+	LockUser(ctx context.Context, id int, until time.Time) error
+	UnlockUser(ctx context.Context, id int) error
+	ActivateUser(ctx context.Context, id int, paymail string) error
+	SearchUsers(ctx context.Context, pattern string, limit, offset int) ([]*User, error)
+	CountUsers(ctx context.Context, pattern string) (int, error)
+	DeleteUser(ctx context.Context, id int) error
+	DeleteExpiredPendingUsers(ctx context.Context, now time.Time) (int64, error)
 }
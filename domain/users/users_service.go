@@ -2,39 +2,71 @@ package users
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/mail"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/rates"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/mailer"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
 	"github.com/libsv/go-bk/bip32"
 	"github.com/libsv/go-bk/bip39"
 	"github.com/libsv/go-bk/chaincfg"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
 )
 
+const verificationTokenLength = 32
+
+// likeEscaper escapes wildcards of LIKE patterns, so they're matched literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 // UserService represents User service and provide access to repository.
 type UserService struct {
 	repo                Repository
 	ratesService        *rates.Service
 	adminWalletClient   AdminWalletClient
 	walletClientFactory WalletClientFactory
+	keyCustody          encryption.KeyCustody
+	secondFactor        SecondFactorVerifier
+	accessKeysKeeper    AccessKeysKeeper
+	mailer              mailer.Mailer
+	verificationTTL     time.Duration
+	verificationURL     string
+	reaperInterval      time.Duration
+	paymailDomain       string
 	log                 *zerolog.Logger
 }
 
 // NewUserService creates UserService instance.
-func NewUserService(repo Repository, adminWalletClient AdminWalletClient, walletClientFactory WalletClientFactory, rService *rates.Service, l *zerolog.Logger) *UserService {
+// If keyCustody is nil, encrypted xPrivs are not additionally wrapped with a data key.
+// If secondFactor is nil, sign-in requires only the password.
+// If accessKeysKeeper is nil, all other access keys are revoked when sessions of the user are terminated.
+func NewUserService(repo Repository, adminWalletClient AdminWalletClient, walletClientFactory WalletClientFactory, rService *rates.Service, keyCustody encryption.KeyCustody, secondFactor SecondFactorVerifier, accessKeysKeeper AccessKeysKeeper, m mailer.Mailer, l *zerolog.Logger) *UserService {
 	userServiceLogger := l.With().Str("service", "user-service").Logger()
 	s := &UserService{
 		repo:                repo,
 		adminWalletClient:   adminWalletClient,
 		walletClientFactory: walletClientFactory,
 		ratesService:        rService,
+		keyCustody:          keyCustody,
+		secondFactor:        secondFactor,
+		accessKeysKeeper:    accessKeysKeeper,
+		mailer:              m,
+		verificationTTL:     viper.GetDuration(config.EnvUsersVerificationTTL),
+		verificationURL:     viper.GetString(config.EnvUsersVerificationURL),
+		reaperInterval:      viper.GetDuration(config.EnvUsersVerificationReaperInterval),
+		paymailDomain:       viper.GetString(config.EnvPaymailDomain),
 		log:                 &userServiceLogger,
 	}
 
@@ -50,8 +82,10 @@ func (s *UserService) InsertUser(user *User) error {
 	return nil
 }
 
-// CreateNewUser creates new user.
-func (s *UserService) CreateNewUser(email, password string) (*CreatedUser, error) {
+// CreateNewUser creates new user waiting for email verification and sends the verification email.
+// xPub and paymail are registered in SPV Wallet only after the email is verified with VerifyUser.
+// If alias is empty, it's derived from the email username.
+func (s *UserService) CreateNewUser(email, password, alias string) (*CreatedUser, error) {
 	if emptyString(password) {
 		return nil, spverrors.ErrEmptyPassword
 	}
@@ -60,6 +94,11 @@ func (s *UserService) CreateNewUser(email, password string) (*CreatedUser, error
 		return nil, err
 	}
 
+	alias, err := s.chooseAlias(alias, email)
+	if err != nil {
+		return nil, err
+	}
+
 	mnemonic, seed, err := generateMnemonic()
 	if err != nil {
 		s.log.Error().Msgf("Error while generating mnemonic: %v", err.Error())
@@ -72,49 +111,199 @@ func (s *UserService) CreateNewUser(email, password string) (*CreatedUser, error
 		return nil, spverrors.ErrGenerateXPriv
 	}
 
-	encryptedXpriv, err := encryptXpriv(password, xpriv.String())
+	encryptedXpriv, err := s.encryptXpriv(password, xpriv.String())
 	if err != nil {
 		s.log.Error().Msgf("Error while encrypting xPriv: %v", err.Error())
 		return nil, spverrors.ErrEncryptXPriv
 	}
 
-	xpub, err := s.adminWalletClient.RegisterXpub(xpriv)
+	xpub, err := xpriv.Neuter()
 	if err != nil {
-		s.log.Error().Msgf("Error while registering xPub: %v", err.Error())
-		return nil, spverrors.ErrRegisterXPub
+		s.log.Error().Msgf("Error while generating xPub: %v", err.Error())
+		return nil, spverrors.ErrGenerateXPriv
 	}
 
-	username, _ := splitEmail(email)
-
-	paymail, err := s.adminWalletClient.RegisterPaymail(username, xpub)
+	token, err := generateVerificationToken()
 	if err != nil {
-		s.log.Error().
-			Str("alias", username).
-			Msgf("Error while registering paymail: %v", err.Error())
-		return nil, spverrors.ErrRegisterPaymail
+		s.log.Error().Msgf("Error while generating verification token: %v", err.Error())
+		return nil, spverrors.ErrInsertUser
 	}
 
 	user := &User{
 		Email:     email,
 		Xpriv:     encryptedXpriv,
-		Paymail:   paymail,
+		Xpub:      xpub.String(),
+		Alias:     alias,
+		Status:    StatusPending,
 		CreatedAt: time.Now(),
 	}
 
-	if err = s.InsertUser(user); err != nil {
+	expiresAt := user.CreatedAt.Add(s.verificationTTL)
+	if err = s.repo.InsertPendingUser(context.Background(), user, hashVerificationToken(token), expiresAt); err != nil {
+		s.log.Error().Msgf("Error while inserting user: %v", err.Error())
 		return nil, spverrors.ErrInsertUser
 	}
 
+	if err = s.sendVerificationEmail(email, token, expiresAt); err != nil {
+		s.log.Error().
+			Str("userEmail", email).
+			Msgf("Error while sending verification email: %v", err.Error())
+
+		// Remove the user so that registration can be retried with the same email.
+		if err = s.repo.DeleteUser(context.Background(), user.ID); err != nil {
+			s.log.Error().
+				Str("userEmail", email).
+				Msgf("Error while deleting unverified user: %v", err.Error())
+		}
+		return nil, spverrors.ErrSendVerificationEmail
+	}
+
 	newUSerData := &CreatedUser{
 		User:     user,
 		Mnemonic: mnemonic,
 	}
 
-	return newUSerData, err
+	return newUSerData, nil
 }
 
-// SignInUser signs in user.
-func (s *UserService) SignInUser(email, password string) (*AuthenticatedUser, error) {
+// VerifyUser verifies the email of the user with the token sent in verification email.
+// User xPub and paymail are registered in SPV Wallet and the user becomes active.
+func (s *UserService) VerifyUser(token string) (*User, error) {
+	if emptyString(token) {
+		return nil, spverrors.ErrInvalidVerificationToken
+	}
+
+	user, err := s.repo.GetPendingUserByVerificationToken(context.Background(), hashVerificationToken(token), time.Now())
+	if err != nil {
+		s.log.Error().Msgf("Error while getting user by verification token: %v", err.Error())
+		return nil, spverrors.ErrGetUser
+	}
+
+	if user == nil {
+		return nil, spverrors.ErrInvalidVerificationToken
+	}
+
+	xpubKey, err := bip32.NewKeyFromString(user.Xpub)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(user.ID)).
+			Msgf("Error while parsing xPub: %v", err.Error())
+		return nil, spverrors.ErrRegisterXPub
+	}
+
+	// Registration steps done by an earlier attempt are skipped, so verification can be retried after a failure.
+	xpub := xpubKey.String()
+	if !user.XpubRegistered {
+		if xpub, err = s.adminWalletClient.RegisterXpub(xpubKey); err != nil {
+			s.log.Error().Msgf("Error while registering xPub: %v", err.Error())
+			return nil, spverrors.ErrRegisterXPub
+		}
+
+		if err = s.repo.MarkXpubRegistered(context.Background(), user.ID); err != nil {
+			s.log.Error().
+				Str("userID", strconv.Itoa(user.ID)).
+				Msgf("Error while marking xPub as registered: %v", err.Error())
+			return nil, spverrors.ErrUpdateUser
+		}
+		user.XpubRegistered = true
+	}
+
+	alias := user.Alias
+	if alias == "" {
+		alias, _ = splitEmail(user.Email)
+	}
+
+	paymail, err := s.adminWalletClient.RegisterPaymail(alias, xpub)
+	if err != nil {
+		var registered bool
+		if paymail, registered = s.paymailRegisteredForXpub(alias, xpub); !registered {
+			s.log.Error().
+				Str("alias", alias).
+				Msgf("Error while registering paymail: %v", err.Error())
+			return nil, spverrors.ErrRegisterPaymail
+		}
+	}
+
+	if err = s.repo.ActivateUser(context.Background(), user.ID, paymail); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(user.ID)).
+			Msgf("Error while activating user: %v", err.Error())
+		return nil, spverrors.ErrUpdateUser
+	}
+
+	user.Paymail = paymail
+	user.Status = StatusActive
+
+	return user, nil
+}
+
+// paymailRegisteredForXpub checks if the paymail with the alias is already registered for the xPub,
+// which happens when the user activation failed after the paymail was registered.
+func (s *UserService) paymailRegisteredForXpub(alias, xpub string) (string, bool) {
+	address := fmt.Sprintf("%s@%s", alias, s.paymailDomain)
+
+	paymailXpub, err := s.adminWalletClient.GetPaymailXPub(address)
+	if err != nil {
+		return "", false
+	}
+
+	xpubHash := sha256.Sum256([]byte(xpub))
+	return address, paymailXpub.GetID() == hex.EncodeToString(xpubHash[:])
+}
+
+// StartVerificationReaper periodically removes users who didn't verify email in time, until ctx is cancelled.
+func (s *UserService) StartVerificationReaper(ctx context.Context) {
+	ticker := time.NewTicker(s.reaperInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RemoveExpiredUnverifiedUsers(ctx)
+		}
+	}
+}
+
+// RemoveExpiredUnverifiedUsers removes users whose verification token expired.
+// The email becomes free again. A paymail registered by a verification attempt which failed halfway stays in SPV Wallet,
+// alias availability is checked there, so the alias isn't given to anyone else.
+func (s *UserService) RemoveExpiredUnverifiedUsers(ctx context.Context) {
+	deleted, err := s.repo.DeleteExpiredPendingUsers(ctx, time.Now())
+	if err != nil {
+		s.log.Error().Msgf("Error while removing unverified users: %v", err.Error())
+		return
+	}
+
+	if deleted > 0 {
+		s.log.Debug().Msgf("Removed %d unverified users", deleted)
+	}
+}
+
+// sendVerificationEmail sends email with the link to verify the user email.
+func (s *UserService) sendVerificationEmail(email, token string, expiresAt time.Time) error {
+	link, err := url.Parse(s.verificationURL)
+	if err != nil {
+		return errors.Wrap(err, "invalid verification url")
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	msg := &mailer.Message{
+		To:      email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Open the link below to verify your email address and activate your wallet:\n\n%s\n\n"+
+			"The link expires at %s. If you didn't create the wallet, ignore this email.",
+			link.String(), expiresAt.UTC().Format(time.RFC1123)),
+	}
+
+	return s.mailer.Send(context.Background(), msg) //nolint:wrapcheck // error wrapped higher in call stack
+}
+
+// SignInUser signs in user. The code is required only if the user has second factor enabled.
+func (s *UserService) SignInUser(email, password, code string) (*AuthenticatedUser, error) {
 	user, err := s.repo.GetUserByEmail(context.Background(), email)
 	if err != nil {
 		s.log.Error().
@@ -127,7 +316,7 @@ func (s *UserService) SignInUser(email, password string) (*AuthenticatedUser, er
 		return nil, spverrors.ErrInvalidCredentials
 	}
 
-	decryptedXpriv, err := decryptXpriv(password, user.Xpriv)
+	decryptedXpriv, err := s.decryptXpriv(password, user.Xpriv)
 	if err != nil {
 		s.log.Error().
 			Str("userEmail", email).
@@ -135,7 +324,31 @@ func (s *UserService) SignInUser(email, password string) (*AuthenticatedUser, er
 		return nil, spverrors.ErrInvalidCredentials
 	}
 
-	userWalletClient, err := s.walletClientFactory.CreateWithXpriv(decryptedXpriv)
+	if user.Status == StatusPending {
+		return nil, spverrors.ErrEmailNotVerified
+	}
+
+	if s.secondFactor != nil {
+		if err = s.secondFactor.VerifySignIn(user.ID, code); err != nil {
+			return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+		}
+	}
+
+	if s.requiresEncryptionUpgrade(user.Xpriv) {
+		s.upgradeXprivEncryption(user, password, decryptedXpriv)
+	}
+
+	return s.SignInWithXpriv(user, decryptedXpriv)
+}
+
+// SignInWithXpriv signs in the user whose xPriv was already unlocked, e.g. with a passkey.
+// Account locked by an operator can't be signed in.
+func (s *UserService) SignInWithXpriv(user *User, xpriv string) (*AuthenticatedUser, error) {
+	if user.Locked() {
+		return nil, spverrors.ErrAccountSuspended
+	}
+
+	userWalletClient, err := s.walletClientFactory.CreateWithXpriv(xpriv)
 	if err != nil {
 		return nil, spverrors.ErrInvalidCredentials.Wrap(err)
 	}
@@ -143,7 +356,7 @@ func (s *UserService) SignInUser(email, password string) (*AuthenticatedUser, er
 	accessKey, err := userWalletClient.CreateAccessKey()
 	if err != nil {
 		s.log.Error().
-			Str("userEmail", email).
+			Str("userEmail", user.Email).
 			Msgf("Error while creating access key: %v", err.Error())
 		return nil, spverrors.ErrCreateAccessKey
 	}
@@ -151,7 +364,7 @@ func (s *UserService) SignInUser(email, password string) (*AuthenticatedUser, er
 	xpub, err := userWalletClient.GetXPub()
 	if err != nil {
 		s.log.Error().
-			Str("userEmail", email).
+			Str("userEmail", user.Email).
 			Msgf("Error while getting xPub: %v", err.Error())
 		return nil, spverrors.ErrGetXPub
 	}
@@ -172,12 +385,45 @@ func (s *UserService) SignInUser(email, password string) (*AuthenticatedUser, er
 			Key: accessKey.GetAccessKey(),
 		},
 		Balance: *balance,
-		Xpriv:   decryptedXpriv,
+		Xpriv:   xpriv,
 	}
 
 	return signInUser, nil
 }
 
+// requiresEncryptionUpgrade checks if encrypted xpriv was stored in legacy format
+// or without a data key while key custody is enabled.
+func (s *UserService) requiresEncryptionUpgrade(encryptedXpriv string) bool {
+	if s.keyCustody != nil && !encryption.IsWrapped(encryptedXpriv) {
+		return true
+	}
+	return encryption.IsLegacy(encryptedXpriv)
+}
+
+// upgradeXprivEncryption re-encrypts xpriv stored in outdated format with the current encryption format.
+// Failure is not critical for the caller, so it's only logged.
+func (s *UserService) upgradeXprivEncryption(user *User, password, xpriv string) {
+	encryptedXpriv, err := s.encryptXpriv(password, xpriv)
+	if err != nil {
+		s.log.Warn().
+			Str("userEmail", user.Email).
+			Msgf("Error while re-encrypting xPriv: %v", err.Error())
+		return
+	}
+
+	if err = s.repo.UpdateUserXpriv(context.Background(), user.ID, encryptedXpriv); err != nil {
+		s.log.Warn().
+			Str("userEmail", user.Email).
+			Msgf("Error while storing re-encrypted xPriv: %v", err.Error())
+		return
+	}
+
+	user.Xpriv = encryptedXpriv
+	s.log.Debug().
+		Str("userEmail", user.Email).
+		Msg("xPriv encryption upgraded")
+}
+
 // GetUserByID returns user by id.
 func (s *UserService) GetUserByID(userID int) (*User, error) {
 	user, err := s.repo.GetUserByID(context.Background(), userID)
@@ -188,9 +434,66 @@ func (s *UserService) GetUserByID(userID int) (*User, error) {
 		return nil, spverrors.ErrGetUser
 	}
 
+	if user == nil {
+		return nil, spverrors.ErrUserNotFound
+	}
+
 	return user, nil
 }
 
+// LockUser locks the account of the user until given time. The lock is kept apart from the sign-in lockout
+// caused by failed attempts, so they can't shorten or remove it.
+func (s *UserService) LockUser(userID int, until time.Time) error {
+	if err := s.repo.LockUser(context.Background(), userID, until); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while locking account: %v", err.Error())
+		return spverrors.ErrLockAccount
+	}
+
+	s.log.Info().
+		Str("userID", strconv.Itoa(userID)).
+		Msgf("Account locked until %v", until)
+	return nil
+}
+
+// UnlockUser removes the lock set by LockUser.
+func (s *UserService) UnlockUser(userID int) error {
+	if err := s.repo.UnlockUser(context.Background(), userID); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while unlocking account: %v", err.Error())
+		return spverrors.ErrUnlockAccount
+	}
+
+	s.log.Info().
+		Str("userID", strconv.Itoa(userID)).
+		Msg("Account unlocked")
+	return nil
+}
+
+// SearchUsers returns a page of users whose email or paymail contains the query and total number of matching users.
+func (s *UserService) SearchUsers(query string, limit, offset int) ([]*User, int, error) {
+	pattern := ""
+	if query = strings.TrimSpace(query); query != "" {
+		pattern = "%" + likeEscaper.Replace(query) + "%"
+	}
+
+	result, err := s.repo.SearchUsers(context.Background(), pattern, limit, offset)
+	if err != nil {
+		s.log.Error().Msgf("Error while searching users: %v", err.Error())
+		return nil, 0, spverrors.ErrSearchUsers
+	}
+
+	total, err := s.repo.CountUsers(context.Background(), pattern)
+	if err != nil {
+		s.log.Error().Msgf("Error while counting users: %v", err.Error())
+		return nil, 0, spverrors.ErrSearchUsers
+	}
+
+	return result, total, nil
+}
+
 // GetUserBalance returns user balance using access key.
 func (s *UserService) GetUserBalance(accessKey string) (*Balance, error) {
 	userWalletClient, err := s.walletClientFactory.CreateWithAccessKey(accessKey)
@@ -227,8 +530,12 @@ func (s *UserService) GetUserXpriv(userID int, password string) (string, error)
 		return "", spverrors.ErrGetUser
 	}
 
+	if user == nil {
+		return "", spverrors.ErrUserNotFound
+	}
+
 	// Decrypt xpriv.
-	decryptedXpriv, err := decryptXpriv(password, user.Xpriv)
+	decryptedXpriv, err := s.decryptXpriv(password, user.Xpriv)
 	if err != nil {
 		s.log.Error().
 			Str("userID", strconv.Itoa(userID)).
@@ -239,6 +546,370 @@ func (s *UserService) GetUserXpriv(userID int, password string) (string, error)
 	return decryptedXpriv, nil
 }
 
+// SetWalletPassphrase encrypts user xpriv, unlocked with the password, with the wallet passphrase. The passphrase unlocks
+// the wallet independently of the password, e.g. for users signed in with single sign-on, so it must differ from the password.
+func (s *UserService) SetWalletPassphrase(userID int, password, passphrase string) error {
+	if emptyString(passphrase) {
+		return spverrors.ErrEmptyWalletPassphrase
+	}
+
+	if passphrase == password {
+		return spverrors.ErrWalletPassphraseSameAsPassword
+	}
+
+	xpriv, err := s.GetUserXpriv(userID, password)
+	if err != nil {
+		return err
+	}
+
+	encryptedXpriv, err := s.encryptXpriv(passphrase, xpriv)
+	if err != nil {
+		s.log.Error().Msgf("Error while encrypting xPriv: %v", err.Error())
+		return spverrors.ErrEncryptXPriv
+	}
+
+	if err = s.repo.UpdateUserPassphraseXpriv(context.Background(), userID, encryptedXpriv); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while updating xPriv encrypted with wallet passphrase: %v", err.Error())
+		return spverrors.ErrUpdateUser
+	}
+
+	return nil
+}
+
+// UnlockWallet decrypts user xpriv with the wallet passphrase.
+func (s *UserService) UnlockWallet(userID int, passphrase string) (string, error) {
+	encryptedXpriv, err := s.repo.GetUserPassphraseXpriv(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting xPriv encrypted with wallet passphrase: %v", err.Error())
+		return "", spverrors.ErrGetUser
+	}
+
+	if encryptedXpriv == "" {
+		return "", spverrors.ErrWalletPassphraseNotSet
+	}
+
+	xpriv, err := s.decryptXpriv(passphrase, encryptedXpriv)
+	if err != nil {
+		s.log.Debug().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while decrypting xPriv with wallet passphrase: %v", err.Error())
+		return "", spverrors.ErrInvalidCredentials
+	}
+
+	return xpriv, nil
+}
+
+// UnlockWalletByEmail unlocks the wallet of the user with given email with the wallet passphrase.
+// The wallet passphrase must be set by the signed-in user first. The code is required only if the user has second factor enabled.
+func (s *UserService) UnlockWalletByEmail(email, passphrase, code string) (*User, string, error) {
+	user, err := s.repo.GetUserByEmail(context.Background(), email)
+	if err != nil {
+		s.log.Error().
+			Str("userEmail", email).
+			Msgf("User wasn't found by email: %v", err.Error())
+		return nil, "", spverrors.ErrGetUser
+	}
+
+	if user == nil {
+		return nil, "", spverrors.ErrInvalidCredentials
+	}
+
+	// Missing passphrase isn't reported, so it can't be used to find out which emails have accounts.
+	xpriv, err := s.UnlockWallet(user.ID, passphrase)
+	if errors.Is(err, spverrors.ErrWalletPassphraseNotSet) {
+		return nil, "", spverrors.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if user.Locked() {
+		return nil, "", spverrors.ErrAccountSuspended
+	}
+
+	if s.secondFactor != nil {
+		if err = s.secondFactor.VerifySignIn(user.ID, code); err != nil {
+			return nil, "", err //nolint:wrapcheck // error wrapped higher in call stack
+		}
+	}
+
+	return user, xpriv, nil
+}
+
+// ChangePassword revokes access keys of all user sessions except the one with currentAccessKeyID, then re-encrypts user xpriv
+// with a new password, removes the wallet passphrase and deletes passkeys of the user. If revoking fails, the password isn't changed.
+func (s *UserService) ChangePassword(userID int, currentAccessKeyID, oldPassword, newPassword string) error {
+	if emptyString(newPassword) {
+		return spverrors.ErrEmptyPassword
+	}
+
+	xpriv, err := s.GetUserXpriv(userID, oldPassword)
+	if err != nil {
+		return err
+	}
+
+	encryptedXpriv, err := s.encryptXpriv(newPassword, xpriv)
+	if err != nil {
+		s.log.Error().Msgf("Error while encrypting xPriv: %v", err.Error())
+		return spverrors.ErrEncryptXPriv
+	}
+
+	// Other sessions are terminated first, so the password isn't changed while they stay active.
+	if err = s.revokeOtherAccessKeys(userID, xpriv, currentAccessKeyID); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while terminating other sessions: %v", err.Error())
+		return spverrors.ErrSessionTerminate
+	}
+
+	if err = s.repo.ResetUserXpriv(context.Background(), userID, encryptedXpriv); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while updating xPriv: %v", err.Error())
+		return spverrors.ErrUpdateUser
+	}
+
+	return nil
+}
+
+// RecoverUser restores access to the wallet of the user with given email using the mnemonic returned on registration.
+// The mnemonic must derive the xPub which owns the user paymail in SPV Wallet. If so, xpriv is re-encrypted
+// with the new password, the wallet passphrase is removed, passkeys of the user are deleted and access keys of all existing user sessions
+// and API tokens are revoked. The recovered user is returned, so its sessions can be forgotten.
+func (s *UserService) RecoverUser(email, mnemonic, password string) (*User, error) {
+	if emptyString(password) {
+		return nil, spverrors.ErrEmptyPassword
+	}
+
+	user, err := s.repo.GetUserByEmail(context.Background(), email)
+	if err != nil {
+		s.log.Error().
+			Str("userEmail", email).
+			Msgf("User wasn't found by email: %v", err.Error())
+		return nil, spverrors.ErrGetUser
+	}
+
+	if user == nil {
+		return nil, spverrors.ErrInvalidCredentials
+	}
+
+	if user.Status == StatusPending {
+		return nil, spverrors.ErrEmailNotVerified
+	}
+
+	seed, err := bip39.MnemonicToSeed(strings.Join(strings.Fields(mnemonic), " "), "")
+	if err != nil {
+		s.log.Debug().
+			Str("userEmail", email).
+			Msgf("Error while converting mnemonic to seed: %v", err.Error())
+		return nil, spverrors.ErrInvalidMnemonic
+	}
+
+	xpriv, err := generateXpriv(seed)
+	if err != nil {
+		s.log.Error().Msgf("Error while generating xPriv: %v", err.Error())
+		return nil, spverrors.ErrGenerateXPriv
+	}
+
+	if err = s.checkPaymailOwner(xpriv.String(), user.Paymail); err != nil {
+		s.log.Warn().
+			Str("userEmail", email).
+			Msgf("Recovered xPub doesn't match registered one: %v", err.Error())
+		return nil, spverrors.ErrInvalidCredentials
+	}
+
+	// Recovery doesn't remove the lock set by an operator.
+	if user.Locked() {
+		return nil, spverrors.ErrAccountSuspended
+	}
+
+	encryptedXpriv, err := s.encryptXpriv(password, xpriv.String())
+	if err != nil {
+		s.log.Error().Msgf("Error while encrypting xPriv: %v", err.Error())
+		return nil, spverrors.ErrEncryptXPriv
+	}
+
+	// Sessions are terminated first, so the password isn't changed while they stay active.
+	if err = s.revokeOtherAccessKeys(user.ID, xpriv.String(), ""); err != nil {
+		s.log.Error().
+			Str("userEmail", email).
+			Msgf("Error while terminating user sessions: %v", err.Error())
+		return nil, spverrors.ErrSessionTerminate
+	}
+
+	if err = s.repo.ResetUserXpriv(context.Background(), user.ID, encryptedXpriv); err != nil {
+		s.log.Error().
+			Str("userEmail", email).
+			Msgf("Error while updating xPriv: %v", err.Error())
+		return nil, spverrors.ErrUpdateUser
+	}
+
+	return user, nil
+}
+
+// DeleteUser permanently removes the user. If sweepTo paymail is provided, all funds are sent to it first.
+// Then all access keys are revoked, paymails are removed from SPV Wallet and the user is deleted together with its sessions.
+// The sweep transaction is returned if funds were sent.
+func (s *UserService) DeleteUser(userID int, password, sweepTo string) (Transaction, error) {
+	xpriv, err := s.GetUserXpriv(userID, password)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	userWalletClient, err := s.walletClientFactory.CreateWithXpriv(xpriv)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while creating user wallet client: %v", err.Error())
+		return nil, spverrors.ErrDeleteUser
+	}
+
+	paymails, err := userWalletClient.GetPaymails()
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting paymails: %v", err.Error())
+		return nil, spverrors.ErrDeleteUser
+	}
+
+	var sweepTx Transaction
+	if !emptyString(sweepTo) {
+		if sweepTx, err = s.sweepFunds(userWalletClient, user, paymails, strings.ToLower(strings.TrimSpace(sweepTo))); err != nil {
+			return nil, err
+		}
+	}
+
+	// The user is deleted together with its outbox, so keys kept to record sent transactions are revoked too.
+	if err = s.revokeAccessKeys(xpriv, nil); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while revoking access keys: %v", err.Error())
+		return nil, spverrors.ErrDeleteUser
+	}
+
+	for _, paymail := range paymails {
+		if err = s.adminWalletClient.DeletePaymail(paymail); err != nil {
+			s.log.Error().
+				Str("userID", strconv.Itoa(userID)).
+				Str("paymail", paymail).
+				Msgf("Error while deleting paymail: %v", err.Error())
+			return nil, spverrors.ErrDeleteUser
+		}
+	}
+
+	// Sessions, paymails and other data of the user are removed by the database.
+	if err = s.repo.DeleteUser(context.Background(), userID); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while deleting user: %v", err.Error())
+		return nil, spverrors.ErrDeleteUser
+	}
+
+	s.log.Info().
+		Str("userID", strconv.Itoa(userID)).
+		Msg("User was deleted")
+
+	return sweepTx, nil
+}
+
+// sweepFunds sends all funds of the user to the paymail of another wallet. Nothing is sent if balance is zero.
+func (s *UserService) sweepFunds(userWalletClient UserWalletClient, user *User, paymails []string, sweepTo string) (Transaction, error) {
+	alias, domain, found := strings.Cut(sweepTo, "@")
+	if !found || alias == "" || domain == "" || strings.ContainsAny(sweepTo, " \t\r\n") || slices.Contains(paymails, sweepTo) {
+		return nil, spverrors.ErrInvalidSweepPaymail
+	}
+
+	xpub, err := userWalletClient.GetXPub()
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(user.ID)).
+			Msgf("Error while getting xPub: %v", err.Error())
+		return nil, spverrors.ErrSweepFunds
+	}
+
+	if xpub.GetCurrentBalance() == 0 {
+		return nil, nil
+	}
+
+	transaction, err := userWalletClient.SendAllTo(sweepTo, user.Paymail)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(user.ID)).
+			Msgf("Error while sending remaining funds: %v", err.Error())
+		return nil, spverrors.ErrSweepFunds
+	}
+
+	return transaction, nil
+}
+
+// checkPaymailOwner checks if the xPub derived from xpriv is registered in SPV Wallet and owns the paymail.
+func (s *UserService) checkPaymailOwner(xpriv, paymail string) error {
+	userWalletClient, err := s.walletClientFactory.CreateWithXpriv(xpriv)
+	if err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	paymails, err := userWalletClient.GetPaymails()
+	if err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	if !slices.Contains(paymails, paymail) {
+		return fmt.Errorf("paymail %s is not owned by recovered xPub", paymail)
+	}
+
+	return nil
+}
+
+// revokeOtherAccessKeys revokes all active access keys of the user except the one with keepAccessKeyID
+// and keys kept by the server to record sent transactions. Because every session is authorized with its own access key,
+// this terminates all other sessions.
+func (s *UserService) revokeOtherAccessKeys(userID int, xpriv, keepAccessKeyID string) error {
+	keep := []string{keepAccessKeyID}
+	if s.accessKeysKeeper != nil {
+		kept, err := s.accessKeysKeeper.GetKeptAccessKeyIDs(userID)
+		if err != nil {
+			return err //nolint:wrapcheck // error wrapped higher in call stack
+		}
+		keep = append(keep, kept...)
+	}
+
+	return s.revokeAccessKeys(xpriv, keep)
+}
+
+// revokeAccessKeys revokes all active access keys of the user except the ones with IDs in keep.
+func (s *UserService) revokeAccessKeys(xpriv string, keep []string) error {
+	userWalletClient, err := s.walletClientFactory.CreateWithXpriv(xpriv)
+	if err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	accessKeys, err := userWalletClient.GetAccessKeys()
+	if err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	for _, accessKey := range accessKeys {
+		if slices.Contains(keep, accessKey.GetAccessKeyID()) {
+			continue
+		}
+		if _, err = userWalletClient.RevokeAccessKey(accessKey.GetAccessKeyID()); err != nil {
+			return err //nolint:wrapcheck // error wrapped higher in call stack
+		}
+	}
+
+	return nil
+}
+
 func (s *UserService) validateUser(email string) error {
 	// Validate email
 	if _, err := mail.ParseAddress(email); err != nil {
@@ -271,6 +942,21 @@ func generateMnemonic() (string, []byte, error) {
 	return bip39.Mnemonic(entropy, "") //nolint:wrapcheck // error wrapped higher in call stack
 }
 
+// generateVerificationToken generates random email verification token.
+func generateVerificationToken() (string, error) {
+	b := make([]byte, verificationTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashVerificationToken hashes verification token, only the hash is stored so a database dump can't be used to verify emails.
+func hashVerificationToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
 // generateXpriv generates xpriv from seed.
 func generateXpriv(seed []byte) (*bip32.ExtendedKey, error) {
 	xpriv, err := bip32.NewMaster(seed, &chaincfg.MainNet)
@@ -280,35 +966,48 @@ func generateXpriv(seed []byte) (*bip32.ExtendedKey, error) {
 	return xpriv, nil
 }
 
-// encryptXpriv encrypts xpriv with password.
-func encryptXpriv(password, xpriv string) (string, error) {
-	// Create hash from password
-	hashedPassword, err := encryption.Hash(password)
+// encryptXpriv encrypts xpriv with password and wraps it with a data key if key custody is enabled.
+func (s *UserService) encryptXpriv(password, xpriv string) (string, error) {
+	encryptedXpriv, err := encryption.Encrypt(password, xpriv)
 	if err != nil {
 		return "", err //nolint:wrapcheck // error wrapped higher in call stack
 	}
 
-	// Encrypt xpriv with hashed password
-	encryptedXpriv, err := encryption.Encrypt(hashedPassword, xpriv)
-	if err != nil {
-		return "", err //nolint:wrapcheck // error wrapped higher in call stack
+	if s.keyCustody == nil {
+		return encryptedXpriv, nil
 	}
 
-	return encryptedXpriv, nil
+	return encryption.WrapWithDataKey(context.Background(), s.keyCustody, encryptedXpriv) //nolint:wrapcheck // error wrapped higher in call stack
 }
 
-// decryptXpriv decrypts xpriv with password.
-func decryptXpriv(password, encryptedXpriv string) (string, error) {
-	// Create hash from password
-	hashedPassword, err := encryption.Hash(password)
-	if err != nil {
-		return "", fmt.Errorf("internal error: %w", err)
+// decryptXpriv unwraps xpriv if it was wrapped with a data key and decrypts it with password.
+func (s *UserService) decryptXpriv(password, encryptedXpriv string) (string, error) {
+	if encryption.IsWrapped(encryptedXpriv) {
+		if s.keyCustody == nil {
+			return "", errors.New("xPriv is wrapped with a data key but key custody is disabled")
+		}
+
+		unwrappedXpriv, err := encryption.UnwrapWithDataKey(context.Background(), s.keyCustody, encryptedXpriv)
+		if err != nil {
+			return "", fmt.Errorf("internal error: %w", err)
+		}
+		encryptedXpriv = unwrappedXpriv
 	}
 
-	// Decrypt xpriv with hashed password
-	xpriv := encryption.Decrypt(hashedPassword, encryptedXpriv)
-	if xpriv == "" {
-		return "", spverrors.ErrInvalidCredentials
+	passphrase := password
+
+	// Legacy ciphertexts were encrypted with hashed password
+	if encryption.IsLegacy(encryptedXpriv) {
+		hashedPassword, err := encryption.Hash(password)
+		if err != nil {
+			return "", fmt.Errorf("internal error: %w", err)
+		}
+		passphrase = hashedPassword
+	}
+
+	xpriv, err := encryption.Decrypt(passphrase, encryptedXpriv)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", spverrors.ErrInvalidCredentials, err)
 	}
 
 	return xpriv, nil
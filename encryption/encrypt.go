@@ -6,38 +6,201 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"strings"
 
+	"github.com/pkg/errors"
 	"github.com/xdg-go/pbkdf2"
+	"golang.org/x/crypto/argon2"
 )
 
+// Ciphertext envelope: v2$argon2id$m=<memory>,t=<time>,p=<threads>$<salt>$<iv>$<data>
+const (
+	envelopeVersion   = "v2"
+	envelopeKDF       = "argon2id"
+	envelopeSeparator = "$"
+	envelopeParts     = 6
+	legacySeparator   = "-"
+	legacyParts       = 3
+)
+
+// Argon2id parameters used for new ciphertexts. Parameters of existing ciphertexts are read from the envelope.
+const (
+	argon2Time    = 3
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+	keyLength     = 32
+	saltLength    = 16
+	ivLength      = 12
+)
+
+// Upper limits of argon2id parameters read from ciphertexts and password hashes, so stored values can't make
+// the derivation take unbounded memory or time.
+const (
+	maxArgon2Time    = 10
+	maxArgon2Memory  = 256 * 1024
+	maxArgon2Threads = 16
+)
+
+// maxConcurrentDerivations limits argon2id derivations running at once, each of them takes argon2Memory KiB of memory.
+const maxConcurrentDerivations = 4
+
+var derivations = make(chan struct{}, maxConcurrentDerivations)
+
+// ErrDecrypt is returned when ciphertext cannot be decrypted, e.g. because of wrong passphrase.
+var ErrDecrypt = errors.New("cannot decrypt ciphertext")
+
+// ErrInvalidCiphertext is returned when ciphertext has unknown or malformed format.
+var ErrInvalidCiphertext = errors.New("invalid ciphertext format")
+
+type argon2Params struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+}
+
+func (p argon2Params) String() string {
+	return fmt.Sprintf("m=%d,t=%d,p=%d", p.memory, p.time, p.threads)
+}
+
+func parseArgon2Params(s string) (argon2Params, error) {
+	var p argon2Params
+	if _, err := fmt.Sscanf(s, "m=%d,t=%d,p=%d", &p.memory, &p.time, &p.threads); err != nil {
+		return p, errors.Wrap(ErrInvalidCiphertext, err.Error())
+	}
+	if p.memory == 0 || p.time == 0 || p.threads == 0 {
+		return p, ErrInvalidCiphertext
+	}
+	if p.memory > maxArgon2Memory || p.time > maxArgon2Time || p.threads > maxArgon2Threads {
+		return p, errors.Wrapf(ErrInvalidCiphertext, "argon2id parameters %s exceed the limit", p)
+	}
+	return p, nil
+}
+
+func deriveArgon2Key(passphrase string, salt []byte, p argon2Params) []byte {
+	derivations <- struct{}{}
+	defer func() { <-derivations }()
+
+	return argon2.IDKey([]byte(passphrase), salt, p.time, p.memory, p.threads, keyLength)
+}
+
+// deriveKey derives key for legacy ciphertexts.
 func deriveKey(passphrase string, salt []byte) ([]byte, []byte) {
 	return pbkdf2.Key([]byte(passphrase), salt, 1000, 32, sha256.New), salt
 }
 
-// Encrypt encrypts the plaintext using AES-GCM.
+// Encrypt encrypts the plaintext using AES-GCM with a key derived by argon2id from passphrase and a random salt.
 func Encrypt(passphrase, plaintext string) (string, error) {
-	key, salt := deriveKey(passphrase, nil)
-	iv := make([]byte, 12)
-	_, err := rand.Read(iv)
+	salt, err := randomBytes(saltLength)
+	if err != nil {
+		return "", err
+	}
+	iv, err := randomBytes(ivLength)
 	if err != nil {
-		panic(err)
+		return "", err
+	}
+
+	params := argon2Params{memory: argon2Memory, time: argon2Time, threads: argon2Threads}
+	aesgcm, err := newGCM(deriveArgon2Key(passphrase, salt, params))
+	if err != nil {
+		return "", err
 	}
-	b, _ := aes.NewCipher(key)
-	aesgcm, _ := cipher.NewGCM(b)
 	data := aesgcm.Seal(nil, iv, []byte(plaintext), nil)
-	return hex.EncodeToString(salt) + "-" + hex.EncodeToString(iv) + "-" + hex.EncodeToString(data), nil
+
+	return strings.Join([]string{
+		envelopeVersion,
+		envelopeKDF,
+		params.String(),
+		hex.EncodeToString(salt),
+		hex.EncodeToString(iv),
+		hex.EncodeToString(data),
+	}, envelopeSeparator), nil
 }
 
 // Decrypt decrypts the ciphertext using AES-GCM.
-func Decrypt(passphrase, ciphertext string) string {
-	arr := strings.Split(ciphertext, "-")
-	salt, _ := hex.DecodeString(arr[0])
-	iv, _ := hex.DecodeString(arr[1])
-	data, _ := hex.DecodeString(arr[2])
+// Both versioned envelope and legacy (salt-iv-data) formats are supported.
+func Decrypt(passphrase, ciphertext string) (string, error) {
+	if IsLegacy(ciphertext) {
+		return decryptLegacy(passphrase, ciphertext)
+	}
+
+	arr := strings.Split(ciphertext, envelopeSeparator)
+	if len(arr) != envelopeParts || arr[0] != envelopeVersion || arr[1] != envelopeKDF {
+		return "", ErrInvalidCiphertext
+	}
+
+	params, err := parseArgon2Params(arr[2])
+	if err != nil {
+		return "", err
+	}
+	salt, iv, data, err := decodeParts(arr[3], arr[4], arr[5])
+	if err != nil {
+		return "", err
+	}
+
+	return open(deriveArgon2Key(passphrase, salt, params), iv, data)
+}
+
+// IsLegacy checks if ciphertext was created with the legacy (unversioned) format.
+func IsLegacy(ciphertext string) bool {
+	return !strings.Contains(ciphertext, envelopeSeparator) &&
+		len(strings.Split(ciphertext, legacySeparator)) == legacyParts
+}
+
+func decryptLegacy(passphrase, ciphertext string) (string, error) {
+	arr := strings.Split(ciphertext, legacySeparator)
+	salt, iv, data, err := decodeParts(arr[0], arr[1], arr[2])
+	if err != nil {
+		return "", err
+	}
 	key, _ := deriveKey(passphrase, salt)
-	b, _ := aes.NewCipher(key)
-	aesgcm, _ := cipher.NewGCM(b)
-	data, _ = aesgcm.Open(nil, iv, data, nil)
-	return string(data)
+	return open(key, iv, data)
+}
+
+func open(key, iv, data []byte) (string, error) {
+	aesgcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(iv) != aesgcm.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+	plaintext, err := aesgcm.Open(nil, iv, data, nil)
+	if err != nil {
+		return "", errors.Wrap(ErrDecrypt, err.Error())
+	}
+	return string(plaintext), nil
+}
+
+func decodeParts(saltHex, ivHex, dataHex string) (salt, iv, data []byte, err error) {
+	if salt, err = hex.DecodeString(saltHex); err != nil {
+		return nil, nil, nil, errors.Wrap(ErrInvalidCiphertext, err.Error())
+	}
+	if iv, err = hex.DecodeString(ivHex); err != nil {
+		return nil, nil, nil, errors.Wrap(ErrInvalidCiphertext, err.Error())
+	}
+	if data, err = hex.DecodeString(dataHex); err != nil {
+		return nil, nil, nil, errors.Wrap(ErrInvalidCiphertext, err.Error())
+	}
+	return salt, iv, data, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	b, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	aesgcm, err := cipher.NewGCM(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return aesgcm, nil
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return b, nil
 }
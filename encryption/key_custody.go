@@ -0,0 +1,110 @@
+package encryption
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// Key custody providers.
+const (
+	KeyCustodyNone  = "none"
+	KeyCustodyLocal = "local"
+	KeyCustodyVault = "vault"
+)
+
+// Wrapped ciphertext envelope: kek1$<wrapped data key>$<iv>$<data>
+const (
+	wrappedVersion = "kek1"
+	wrappedParts   = 4
+	dataKeyLength  = 32
+)
+
+// KeyCustody holds a key encryption key (KEK) outside of the database
+// and uses it to wrap and unwrap data encryption keys.
+type KeyCustody interface {
+	// WrapKey encrypts the data key with the KEK.
+	WrapKey(ctx context.Context, dataKey []byte) (string, error)
+	// UnwrapKey decrypts the data key wrapped by WrapKey.
+	UnwrapKey(ctx context.Context, wrappedKey string) ([]byte, error)
+}
+
+// NewKeyCustody creates KeyCustody based on configuration. It returns nil if key custody is disabled.
+func NewKeyCustody() (KeyCustody, error) {
+	provider := viper.GetString(config.EnvKeyCustodyProvider)
+	switch provider {
+	case KeyCustodyNone, "":
+		return nil, nil
+	case KeyCustodyLocal:
+		return NewLocalKeyCustody(viper.GetString(config.EnvKeyCustodyLocalKekPath))
+	case KeyCustodyVault:
+		return NewVaultKeyCustody(
+			viper.GetString(config.EnvKeyCustodyVaultAddress),
+			viper.GetString(config.EnvKeyCustodyVaultToken),
+			viper.GetString(config.EnvKeyCustodyVaultKeyName),
+			nil,
+		), nil
+	default:
+		return nil, fmt.Errorf("unknown key custody provider: %s", provider)
+	}
+}
+
+// WrapWithDataKey encrypts the plaintext with a new random data key and stores the data key wrapped by custody next to it.
+func WrapWithDataKey(ctx context.Context, custody KeyCustody, plaintext string) (string, error) {
+	dataKey, err := randomBytes(dataKeyLength)
+	if err != nil {
+		return "", err
+	}
+	iv, err := randomBytes(ivLength)
+	if err != nil {
+		return "", err
+	}
+
+	wrappedKey, err := custody.WrapKey(ctx, dataKey)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot wrap data key")
+	}
+
+	aesgcm, err := newGCM(dataKey)
+	if err != nil {
+		return "", err
+	}
+	data := aesgcm.Seal(nil, iv, []byte(plaintext), nil)
+
+	return strings.Join([]string{
+		wrappedVersion,
+		hex.EncodeToString([]byte(wrappedKey)),
+		hex.EncodeToString(iv),
+		hex.EncodeToString(data),
+	}, envelopeSeparator), nil
+}
+
+// UnwrapWithDataKey decrypts the ciphertext created by WrapWithDataKey.
+func UnwrapWithDataKey(ctx context.Context, custody KeyCustody, ciphertext string) (string, error) {
+	arr := strings.Split(ciphertext, envelopeSeparator)
+	if len(arr) != wrappedParts || arr[0] != wrappedVersion {
+		return "", ErrInvalidCiphertext
+	}
+
+	wrappedKey, iv, data, err := decodeParts(arr[1], arr[2], arr[3])
+	if err != nil {
+		return "", err
+	}
+
+	dataKey, err := custody.UnwrapKey(ctx, string(wrappedKey))
+	if err != nil {
+		return "", errors.Wrap(err, "cannot unwrap data key")
+	}
+
+	return open(dataKey, iv, data)
+}
+
+// IsWrapped checks if ciphertext was wrapped with a data key by WrapWithDataKey.
+func IsWrapped(ciphertext string) bool {
+	return strings.HasPrefix(ciphertext, wrappedVersion+envelopeSeparator)
+}
@@ -0,0 +1,63 @@
+package encryption
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// LocalKeyCustody is a KeyCustody which keeps the KEK in a local file, outside of the database.
+type LocalKeyCustody struct {
+	kek []byte
+}
+
+// NewLocalKeyCustody creates LocalKeyCustody with a hex encoded 32 bytes KEK read from the file.
+func NewLocalKeyCustody(kekPath string) (*LocalKeyCustody, error) {
+	content, err := os.ReadFile(kekPath) //nolint:gosec // path comes from configuration
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read KEK file")
+	}
+
+	kek, err := hex.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, errors.Wrap(err, "KEK file should contain hex encoded key")
+	}
+	if len(kek) != dataKeyLength {
+		return nil, errors.Errorf("KEK should have %d bytes, got %d", dataKeyLength, len(kek))
+	}
+
+	return &LocalKeyCustody{kek: kek}, nil
+}
+
+// WrapKey encrypts the data key with the KEK using AES-GCM.
+func (l *LocalKeyCustody) WrapKey(_ context.Context, dataKey []byte) (string, error) {
+	iv, err := randomBytes(ivLength)
+	if err != nil {
+		return "", err
+	}
+	aesgcm, err := newGCM(l.kek)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(aesgcm.Seal(iv, iv, dataKey, nil)), nil
+}
+
+// UnwrapKey decrypts the data key wrapped by WrapKey.
+func (l *LocalKeyCustody) UnwrapKey(_ context.Context, wrappedKey string) ([]byte, error) {
+	raw, err := hex.DecodeString(wrappedKey)
+	if err != nil || len(raw) < ivLength {
+		return nil, ErrInvalidCiphertext
+	}
+	aesgcm, err := newGCM(l.kek)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := aesgcm.Open(nil, raw[:ivLength], raw[ivLength:], nil)
+	if err != nil {
+		return nil, errors.Wrap(ErrDecrypt, err.Error())
+	}
+	return dataKey, nil
+}
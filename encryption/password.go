@@ -0,0 +1,54 @@
+package encryption
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Password hash format: argon2id$m=<memory>,t=<time>,p=<threads>$<salt>$<hash>
+const passwordHashParts = 4
+
+// ErrInvalidPasswordHash is returned when stored password hash has unknown or malformed format.
+var ErrInvalidPasswordHash = errors.New("invalid password hash format")
+
+// HashPassword hashes the password using argon2id with a random salt, so it can be stored and verified later.
+func HashPassword(password string) (string, error) {
+	salt, err := randomBytes(saltLength)
+	if err != nil {
+		return "", err
+	}
+
+	params := argon2Params{memory: argon2Memory, time: argon2Time, threads: argon2Threads}
+	return strings.Join([]string{
+		envelopeKDF,
+		params.String(),
+		hex.EncodeToString(salt),
+		hex.EncodeToString(deriveArgon2Key(password, salt, params)),
+	}, envelopeSeparator), nil
+}
+
+// VerifyPassword checks if the password matches the hash created by HashPassword.
+func VerifyPassword(password, passwordHash string) (bool, error) {
+	arr := strings.Split(passwordHash, envelopeSeparator)
+	if len(arr) != passwordHashParts || arr[0] != envelopeKDF {
+		return false, ErrInvalidPasswordHash
+	}
+
+	params, err := parseArgon2Params(arr[1])
+	if err != nil {
+		return false, errors.Wrap(ErrInvalidPasswordHash, err.Error())
+	}
+	salt, err := hex.DecodeString(arr[2])
+	if err != nil {
+		return false, errors.Wrap(ErrInvalidPasswordHash, err.Error())
+	}
+	expected, err := hex.DecodeString(arr[3])
+	if err != nil || len(expected) != keyLength {
+		return false, ErrInvalidPasswordHash
+	}
+
+	return subtle.ConstantTimeCompare(deriveArgon2Key(password, salt, params), expected) == 1, nil
+}
@@ -0,0 +1,113 @@
+package encryption
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/hkdf"
+)
+
+// Sealed ciphertext envelope: s1$<iv>$<data>
+const (
+	sealedVersion = "s1"
+	sealedParts   = 3
+	sealerInfo    = "spv-wallet-web-backend sealer v1"
+)
+
+// Sealer encrypts secrets held by the server, e.g. access keys, with AES-GCM and a key derived once from the sealer secret.
+// Unlike Encrypt, it doesn't derive the key for every ciphertext, so it's cheap enough to open a ciphertext on every request.
+// If key custody is set, sealed ciphertexts are additionally wrapped with a data key.
+type Sealer struct {
+	aead    cipher.AEAD
+	custody KeyCustody
+}
+
+// NewSealer creates Sealer with a key derived by HKDF-SHA256 from the secret. Custody can be nil.
+func NewSealer(secret string, custody KeyCustody) (*Sealer, error) {
+	key := make([]byte, keyLength)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, []byte(secret), nil, []byte(sealerInfo)), key); err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Sealer{
+		aead:    aead,
+		custody: custody,
+	}, nil
+}
+
+// NewServerSealer creates Sealer with the sealer secret from configuration. The secret has no default,
+// so sealed access keys don't depend on a secret shared with other parts of the server.
+func NewServerSealer(custody KeyCustody) (*Sealer, error) {
+	secret := viper.GetString(config.EnvSealerSecret)
+	if secret == "" {
+		return nil, errors.Errorf("sealer secret is not configured, set %s", config.EnvSealerSecret)
+	}
+	return NewSealer(secret, custody)
+}
+
+// Seal encrypts the plaintext and wraps it with a data key if key custody is set.
+func (s *Sealer) Seal(ctx context.Context, plaintext string) (string, error) {
+	iv, err := randomBytes(ivLength)
+	if err != nil {
+		return "", err
+	}
+	data := s.aead.Seal(nil, iv, []byte(plaintext), nil)
+
+	sealed := strings.Join([]string{
+		sealedVersion,
+		hex.EncodeToString(iv),
+		hex.EncodeToString(data),
+	}, envelopeSeparator)
+
+	if s.custody == nil {
+		return sealed, nil
+	}
+	return WrapWithDataKey(ctx, s.custody, sealed)
+}
+
+// Open decrypts the ciphertext created by Seal.
+func (s *Sealer) Open(ctx context.Context, ciphertext string) (string, error) {
+	if IsWrapped(ciphertext) {
+		if s.custody == nil {
+			return "", errors.New("ciphertext is wrapped with a data key but key custody is disabled")
+		}
+		var err error
+		if ciphertext, err = UnwrapWithDataKey(ctx, s.custody, ciphertext); err != nil {
+			return "", err
+		}
+	}
+
+	arr := strings.Split(ciphertext, envelopeSeparator)
+	if len(arr) != sealedParts || arr[0] != sealedVersion {
+		return "", ErrInvalidCiphertext
+	}
+	iv, err := hex.DecodeString(arr[1])
+	if err != nil {
+		return "", errors.Wrap(ErrInvalidCiphertext, err.Error())
+	}
+	data, err := hex.DecodeString(arr[2])
+	if err != nil {
+		return "", errors.Wrap(ErrInvalidCiphertext, err.Error())
+	}
+	if len(iv) != s.aead.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+
+	opened, err := s.aead.Open(nil, iv, data, nil)
+	if err != nil {
+		return "", errors.Wrap(ErrDecrypt, err.Error())
+	}
+	return string(opened), nil
+}
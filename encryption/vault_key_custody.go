@@ -0,0 +1,112 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// VaultKeyCustody is a KeyCustody which delegates wrapping of data keys to the HashiCorp Vault transit secrets engine.
+// The KEK never leaves Vault.
+type VaultKeyCustody struct {
+	address    string
+	token      string
+	keyName    string
+	httpClient *http.Client
+}
+
+type vaultTransitRequest struct {
+	Plaintext  string `json:"plaintext,omitempty"`
+	Ciphertext string `json:"ciphertext,omitempty"`
+}
+
+type vaultTransitResponse struct {
+	Data struct {
+		Plaintext  string `json:"plaintext"`
+		Ciphertext string `json:"ciphertext"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+// NewVaultKeyCustody creates VaultKeyCustody using the transit key with given name.
+// If httpClient is nil, http.DefaultClient is used.
+func NewVaultKeyCustody(address, token, keyName string, httpClient *http.Client) *VaultKeyCustody {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &VaultKeyCustody{
+		address:    strings.TrimSuffix(address, "/"),
+		token:      token,
+		keyName:    keyName,
+		httpClient: httpClient,
+	}
+}
+
+// WrapKey encrypts the data key with the transit key.
+func (v *VaultKeyCustody) WrapKey(ctx context.Context, dataKey []byte) (string, error) {
+	res, err := v.call(ctx, "encrypt", vaultTransitRequest{Plaintext: base64.StdEncoding.EncodeToString(dataKey)})
+	if err != nil {
+		return "", err
+	}
+	if res.Data.Ciphertext == "" {
+		return "", errors.New("vault returned empty ciphertext")
+	}
+	return res.Data.Ciphertext, nil
+}
+
+// UnwrapKey decrypts the data key with the transit key.
+func (v *VaultKeyCustody) UnwrapKey(ctx context.Context, wrappedKey string) ([]byte, error) {
+	res, err := v.call(ctx, "decrypt", vaultTransitRequest{Ciphertext: wrappedKey})
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := base64.StdEncoding.DecodeString(res.Data.Plaintext)
+	if err != nil {
+		return nil, errors.Wrap(err, "vault returned invalid plaintext")
+	}
+	return dataKey, nil
+}
+
+func (v *VaultKeyCustody) call(ctx context.Context, operation string, body vaultTransitRequest) (*vaultTransitResponse, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+
+	url := fmt.Sprintf("%s/v1/transit/%s/%s", v.address, operation, v.keyName)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, errors.Wrap(err, "error during creating vault request")
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error during vault %s", operation)
+	}
+	defer res.Body.Close() //nolint: all
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "error during reading vault response body")
+	}
+
+	var transitRes vaultTransitResponse
+	if err = json.Unmarshal(bodyBytes, &transitRes); err != nil {
+		return nil, errors.Wrap(err, "error during unmarshalling vault response body")
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("vault %s failed with status %d: %s", operation, res.StatusCode, strings.Join(transitRes.Errors, "; "))
+	}
+
+	return &transitRes, nil
+}
@@ -5,7 +5,6 @@ go 1.22.5
 toolchain go1.22.6
 
 require (
-	github.com/avast/retry-go/v4 v4.6.0
 	github.com/bitcoin-sv/spv-wallet-go-client v1.0.0-beta.23
 	github.com/bitcoin-sv/spv-wallet/models v1.0.0-beta.40
 	github.com/brianvoe/gofakeit/v6 v6.28.0
@@ -13,14 +12,17 @@ require (
 	github.com/gin-contrib/sessions v1.0.2
 	github.com/golang/mock v1.6.0
 	github.com/libsv/go-bk v0.1.6
+	github.com/pquerna/otp v1.4.0
 	github.com/rs/zerolog v1.33.0
 	github.com/spf13/viper v1.19.0
 	github.com/stretchr/testify v1.10.0
 	github.com/swaggo/files v1.0.1
 	github.com/swaggo/gin-swagger v1.6.0
 	github.com/swaggo/swag v1.16.4
+	github.com/ugorji/go/codec v1.2.12
 	github.com/xdg-go/pbkdf2 v1.0.0
 	go.elastic.co/ecszerolog v0.2.0
+	golang.org/x/crypto v0.31.0
 )
 
 require (
@@ -69,7 +71,6 @@ require (
 	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
 	github.com/planetscale/vtprotobuf v0.6.0 // indirect
 	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
-	github.com/pquerna/otp v1.4.0 // indirect
 	github.com/prometheus/client_golang v1.20.5 // indirect
 	github.com/prometheus/client_model v0.6.1 // indirect
 	github.com/prometheus/common v0.55.0 // indirect
@@ -83,12 +84,10 @@ require (
 	github.com/shadowspore/fossil-delta v0.0.0-20240102155221-e3a8590b820b // indirect
 	github.com/sourcegraph/conc v0.3.0 // indirect
 	github.com/twitchyliquid64/golang-asm v0.15.1 // indirect
-	github.com/ugorji/go/codec v1.2.12 // indirect
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
 	go.uber.org/atomic v1.11.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	golang.org/x/arch v0.8.0 // indirect
-	golang.org/x/crypto v0.31.0 // indirect
 	golang.org/x/exp v0.0.0-20240719175910-8a7402abbf56 // indirect
 	golang.org/x/net v0.33.0 // indirect
 	golang.org/x/sync v0.10.0 // indirect
@@ -0,0 +1,46 @@
+package mailer
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// FileMailer appends emails to a file instead of sending them. Intended for development and tests.
+type FileMailer struct {
+	path  string
+	from  string
+	mutex sync.Mutex
+}
+
+// NewFileMailer creates file mailer.
+func NewFileMailer(path, from string) *FileMailer {
+	return &FileMailer{
+		path: path,
+		from: from,
+	}
+}
+
+// Send appends the message to the file.
+func (m *FileMailer) Send(_ context.Context, msg *Message) error {
+	body, err := format(m.from, msg)
+	if err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	f, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "cannot open mail file")
+	}
+	defer f.Close() //nolint:all
+
+	if _, err = f.Write(append(body, '\r', '\n')); err != nil {
+		return errors.Wrap(err, "cannot write mail file")
+	}
+	return nil
+}
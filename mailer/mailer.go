@@ -0,0 +1,74 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+)
+
+// Mailer providers.
+const (
+	ProviderSMTP = "smtp"
+	ProviderFile = "file"
+	ProviderLog  = "log"
+)
+
+// Message is a plain text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends emails.
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// NewMailer creates Mailer based on configuration.
+func NewMailer(log *zerolog.Logger) (Mailer, error) {
+	provider := viper.GetString(config.EnvMailerProvider)
+	switch provider {
+	case ProviderSMTP:
+		return NewSMTPMailer(
+			viper.GetString(config.EnvMailerSMTPHost),
+			viper.GetInt(config.EnvMailerSMTPPort),
+			viper.GetString(config.EnvMailerSMTPUsername),
+			viper.GetString(config.EnvMailerSMTPPassword),
+			viper.GetString(config.EnvMailerFrom),
+		), nil
+	case ProviderFile:
+		return NewFileMailer(viper.GetString(config.EnvMailerFilePath), viper.GetString(config.EnvMailerFrom)), nil
+	case ProviderLog:
+		return NewLogMailer(log), nil
+	case "":
+		return nil, fmt.Errorf("mailer provider is not configured, set %s to %s, %s or %s", config.EnvMailerProvider, ProviderSMTP, ProviderFile, ProviderLog)
+	default:
+		return nil, fmt.Errorf("unknown mailer provider: %s", provider)
+	}
+}
+
+// format renders the message with headers, it rejects header values which would inject additional headers.
+func format(from string, msg *Message) ([]byte, error) {
+	for _, v := range []string{from, msg.To, msg.Subject} {
+		if strings.ContainsAny(v, "\r\n") {
+			return nil, fmt.Errorf("invalid header value: %q", v)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + msg.Subject + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(msg.Body)
+	b.WriteString("\r\n")
+
+	return []byte(b.String()), nil
+}
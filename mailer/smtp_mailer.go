@@ -0,0 +1,36 @@
+package mailer
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+	"strconv"
+)
+
+// SMTPMailer sends emails through SMTP server.
+type SMTPMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPMailer creates SMTP mailer. If username is empty, emails are sent without authentication.
+func NewSMTPMailer(host string, port int, username, password, from string) *SMTPMailer {
+	m := &SMTPMailer{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		from: from,
+	}
+	if username != "" {
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return m
+}
+
+// Send sends the message. STARTTLS is used if the server supports it.
+func (m *SMTPMailer) Send(_ context.Context, msg *Message) error {
+	body, err := format(m.from, msg)
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{msg.To}, body) //nolint:wrapcheck // error wrapped higher in call stack
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/outbox"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/spvwallet"
 	"github.com/bitcoin-sv/spv-wallet/models"
 	"github.com/bitcoin-sv/spv-wallet/models/response"
@@ -75,6 +76,27 @@ func PrepareTransactionEvent(tx *models.Transaction) TransactionEvent {
 	}
 }
 
+// PreparePendingTransactionEvent prepares event in NewTransactionEvent struct about the sent transaction which isn't recorded yet,
+// it's retried in background.
+func PreparePendingTransactionEvent(tx *outbox.Transaction) TransactionEvent {
+	return TransactionEvent{
+		BaseEvent: BaseEvent{
+			Status:    "pending",
+			Error:     nil,
+			EventType: "create_transaction",
+		},
+		Transaction: &Transaction{
+			ID:         tx.ID,
+			Receiver:   tx.Receiver,
+			Sender:     tx.Sender,
+			Status:     tx.Status,
+			Direction:  tx.Direction,
+			TotalValue: tx.TotalValue,
+			CreatedAt:  tx.CreatedAt,
+		},
+	}
+}
+
 // PrepareTransactionErrorEvent prepares error event in NewTransactionEvent struct.
 func PrepareTransactionErrorEvent(err error) TransactionEvent {
 	errString := err.Error()
@@ -87,3 +109,40 @@ func PrepareTransactionErrorEvent(err error) TransactionEvent {
 		Transaction: nil,
 	}
 }
+
+// PayoutBatchEvent represents notification about progress of the payout batch.
+type PayoutBatchEvent struct {
+	BaseEvent
+	Batch *PayoutBatch `json:"batch"`
+}
+
+// PayoutBatch represents progress of the payout batch.
+type PayoutBatch struct {
+	ID     int         `json:"id"`
+	Status string      `json:"status"`
+	Total  int         `json:"total"`
+	Sent   int         `json:"sent"`
+	Failed int         `json:"failed"`
+	Rows   []PayoutRow `json:"rows"`
+}
+
+// PayoutRow represents status of the payout batch row changed since the previous event.
+type PayoutRow struct {
+	Row           int    `json:"row"`
+	Paymail       string `json:"paymail"`
+	Status        string `json:"status"`
+	TransactionID string `json:"transactionId,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// PreparePayoutBatchEvent prepares event in PayoutBatchEvent struct.
+func PreparePayoutBatchEvent(batch *PayoutBatch) PayoutBatchEvent {
+	return PayoutBatchEvent{
+		BaseEvent: BaseEvent{
+			Status:    "success",
+			Error:     nil,
+			EventType: "payout_batch",
+		},
+		Batch: batch,
+	}
+}
@@ -0,0 +1,208 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// scopes requested from the provider, email is used to find the wallet account when the identity is linked.
+const scopes = "openid email profile"
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// GenericProvider is a Provider which discovers its configuration from the issuer url,
+// so it works with any OpenID Connect compliant provider.
+type GenericProvider struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	httpClient   *http.Client
+
+	mutex     sync.Mutex
+	discovery *discoveryDocument
+	keys      map[string]*rsa.PublicKey
+}
+
+// NewGenericProvider creates GenericProvider for the client registered at the issuer.
+// If httpClient is nil, http.DefaultClient is used.
+func NewGenericProvider(issuer, clientID, clientSecret, redirectURL string, httpClient *http.Client) *GenericProvider {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &GenericProvider{
+		issuer:       strings.TrimSuffix(issuer, "/"),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		httpClient:   httpClient,
+	}
+}
+
+// AuthCodeURL returns the url of the provider authorization endpoint.
+func (p *GenericProvider) AuthCodeURL(ctx context.Context, state, nonce string) (string, error) {
+	discovery, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid authorization endpoint")
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.clientID)
+	query.Set("redirect_uri", p.redirectURL)
+	query.Set("scope", scopes)
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+// Exchange calls the provider token endpoint and verifies the returned ID token.
+func (p *GenericProvider) Exchange(ctx context.Context, code, nonce string) (*Claims, error) {
+	discovery, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.redirectURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errors.Wrap(err, "error during creating token request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(p.clientID), url.QueryEscape(p.clientSecret))
+
+	var token tokenResponse
+	status, err := p.call(req, &token)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, errors.Errorf("token request failed with status %d: %s %s", status, token.Error, token.ErrorDescription)
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("token response contains no id token")
+	}
+
+	return p.verifyIDToken(ctx, token.IDToken, nonce)
+}
+
+// discover loads the provider configuration once and keeps it for the lifetime of the provider.
+func (p *GenericProvider) discover(ctx context.Context) (*discoveryDocument, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error during creating discovery request")
+	}
+
+	var discovery discoveryDocument
+	status, err := p.call(req, &discovery)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, errors.Errorf("discovery failed with status %d", status)
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != p.issuer {
+		return nil, errors.Errorf("discovered issuer %s doesn't match configured issuer %s", discovery.Issuer, p.issuer)
+	}
+
+	p.discovery = &discovery
+	return p.discovery, nil
+}
+
+// publicKey returns the provider key with given id. Keys are reloaded when the id is unknown, because providers rotate them.
+func (p *GenericProvider) publicKey(ctx context.Context, keyID string) (*rsa.PublicKey, error) {
+	discovery, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if key, ok := p.keys[keyID]; ok {
+		return key, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discovery.JwksURI, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error during creating jwks request")
+	}
+
+	var set jsonWebKeySet
+	status, err := p.call(req, &set)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, errors.Errorf("jwks request failed with status %d", status)
+	}
+
+	keys, err := set.rsaKeys()
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+
+	key, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id: %s", keyID)
+	}
+	return key, nil
+}
+
+func (p *GenericProvider) call(req *http.Request, result any) (int, error) {
+	res, err := p.httpClient.Do(req)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error during oidc request to %s", req.URL.Host)
+	}
+	defer res.Body.Close() //nolint: all
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return 0, errors.Wrap(err, "error during reading oidc response body")
+	}
+
+	if err = json.Unmarshal(bodyBytes, result); err != nil {
+		return 0, errors.Wrap(err, "error during unmarshalling oidc response body")
+	}
+
+	return res.StatusCode, nil
+}
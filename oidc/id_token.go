@@ -0,0 +1,161 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// clockSkew is tolerated difference between the provider clock and ours.
+const clockSkew = time.Minute
+
+type idTokenHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+type idTokenClaims struct {
+	Issuer        string   `json:"iss"`
+	Subject       string   `json:"sub"`
+	Audience      audience `json:"aud"`
+	Expiry        int64    `json:"exp"`
+	IssuedAt      int64    `json:"iat"`
+	Nonce         string   `json:"nonce"`
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+	Name          string   `json:"name"`
+}
+
+// audience is a single client id or a list of them.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return errors.Wrap(err, "invalid audience")
+	}
+	*a = list
+	return nil
+}
+
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// rsaKeys returns signing RSA keys of the set by their ids, other keys are skipped.
+func (s *jsonWebKeySet) rsaKeys() (map[string]*rsa.PublicKey, error) {
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range s.Keys {
+		if k.KeyType != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid modulus of key %s", k.KeyID)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid exponent of key %s", k.KeyID)
+		}
+
+		keys[k.KeyID] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, nil
+}
+
+// verifyIDToken checks signature of the ID token and that it was issued by the provider for this client and this sign-in.
+// Only RS256 is accepted, it's the algorithm which every OpenID Connect provider must support.
+func (p *GenericProvider) verifyIDToken(ctx context.Context, idToken, nonce string) (*Claims, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed id token")
+	}
+
+	var header idTokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header.Algorithm != "RS256" {
+		return nil, errors.Errorf("unsupported id token algorithm: %s", header.Algorithm)
+	}
+
+	key, err := p.publicKey(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid id token signature encoding")
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, errors.Wrap(err, "invalid id token signature")
+	}
+
+	var claims idTokenClaims
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	switch {
+	case strings.TrimSuffix(claims.Issuer, "/") != p.issuer:
+		return nil, errors.Errorf("id token issued by %s", claims.Issuer)
+	case !slices.Contains(claims.Audience, p.clientID):
+		return nil, errors.New("id token issued for another client")
+	case now.After(time.Unix(claims.Expiry, 0).Add(clockSkew)):
+		return nil, errors.New("id token expired")
+	case subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1:
+		return nil, errors.New("id token nonce doesn't match")
+	case claims.Subject == "":
+		return nil, errors.New("id token has no subject")
+	}
+
+	return &Claims{
+		Issuer:        p.issuer,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}, nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errors.Wrap(err, "invalid id token encoding")
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return errors.Wrap(err, "invalid id token")
+	}
+	return nil
+}
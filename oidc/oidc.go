@@ -0,0 +1,58 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/spf13/viper"
+)
+
+// OpenID Connect providers.
+const (
+	ProviderNone    = "none"
+	ProviderGeneric = "oidc"
+	ProviderStub    = "stub"
+)
+
+// Claims are claims of the ID token which identify the signed-in user.
+type Claims struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Provider signs users in with the OpenID Connect authorization code flow.
+type Provider interface {
+	// AuthCodeURL returns the url of the provider sign-in page, the user is redirected back to the callback with the code and the state.
+	AuthCodeURL(ctx context.Context, state, nonce string) (string, error)
+	// Exchange exchanges the code for the ID token and returns its verified claims. Nonce must match the one sent with AuthCodeURL.
+	Exchange(ctx context.Context, code, nonce string) (*Claims, error)
+}
+
+// NewProvider creates Provider based on configuration. It returns nil if single sign-on is disabled.
+func NewProvider() (Provider, error) {
+	provider := viper.GetString(config.EnvOIDCProvider)
+	switch provider {
+	case ProviderNone, "":
+		return nil, nil
+	case ProviderGeneric:
+		return NewGenericProvider(
+			viper.GetString(config.EnvOIDCIssuer),
+			viper.GetString(config.EnvOIDCClientID),
+			viper.GetString(config.EnvOIDCClientSecret),
+			viper.GetString(config.EnvOIDCRedirectURL),
+			nil,
+		), nil
+	case ProviderStub:
+		return NewStubProvider(viper.GetString(config.EnvOIDCRedirectURL), Claims{
+			Subject:       viper.GetString(config.EnvOIDCStubSubject),
+			Email:         viper.GetString(config.EnvOIDCStubEmail),
+			EmailVerified: true,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown oidc provider: %s", provider)
+	}
+}
@@ -0,0 +1,55 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// StubIssuer is an issuer of identities signed in by StubProvider.
+const StubIssuer = "stub"
+
+// stubCode is the only code accepted by StubProvider.
+const stubCode = "stub-code"
+
+// StubProvider is a Provider for development and tests. It skips the provider sign-in page
+// and signs in always the same configured user, so it must never be used in production.
+type StubProvider struct {
+	redirectURL string
+	claims      Claims
+}
+
+// NewStubProvider creates StubProvider which redirects directly to the callback and signs in the user with given claims.
+func NewStubProvider(redirectURL string, claims Claims) *StubProvider {
+	claims.Issuer = StubIssuer
+	return &StubProvider{
+		redirectURL: redirectURL,
+		claims:      claims,
+	}
+}
+
+// AuthCodeURL returns the callback url with the code and the state.
+func (p *StubProvider) AuthCodeURL(_ context.Context, state, _ string) (string, error) {
+	callbackURL, err := url.Parse(p.redirectURL)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid redirect url")
+	}
+
+	query := callbackURL.Query()
+	query.Set("code", stubCode)
+	query.Set("state", state)
+	callbackURL.RawQuery = query.Encode()
+
+	return callbackURL.String(), nil
+}
+
+// Exchange returns claims of the configured user.
+func (p *StubProvider) Exchange(_ context.Context, code, _ string) (*Claims, error) {
+	if code != stubCode {
+		return nil, errors.New("invalid code")
+	}
+
+	claims := p.claims
+	return &claims, nil
+}
@@ -66,6 +66,27 @@ var ErrCreateTransaction = models.SPVError{
 	Code:       "error-transaction-create",
 }
 
+// ErrInvalidRecipients indicates the transaction has no recipients or a recipient without paymail or satoshis
+var ErrInvalidRecipients = models.SPVError{
+	Message:    "Transaction requires at least one recipient, every recipient with paymail and satoshis",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-transaction-recipients-invalid",
+}
+
+// ErrDuplicateRecipient indicates the same recipient is listed more than once in the transaction
+var ErrDuplicateRecipient = models.SPVError{
+	Message:    "Recipient can be listed only once in the transaction",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-transaction-recipient-duplicate",
+}
+
+// ErrInsufficientBalance indicates total value of the transaction exceeds the balance
+var ErrInsufficientBalance = models.SPVError{
+	Message:    "Total amount exceeds the balance",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-transaction-balance-insufficient",
+}
+
 // ErrGetTransaction indicates failure to get a transaction
 var ErrGetTransaction = models.SPVError{
 	Message:    "Cannot get transaction",
@@ -87,6 +108,113 @@ var ErrRecordTransaction = models.SPVError{
 	Code:       "error-transaction-record",
 }
 
+// ErrPreviewTransaction indicates failure to draft the transaction for the preview
+var ErrPreviewTransaction = models.SPVError{
+	Message:    "Cannot preview transaction",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-transaction-preview",
+}
+
+// ErrInvalidTransactionPreview indicates the transaction is sent without a preview token or the token expired
+var ErrInvalidTransactionPreview = models.SPVError{
+	Message:    "Transaction must be previewed again before it's sent",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-transaction-preview-invalid",
+}
+
+// ErrTransactionPreviewMismatch indicates recipients of the sent transaction are different from the previewed ones
+var ErrTransactionPreviewMismatch = models.SPVError{
+	Message:    "Recipients are different from the previewed transaction",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-transaction-preview-mismatch",
+}
+
+// ErrInvalidIdempotencyKey indicates the Idempotency-Key header is too long
+var ErrInvalidIdempotencyKey = models.SPVError{
+	Message:    "Idempotency key must have at most 255 characters",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-idempotency-key-invalid",
+}
+
+// ErrIdempotencyKeyReused indicates the idempotency key was already used with a different request
+var ErrIdempotencyKeyReused = models.SPVError{
+	Message:    "Idempotency key was already used with a different request",
+	StatusCode: http.StatusConflict,
+	Code:       "error-idempotency-key-reused",
+}
+
+// ErrIdempotencyKeyInProgress indicates the request with the idempotency key is still being processed
+var ErrIdempotencyKeyInProgress = models.SPVError{
+	Message:    "Request with this idempotency key is still in progress",
+	StatusCode: http.StatusConflict,
+	Code:       "error-idempotency-key-in-progress",
+}
+
+// ErrIdempotencyKey indicates failure to store or read the idempotency key
+var ErrIdempotencyKey = models.SPVError{
+	Message:    "Cannot process idempotency key",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-idempotency-key",
+}
+
+// ////////////////////////////////// PAYOUT BATCH ERRORS
+
+// ErrInvalidPayoutCSV indicates the uploaded payout batch isn't readable CSV or it has no rows
+var ErrInvalidPayoutCSV = models.SPVError{
+	Message:    "Payout batch must be CSV with rows of paymail,satoshis,memo",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-payout-csv-invalid",
+}
+
+// ErrPayoutBatchTooLarge indicates the uploaded payout batch has more rows than allowed
+var ErrPayoutBatchTooLarge = models.SPVError{
+	Message:    "Payout batch has too many rows",
+	StatusCode: http.StatusRequestEntityTooLarge,
+	Code:       "error-payout-batch-too-large",
+}
+
+// ErrPayoutBatchNotFound indicates the user has no payout batch with the id
+var ErrPayoutBatchNotFound = models.SPVError{
+	Message:    "Payout batch not found",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-payout-batch-not-found",
+}
+
+// ErrPayoutBatchRunning indicates the payout batch is already being executed
+var ErrPayoutBatchRunning = models.SPVError{
+	Message:    "Payout batch is already running",
+	StatusCode: http.StatusConflict,
+	Code:       "error-payout-batch-running",
+}
+
+// ErrPayoutBatchCompleted indicates all rows of the payout batch are already paid
+var ErrPayoutBatchCompleted = models.SPVError{
+	Message:    "Payout batch is already completed",
+	StatusCode: http.StatusConflict,
+	Code:       "error-payout-batch-completed",
+}
+
+// ErrSavePayoutBatch indicates failure to save the payout batch
+var ErrSavePayoutBatch = models.SPVError{
+	Message:    "Cannot save payout batch",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-payout-batch-save",
+}
+
+// ErrGetPayoutBatch indicates failure to get the payout batch
+var ErrGetPayoutBatch = models.SPVError{
+	Message:    "Cannot get payout batch",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-payout-batch-get",
+}
+
+// ErrExecutePayoutBatch indicates failure to start paying the payout batch
+var ErrExecutePayoutBatch = models.SPVError{
+	Message:    "Cannot execute payout batch",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-payout-batch-execute",
+}
+
 // ////////////////////////////////// USER ERRORS
 
 // ErrUnauthorized indicates the user is unauthorized
@@ -124,6 +252,27 @@ var ErrEmptyPassword = models.SPVError{
 	Code:       "error-password-empty",
 }
 
+// ErrEmptyWalletPassphrase indicates the wallet passphrase cannot be empty
+var ErrEmptyWalletPassphrase = models.SPVError{
+	Message:    "Wallet passphrase cannot be empty",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-wallet-passphrase-empty",
+}
+
+// ErrWalletPassphraseSameAsPassword indicates the wallet passphrase is the same as the password of the account
+var ErrWalletPassphraseSameAsPassword = models.SPVError{
+	Message:    "Wallet passphrase must be different from the password",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-wallet-passphrase-same-as-password",
+}
+
+// ErrWalletPassphraseNotSet indicates the user has no wallet passphrase, e.g. it was removed on password change
+var ErrWalletPassphraseNotSet = models.SPVError{
+	Message:    "Wallet passphrase is not set, set it with the password first",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-wallet-passphrase-not-set",
+}
+
 // ErrPasswordMismatch indicates the password and confirmation password do not match
 var ErrPasswordMismatch = models.SPVError{
 	Message:    "Password and confirmation password do not match",
@@ -152,6 +301,34 @@ var ErrRegisterPaymail = models.SPVError{
 	Code:       "error-paymail-register",
 }
 
+// ErrInvalidAlias indicates the paymail alias doesn't follow paymail alias rules
+var ErrInvalidAlias = models.SPVError{
+	Message:    "Invalid paymail alias, use 1-64 lowercase letters, digits, dots, dashes or underscores starting and ending with a letter or digit",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-paymail-alias-invalid",
+}
+
+// ErrReservedAlias indicates the paymail alias is reserved and cannot be registered by users
+var ErrReservedAlias = models.SPVError{
+	Message:    "Paymail alias is reserved",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-paymail-alias-reserved",
+}
+
+// ErrAliasTaken indicates the paymail alias is already used
+var ErrAliasTaken = models.SPVError{
+	Message:    "Paymail alias is already taken",
+	StatusCode: http.StatusConflict,
+	Code:       "error-paymail-alias-taken",
+}
+
+// ErrCheckAlias indicates failure to check if the paymail alias is available
+var ErrCheckAlias = models.SPVError{
+	Message:    "Cannot check paymail alias availability",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-paymail-alias-check",
+}
+
 // ErrGenerateMnemonic indicates failure to generate a mnemonic
 var ErrGenerateMnemonic = models.SPVError{
 	Message:    "Cannot generate mnemonic",
@@ -159,6 +336,13 @@ var ErrGenerateMnemonic = models.SPVError{
 	Code:       "error-mnemonic-generate",
 }
 
+// ErrInvalidMnemonic indicates an incorrect mnemonic was provided
+var ErrInvalidMnemonic = models.SPVError{
+	Message:    "Invalid mnemonic",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-mnemonic-invalid",
+}
+
 // ErrGenerateXPriv indicates failure to generate an xPriv
 var ErrGenerateXPriv = models.SPVError{
 	Message:    "Cannot generate xPriv",
@@ -173,6 +357,13 @@ var ErrEncryptXPriv = models.SPVError{
 	Code:       "error-xpriv-encrypt",
 }
 
+// ErrUpdateUser indicates failure to update user information
+var ErrUpdateUser = models.SPVError{
+	Message:    "Cannot update user",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-user-update",
+}
+
 // ErrGetUser indicates failure to get user information
 var ErrGetUser = models.SPVError{
 	Message:    "Cannot get user",
@@ -201,6 +392,90 @@ var ErrGetBalance = models.SPVError{
 	Code:       "error-balance-get",
 }
 
+// ErrAccountLocked indicates sign-in to the account is temporarily locked because of too many failed attempts
+var ErrAccountLocked = models.SPVError{
+	Message:    "Account temporarily locked, try again later",
+	StatusCode: http.StatusLocked,
+	Code:       "error-account-locked",
+}
+
+// ErrAccountSuspended indicates the account is locked by an operator, it's unlocked only by an operator too
+var ErrAccountSuspended = models.SPVError{
+	Message:    "Account is locked, contact support",
+	StatusCode: http.StatusLocked,
+	Code:       "error-account-suspended",
+}
+
+// ErrTooManySignInAttempts indicates sign-in from the client is temporarily blocked because of too many failed attempts
+var ErrTooManySignInAttempts = models.SPVError{
+	Message:    "Too many failed sign-in attempts, try again later",
+	StatusCode: http.StatusTooManyRequests,
+	Code:       "error-sign-in-too-many-attempts",
+}
+
+// ErrLockAccount indicates failure to lock the account
+var ErrLockAccount = models.SPVError{
+	Message:    "Cannot lock account",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-account-lock",
+}
+
+// ErrUnlockAccount indicates failure to unlock the account
+var ErrUnlockAccount = models.SPVError{
+	Message:    "Cannot unlock account",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-account-unlock",
+}
+
+// ErrEmailNotVerified indicates the user didn't verify the email address yet
+var ErrEmailNotVerified = models.SPVError{
+	Message:    "Email address is not verified",
+	StatusCode: http.StatusForbidden,
+	Code:       "error-user-email-not-verified",
+}
+
+// ErrInvalidVerificationToken indicates the email verification token is unknown or expired
+var ErrInvalidVerificationToken = models.SPVError{
+	Message:    "Invalid or expired verification token",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-user-verification-token-invalid",
+}
+
+// ErrSendVerificationEmail indicates failure to send the email with verification link
+var ErrSendVerificationEmail = models.SPVError{
+	Message:    "Cannot send verification email",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-user-verification-email-send",
+}
+
+// ErrDeleteUser indicates failure to delete the user
+var ErrDeleteUser = models.SPVError{
+	Message:    "Cannot delete user",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-user-delete",
+}
+
+// ErrInvalidSweepPaymail indicates the paymail for remaining funds is invalid or it belongs to the deleted user
+var ErrInvalidSweepPaymail = models.SPVError{
+	Message:    "Remaining funds must be sent to a valid paymail of another wallet",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-user-delete-invalid-sweep-paymail",
+}
+
+// ErrSweepFunds indicates failure to send remaining funds before deleting the user
+var ErrSweepFunds = models.SPVError{
+	Message:    "Cannot send remaining funds, user wasn't deleted",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-user-delete-sweep",
+}
+
+// ErrExportUser indicates failure to export user data
+var ErrExportUser = models.SPVError{
+	Message:    "Cannot export user data",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-user-export",
+}
+
 // ErrSessionUpdate indicates failure to update the session
 var ErrSessionUpdate = models.SPVError{
 	Message:    "Cannot update session",
@@ -215,6 +490,435 @@ var ErrSessionTerminate = models.SPVError{
 	Code:       "error-session-terminate",
 }
 
+// ErrSigningGrantRequired indicates the signing grant is missing or expired and the password must be provided
+var ErrSigningGrantRequired = models.SPVError{
+	Message:    "Signing grant is missing or expired, password is required",
+	StatusCode: http.StatusForbidden,
+	Code:       "error-signing-grant-required",
+}
+
+// ErrCreateSigningGrant indicates failure to create a signing grant
+var ErrCreateSigningGrant = models.SPVError{
+	Message:    "Cannot create signing grant",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-signing-grant-create",
+}
+
+// ErrCreateSession indicates failure to start tracking the session
+var ErrCreateSession = models.SPVError{
+	Message:    "Cannot create session",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-session-create",
+}
+
+// ErrGetSessions indicates failure to get user sessions
+var ErrGetSessions = models.SPVError{
+	Message:    "Cannot get sessions",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-sessions-get",
+}
+
+// ErrSessionNotFound indicates the session doesn't exist or is already terminated
+var ErrSessionNotFound = models.SPVError{
+	Message:    "Session not found",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-session-not-found",
+}
+
+// ////////////////////////////////// PAYMAIL ERRORS
+
+// ErrGetPaymails indicates failure to get user paymails
+var ErrGetPaymails = models.SPVError{
+	Message:    "Cannot get paymails",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-paymails-get",
+}
+
+// ErrAddPaymail indicates failure to add a new paymail
+var ErrAddPaymail = models.SPVError{
+	Message:    "Cannot add paymail",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-paymail-add",
+}
+
+// ErrPaymailDomainNotSupported indicates the paymail domain is not served by SPV Wallet
+var ErrPaymailDomainNotSupported = models.SPVError{
+	Message:    "Paymail domain is not supported",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-paymail-domain-not-supported",
+}
+
+// ErrPaymailNotFound indicates the paymail doesn't exist or doesn't belong to the user
+var ErrPaymailNotFound = models.SPVError{
+	Message:    "Paymail not found",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-paymail-not-found",
+}
+
+// ErrUpdatePaymail indicates failure to update the paymail
+var ErrUpdatePaymail = models.SPVError{
+	Message:    "Cannot update paymail",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-paymail-update",
+}
+
+// ErrRemovePrimaryPaymail indicates the primary paymail cannot be removed
+var ErrRemovePrimaryPaymail = models.SPVError{
+	Message:    "Primary paymail cannot be removed, set another paymail as primary first",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-paymail-remove-primary",
+}
+
+// ErrRemovePaymail indicates failure to remove the paymail
+var ErrRemovePaymail = models.SPVError{
+	Message:    "Cannot remove paymail",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-paymail-remove",
+}
+
+// ////////////////////////////////// PROFILE ERRORS
+
+// ErrGetProfile indicates failure to get user profile
+var ErrGetProfile = models.SPVError{
+	Message:    "Cannot get profile",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-profile-get",
+}
+
+// ErrUpdateProfile indicates failure to update user profile
+var ErrUpdateProfile = models.SPVError{
+	Message:    "Cannot update profile",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-profile-update",
+}
+
+// ErrInvalidPublicName indicates the public name is empty, too long or contains control characters
+var ErrInvalidPublicName = models.SPVError{
+	Message:    "Invalid public name",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-profile-invalid-public-name",
+}
+
+// ErrInvalidAvatar indicates the uploaded avatar is too big or it isn't a supported image
+var ErrInvalidAvatar = models.SPVError{
+	Message:    "Avatar must be a PNG, JPEG, GIF or WebP image within the size limit",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-profile-invalid-avatar",
+}
+
+// ErrAvatarNotFound indicates there is no avatar with the key
+var ErrAvatarNotFound = models.SPVError{
+	Message:    "Avatar not found",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-avatar-not-found",
+}
+
+// ////////////////////////////////// TWO-FACTOR ERRORS
+
+// ErrTwoFactorRequired indicates the second factor code must be provided
+var ErrTwoFactorRequired = models.SPVError{
+	Message:    "Two-factor authentication code is required",
+	StatusCode: http.StatusUnauthorized,
+	Code:       "error-2fa-required",
+}
+
+// ErrInvalidTwoFactorCode indicates the second factor code is invalid or was already used
+var ErrInvalidTwoFactorCode = models.SPVError{
+	Message:    "Invalid two-factor authentication code",
+	StatusCode: http.StatusUnauthorized,
+	Code:       "error-2fa-code-invalid",
+}
+
+// ErrTwoFactorAlreadyEnabled indicates the user has already enabled second factor
+var ErrTwoFactorAlreadyEnabled = models.SPVError{
+	Message:    "Two-factor authentication is already enabled",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-2fa-already-enabled",
+}
+
+// ErrTwoFactorNotEnrolled indicates the user hasn't enrolled or enabled second factor
+var ErrTwoFactorNotEnrolled = models.SPVError{
+	Message:    "Two-factor authentication is not enabled",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-2fa-not-enrolled",
+}
+
+// ErrTwoFactor indicates failure to process two-factor authentication
+var ErrTwoFactor = models.SPVError{
+	Message:    "Cannot process two-factor authentication",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-2fa",
+}
+
+// ////////////////////////////////// ADMIN ERRORS
+
+// ErrForbidden indicates the role of operator or user doesn't allow the action
+var ErrForbidden = models.SPVError{
+	Message:    "Forbidden",
+	StatusCode: http.StatusForbidden,
+	Code:       "error-forbidden",
+}
+
+// ErrGetOperator indicates failure to get operator information
+var ErrGetOperator = models.SPVError{
+	Message:    "Cannot get operator",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-operator-get",
+}
+
+// ErrCreateOperator indicates failure to create a new operator
+var ErrCreateOperator = models.SPVError{
+	Message:    "Cannot create operator",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-operator-create",
+}
+
+// ErrOperatorAlreadyExists indicates operator with the email already exists
+var ErrOperatorAlreadyExists = models.SPVError{
+	Message:    "Operator already exists",
+	StatusCode: http.StatusConflict,
+	Code:       "error-operator-already-exists",
+}
+
+// ErrInvalidOperatorRole indicates the role is not defined
+var ErrInvalidOperatorRole = models.SPVError{
+	Message:    "Invalid operator role",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-operator-role-invalid",
+}
+
+// ErrOperatorSession indicates failure to create or terminate operator session
+var ErrOperatorSession = models.SPVError{
+	Message:    "Cannot process operator session",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-operator-session",
+}
+
+// ErrSearchUsers indicates failure to search users
+var ErrSearchUsers = models.SPVError{
+	Message:    "Cannot search users",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-users-search",
+}
+
+// ErrUserNotFound indicates the user doesn't exist
+var ErrUserNotFound = models.SPVError{
+	Message:    "User not found",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-user-not-found",
+}
+
+// ErrGetAuditLog indicates failure to get the admin audit log or the security log of users
+var ErrGetAuditLog = models.SPVError{
+	Message:    "Cannot get audit log",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-audit-log-get",
+}
+
+// ////////////////////////////////// ROLE ERRORS
+
+// ErrGetRoles indicates failure to get roles or permissions
+var ErrGetRoles = models.SPVError{
+	Message:    "Cannot get roles",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-roles-get",
+}
+
+// ErrUpdateUserRoles indicates failure to assign or remove user role
+var ErrUpdateUserRoles = models.SPVError{
+	Message:    "Cannot update user roles",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-user-roles-update",
+}
+
+// ErrInvalidRole indicates the role is not defined
+var ErrInvalidRole = models.SPVError{
+	Message:    "Invalid role",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-role-invalid",
+}
+
+// ////////////////////////////////// SINGLE SIGN-ON ERRORS
+
+// ErrSSODisabled indicates no OpenID Connect provider is configured
+var ErrSSODisabled = models.SPVError{
+	Message:    "Single sign-on is not enabled",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-sso-disabled",
+}
+
+// ErrSSOAuthentication indicates the sign-in with the OpenID Connect provider failed or wasn't started by this client
+var ErrSSOAuthentication = models.SPVError{
+	Message:    "Single sign-on failed",
+	StatusCode: http.StatusUnauthorized,
+	Code:       "error-sso-authentication",
+}
+
+// ErrIdentityNotLinked indicates the identity signed in with single sign-on isn't linked to any wallet account yet
+var ErrIdentityNotLinked = models.SPVError{
+	Message:    "Identity is not linked to a wallet account, unlock the wallet with its passphrase to link it",
+	StatusCode: http.StatusUnauthorized,
+	Code:       "error-identity-not-linked",
+}
+
+// ErrIdentityUnlockRequired indicates the access key of the linked identity was revoked, e.g. on recovery of the wallet
+var ErrIdentityUnlockRequired = models.SPVError{
+	Message:    "Wallet must be unlocked with its passphrase to sign in with the identity again",
+	StatusCode: http.StatusUnauthorized,
+	Code:       "error-identity-unlock-required",
+}
+
+// ErrNoPendingIdentity indicates there is no identity signed in with single sign-on which waits to be linked
+var ErrNoPendingIdentity = models.SPVError{
+	Message:    "No identity is waiting to be linked, sign in with single sign-on first",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-identity-not-pending",
+}
+
+// ErrIdentityLinkedToAnotherUser indicates the identity is already linked to another wallet account
+var ErrIdentityLinkedToAnotherUser = models.SPVError{
+	Message:    "Identity is already linked to another wallet account",
+	StatusCode: http.StatusConflict,
+	Code:       "error-identity-linked-to-another-user",
+}
+
+// ErrLinkIdentity indicates failure to link or unlink the identity
+var ErrLinkIdentity = models.SPVError{
+	Message:    "Cannot link identity",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-identity-link",
+}
+
+// ErrGetIdentities indicates failure to get identities of the user
+var ErrGetIdentities = models.SPVError{
+	Message:    "Cannot get identities",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-identities-get",
+}
+
+// ErrIdentityNotFound indicates the user has no identity with the id
+var ErrIdentityNotFound = models.SPVError{
+	Message:    "Identity not found",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-identity-not-found",
+}
+
+// ////////////////////////////////// PASSKEY ERRORS
+
+// ErrNoPendingPasskeyCeremony indicates the passkey ceremony wasn't started by this client or it expired
+var ErrNoPendingPasskeyCeremony = models.SPVError{
+	Message:    "No passkey ceremony is in progress, request options first",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-passkey-ceremony-not-pending",
+}
+
+// ErrPasskeyRegistration indicates the registration response of the authenticator is invalid
+var ErrPasskeyRegistration = models.SPVError{
+	Message:    "Passkey registration failed",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-passkey-registration",
+}
+
+// ErrPasskeyPRFNotSupported indicates the authenticator didn't evaluate the PRF extension, so the passkey can't unlock the wallet
+var ErrPasskeyPRFNotSupported = models.SPVError{
+	Message:    "Authenticator doesn't support the PRF extension required to unlock the wallet",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-passkey-prf-not-supported",
+}
+
+// ErrPasskeyAlreadyRegistered indicates the credential is already registered
+var ErrPasskeyAlreadyRegistered = models.SPVError{
+	Message:    "Passkey is already registered",
+	StatusCode: http.StatusConflict,
+	Code:       "error-passkey-already-registered",
+}
+
+// ErrPasskeyAuthentication indicates the assertion of the authenticator is invalid or the passkey isn't registered
+var ErrPasskeyAuthentication = models.SPVError{
+	Message:    "Passkey authentication failed",
+	StatusCode: http.StatusUnauthorized,
+	Code:       "error-passkey-authentication",
+}
+
+// ErrSavePasskey indicates failure to save or delete the passkey
+var ErrSavePasskey = models.SPVError{
+	Message:    "Cannot save passkey",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-passkey-save",
+}
+
+// ErrGetPasskeys indicates failure to get passkeys of the user
+var ErrGetPasskeys = models.SPVError{
+	Message:    "Cannot get passkeys",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-passkeys-get",
+}
+
+// ErrPasskeyNotFound indicates the user has no passkey with the id
+var ErrPasskeyNotFound = models.SPVError{
+	Message:    "Passkey not found",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-passkey-not-found",
+}
+
+// ////////////////////////////////// API TOKEN ERRORS
+
+// ErrInvalidAPIToken indicates the API token is unknown, revoked or expired
+var ErrInvalidAPIToken = models.SPVError{
+	Message:    "Invalid API token",
+	StatusCode: http.StatusUnauthorized,
+	Code:       "error-api-token-invalid",
+}
+
+// ErrInvalidAPITokenScopes indicates no scopes or an unknown scope were requested for the API token
+var ErrInvalidAPITokenScopes = models.SPVError{
+	Message:    "API token requires at least one of scopes: read-only, send, contacts",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-api-token-scopes-invalid",
+}
+
+// ErrInvalidAPITokenExpiration indicates the requested expiration of the API token already passed
+var ErrInvalidAPITokenExpiration = models.SPVError{
+	Message:    "API token expiration must be in the future",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-api-token-expiration-invalid",
+}
+
+// ErrAPITokenNotAllowed indicates the action is available only in the session signed in by the user
+var ErrAPITokenNotAllowed = models.SPVError{
+	Message:    "This action cannot be performed with API token",
+	StatusCode: http.StatusForbidden,
+	Code:       "error-api-token-not-allowed",
+}
+
+// ErrCreateAPIToken indicates failure to create the API token
+var ErrCreateAPIToken = models.SPVError{
+	Message:    "Cannot create API token",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-api-token-create",
+}
+
+// ErrGetAPITokens indicates failure to get API tokens
+var ErrGetAPITokens = models.SPVError{
+	Message:    "Cannot get API tokens",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-api-tokens-get",
+}
+
+// ErrRevokeAPIToken indicates failure to revoke the API token
+var ErrRevokeAPIToken = models.SPVError{
+	Message:    "Cannot revoke API token",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-api-token-revoke",
+}
+
+// ErrAPITokenNotFound indicates the user has no API token with the id
+var ErrAPITokenNotFound = models.SPVError{
+	Message:    "API token not found",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-api-token-not-found",
+}
+
 // ////////////////////////////////// RATE ERRORS
 
 // ErrRateNotFound indicates the requested rate was not found
@@ -10,16 +10,25 @@ import (
 
 // ErrorResponse is searching for error and setting it up in gin context
 func ErrorResponse(c *gin.Context, err error, log *zerolog.Logger) {
+	attachError(c, err)
 	response, statusCode := getError(err, log)
 	c.JSON(statusCode, response)
 }
 
 // AbortWithErrorResponse is searching for error and abort with error set
 func AbortWithErrorResponse(c *gin.Context, err error, log *zerolog.Logger) {
+	attachError(c, err)
 	response, statusCode := getError(err, log)
 	c.AbortWithStatusJSON(statusCode, response)
 }
 
+// attachError adds error to gin context, so middlewares like audit can see why the request failed.
+func attachError(c *gin.Context, err error) {
+	if err != nil {
+		_ = c.Error(err)
+	}
+}
+
 func getError(err error, log *zerolog.Logger) (models.ResponseError, int) {
 	var extendedErr models.ExtendedError
 	if errors.As(err, &extendedErr) {
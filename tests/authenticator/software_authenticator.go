@@ -0,0 +1,206 @@
+// Package authenticator provides a software WebAuthn authenticator for tests.
+package authenticator
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/webauthn"
+	"github.com/ugorji/go/codec"
+)
+
+const (
+	flagUserPresent        = 0x01
+	flagUserVerified       = 0x04
+	flagAttestedCredential = 0x40
+)
+
+// Authenticator is a software authenticator with one ES256 credential, which supports the PRF extension.
+// Its fields can be changed to produce invalid responses.
+type Authenticator struct {
+	Origin       string
+	RPID         string
+	UserVerified bool
+	PRF          bool
+	// SignCount is the signature counter, it's incremented before every assertion.
+	SignCount uint32
+
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	userHandle   string
+	prfKey       []byte
+}
+
+// New creates authenticator which answers ceremonies of the relying party from the origin.
+func New(rpID, origin string) (*Authenticator, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // test helper
+	}
+
+	credentialID := make([]byte, 16)
+	prfKey := make([]byte, 32)
+	if _, err = rand.Read(credentialID); err != nil {
+		return nil, err //nolint:wrapcheck // test helper
+	}
+	if _, err = rand.Read(prfKey); err != nil {
+		return nil, err //nolint:wrapcheck // test helper
+	}
+
+	return &Authenticator{
+		Origin:       origin,
+		RPID:         rpID,
+		UserVerified: true,
+		PRF:          true,
+		key:          key,
+		credentialID: credentialID,
+		prfKey:       prfKey,
+	}, nil
+}
+
+// CredentialID returns id of the credential.
+func (a *Authenticator) CredentialID() []byte {
+	return a.credentialID
+}
+
+// Create creates the credential as navigator.credentials.create does.
+func (a *Authenticator) Create(options *webauthn.CreationOptions) (*webauthn.RegistrationResponse, error) {
+	a.userHandle = options.User.ID
+
+	publicKey, err := a.publicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	authData := a.authenticatorData(flagAttestedCredential)
+	authData = append(authData, make([]byte, 16)...) // aaguid
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.credentialID)))
+	authData = append(authData, a.credentialID...)
+	authData = append(authData, publicKey...)
+
+	attestationObject, err := encode(map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": authData,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	clientDataJSON, err := a.clientData("webauthn.create", options.Challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webauthn.RegistrationResponse{
+		ID:    encodeBase64(a.credentialID),
+		RawID: encodeBase64(a.credentialID),
+		Type:  "public-key",
+		Response: webauthn.AuthenticatorAttestation{
+			ClientDataJSON:    encodeBase64(clientDataJSON),
+			AttestationObject: encodeBase64(attestationObject),
+			Transports:        []string{"internal"},
+		},
+		ClientExtensionResults: a.prfResults(options.Extensions),
+	}, nil
+}
+
+// Get signs the assertion as navigator.credentials.get does.
+func (a *Authenticator) Get(options *webauthn.RequestOptions) (*webauthn.AssertionResponse, error) {
+	a.SignCount++
+	authData := a.authenticatorData(0)
+
+	clientDataJSON, err := a.clientData("webauthn.get", options.Challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(authData, clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		return nil, err //nolint:wrapcheck // test helper
+	}
+
+	return &webauthn.AssertionResponse{
+		ID:    encodeBase64(a.credentialID),
+		RawID: encodeBase64(a.credentialID),
+		Type:  "public-key",
+		Response: webauthn.AuthenticatorAssertion{
+			ClientDataJSON:    encodeBase64(clientDataJSON),
+			AuthenticatorData: encodeBase64(authData),
+			Signature:         encodeBase64(signature),
+			UserHandle:        a.userHandle,
+		},
+		ClientExtensionResults: a.prfResults(options.Extensions),
+	}, nil
+}
+
+func (a *Authenticator) authenticatorData(flags byte) []byte {
+	flags |= flagUserPresent
+	if a.UserVerified {
+		flags |= flagUserVerified
+	}
+
+	rpIDHash := sha256.Sum256([]byte(a.RPID))
+	authData := append(rpIDHash[:], flags)
+	return binary.BigEndian.AppendUint32(authData, a.SignCount)
+}
+
+func (a *Authenticator) publicKey() ([]byte, error) {
+	return encode(map[int]any{
+		1:  2,  // EC2
+		3:  -7, // ES256
+		-1: 1,  // P-256
+		-2: a.key.X.FillBytes(make([]byte, 32)),
+		-3: a.key.Y.FillBytes(make([]byte, 32)),
+	})
+}
+
+func (a *Authenticator) clientData(clientDataType, challenge string) ([]byte, error) {
+	return json.Marshal(map[string]any{ //nolint:wrapcheck // test helper
+		"type":        clientDataType,
+		"challenge":   challenge,
+		"origin":      a.Origin,
+		"crossOrigin": false,
+	})
+}
+
+// prfResults evaluates the PRF extension with HMAC, as authenticators implementing hmac-secret do.
+func (a *Authenticator) prfResults(extensions webauthn.Extensions) webauthn.ClientExtensionResults {
+	if !a.PRF || extensions.PRF == nil || extensions.PRF.Eval == nil {
+		return webauthn.ClientExtensionResults{}
+	}
+
+	salt, err := base64.RawURLEncoding.DecodeString(extensions.PRF.Eval.First)
+	if err != nil {
+		return webauthn.ClientExtensionResults{}
+	}
+
+	mac := hmac.New(sha256.New, a.prfKey)
+	mac.Write(salt)
+	return webauthn.ClientExtensionResults{
+		PRF: &webauthn.PRFOutputs{
+			Enabled: true,
+			Results: &webauthn.PRFValues{First: encodeBase64(mac.Sum(nil))},
+		},
+	}
+}
+
+func encode(v any) ([]byte, error) {
+	var data []byte
+	if err := codec.NewEncoderBytes(&data, &codec.CborHandle{}).Encode(v); err != nil {
+		return nil, err //nolint:wrapcheck // test helper
+	}
+	return data, nil
+}
+
+func encodeBase64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
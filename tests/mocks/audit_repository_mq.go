@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/audit/audit_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+        context "context"
+        reflect "reflect"
+
+        audit "github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
+        gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuditRepository is a mock of Repository interface.
+type MockAuditRepository struct {
+        ctrl     *gomock.Controller
+        recorder *MockAuditRepositoryMockRecorder
+}
+
+// MockAuditRepositoryMockRecorder is the mock recorder for MockAuditRepository.
+type MockAuditRepositoryMockRecorder struct {
+        mock *MockAuditRepository
+}
+
+// NewMockAuditRepository creates a new mock instance.
+func NewMockAuditRepository(ctrl *gomock.Controller) *MockAuditRepository {
+        mock := &MockAuditRepository{ctrl: ctrl}
+        mock.recorder = &MockAuditRepositoryMockRecorder{mock}
+        return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRepository) EXPECT() *MockAuditRepositoryMockRecorder {
+        return m.recorder
+}
+
+// GetEntries mocks base method.
+func (m *MockAuditRepository) GetEntries(ctx context.Context, userID *int, limit, offset int) ([]*audit.Entry, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetEntries", ctx, userID, limit, offset)
+        ret0, _ := ret[0].([]*audit.Entry)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetEntries indicates an expected call of GetEntries.
+func (mr *MockAuditRepositoryMockRecorder) GetEntries(ctx, userID, limit, offset interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntries", reflect.TypeOf((*MockAuditRepository)(nil).GetEntries), ctx, userID, limit, offset)
+}
+
+// GetEntriesAfter mocks base method.
+func (m *MockAuditRepository) GetEntriesAfter(ctx context.Context, afterID int64, limit int) ([]*audit.Entry, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetEntriesAfter", ctx, afterID, limit)
+        ret0, _ := ret[0].([]*audit.Entry)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetEntriesAfter indicates an expected call of GetEntriesAfter.
+func (mr *MockAuditRepositoryMockRecorder) GetEntriesAfter(ctx, afterID, limit interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntriesAfter", reflect.TypeOf((*MockAuditRepository)(nil).GetEntriesAfter), ctx, afterID, limit)
+}
+
+// GetUserEntries mocks base method.
+func (m *MockAuditRepository) GetUserEntries(ctx context.Context, userID, limit, offset int) ([]*audit.Entry, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetUserEntries", ctx, userID, limit, offset)
+        ret0, _ := ret[0].([]*audit.Entry)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetUserEntries indicates an expected call of GetUserEntries.
+func (mr *MockAuditRepositoryMockRecorder) GetUserEntries(ctx, userID, limit, offset interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserEntries", reflect.TypeOf((*MockAuditRepository)(nil).GetUserEntries), ctx, userID, limit, offset)
+}
+
+// InsertEntry mocks base method.
+func (m *MockAuditRepository) InsertEntry(ctx context.Context, entry *audit.Entry, chained bool) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "InsertEntry", ctx, entry, chained)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// InsertEntry indicates an expected call of InsertEntry.
+func (mr *MockAuditRepositoryMockRecorder) InsertEntry(ctx, entry, chained interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertEntry", reflect.TypeOf((*MockAuditRepository)(nil).InsertEntry), ctx, entry, chained)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/idempotency/idempotency_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+        context "context"
+        reflect "reflect"
+        time "time"
+
+        idempotency "github.com/bitcoin-sv/spv-wallet-web-backend/domain/idempotency"
+        gomock "github.com/golang/mock/gomock"
+)
+
+// MockIdempotencyRepository is a mock of Repository interface.
+type MockIdempotencyRepository struct {
+        ctrl     *gomock.Controller
+        recorder *MockIdempotencyRepositoryMockRecorder
+}
+
+// MockIdempotencyRepositoryMockRecorder is the mock recorder for MockIdempotencyRepository.
+type MockIdempotencyRepositoryMockRecorder struct {
+        mock *MockIdempotencyRepository
+}
+
+// NewMockIdempotencyRepository creates a new mock instance.
+func NewMockIdempotencyRepository(ctrl *gomock.Controller) *MockIdempotencyRepository {
+        mock := &MockIdempotencyRepository{ctrl: ctrl}
+        mock.recorder = &MockIdempotencyRepositoryMockRecorder{mock}
+        return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyRepository) EXPECT() *MockIdempotencyRepositoryMockRecorder {
+        return m.recorder
+}
+
+// CompleteKey mocks base method.
+func (m *MockIdempotencyRepository) CompleteKey(ctx context.Context, userID int, key, draftID, transactionID string) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "CompleteKey", ctx, userID, key, draftID, transactionID)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// CompleteKey indicates an expected call of CompleteKey.
+func (mr *MockIdempotencyRepositoryMockRecorder) CompleteKey(ctx, userID, key, draftID, transactionID interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteKey", reflect.TypeOf((*MockIdempotencyRepository)(nil).CompleteKey), ctx, userID, key, draftID, transactionID)
+}
+
+// DeleteExpiredKeys mocks base method.
+func (m *MockIdempotencyRepository) DeleteExpiredKeys(ctx context.Context, now time.Time) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "DeleteExpiredKeys", ctx, now)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// DeleteExpiredKeys indicates an expected call of DeleteExpiredKeys.
+func (mr *MockIdempotencyRepositoryMockRecorder) DeleteExpiredKeys(ctx, now interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredKeys", reflect.TypeOf((*MockIdempotencyRepository)(nil).DeleteExpiredKeys), ctx, now)
+}
+
+// DeleteKey mocks base method.
+func (m *MockIdempotencyRepository) DeleteKey(ctx context.Context, userID int, key string) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "DeleteKey", ctx, userID, key)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// DeleteKey indicates an expected call of DeleteKey.
+func (mr *MockIdempotencyRepositoryMockRecorder) DeleteKey(ctx, userID, key interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKey", reflect.TypeOf((*MockIdempotencyRepository)(nil).DeleteKey), ctx, userID, key)
+}
+
+// GetKey mocks base method.
+func (m *MockIdempotencyRepository) GetKey(ctx context.Context, userID int, key string) (*idempotency.Key, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetKey", ctx, userID, key)
+        ret0, _ := ret[0].(*idempotency.Key)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetKey indicates an expected call of GetKey.
+func (mr *MockIdempotencyRepositoryMockRecorder) GetKey(ctx, userID, key interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKey", reflect.TypeOf((*MockIdempotencyRepository)(nil).GetKey), ctx, userID, key)
+}
+
+// ReserveKey mocks base method.
+func (m *MockIdempotencyRepository) ReserveKey(ctx context.Context, key *idempotency.Key) (bool, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "ReserveKey", ctx, key)
+        ret0, _ := ret[0].(bool)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// ReserveKey indicates an expected call of ReserveKey.
+func (mr *MockIdempotencyRepositoryMockRecorder) ReserveKey(ctx, key interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveKey", reflect.TypeOf((*MockIdempotencyRepository)(nil).ReserveKey), ctx, key)
+}
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/identities/identities_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+        context "context"
+        reflect "reflect"
+        time "time"
+
+        identities "github.com/bitcoin-sv/spv-wallet-web-backend/domain/identities"
+        gomock "github.com/golang/mock/gomock"
+)
+
+// MockIdentitiesRepository is a mock of Repository interface.
+type MockIdentitiesRepository struct {
+        ctrl     *gomock.Controller
+        recorder *MockIdentitiesRepositoryMockRecorder
+}
+
+// MockIdentitiesRepositoryMockRecorder is the mock recorder for MockIdentitiesRepository.
+type MockIdentitiesRepositoryMockRecorder struct {
+        mock *MockIdentitiesRepository
+}
+
+// NewMockIdentitiesRepository creates a new mock instance.
+func NewMockIdentitiesRepository(ctrl *gomock.Controller) *MockIdentitiesRepository {
+        mock := &MockIdentitiesRepository{ctrl: ctrl}
+        mock.recorder = &MockIdentitiesRepositoryMockRecorder{mock}
+        return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentitiesRepository) EXPECT() *MockIdentitiesRepositoryMockRecorder {
+        return m.recorder
+}
+
+// DeleteIdentity mocks base method.
+func (m *MockIdentitiesRepository) DeleteIdentity(ctx context.Context, userID, id int) (*identities.Identity, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "DeleteIdentity", ctx, userID, id)
+        ret0, _ := ret[0].(*identities.Identity)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// DeleteIdentity indicates an expected call of DeleteIdentity.
+func (mr *MockIdentitiesRepositoryMockRecorder) DeleteIdentity(ctx, userID, id interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdentity", reflect.TypeOf((*MockIdentitiesRepository)(nil).DeleteIdentity), ctx, userID, id)
+}
+
+// GetIdentity mocks base method.
+func (m *MockIdentitiesRepository) GetIdentity(ctx context.Context, issuer, subject string) (*identities.Identity, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetIdentity", ctx, issuer, subject)
+        ret0, _ := ret[0].(*identities.Identity)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetIdentity indicates an expected call of GetIdentity.
+func (mr *MockIdentitiesRepositoryMockRecorder) GetIdentity(ctx, issuer, subject interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentity", reflect.TypeOf((*MockIdentitiesRepository)(nil).GetIdentity), ctx, issuer, subject)
+}
+
+// GetUserIdentities mocks base method.
+func (m *MockIdentitiesRepository) GetUserIdentities(ctx context.Context, userID int) ([]*identities.Identity, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetUserIdentities", ctx, userID)
+        ret0, _ := ret[0].([]*identities.Identity)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetUserIdentities indicates an expected call of GetUserIdentities.
+func (mr *MockIdentitiesRepositoryMockRecorder) GetUserIdentities(ctx, userID interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIdentities", reflect.TypeOf((*MockIdentitiesRepository)(nil).GetUserIdentities), ctx, userID)
+}
+
+// InsertIdentity mocks base method.
+func (m *MockIdentitiesRepository) InsertIdentity(ctx context.Context, identity *identities.Identity) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "InsertIdentity", ctx, identity)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// InsertIdentity indicates an expected call of InsertIdentity.
+func (mr *MockIdentitiesRepositoryMockRecorder) InsertIdentity(ctx, identity interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertIdentity", reflect.TypeOf((*MockIdentitiesRepository)(nil).InsertIdentity), ctx, identity)
+}
+
+// TouchIdentity mocks base method.
+func (m *MockIdentitiesRepository) TouchIdentity(ctx context.Context, id int, usedAt time.Time) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "TouchIdentity", ctx, id, usedAt)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// TouchIdentity indicates an expected call of TouchIdentity.
+func (mr *MockIdentitiesRepositoryMockRecorder) TouchIdentity(ctx, id, usedAt interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchIdentity", reflect.TypeOf((*MockIdentitiesRepository)(nil).TouchIdentity), ctx, id, usedAt)
+}
+
+// UpdateIdentityAccessKey mocks base method.
+func (m *MockIdentitiesRepository) UpdateIdentityAccessKey(ctx context.Context, id int, accessKeyID, accessKey string) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "UpdateIdentityAccessKey", ctx, id, accessKeyID, accessKey)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// UpdateIdentityAccessKey indicates an expected call of UpdateIdentityAccessKey.
+func (mr *MockIdentitiesRepositoryMockRecorder) UpdateIdentityAccessKey(ctx, id, accessKeyID, accessKey interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIdentityAccessKey", reflect.TypeOf((*MockIdentitiesRepository)(nil).UpdateIdentityAccessKey), ctx, id, accessKeyID, accessKey)
+}
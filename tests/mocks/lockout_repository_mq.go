@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/lockout/lockout_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+        context "context"
+        reflect "reflect"
+        time "time"
+
+        gomock "github.com/golang/mock/gomock"
+)
+
+// MockLockoutRepository is a mock of Repository interface.
+type MockLockoutRepository struct {
+        ctrl     *gomock.Controller
+        recorder *MockLockoutRepositoryMockRecorder
+}
+
+// MockLockoutRepositoryMockRecorder is the mock recorder for MockLockoutRepository.
+type MockLockoutRepositoryMockRecorder struct {
+        mock *MockLockoutRepository
+}
+
+// NewMockLockoutRepository creates a new mock instance.
+func NewMockLockoutRepository(ctrl *gomock.Controller) *MockLockoutRepository {
+        mock := &MockLockoutRepository{ctrl: ctrl}
+        mock.recorder = &MockLockoutRepositoryMockRecorder{mock}
+        return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLockoutRepository) EXPECT() *MockLockoutRepositoryMockRecorder {
+        return m.recorder
+}
+
+// GetLockedUntil mocks base method.
+func (m *MockLockoutRepository) GetLockedUntil(ctx context.Context, key string) (time.Time, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetLockedUntil", ctx, key)
+        ret0, _ := ret[0].(time.Time)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetLockedUntil indicates an expected call of GetLockedUntil.
+func (mr *MockLockoutRepositoryMockRecorder) GetLockedUntil(ctx, key interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLockedUntil", reflect.TypeOf((*MockLockoutRepository)(nil).GetLockedUntil), ctx, key)
+}
+
+// Lock mocks base method.
+func (m *MockLockoutRepository) Lock(ctx context.Context, key string, until time.Time) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "Lock", ctx, key, until)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockLockoutRepositoryMockRecorder) Lock(ctx, key, until interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockLockoutRepository)(nil).Lock), ctx, key, until)
+}
+
+// RecordFailure mocks base method.
+func (m *MockLockoutRepository) RecordFailure(ctx context.Context, key string, now, resetBefore time.Time) (int, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "RecordFailure", ctx, key, now, resetBefore)
+        ret0, _ := ret[0].(int)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockLockoutRepositoryMockRecorder) RecordFailure(ctx, key, now, resetBefore interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockLockoutRepository)(nil).RecordFailure), ctx, key, now, resetBefore)
+}
+
+// Reset mocks base method.
+func (m *MockLockoutRepository) Reset(ctx context.Context, key string) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "Reset", ctx, key)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockLockoutRepositoryMockRecorder) Reset(ctx, key interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockLockoutRepository)(nil).Reset), ctx, key)
+}
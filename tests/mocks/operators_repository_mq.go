@@ -0,0 +1,167 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/operators/operators_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+        context "context"
+        reflect "reflect"
+        time "time"
+
+        operators "github.com/bitcoin-sv/spv-wallet-web-backend/domain/operators"
+        gomock "github.com/golang/mock/gomock"
+)
+
+// MockOperatorsRepository is a mock of Repository interface.
+type MockOperatorsRepository struct {
+        ctrl     *gomock.Controller
+        recorder *MockOperatorsRepositoryMockRecorder
+}
+
+// MockOperatorsRepositoryMockRecorder is the mock recorder for MockOperatorsRepository.
+type MockOperatorsRepositoryMockRecorder struct {
+        mock *MockOperatorsRepository
+}
+
+// NewMockOperatorsRepository creates a new mock instance.
+func NewMockOperatorsRepository(ctrl *gomock.Controller) *MockOperatorsRepository {
+        mock := &MockOperatorsRepository{ctrl: ctrl}
+        mock.recorder = &MockOperatorsRepositoryMockRecorder{mock}
+        return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperatorsRepository) EXPECT() *MockOperatorsRepositoryMockRecorder {
+        return m.recorder
+}
+
+// DeleteExpiredSessions mocks base method.
+func (m *MockOperatorsRepository) DeleteExpiredSessions(ctx context.Context, now time.Time) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "DeleteExpiredSessions", ctx, now)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// DeleteExpiredSessions indicates an expected call of DeleteExpiredSessions.
+func (mr *MockOperatorsRepositoryMockRecorder) DeleteExpiredSessions(ctx, now interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredSessions", reflect.TypeOf((*MockOperatorsRepository)(nil).DeleteExpiredSessions), ctx, now)
+}
+
+// DeleteSession mocks base method.
+func (m *MockOperatorsRepository) DeleteSession(ctx context.Context, tokenHash string) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "DeleteSession", ctx, tokenHash)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockOperatorsRepositoryMockRecorder) DeleteSession(ctx, tokenHash interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockOperatorsRepository)(nil).DeleteSession), ctx, tokenHash)
+}
+
+// GetAuditEntries mocks base method.
+func (m *MockOperatorsRepository) GetAuditEntries(ctx context.Context, targetUserID *int, limit, offset int) ([]*operators.AuditEntry, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetAuditEntries", ctx, targetUserID, limit, offset)
+        ret0, _ := ret[0].([]*operators.AuditEntry)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetAuditEntries indicates an expected call of GetAuditEntries.
+func (mr *MockOperatorsRepositoryMockRecorder) GetAuditEntries(ctx, targetUserID, limit, offset interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEntries", reflect.TypeOf((*MockOperatorsRepository)(nil).GetAuditEntries), ctx, targetUserID, limit, offset)
+}
+
+// GetOperatorByEmail mocks base method.
+func (m *MockOperatorsRepository) GetOperatorByEmail(ctx context.Context, email string) (*operators.Operator, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetOperatorByEmail", ctx, email)
+        ret0, _ := ret[0].(*operators.Operator)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetOperatorByEmail indicates an expected call of GetOperatorByEmail.
+func (mr *MockOperatorsRepositoryMockRecorder) GetOperatorByEmail(ctx, email interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperatorByEmail", reflect.TypeOf((*MockOperatorsRepository)(nil).GetOperatorByEmail), ctx, email)
+}
+
+// GetOperatorBySession mocks base method.
+func (m *MockOperatorsRepository) GetOperatorBySession(ctx context.Context, tokenHash string, now time.Time) (*operators.Operator, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetOperatorBySession", ctx, tokenHash, now)
+        ret0, _ := ret[0].(*operators.Operator)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetOperatorBySession indicates an expected call of GetOperatorBySession.
+func (mr *MockOperatorsRepositoryMockRecorder) GetOperatorBySession(ctx, tokenHash, now interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperatorBySession", reflect.TypeOf((*MockOperatorsRepository)(nil).GetOperatorBySession), ctx, tokenHash, now)
+}
+
+// GetRoles mocks base method.
+func (m *MockOperatorsRepository) GetRoles(ctx context.Context) ([]*operators.Role, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetRoles", ctx)
+        ret0, _ := ret[0].([]*operators.Role)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetRoles indicates an expected call of GetRoles.
+func (mr *MockOperatorsRepositoryMockRecorder) GetRoles(ctx interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoles", reflect.TypeOf((*MockOperatorsRepository)(nil).GetRoles), ctx)
+}
+
+// InsertAuditEntry mocks base method.
+func (m *MockOperatorsRepository) InsertAuditEntry(ctx context.Context, entry *operators.AuditEntry) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "InsertAuditEntry", ctx, entry)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// InsertAuditEntry indicates an expected call of InsertAuditEntry.
+func (mr *MockOperatorsRepositoryMockRecorder) InsertAuditEntry(ctx, entry interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAuditEntry", reflect.TypeOf((*MockOperatorsRepository)(nil).InsertAuditEntry), ctx, entry)
+}
+
+// InsertOperator mocks base method.
+func (m *MockOperatorsRepository) InsertOperator(ctx context.Context, operator *operators.Operator) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "InsertOperator", ctx, operator)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// InsertOperator indicates an expected call of InsertOperator.
+func (mr *MockOperatorsRepositoryMockRecorder) InsertOperator(ctx, operator interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOperator", reflect.TypeOf((*MockOperatorsRepository)(nil).InsertOperator), ctx, operator)
+}
+
+// InsertSession mocks base method.
+func (m *MockOperatorsRepository) InsertSession(ctx context.Context, tokenHash string, operatorID int, ip string, createdAt, expiresAt time.Time) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "InsertSession", ctx, tokenHash, operatorID, ip, createdAt, expiresAt)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// InsertSession indicates an expected call of InsertSession.
+func (mr *MockOperatorsRepositoryMockRecorder) InsertSession(ctx, tokenHash, operatorID, ip, createdAt, expiresAt interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSession", reflect.TypeOf((*MockOperatorsRepository)(nil).InsertSession), ctx, tokenHash, operatorID, ip, createdAt, expiresAt)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/outbox/outbox_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+        context "context"
+        reflect "reflect"
+        time "time"
+
+        outbox "github.com/bitcoin-sv/spv-wallet-web-backend/domain/outbox"
+        gomock "github.com/golang/mock/gomock"
+)
+
+// MockOutboxRepository is a mock of Repository interface.
+type MockOutboxRepository struct {
+        ctrl     *gomock.Controller
+        recorder *MockOutboxRepositoryMockRecorder
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+        mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+        mock := &MockOutboxRepository{ctrl: ctrl}
+        mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+        return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+        return m.recorder
+}
+
+// ClaimDueEntries mocks base method.
+func (m *MockOutboxRepository) ClaimDueEntries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*outbox.Entry, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "ClaimDueEntries", ctx, now, lease, limit)
+        ret0, _ := ret[0].([]*outbox.Entry)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// ClaimDueEntries indicates an expected call of ClaimDueEntries.
+func (mr *MockOutboxRepositoryMockRecorder) ClaimDueEntries(ctx, now, lease, limit interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueEntries", reflect.TypeOf((*MockOutboxRepository)(nil).ClaimDueEntries), ctx, now, lease, limit)
+}
+
+// GetUserEntries mocks base method.
+func (m *MockOutboxRepository) GetUserEntries(ctx context.Context, userID int, status string) ([]*outbox.Entry, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetUserEntries", ctx, userID, status)
+        ret0, _ := ret[0].([]*outbox.Entry)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetUserEntries indicates an expected call of GetUserEntries.
+func (mr *MockOutboxRepositoryMockRecorder) GetUserEntries(ctx, userID, status interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserEntries", reflect.TypeOf((*MockOutboxRepository)(nil).GetUserEntries), ctx, userID, status)
+}
+
+// GetUserEntry mocks base method.
+func (m *MockOutboxRepository) GetUserEntry(ctx context.Context, userID int, transactionID string) (*outbox.Entry, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetUserEntry", ctx, userID, transactionID)
+        ret0, _ := ret[0].(*outbox.Entry)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetUserEntry indicates an expected call of GetUserEntry.
+func (mr *MockOutboxRepositoryMockRecorder) GetUserEntry(ctx, userID, transactionID interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserEntry", reflect.TypeOf((*MockOutboxRepository)(nil).GetUserEntry), ctx, userID, transactionID)
+}
+
+// InsertEntry mocks base method.
+func (m *MockOutboxRepository) InsertEntry(ctx context.Context, entry *outbox.Entry) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "InsertEntry", ctx, entry)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// InsertEntry indicates an expected call of InsertEntry.
+func (mr *MockOutboxRepositoryMockRecorder) InsertEntry(ctx, entry interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertEntry", reflect.TypeOf((*MockOutboxRepository)(nil).InsertEntry), ctx, entry)
+}
+
+// UpdateEntry mocks base method.
+func (m *MockOutboxRepository) UpdateEntry(ctx context.Context, entry *outbox.Entry) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "UpdateEntry", ctx, entry)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// UpdateEntry indicates an expected call of UpdateEntry.
+func (mr *MockOutboxRepositoryMockRecorder) UpdateEntry(ctx, entry interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEntry", reflect.TypeOf((*MockOutboxRepository)(nil).UpdateEntry), ctx, entry)
+}
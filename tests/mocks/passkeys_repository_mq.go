@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/passkeys/passkeys_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+        context "context"
+        reflect "reflect"
+        time "time"
+
+        passkeys "github.com/bitcoin-sv/spv-wallet-web-backend/domain/passkeys"
+        gomock "github.com/golang/mock/gomock"
+)
+
+// MockPasskeysRepository is a mock of Repository interface.
+type MockPasskeysRepository struct {
+        ctrl     *gomock.Controller
+        recorder *MockPasskeysRepositoryMockRecorder
+}
+
+// MockPasskeysRepositoryMockRecorder is the mock recorder for MockPasskeysRepository.
+type MockPasskeysRepositoryMockRecorder struct {
+        mock *MockPasskeysRepository
+}
+
+// NewMockPasskeysRepository creates a new mock instance.
+func NewMockPasskeysRepository(ctrl *gomock.Controller) *MockPasskeysRepository {
+        mock := &MockPasskeysRepository{ctrl: ctrl}
+        mock.recorder = &MockPasskeysRepositoryMockRecorder{mock}
+        return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasskeysRepository) EXPECT() *MockPasskeysRepositoryMockRecorder {
+        return m.recorder
+}
+
+// DeletePasskey mocks base method.
+func (m *MockPasskeysRepository) DeletePasskey(ctx context.Context, userID, id int) (*passkeys.Passkey, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "DeletePasskey", ctx, userID, id)
+        ret0, _ := ret[0].(*passkeys.Passkey)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// DeletePasskey indicates an expected call of DeletePasskey.
+func (mr *MockPasskeysRepositoryMockRecorder) DeletePasskey(ctx, userID, id interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePasskey", reflect.TypeOf((*MockPasskeysRepository)(nil).DeletePasskey), ctx, userID, id)
+}
+
+// GetPasskeyByCredentialID mocks base method.
+func (m *MockPasskeysRepository) GetPasskeyByCredentialID(ctx context.Context, credentialID []byte) (*passkeys.Passkey, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetPasskeyByCredentialID", ctx, credentialID)
+        ret0, _ := ret[0].(*passkeys.Passkey)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetPasskeyByCredentialID indicates an expected call of GetPasskeyByCredentialID.
+func (mr *MockPasskeysRepositoryMockRecorder) GetPasskeyByCredentialID(ctx, credentialID interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasskeyByCredentialID", reflect.TypeOf((*MockPasskeysRepository)(nil).GetPasskeyByCredentialID), ctx, credentialID)
+}
+
+// GetUserPasskeys mocks base method.
+func (m *MockPasskeysRepository) GetUserPasskeys(ctx context.Context, userID int) ([]*passkeys.Passkey, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetUserPasskeys", ctx, userID)
+        ret0, _ := ret[0].([]*passkeys.Passkey)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetUserPasskeys indicates an expected call of GetUserPasskeys.
+func (mr *MockPasskeysRepositoryMockRecorder) GetUserPasskeys(ctx, userID interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPasskeys", reflect.TypeOf((*MockPasskeysRepository)(nil).GetUserPasskeys), ctx, userID)
+}
+
+// InsertPasskey mocks base method.
+func (m *MockPasskeysRepository) InsertPasskey(ctx context.Context, passkey *passkeys.Passkey) (bool, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "InsertPasskey", ctx, passkey)
+        ret0, _ := ret[0].(bool)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// InsertPasskey indicates an expected call of InsertPasskey.
+func (mr *MockPasskeysRepositoryMockRecorder) InsertPasskey(ctx, passkey interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPasskey", reflect.TypeOf((*MockPasskeysRepository)(nil).InsertPasskey), ctx, passkey)
+}
+
+// UpdatePasskeyUse mocks base method.
+func (m *MockPasskeysRepository) UpdatePasskeyUse(ctx context.Context, id int, signCount uint32, usedAt time.Time) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "UpdatePasskeyUse", ctx, id, signCount, usedAt)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// UpdatePasskeyUse indicates an expected call of UpdatePasskeyUse.
+func (mr *MockPasskeysRepositoryMockRecorder) UpdatePasskeyUse(ctx, id, signCount, usedAt interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePasskeyUse", reflect.TypeOf((*MockPasskeysRepository)(nil).UpdatePasskeyUse), ctx, id, signCount, usedAt)
+}
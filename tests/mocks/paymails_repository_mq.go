@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/paymails/paymails_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+        context "context"
+        reflect "reflect"
+
+        paymails "github.com/bitcoin-sv/spv-wallet-web-backend/domain/paymails"
+        gomock "github.com/golang/mock/gomock"
+)
+
+// MockPaymailsRepository is a mock of Repository interface.
+type MockPaymailsRepository struct {
+        ctrl     *gomock.Controller
+        recorder *MockPaymailsRepositoryMockRecorder
+}
+
+// MockPaymailsRepositoryMockRecorder is the mock recorder for MockPaymailsRepository.
+type MockPaymailsRepositoryMockRecorder struct {
+        mock *MockPaymailsRepository
+}
+
+// NewMockPaymailsRepository creates a new mock instance.
+func NewMockPaymailsRepository(ctrl *gomock.Controller) *MockPaymailsRepository {
+        mock := &MockPaymailsRepository{ctrl: ctrl}
+        mock.recorder = &MockPaymailsRepositoryMockRecorder{mock}
+        return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymailsRepository) EXPECT() *MockPaymailsRepositoryMockRecorder {
+        return m.recorder
+}
+
+// DeletePaymail mocks base method.
+func (m *MockPaymailsRepository) DeletePaymail(ctx context.Context, userID, id int) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "DeletePaymail", ctx, userID, id)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// DeletePaymail indicates an expected call of DeletePaymail.
+func (mr *MockPaymailsRepositoryMockRecorder) DeletePaymail(ctx, userID, id interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePaymail", reflect.TypeOf((*MockPaymailsRepository)(nil).DeletePaymail), ctx, userID, id)
+}
+
+// GetUserPaymail mocks base method.
+func (m *MockPaymailsRepository) GetUserPaymail(ctx context.Context, userID, id int) (*paymails.Paymail, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetUserPaymail", ctx, userID, id)
+        ret0, _ := ret[0].(*paymails.Paymail)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetUserPaymail indicates an expected call of GetUserPaymail.
+func (mr *MockPaymailsRepositoryMockRecorder) GetUserPaymail(ctx, userID, id interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPaymail", reflect.TypeOf((*MockPaymailsRepository)(nil).GetUserPaymail), ctx, userID, id)
+}
+
+// GetUserPaymails mocks base method.
+func (m *MockPaymailsRepository) GetUserPaymails(ctx context.Context, userID int) ([]*paymails.Paymail, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetUserPaymails", ctx, userID)
+        ret0, _ := ret[0].([]*paymails.Paymail)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetUserPaymails indicates an expected call of GetUserPaymails.
+func (mr *MockPaymailsRepositoryMockRecorder) GetUserPaymails(ctx, userID interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPaymails", reflect.TypeOf((*MockPaymailsRepository)(nil).GetUserPaymails), ctx, userID)
+}
+
+// InsertPaymail mocks base method.
+func (m *MockPaymailsRepository) InsertPaymail(ctx context.Context, paymail *paymails.Paymail) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "InsertPaymail", ctx, paymail)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// InsertPaymail indicates an expected call of InsertPaymail.
+func (mr *MockPaymailsRepositoryMockRecorder) InsertPaymail(ctx, paymail interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPaymail", reflect.TypeOf((*MockPaymailsRepository)(nil).InsertPaymail), ctx, paymail)
+}
+
+// SetPrimaryPaymail mocks base method.
+func (m *MockPaymailsRepository) SetPrimaryPaymail(ctx context.Context, userID, id int) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "SetPrimaryPaymail", ctx, userID, id)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// SetPrimaryPaymail indicates an expected call of SetPrimaryPaymail.
+func (mr *MockPaymailsRepositoryMockRecorder) SetPrimaryPaymail(ctx, userID, id interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrimaryPaymail", reflect.TypeOf((*MockPaymailsRepository)(nil).SetPrimaryPaymail), ctx, userID, id)
+}
+
+// MockPendingAliasChecker is a mock of PendingAliasChecker interface.
+type MockPendingAliasChecker struct {
+        ctrl     *gomock.Controller
+        recorder *MockPendingAliasCheckerMockRecorder
+}
+
+// MockPendingAliasCheckerMockRecorder is the mock recorder for MockPendingAliasChecker.
+type MockPendingAliasCheckerMockRecorder struct {
+        mock *MockPendingAliasChecker
+}
+
+// NewMockPendingAliasChecker creates a new mock instance.
+func NewMockPendingAliasChecker(ctrl *gomock.Controller) *MockPendingAliasChecker {
+        mock := &MockPendingAliasChecker{ctrl: ctrl}
+        mock.recorder = &MockPendingAliasCheckerMockRecorder{mock}
+        return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPendingAliasChecker) EXPECT() *MockPendingAliasCheckerMockRecorder {
+        return m.recorder
+}
+
+// IsAliasPending mocks base method.
+func (m *MockPendingAliasChecker) IsAliasPending(ctx context.Context, alias string) (bool, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "IsAliasPending", ctx, alias)
+        ret0, _ := ret[0].(bool)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// IsAliasPending indicates an expected call of IsAliasPending.
+func (mr *MockPendingAliasCheckerMockRecorder) IsAliasPending(ctx, alias interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAliasPending", reflect.TypeOf((*MockPendingAliasChecker)(nil).IsAliasPending), ctx, alias)
+}
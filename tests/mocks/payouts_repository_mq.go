@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/payouts/payouts_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+        context "context"
+        reflect "reflect"
+        time "time"
+
+        payouts "github.com/bitcoin-sv/spv-wallet-web-backend/domain/payouts"
+        gomock "github.com/golang/mock/gomock"
+)
+
+// MockPayoutsRepository is a mock of Repository interface.
+type MockPayoutsRepository struct {
+        ctrl     *gomock.Controller
+        recorder *MockPayoutsRepositoryMockRecorder
+}
+
+// MockPayoutsRepositoryMockRecorder is the mock recorder for MockPayoutsRepository.
+type MockPayoutsRepositoryMockRecorder struct {
+        mock *MockPayoutsRepository
+}
+
+// NewMockPayoutsRepository creates a new mock instance.
+func NewMockPayoutsRepository(ctrl *gomock.Controller) *MockPayoutsRepository {
+        mock := &MockPayoutsRepository{ctrl: ctrl}
+        mock.recorder = &MockPayoutsRepositoryMockRecorder{mock}
+        return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPayoutsRepository) EXPECT() *MockPayoutsRepositoryMockRecorder {
+        return m.recorder
+}
+
+// ClaimBatch mocks base method.
+func (m *MockPayoutsRepository) ClaimBatch(ctx context.Context, userID, id int, now time.Time, staleAfter time.Duration) (bool, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "ClaimBatch", ctx, userID, id, now, staleAfter)
+        ret0, _ := ret[0].(bool)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// ClaimBatch indicates an expected call of ClaimBatch.
+func (mr *MockPayoutsRepositoryMockRecorder) ClaimBatch(ctx, userID, id, now, staleAfter interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimBatch", reflect.TypeOf((*MockPayoutsRepository)(nil).ClaimBatch), ctx, userID, id, now, staleAfter)
+}
+
+// GetBatch mocks base method.
+func (m *MockPayoutsRepository) GetBatch(ctx context.Context, userID, id int) (*payouts.Batch, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetBatch", ctx, userID, id)
+        ret0, _ := ret[0].(*payouts.Batch)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetBatch indicates an expected call of GetBatch.
+func (mr *MockPayoutsRepositoryMockRecorder) GetBatch(ctx, userID, id interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatch", reflect.TypeOf((*MockPayoutsRepository)(nil).GetBatch), ctx, userID, id)
+}
+
+// InsertBatch mocks base method.
+func (m *MockPayoutsRepository) InsertBatch(ctx context.Context, batch *payouts.Batch) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "InsertBatch", ctx, batch)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// InsertBatch indicates an expected call of InsertBatch.
+func (mr *MockPayoutsRepositoryMockRecorder) InsertBatch(ctx, batch interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBatch", reflect.TypeOf((*MockPayoutsRepository)(nil).InsertBatch), ctx, batch)
+}
+
+// UpdateBatchStatus mocks base method.
+func (m *MockPayoutsRepository) UpdateBatchStatus(ctx context.Context, id int, status string, updatedAt time.Time) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "UpdateBatchStatus", ctx, id, status, updatedAt)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// UpdateBatchStatus indicates an expected call of UpdateBatchStatus.
+func (mr *MockPayoutsRepositoryMockRecorder) UpdateBatchStatus(ctx, id, status, updatedAt interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBatchStatus", reflect.TypeOf((*MockPayoutsRepository)(nil).UpdateBatchStatus), ctx, id, status, updatedAt)
+}
+
+// UpdateRows mocks base method.
+func (m *MockPayoutsRepository) UpdateRows(ctx context.Context, rows []*payouts.Row) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "UpdateRows", ctx, rows)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// UpdateRows indicates an expected call of UpdateRows.
+func (mr *MockPayoutsRepositoryMockRecorder) UpdateRows(ctx, rows interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRows", reflect.TypeOf((*MockPayoutsRepository)(nil).UpdateRows), ctx, rows)
+}
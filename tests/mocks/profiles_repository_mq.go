@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/profiles/profiles_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+        context "context"
+        reflect "reflect"
+
+        profiles "github.com/bitcoin-sv/spv-wallet-web-backend/domain/profiles"
+        gomock "github.com/golang/mock/gomock"
+)
+
+// MockProfilesRepository is a mock of Repository interface.
+type MockProfilesRepository struct {
+        ctrl     *gomock.Controller
+        recorder *MockProfilesRepositoryMockRecorder
+}
+
+// MockProfilesRepositoryMockRecorder is the mock recorder for MockProfilesRepository.
+type MockProfilesRepositoryMockRecorder struct {
+        mock *MockProfilesRepository
+}
+
+// NewMockProfilesRepository creates a new mock instance.
+func NewMockProfilesRepository(ctrl *gomock.Controller) *MockProfilesRepository {
+        mock := &MockProfilesRepository{ctrl: ctrl}
+        mock.recorder = &MockProfilesRepositoryMockRecorder{mock}
+        return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfilesRepository) EXPECT() *MockProfilesRepositoryMockRecorder {
+        return m.recorder
+}
+
+// GetProfile mocks base method.
+func (m *MockProfilesRepository) GetProfile(ctx context.Context, userID int) (*profiles.Profile, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetProfile", ctx, userID)
+        ret0, _ := ret[0].(*profiles.Profile)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockProfilesRepositoryMockRecorder) GetProfile(ctx, userID interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockProfilesRepository)(nil).GetProfile), ctx, userID)
+}
+
+// UpsertProfile mocks base method.
+func (m *MockProfilesRepository) UpsertProfile(ctx context.Context, profile *profiles.Profile) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "UpsertProfile", ctx, profile)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// UpsertProfile indicates an expected call of UpsertProfile.
+func (mr *MockProfilesRepositoryMockRecorder) UpsertProfile(ctx, profile interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertProfile", reflect.TypeOf((*MockProfilesRepository)(nil).UpsertProfile), ctx, profile)
+}
+
+// MockPaymailsUpdater is a mock of PaymailsUpdater interface.
+type MockPaymailsUpdater struct {
+        ctrl     *gomock.Controller
+        recorder *MockPaymailsUpdaterMockRecorder
+}
+
+// MockPaymailsUpdaterMockRecorder is the mock recorder for MockPaymailsUpdater.
+type MockPaymailsUpdaterMockRecorder struct {
+        mock *MockPaymailsUpdater
+}
+
+// NewMockPaymailsUpdater creates a new mock instance.
+func NewMockPaymailsUpdater(ctrl *gomock.Controller) *MockPaymailsUpdater {
+        mock := &MockPaymailsUpdater{ctrl: ctrl}
+        mock.recorder = &MockPaymailsUpdaterMockRecorder{mock}
+        return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymailsUpdater) EXPECT() *MockPaymailsUpdaterMockRecorder {
+        return m.recorder
+}
+
+// UpdateProfile mocks base method.
+func (m *MockPaymailsUpdater) UpdateProfile(userID int, xpriv, publicName, avatar string) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "UpdateProfile", userID, xpriv, publicName, avatar)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockPaymailsUpdaterMockRecorder) UpdateProfile(userID, xpriv, publicName, avatar interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockPaymailsUpdater)(nil).UpdateProfile), userID, xpriv, publicName, avatar)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/roles/roles_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+        context "context"
+        reflect "reflect"
+        time "time"
+
+        roles "github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+        gomock "github.com/golang/mock/gomock"
+)
+
+// MockRolesRepository is a mock of Repository interface.
+type MockRolesRepository struct {
+        ctrl     *gomock.Controller
+        recorder *MockRolesRepositoryMockRecorder
+}
+
+// MockRolesRepositoryMockRecorder is the mock recorder for MockRolesRepository.
+type MockRolesRepositoryMockRecorder struct {
+        mock *MockRolesRepository
+}
+
+// NewMockRolesRepository creates a new mock instance.
+func NewMockRolesRepository(ctrl *gomock.Controller) *MockRolesRepository {
+        mock := &MockRolesRepository{ctrl: ctrl}
+        mock.recorder = &MockRolesRepositoryMockRecorder{mock}
+        return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRolesRepository) EXPECT() *MockRolesRepositoryMockRecorder {
+        return m.recorder
+}
+
+// DeleteUserRole mocks base method.
+func (m *MockRolesRepository) DeleteUserRole(ctx context.Context, userID int, role string) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "DeleteUserRole", ctx, userID, role)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// DeleteUserRole indicates an expected call of DeleteUserRole.
+func (mr *MockRolesRepositoryMockRecorder) DeleteUserRole(ctx, userID, role interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserRole", reflect.TypeOf((*MockRolesRepository)(nil).DeleteUserRole), ctx, userID, role)
+}
+
+// GetRoles mocks base method.
+func (m *MockRolesRepository) GetRoles(ctx context.Context) ([]*roles.Role, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetRoles", ctx)
+        ret0, _ := ret[0].([]*roles.Role)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetRoles indicates an expected call of GetRoles.
+func (mr *MockRolesRepositoryMockRecorder) GetRoles(ctx interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoles", reflect.TypeOf((*MockRolesRepository)(nil).GetRoles), ctx)
+}
+
+// GetUserPermissions mocks base method.
+func (m *MockRolesRepository) GetUserPermissions(ctx context.Context, userID int) ([]string, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetUserPermissions", ctx, userID)
+        ret0, _ := ret[0].([]string)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetUserPermissions indicates an expected call of GetUserPermissions.
+func (mr *MockRolesRepositoryMockRecorder) GetUserPermissions(ctx, userID interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPermissions", reflect.TypeOf((*MockRolesRepository)(nil).GetUserPermissions), ctx, userID)
+}
+
+// GetUserRoles mocks base method.
+func (m *MockRolesRepository) GetUserRoles(ctx context.Context, userID int) ([]string, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetUserRoles", ctx, userID)
+        ret0, _ := ret[0].([]string)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetUserRoles indicates an expected call of GetUserRoles.
+func (mr *MockRolesRepositoryMockRecorder) GetUserRoles(ctx, userID interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoles", reflect.TypeOf((*MockRolesRepository)(nil).GetUserRoles), ctx, userID)
+}
+
+// InsertUserRole mocks base method.
+func (m *MockRolesRepository) InsertUserRole(ctx context.Context, userID int, role string, createdAt time.Time) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "InsertUserRole", ctx, userID, role, createdAt)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// InsertUserRole indicates an expected call of InsertUserRole.
+func (mr *MockRolesRepositoryMockRecorder) InsertUserRole(ctx, userID, role, createdAt interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUserRole", reflect.TypeOf((*MockRolesRepository)(nil).InsertUserRole), ctx, userID, role, createdAt)
+}
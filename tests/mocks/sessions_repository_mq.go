@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/sessions/sessions_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+        context "context"
+        reflect "reflect"
+        time "time"
+
+        sessions "github.com/bitcoin-sv/spv-wallet-web-backend/domain/sessions"
+        gomock "github.com/golang/mock/gomock"
+)
+
+// MockSessionsRepository is a mock of Repository interface.
+type MockSessionsRepository struct {
+        ctrl     *gomock.Controller
+        recorder *MockSessionsRepositoryMockRecorder
+}
+
+// MockSessionsRepositoryMockRecorder is the mock recorder for MockSessionsRepository.
+type MockSessionsRepositoryMockRecorder struct {
+        mock *MockSessionsRepository
+}
+
+// NewMockSessionsRepository creates a new mock instance.
+func NewMockSessionsRepository(ctrl *gomock.Controller) *MockSessionsRepository {
+        mock := &MockSessionsRepository{ctrl: ctrl}
+        mock.recorder = &MockSessionsRepositoryMockRecorder{mock}
+        return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionsRepository) EXPECT() *MockSessionsRepositoryMockRecorder {
+        return m.recorder
+}
+
+// ExtendSession mocks base method.
+func (m *MockSessionsRepository) ExtendSession(ctx context.Context, accessKeyID string, expiresAt time.Time) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "ExtendSession", ctx, accessKeyID, expiresAt)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// ExtendSession indicates an expected call of ExtendSession.
+func (mr *MockSessionsRepositoryMockRecorder) ExtendSession(ctx, accessKeyID, expiresAt interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendSession", reflect.TypeOf((*MockSessionsRepository)(nil).ExtendSession), ctx, accessKeyID, expiresAt)
+}
+
+// GetActiveUserSession mocks base method.
+func (m *MockSessionsRepository) GetActiveUserSession(ctx context.Context, userID, id int) (*sessions.Session, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetActiveUserSession", ctx, userID, id)
+        ret0, _ := ret[0].(*sessions.Session)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetActiveUserSession indicates an expected call of GetActiveUserSession.
+func (mr *MockSessionsRepositoryMockRecorder) GetActiveUserSession(ctx, userID, id interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveUserSession", reflect.TypeOf((*MockSessionsRepository)(nil).GetActiveUserSession), ctx, userID, id)
+}
+
+// GetActiveUserSessions mocks base method.
+func (m *MockSessionsRepository) GetActiveUserSessions(ctx context.Context, userID int) ([]*sessions.Session, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetActiveUserSessions", ctx, userID)
+        ret0, _ := ret[0].([]*sessions.Session)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetActiveUserSessions indicates an expected call of GetActiveUserSessions.
+func (mr *MockSessionsRepositoryMockRecorder) GetActiveUserSessions(ctx, userID interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveUserSessions", reflect.TypeOf((*MockSessionsRepository)(nil).GetActiveUserSessions), ctx, userID)
+}
+
+// GetExpiredSessions mocks base method.
+func (m *MockSessionsRepository) GetExpiredSessions(ctx context.Context, now time.Time, limit int) ([]*sessions.Session, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetExpiredSessions", ctx, now, limit)
+        ret0, _ := ret[0].([]*sessions.Session)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetExpiredSessions indicates an expected call of GetExpiredSessions.
+func (mr *MockSessionsRepositoryMockRecorder) GetExpiredSessions(ctx, now, limit interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredSessions", reflect.TypeOf((*MockSessionsRepository)(nil).GetExpiredSessions), ctx, now, limit)
+}
+
+// InsertSession mocks base method.
+func (m *MockSessionsRepository) InsertSession(ctx context.Context, session *sessions.Session) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "InsertSession", ctx, session)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// InsertSession indicates an expected call of InsertSession.
+func (mr *MockSessionsRepositoryMockRecorder) InsertSession(ctx, session interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSession", reflect.TypeOf((*MockSessionsRepository)(nil).InsertSession), ctx, session)
+}
+
+// MarkSessionRevoked mocks base method.
+func (m *MockSessionsRepository) MarkSessionRevoked(ctx context.Context, accessKeyID string, revokedAt time.Time) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "MarkSessionRevoked", ctx, accessKeyID, revokedAt)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// MarkSessionRevoked indicates an expected call of MarkSessionRevoked.
+func (mr *MockSessionsRepositoryMockRecorder) MarkSessionRevoked(ctx, accessKeyID, revokedAt interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSessionRevoked", reflect.TypeOf((*MockSessionsRepository)(nil).MarkSessionRevoked), ctx, accessKeyID, revokedAt)
+}
+
+// MarkUserSessionsRevoked mocks base method.
+func (m *MockSessionsRepository) MarkUserSessionsRevoked(ctx context.Context, userID int, keepAccessKeyID string, revokedAt time.Time) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "MarkUserSessionsRevoked", ctx, userID, keepAccessKeyID, revokedAt)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// MarkUserSessionsRevoked indicates an expected call of MarkUserSessionsRevoked.
+func (mr *MockSessionsRepositoryMockRecorder) MarkUserSessionsRevoked(ctx, userID, keepAccessKeyID, revokedAt interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUserSessionsRevoked", reflect.TypeOf((*MockSessionsRepository)(nil).MarkUserSessionsRevoked), ctx, userID, keepAccessKeyID, revokedAt)
+}
+
+// TouchSession mocks base method.
+func (m *MockSessionsRepository) TouchSession(ctx context.Context, id int, accessKeyID string, lastSeenAt time.Time) (bool, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "TouchSession", ctx, id, accessKeyID, lastSeenAt)
+        ret0, _ := ret[0].(bool)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// TouchSession indicates an expected call of TouchSession.
+func (mr *MockSessionsRepositoryMockRecorder) TouchSession(ctx, id, accessKeyID, lastSeenAt interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchSession", reflect.TypeOf((*MockSessionsRepository)(nil).TouchSession), ctx, id, accessKeyID, lastSeenAt)
+}
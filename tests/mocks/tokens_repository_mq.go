@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/tokens/tokens_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+        context "context"
+        reflect "reflect"
+        time "time"
+
+        tokens "github.com/bitcoin-sv/spv-wallet-web-backend/domain/tokens"
+        gomock "github.com/golang/mock/gomock"
+)
+
+// MockTokensRepository is a mock of Repository interface.
+type MockTokensRepository struct {
+        ctrl     *gomock.Controller
+        recorder *MockTokensRepositoryMockRecorder
+}
+
+// MockTokensRepositoryMockRecorder is the mock recorder for MockTokensRepository.
+type MockTokensRepositoryMockRecorder struct {
+        mock *MockTokensRepository
+}
+
+// NewMockTokensRepository creates a new mock instance.
+func NewMockTokensRepository(ctrl *gomock.Controller) *MockTokensRepository {
+        mock := &MockTokensRepository{ctrl: ctrl}
+        mock.recorder = &MockTokensRepositoryMockRecorder{mock}
+        return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokensRepository) EXPECT() *MockTokensRepositoryMockRecorder {
+        return m.recorder
+}
+
+// DeleteToken mocks base method.
+func (m *MockTokensRepository) DeleteToken(ctx context.Context, userID, id int) (*tokens.Token, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "DeleteToken", ctx, userID, id)
+        ret0, _ := ret[0].(*tokens.Token)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// DeleteToken indicates an expected call of DeleteToken.
+func (mr *MockTokensRepositoryMockRecorder) DeleteToken(ctx, userID, id interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteToken", reflect.TypeOf((*MockTokensRepository)(nil).DeleteToken), ctx, userID, id)
+}
+
+// DeleteUserTokens mocks base method.
+func (m *MockTokensRepository) DeleteUserTokens(ctx context.Context, userID int) ([]*tokens.Token, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "DeleteUserTokens", ctx, userID)
+        ret0, _ := ret[0].([]*tokens.Token)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// DeleteUserTokens indicates an expected call of DeleteUserTokens.
+func (mr *MockTokensRepositoryMockRecorder) DeleteUserTokens(ctx, userID interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTokens", reflect.TypeOf((*MockTokensRepository)(nil).DeleteUserTokens), ctx, userID)
+}
+
+// GetTokenByHash mocks base method.
+func (m *MockTokensRepository) GetTokenByHash(ctx context.Context, hash string) (*tokens.Token, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetTokenByHash", ctx, hash)
+        ret0, _ := ret[0].(*tokens.Token)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetTokenByHash indicates an expected call of GetTokenByHash.
+func (mr *MockTokensRepositoryMockRecorder) GetTokenByHash(ctx, hash interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenByHash", reflect.TypeOf((*MockTokensRepository)(nil).GetTokenByHash), ctx, hash)
+}
+
+// GetUserTokens mocks base method.
+func (m *MockTokensRepository) GetUserTokens(ctx context.Context, userID int) ([]*tokens.Token, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetUserTokens", ctx, userID)
+        ret0, _ := ret[0].([]*tokens.Token)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetUserTokens indicates an expected call of GetUserTokens.
+func (mr *MockTokensRepositoryMockRecorder) GetUserTokens(ctx, userID interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTokens", reflect.TypeOf((*MockTokensRepository)(nil).GetUserTokens), ctx, userID)
+}
+
+// InsertToken mocks base method.
+func (m *MockTokensRepository) InsertToken(ctx context.Context, token *tokens.Token) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "InsertToken", ctx, token)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// InsertToken indicates an expected call of InsertToken.
+func (mr *MockTokensRepositoryMockRecorder) InsertToken(ctx, token interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertToken", reflect.TypeOf((*MockTokensRepository)(nil).InsertToken), ctx, token)
+}
+
+// TouchToken mocks base method.
+func (m *MockTokensRepository) TouchToken(ctx context.Context, id int, usedAt time.Time) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "TouchToken", ctx, id, usedAt)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// TouchToken indicates an expected call of TouchToken.
+func (mr *MockTokensRepositoryMockRecorder) TouchToken(ctx, id, usedAt interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchToken", reflect.TypeOf((*MockTokensRepository)(nil).TouchToken), ctx, id, usedAt)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/transactions/transactions_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+        context "context"
+        reflect "reflect"
+        time "time"
+
+        transactions "github.com/bitcoin-sv/spv-wallet-web-backend/domain/transactions"
+        gomock "github.com/golang/mock/gomock"
+)
+
+// MockTransactionsRepository is a mock of Repository interface.
+type MockTransactionsRepository struct {
+        ctrl     *gomock.Controller
+        recorder *MockTransactionsRepositoryMockRecorder
+}
+
+// MockTransactionsRepositoryMockRecorder is the mock recorder for MockTransactionsRepository.
+type MockTransactionsRepositoryMockRecorder struct {
+        mock *MockTransactionsRepository
+}
+
+// NewMockTransactionsRepository creates a new mock instance.
+func NewMockTransactionsRepository(ctrl *gomock.Controller) *MockTransactionsRepository {
+        mock := &MockTransactionsRepository{ctrl: ctrl}
+        mock.recorder = &MockTransactionsRepositoryMockRecorder{mock}
+        return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionsRepository) EXPECT() *MockTransactionsRepositoryMockRecorder {
+        return m.recorder
+}
+
+// ClaimPreview mocks base method.
+func (m *MockTransactionsRepository) ClaimPreview(ctx context.Context, userID int, token string, now time.Time) (*transactions.Preview, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "ClaimPreview", ctx, userID, token, now)
+        ret0, _ := ret[0].(*transactions.Preview)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// ClaimPreview indicates an expected call of ClaimPreview.
+func (mr *MockTransactionsRepositoryMockRecorder) ClaimPreview(ctx, userID, token, now interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPreview", reflect.TypeOf((*MockTransactionsRepository)(nil).ClaimPreview), ctx, userID, token, now)
+}
+
+// DeletePreview mocks base method.
+func (m *MockTransactionsRepository) DeletePreview(ctx context.Context, token string) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "DeletePreview", ctx, token)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// DeletePreview indicates an expected call of DeletePreview.
+func (mr *MockTransactionsRepositoryMockRecorder) DeletePreview(ctx, token interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePreview", reflect.TypeOf((*MockTransactionsRepository)(nil).DeletePreview), ctx, token)
+}
+
+// GetPreview mocks base method.
+func (m *MockTransactionsRepository) GetPreview(ctx context.Context, userID int, token string) (*transactions.Preview, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetPreview", ctx, userID, token)
+        ret0, _ := ret[0].(*transactions.Preview)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetPreview indicates an expected call of GetPreview.
+func (mr *MockTransactionsRepositoryMockRecorder) GetPreview(ctx, userID, token interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreview", reflect.TypeOf((*MockTransactionsRepository)(nil).GetPreview), ctx, userID, token)
+}
+
+// InsertPreview mocks base method.
+func (m *MockTransactionsRepository) InsertPreview(ctx context.Context, preview *transactions.Preview, now time.Time) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "InsertPreview", ctx, preview, now)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// InsertPreview indicates an expected call of InsertPreview.
+func (mr *MockTransactionsRepositoryMockRecorder) InsertPreview(ctx, preview, now interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPreview", reflect.TypeOf((*MockTransactionsRepository)(nil).InsertPreview), ctx, preview, now)
+}
+
+// ReleasePreview mocks base method.
+func (m *MockTransactionsRepository) ReleasePreview(ctx context.Context, token string) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "ReleasePreview", ctx, token)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// ReleasePreview indicates an expected call of ReleasePreview.
+func (mr *MockTransactionsRepositoryMockRecorder) ReleasePreview(ctx, token interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleasePreview", reflect.TypeOf((*MockTransactionsRepository)(nil).ReleasePreview), ctx, token)
+}
@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/twofactor/twofactor_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+        context "context"
+        reflect "reflect"
+        time "time"
+
+        twofactor "github.com/bitcoin-sv/spv-wallet-web-backend/domain/twofactor"
+        gomock "github.com/golang/mock/gomock"
+)
+
+// MockTwoFactorRepository is a mock of Repository interface.
+type MockTwoFactorRepository struct {
+        ctrl     *gomock.Controller
+        recorder *MockTwoFactorRepositoryMockRecorder
+}
+
+// MockTwoFactorRepositoryMockRecorder is the mock recorder for MockTwoFactorRepository.
+type MockTwoFactorRepositoryMockRecorder struct {
+        mock *MockTwoFactorRepository
+}
+
+// NewMockTwoFactorRepository creates a new mock instance.
+func NewMockTwoFactorRepository(ctrl *gomock.Controller) *MockTwoFactorRepository {
+        mock := &MockTwoFactorRepository{ctrl: ctrl}
+        mock.recorder = &MockTwoFactorRepositoryMockRecorder{mock}
+        return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorRepository) EXPECT() *MockTwoFactorRepositoryMockRecorder {
+        return m.recorder
+}
+
+// DeleteTwoFactor mocks base method.
+func (m *MockTwoFactorRepository) DeleteTwoFactor(ctx context.Context, userID int) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "DeleteTwoFactor", ctx, userID)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// DeleteTwoFactor indicates an expected call of DeleteTwoFactor.
+func (mr *MockTwoFactorRepositoryMockRecorder) DeleteTwoFactor(ctx, userID interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTwoFactor", reflect.TypeOf((*MockTwoFactorRepository)(nil).DeleteTwoFactor), ctx, userID)
+}
+
+// EnableTwoFactor mocks base method.
+func (m *MockTwoFactorRepository) EnableTwoFactor(ctx context.Context, userID int) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "EnableTwoFactor", ctx, userID)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// EnableTwoFactor indicates an expected call of EnableTwoFactor.
+func (mr *MockTwoFactorRepositoryMockRecorder) EnableTwoFactor(ctx, userID interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTwoFactor", reflect.TypeOf((*MockTwoFactorRepository)(nil).EnableTwoFactor), ctx, userID)
+}
+
+// GetTwoFactor mocks base method.
+func (m *MockTwoFactorRepository) GetTwoFactor(ctx context.Context, userID int) (*twofactor.TwoFactor, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetTwoFactor", ctx, userID)
+        ret0, _ := ret[0].(*twofactor.TwoFactor)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetTwoFactor indicates an expected call of GetTwoFactor.
+func (mr *MockTwoFactorRepositoryMockRecorder) GetTwoFactor(ctx, userID interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTwoFactor", reflect.TypeOf((*MockTwoFactorRepository)(nil).GetTwoFactor), ctx, userID)
+}
+
+// ReplaceTwoFactor mocks base method.
+func (m *MockTwoFactorRepository) ReplaceTwoFactor(ctx context.Context, twoFactor *twofactor.TwoFactor, recoveryCodeHashes []string) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "ReplaceTwoFactor", ctx, twoFactor, recoveryCodeHashes)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// ReplaceTwoFactor indicates an expected call of ReplaceTwoFactor.
+func (mr *MockTwoFactorRepositoryMockRecorder) ReplaceTwoFactor(ctx, twoFactor, recoveryCodeHashes interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTwoFactor", reflect.TypeOf((*MockTwoFactorRepository)(nil).ReplaceTwoFactor), ctx, twoFactor, recoveryCodeHashes)
+}
+
+// UpdateLastUsedStep mocks base method.
+func (m *MockTwoFactorRepository) UpdateLastUsedStep(ctx context.Context, userID int, step int64) (bool, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "UpdateLastUsedStep", ctx, userID, step)
+        ret0, _ := ret[0].(bool)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// UpdateLastUsedStep indicates an expected call of UpdateLastUsedStep.
+func (mr *MockTwoFactorRepositoryMockRecorder) UpdateLastUsedStep(ctx, userID, step interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastUsedStep", reflect.TypeOf((*MockTwoFactorRepository)(nil).UpdateLastUsedStep), ctx, userID, step)
+}
+
+// UpdateTransactionThreshold mocks base method.
+func (m *MockTwoFactorRepository) UpdateTransactionThreshold(ctx context.Context, userID int, threshold *uint64) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "UpdateTransactionThreshold", ctx, userID, threshold)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// UpdateTransactionThreshold indicates an expected call of UpdateTransactionThreshold.
+func (mr *MockTwoFactorRepositoryMockRecorder) UpdateTransactionThreshold(ctx, userID, threshold interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransactionThreshold", reflect.TypeOf((*MockTwoFactorRepository)(nil).UpdateTransactionThreshold), ctx, userID, threshold)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockTwoFactorRepository) UseRecoveryCode(ctx context.Context, userID int, codeHash string, usedAt time.Time) (bool, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, userID, codeHash, usedAt)
+        ret0, _ := ret[0].(bool)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockTwoFactorRepositoryMockRecorder) UseRecoveryCode(ctx, userID, codeHash, usedAt interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockTwoFactorRepository)(nil).UseRecoveryCode), ctx, userID, codeHash, usedAt)
+}
@@ -467,6 +467,34 @@ func (m *MockDraftTransaction) EXPECT() *MockDraftTransactionMockRecorder {
         return m.recorder
 }
 
+// GetDraftTransactionChange mocks base method.
+func (m *MockDraftTransaction) GetDraftTransactionChange() uint64 {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetDraftTransactionChange")
+        ret0, _ := ret[0].(uint64)
+        return ret0
+}
+
+// GetDraftTransactionChange indicates an expected call of GetDraftTransactionChange.
+func (mr *MockDraftTransactionMockRecorder) GetDraftTransactionChange() *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraftTransactionChange", reflect.TypeOf((*MockDraftTransaction)(nil).GetDraftTransactionChange))
+}
+
+// GetDraftTransactionFee mocks base method.
+func (m *MockDraftTransaction) GetDraftTransactionFee() uint64 {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetDraftTransactionFee")
+        ret0, _ := ret[0].(uint64)
+        return ret0
+}
+
+// GetDraftTransactionFee indicates an expected call of GetDraftTransactionFee.
+func (mr *MockDraftTransactionMockRecorder) GetDraftTransactionFee() *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraftTransactionFee", reflect.TypeOf((*MockDraftTransaction)(nil).GetDraftTransactionFee))
+}
+
 // GetDraftTransactionHex mocks base method.
 func (m *MockDraftTransaction) GetDraftTransactionHex() string {
         m.ctrl.T.Helper()
@@ -495,6 +523,34 @@ func (mr *MockDraftTransactionMockRecorder) GetDraftTransactionID() *gomock.Call
         return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraftTransactionID", reflect.TypeOf((*MockDraftTransaction)(nil).GetDraftTransactionID))
 }
 
+// GetDraftTransactionInputs mocks base method.
+func (m *MockDraftTransaction) GetDraftTransactionInputs() []users.DraftInput {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetDraftTransactionInputs")
+        ret0, _ := ret[0].([]users.DraftInput)
+        return ret0
+}
+
+// GetDraftTransactionInputs indicates an expected call of GetDraftTransactionInputs.
+func (mr *MockDraftTransactionMockRecorder) GetDraftTransactionInputs() *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraftTransactionInputs", reflect.TypeOf((*MockDraftTransaction)(nil).GetDraftTransactionInputs))
+}
+
+// GetDraftTransactionOutputs mocks base method.
+func (m *MockDraftTransaction) GetDraftTransactionOutputs() []users.DraftOutput {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetDraftTransactionOutputs")
+        ret0, _ := ret[0].([]users.DraftOutput)
+        return ret0
+}
+
+// GetDraftTransactionOutputs indicates an expected call of GetDraftTransactionOutputs.
+func (mr *MockDraftTransactionMockRecorder) GetDraftTransactionOutputs() *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraftTransactionOutputs", reflect.TypeOf((*MockDraftTransaction)(nil).GetDraftTransactionOutputs))
+}
+
 // MockUserWalletClient is a mock of UserWalletClient interface.
 type MockUserWalletClient struct {
         ctrl     *gomock.Controller
@@ -561,19 +617,34 @@ func (mr *MockUserWalletClientMockRecorder) CreateAccessKey() *gomock.Call {
         return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessKey", reflect.TypeOf((*MockUserWalletClient)(nil).CreateAccessKey))
 }
 
-// CreateAndFinalizeTransaction mocks base method.
-func (m *MockUserWalletClient) CreateAndFinalizeTransaction(recipients []*commands.Recipients, metadata map[string]any) (users.DraftTransaction, error) {
+// DraftToRecipients mocks base method.
+func (m *MockUserWalletClient) DraftToRecipients(recipients []*commands.Recipients, metadata map[string]any, expiresIn time.Duration) (users.DraftTransaction, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "DraftToRecipients", recipients, metadata, expiresIn)
+        ret0, _ := ret[0].(users.DraftTransaction)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// DraftToRecipients indicates an expected call of DraftToRecipients.
+func (mr *MockUserWalletClientMockRecorder) DraftToRecipients(recipients, metadata, expiresIn interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DraftToRecipients", reflect.TypeOf((*MockUserWalletClient)(nil).DraftToRecipients), recipients, metadata, expiresIn)
+}
+
+// FinalizeTransaction mocks base method.
+func (m *MockUserWalletClient) FinalizeTransaction(draft users.DraftTransaction) (users.DraftTransaction, error) {
         m.ctrl.T.Helper()
-        ret := m.ctrl.Call(m, "CreateAndFinalizeTransaction", recipients, metadata)
+        ret := m.ctrl.Call(m, "FinalizeTransaction", draft)
         ret0, _ := ret[0].(users.DraftTransaction)
         ret1, _ := ret[1].(error)
         return ret0, ret1
 }
 
-// CreateAndFinalizeTransaction indicates an expected call of CreateAndFinalizeTransaction.
-func (mr *MockUserWalletClientMockRecorder) CreateAndFinalizeTransaction(recipients, metadata interface{}) *gomock.Call {
+// FinalizeTransaction indicates an expected call of FinalizeTransaction.
+func (mr *MockUserWalletClientMockRecorder) FinalizeTransaction(draft interface{}) *gomock.Call {
         mr.mock.ctrl.T.Helper()
-        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAndFinalizeTransaction", reflect.TypeOf((*MockUserWalletClient)(nil).CreateAndFinalizeTransaction), recipients, metadata)
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinalizeTransaction", reflect.TypeOf((*MockUserWalletClient)(nil).FinalizeTransaction), draft)
 }
 
 // GenerateTotpForContact mocks base method.
@@ -606,6 +677,21 @@ func (mr *MockUserWalletClientMockRecorder) GetAccessKey(accessKeyID interface{}
         return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessKey", reflect.TypeOf((*MockUserWalletClient)(nil).GetAccessKey), accessKeyID)
 }
 
+// GetAccessKeys mocks base method.
+func (m *MockUserWalletClient) GetAccessKeys() ([]users.AccKey, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetAccessKeys")
+        ret0, _ := ret[0].([]users.AccKey)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetAccessKeys indicates an expected call of GetAccessKeys.
+func (mr *MockUserWalletClientMockRecorder) GetAccessKeys() *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessKeys", reflect.TypeOf((*MockUserWalletClient)(nil).GetAccessKeys))
+}
+
 // GetContacts mocks base method.
 func (m *MockUserWalletClient) GetContacts(ctx context.Context, conditions *filter.ContactFilter, metadata map[string]any, queryParams *filter.QueryParams) (*models.SearchContactsResponse, error) {
         m.ctrl.T.Helper()
@@ -621,6 +707,21 @@ func (mr *MockUserWalletClientMockRecorder) GetContacts(ctx, conditions, metadat
         return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContacts", reflect.TypeOf((*MockUserWalletClient)(nil).GetContacts), ctx, conditions, metadata, queryParams)  
 }
 
+// GetPaymails mocks base method.
+func (m *MockUserWalletClient) GetPaymails() ([]string, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetPaymails")
+        ret0, _ := ret[0].([]string)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetPaymails indicates an expected call of GetPaymails.
+func (mr *MockUserWalletClientMockRecorder) GetPaymails() *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymails", reflect.TypeOf((*MockUserWalletClient)(nil).GetPaymails))
+}
+
 // GetTransaction mocks base method.
 func (m *MockUserWalletClient) GetTransaction(transactionID, userPaymail string) (users.FullTransaction, error) {
         m.ctrl.T.Helper()
@@ -666,6 +767,21 @@ func (mr *MockUserWalletClientMockRecorder) GetTransactionsCount() *gomock.Call
         return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionsCount", reflect.TypeOf((*MockUserWalletClient)(nil).GetTransactionsCount))
 }
 
+// GetTransactionsWithMetadata mocks base method.
+func (m *MockUserWalletClient) GetTransactionsWithMetadata(metadata map[string]any, userPaymail string) ([]users.Transaction, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetTransactionsWithMetadata", metadata, userPaymail)
+        ret0, _ := ret[0].([]users.Transaction)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetTransactionsWithMetadata indicates an expected call of GetTransactionsWithMetadata.
+func (mr *MockUserWalletClientMockRecorder) GetTransactionsWithMetadata(metadata, userPaymail interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionsWithMetadata", reflect.TypeOf((*MockUserWalletClient)(nil).GetTransactionsWithMetadata), metadata, userPaymail)
+}
+
 // GetXPub mocks base method.
 func (m *MockUserWalletClient) GetXPub() (users.PubKey, error) {
         m.ctrl.T.Helper()
@@ -725,19 +841,34 @@ func (mr *MockUserWalletClientMockRecorder) RevokeAccessKey(accessKeyID interfac
         return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAccessKey", reflect.TypeOf((*MockUserWalletClient)(nil).RevokeAccessKey), accessKeyID)
 }
 
+// SendAllTo mocks base method.
+func (m *MockUserWalletClient) SendAllTo(recipient, senderPaymail string) (users.Transaction, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "SendAllTo", recipient, senderPaymail)
+        ret0, _ := ret[0].(users.Transaction)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// SendAllTo indicates an expected call of SendAllTo.
+func (mr *MockUserWalletClientMockRecorder) SendAllTo(recipient, senderPaymail interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAllTo", reflect.TypeOf((*MockUserWalletClient)(nil).SendAllTo), recipient, senderPaymail)
+}
+
 // SendToRecipients mocks base method.
-func (m *MockUserWalletClient) SendToRecipients(recipients []*commands.Recipients, senderPaymail string) (users.Transaction, error) {
+func (m *MockUserWalletClient) SendToRecipients(recipients []*commands.Recipients, metadata map[string]any) (users.Transaction, error) {
         m.ctrl.T.Helper()
-        ret := m.ctrl.Call(m, "SendToRecipients", recipients, senderPaymail)
+        ret := m.ctrl.Call(m, "SendToRecipients", recipients, metadata)
         ret0, _ := ret[0].(users.Transaction)
         ret1, _ := ret[1].(error)
         return ret0, ret1
 }
 
 // SendToRecipients indicates an expected call of SendToRecipients.
-func (mr *MockUserWalletClientMockRecorder) SendToRecipients(recipients, senderPaymail interface{}) *gomock.Call {
+func (mr *MockUserWalletClientMockRecorder) SendToRecipients(recipients, metadata interface{}) *gomock.Call {
         mr.mock.ctrl.T.Helper()
-        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendToRecipients", reflect.TypeOf((*MockUserWalletClient)(nil).SendToRecipients), recipients, senderPaymail)     
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendToRecipients", reflect.TypeOf((*MockUserWalletClient)(nil).SendToRecipients), recipients, metadata)
 }
 
 // UpsertContact mocks base method.
@@ -778,6 +909,66 @@ func (m *MockAdminWalletClient) EXPECT() *MockAdminWalletClientMockRecorder {
         return m.recorder
 }
 
+// CreatePaymail mocks base method.
+func (m *MockAdminWalletClient) CreatePaymail(alias, domain, xpub string) (string, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "CreatePaymail", alias, domain, xpub)
+        ret0, _ := ret[0].(string)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// CreatePaymail indicates an expected call of CreatePaymail.
+func (mr *MockAdminWalletClientMockRecorder) CreatePaymail(alias, domain, xpub interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymail", reflect.TypeOf((*MockAdminWalletClient)(nil).CreatePaymail), alias, domain, xpub)
+}
+
+// DeletePaymail mocks base method.
+func (m *MockAdminWalletClient) DeletePaymail(address string) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "DeletePaymail", address)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// DeletePaymail indicates an expected call of DeletePaymail.
+func (mr *MockAdminWalletClientMockRecorder) DeletePaymail(address interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePaymail", reflect.TypeOf((*MockAdminWalletClient)(nil).DeletePaymail), address)
+}
+
+// GetPaymailXPub mocks base method.
+func (m *MockAdminWalletClient) GetPaymailXPub(address string) (users.PubKey, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetPaymailXPub", address)
+        ret0, _ := ret[0].(users.PubKey)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetPaymailXPub indicates an expected call of GetPaymailXPub.
+func (mr *MockAdminWalletClientMockRecorder) GetPaymailXPub(address interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymailXPub", reflect.TypeOf((*MockAdminWalletClient)(nil).GetPaymailXPub), address)
+}
+
+// GetXPubTransactions mocks base method.
+func (m *MockAdminWalletClient) GetXPubTransactions(xpubID string, queryParam *filter.QueryParams, userPaymail string) ([]users.Transaction, int64, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetXPubTransactions", xpubID, queryParam, userPaymail)
+        ret0, _ := ret[0].([]users.Transaction)
+        ret1, _ := ret[1].(int64)
+        ret2, _ := ret[2].(error)
+        return ret0, ret1, ret2
+}
+
+// GetXPubTransactions indicates an expected call of GetXPubTransactions.
+func (mr *MockAdminWalletClientMockRecorder) GetXPubTransactions(xpubID, queryParam, userPaymail interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXPubTransactions", reflect.TypeOf((*MockAdminWalletClient)(nil).GetXPubTransactions), xpubID, queryParam, userPaymail)
+}
+
 // GetSharedConfig mocks base method.
 func (m *MockAdminWalletClient) GetSharedConfig() (*models.SharedConfig, error) {
         m.ctrl.T.Helper()
@@ -793,6 +984,21 @@ func (mr *MockAdminWalletClientMockRecorder) GetSharedConfig() *gomock.Call {
         return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedConfig", reflect.TypeOf((*MockAdminWalletClient)(nil).GetSharedConfig))
 }
 
+// PaymailExists mocks base method.
+func (m *MockAdminWalletClient) PaymailExists(alias, domain string) (bool, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "PaymailExists", alias, domain)
+        ret0, _ := ret[0].(bool)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// PaymailExists indicates an expected call of PaymailExists.
+func (mr *MockAdminWalletClientMockRecorder) PaymailExists(alias, domain interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaymailExists", reflect.TypeOf((*MockAdminWalletClient)(nil).PaymailExists), alias, domain)
+}
+
 // RegisterPaymail mocks base method.
 func (m *MockAdminWalletClient) RegisterPaymail(alias, xpub string) (string, error) {
         m.ctrl.T.Helper()
@@ -823,6 +1029,95 @@ func (mr *MockAdminWalletClientMockRecorder) RegisterXpub(xpriv interface{}) *go
         return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterXpub", reflect.TypeOf((*MockAdminWalletClient)(nil).RegisterXpub), xpriv)
 }
 
+// UpdatePaymailProfile mocks base method.
+func (m *MockAdminWalletClient) UpdatePaymailProfile(address, xpub, publicName, avatar string) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "UpdatePaymailProfile", address, xpub, publicName, avatar)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// UpdatePaymailProfile indicates an expected call of UpdatePaymailProfile.
+func (mr *MockAdminWalletClientMockRecorder) UpdatePaymailProfile(address, xpub, publicName, avatar interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymailProfile", reflect.TypeOf((*MockAdminWalletClient)(nil).UpdatePaymailProfile), address, xpub, publicName, avatar)
+}
+
+// MockSecondFactorVerifier is a mock of SecondFactorVerifier interface.
+type MockSecondFactorVerifier struct {
+        ctrl     *gomock.Controller
+        recorder *MockSecondFactorVerifierMockRecorder
+}
+
+// MockSecondFactorVerifierMockRecorder is the mock recorder for MockSecondFactorVerifier.
+type MockSecondFactorVerifierMockRecorder struct {
+        mock *MockSecondFactorVerifier
+}
+
+// NewMockSecondFactorVerifier creates a new mock instance.
+func NewMockSecondFactorVerifier(ctrl *gomock.Controller) *MockSecondFactorVerifier {
+        mock := &MockSecondFactorVerifier{ctrl: ctrl}
+        mock.recorder = &MockSecondFactorVerifierMockRecorder{mock}
+        return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecondFactorVerifier) EXPECT() *MockSecondFactorVerifierMockRecorder {
+        return m.recorder
+}
+
+// VerifySignIn mocks base method.
+func (m *MockSecondFactorVerifier) VerifySignIn(userID int, code string) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "VerifySignIn", userID, code)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// VerifySignIn indicates an expected call of VerifySignIn.
+func (mr *MockSecondFactorVerifierMockRecorder) VerifySignIn(userID, code interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifySignIn", reflect.TypeOf((*MockSecondFactorVerifier)(nil).VerifySignIn), userID, code)
+}
+
+// MockAccessKeysKeeper is a mock of AccessKeysKeeper interface.
+type MockAccessKeysKeeper struct {
+        ctrl     *gomock.Controller
+        recorder *MockAccessKeysKeeperMockRecorder
+}
+
+// MockAccessKeysKeeperMockRecorder is the mock recorder for MockAccessKeysKeeper.
+type MockAccessKeysKeeperMockRecorder struct {
+        mock *MockAccessKeysKeeper
+}
+
+// NewMockAccessKeysKeeper creates a new mock instance.
+func NewMockAccessKeysKeeper(ctrl *gomock.Controller) *MockAccessKeysKeeper {
+        mock := &MockAccessKeysKeeper{ctrl: ctrl}
+        mock.recorder = &MockAccessKeysKeeperMockRecorder{mock}
+        return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccessKeysKeeper) EXPECT() *MockAccessKeysKeeperMockRecorder {
+        return m.recorder
+}
+
+// GetKeptAccessKeyIDs mocks base method.
+func (m *MockAccessKeysKeeper) GetKeptAccessKeyIDs(userID int) ([]string, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetKeptAccessKeyIDs", userID)
+        ret0, _ := ret[0].([]string)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetKeptAccessKeyIDs indicates an expected call of GetKeptAccessKeyIDs.
+func (mr *MockAccessKeysKeeperMockRecorder) GetKeptAccessKeyIDs(userID interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeptAccessKeyIDs", reflect.TypeOf((*MockAccessKeysKeeper)(nil).GetKeptAccessKeyIDs), userID)
+}
+
 // MockWalletClientFactory is a mock of WalletClientFactory interface.
 type MockWalletClientFactory struct {
         ctrl     *gomock.Controller
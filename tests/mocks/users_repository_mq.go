@@ -7,6 +7,7 @@ package mock
 import (
         context "context"
         reflect "reflect"
+        time "time"
 
         users "github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
         gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,79 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
         return m.recorder
 }
 
+// ActivateUser mocks base method.
+func (m *MockRepository) ActivateUser(ctx context.Context, id int, paymail string) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "ActivateUser", ctx, id, paymail)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// ActivateUser indicates an expected call of ActivateUser.
+func (mr *MockRepositoryMockRecorder) ActivateUser(ctx, id, paymail interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateUser", reflect.TypeOf((*MockRepository)(nil).ActivateUser), ctx, id, paymail)
+}
+
+// CountUsers mocks base method.
+func (m *MockRepository) CountUsers(ctx context.Context, pattern string) (int, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "CountUsers", ctx, pattern)
+        ret0, _ := ret[0].(int)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// CountUsers indicates an expected call of CountUsers.
+func (mr *MockRepositoryMockRecorder) CountUsers(ctx, pattern interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockRepository)(nil).CountUsers), ctx, pattern)
+}
+
+// DeleteExpiredPendingUsers mocks base method.
+func (m *MockRepository) DeleteExpiredPendingUsers(ctx context.Context, now time.Time) (int64, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "DeleteExpiredPendingUsers", ctx, now)
+        ret0, _ := ret[0].(int64)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// DeleteExpiredPendingUsers indicates an expected call of DeleteExpiredPendingUsers.
+func (mr *MockRepositoryMockRecorder) DeleteExpiredPendingUsers(ctx, now interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredPendingUsers", reflect.TypeOf((*MockRepository)(nil).DeleteExpiredPendingUsers), ctx, now)
+}
+
+// DeleteUser mocks base method.
+func (m *MockRepository) DeleteUser(ctx context.Context, id int) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "DeleteUser", ctx, id)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockRepositoryMockRecorder) DeleteUser(ctx, id interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockRepository)(nil).DeleteUser), ctx, id)
+}
+
+// GetPendingUserByVerificationToken mocks base method.
+func (m *MockRepository) GetPendingUserByVerificationToken(ctx context.Context, verificationTokenHash string, now time.Time) (*users.User, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetPendingUserByVerificationToken", ctx, verificationTokenHash, now)
+        ret0, _ := ret[0].(*users.User)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetPendingUserByVerificationToken indicates an expected call of GetPendingUserByVerificationToken.
+func (mr *MockRepositoryMockRecorder) GetPendingUserByVerificationToken(ctx, verificationTokenHash, now interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingUserByVerificationToken", reflect.TypeOf((*MockRepository)(nil).GetPendingUserByVerificationToken), ctx, verificationTokenHash, now)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockRepository) GetUserByEmail(ctx context.Context, email string) (*users.User, error) {
         m.ctrl.T.Helper()
@@ -65,6 +139,35 @@ func (mr *MockRepositoryMockRecorder) GetUserByID(ctx, id interface{}) *gomock.C
         return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockRepository)(nil).GetUserByID), ctx, id)
 }
 
+// GetUserPassphraseXpriv mocks base method.
+func (m *MockRepository) GetUserPassphraseXpriv(ctx context.Context, id int) (string, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "GetUserPassphraseXpriv", ctx, id)
+        ret0, _ := ret[0].(string)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// GetUserPassphraseXpriv indicates an expected call of GetUserPassphraseXpriv.
+func (mr *MockRepositoryMockRecorder) GetUserPassphraseXpriv(ctx, id interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPassphraseXpriv", reflect.TypeOf((*MockRepository)(nil).GetUserPassphraseXpriv), ctx, id)
+}
+
+// InsertPendingUser mocks base method.
+func (m *MockRepository) InsertPendingUser(ctx context.Context, user *users.User, verificationTokenHash string, verificationExpiresAt time.Time) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "InsertPendingUser", ctx, user, verificationTokenHash, verificationExpiresAt)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// InsertPendingUser indicates an expected call of InsertPendingUser.
+func (mr *MockRepositoryMockRecorder) InsertPendingUser(ctx, user, verificationTokenHash, verificationExpiresAt interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPendingUser", reflect.TypeOf((*MockRepository)(nil).InsertPendingUser), ctx, user, verificationTokenHash, verificationExpiresAt)
+}
+
 // InsertUser mocks base method.
 func (m *MockRepository) InsertUser(ctx context.Context, user *users.User) error {
         m.ctrl.T.Helper()
@@ -78,3 +181,117 @@ func (mr *MockRepositoryMockRecorder) InsertUser(ctx, user interface{}) *gomock.
         mr.mock.ctrl.T.Helper()
         return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUser", reflect.TypeOf((*MockRepository)(nil).InsertUser), ctx, user)
 }
+
+// IsAliasPending mocks base method.
+func (m *MockRepository) IsAliasPending(ctx context.Context, alias string) (bool, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "IsAliasPending", ctx, alias)
+        ret0, _ := ret[0].(bool)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// IsAliasPending indicates an expected call of IsAliasPending.
+func (mr *MockRepositoryMockRecorder) IsAliasPending(ctx, alias interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAliasPending", reflect.TypeOf((*MockRepository)(nil).IsAliasPending), ctx, alias)
+}
+
+// LockUser mocks base method.
+func (m *MockRepository) LockUser(ctx context.Context, id int, until time.Time) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "LockUser", ctx, id, until)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// LockUser indicates an expected call of LockUser.
+func (mr *MockRepositoryMockRecorder) LockUser(ctx, id, until interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUser", reflect.TypeOf((*MockRepository)(nil).LockUser), ctx, id, until)
+}
+
+// MarkXpubRegistered mocks base method.
+func (m *MockRepository) MarkXpubRegistered(ctx context.Context, id int) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "MarkXpubRegistered", ctx, id)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// MarkXpubRegistered indicates an expected call of MarkXpubRegistered.
+func (mr *MockRepositoryMockRecorder) MarkXpubRegistered(ctx, id interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkXpubRegistered", reflect.TypeOf((*MockRepository)(nil).MarkXpubRegistered), ctx, id)
+}
+
+// ResetUserXpriv mocks base method.
+func (m *MockRepository) ResetUserXpriv(ctx context.Context, id int, xpriv string) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "ResetUserXpriv", ctx, id, xpriv)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// ResetUserXpriv indicates an expected call of ResetUserXpriv.
+func (mr *MockRepositoryMockRecorder) ResetUserXpriv(ctx, id, xpriv interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetUserXpriv", reflect.TypeOf((*MockRepository)(nil).ResetUserXpriv), ctx, id, xpriv)
+}
+
+// SearchUsers mocks base method.
+func (m *MockRepository) SearchUsers(ctx context.Context, pattern string, limit, offset int) ([]*users.User, error) {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "SearchUsers", ctx, pattern, limit, offset)
+        ret0, _ := ret[0].([]*users.User)
+        ret1, _ := ret[1].(error)
+        return ret0, ret1
+}
+
+// SearchUsers indicates an expected call of SearchUsers.
+func (mr *MockRepositoryMockRecorder) SearchUsers(ctx, pattern, limit, offset interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockRepository)(nil).SearchUsers), ctx, pattern, limit, offset)
+}
+
+// UnlockUser mocks base method.
+func (m *MockRepository) UnlockUser(ctx context.Context, id int) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "UnlockUser", ctx, id)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockRepositoryMockRecorder) UnlockUser(ctx, id interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockRepository)(nil).UnlockUser), ctx, id)
+}
+
+// UpdateUserPassphraseXpriv mocks base method.
+func (m *MockRepository) UpdateUserPassphraseXpriv(ctx context.Context, id int, xpriv string) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "UpdateUserPassphraseXpriv", ctx, id, xpriv)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// UpdateUserPassphraseXpriv indicates an expected call of UpdateUserPassphraseXpriv.
+func (mr *MockRepositoryMockRecorder) UpdateUserPassphraseXpriv(ctx, id, xpriv interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassphraseXpriv", reflect.TypeOf((*MockRepository)(nil).UpdateUserPassphraseXpriv), ctx, id, xpriv)
+}
+
+// UpdateUserXpriv mocks base method.
+func (m *MockRepository) UpdateUserXpriv(ctx context.Context, id int, xpriv string) error {
+        m.ctrl.T.Helper()
+        ret := m.ctrl.Call(m, "UpdateUserXpriv", ctx, id, xpriv)
+        ret0, _ := ret[0].(error)
+        return ret0
+}
+
+// UpdateUserXpriv indicates an expected call of UpdateUserXpriv.
+func (mr *MockRepositoryMockRecorder) UpdateUserXpriv(ctx, id, xpriv interface{}) *gomock.Call {
+        mr.mock.ctrl.T.Helper()
+        return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserXpriv", reflect.TypeOf((*MockRepository)(nil).UpdateUserXpriv), ctx, id, xpriv)
+}
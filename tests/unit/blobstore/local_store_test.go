@@ -0,0 +1,49 @@
+package blobstore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/blobstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLocalStore tests if blobs are stored, read and removed.
+func TestLocalStore(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	sut := blobstore.NewLocalStore(t.TempDir())
+
+	// Act & Assert
+	require.NoError(t, sut.Put(ctx, "avatar.png", []byte("first")))
+	require.NoError(t, sut.Put(ctx, "avatar.png", []byte("second")))
+
+	data, err := sut.Get(ctx, "avatar.png")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("second"), data)
+
+	require.NoError(t, sut.Delete(ctx, "avatar.png"))
+	require.NoError(t, sut.Delete(ctx, "avatar.png"))
+
+	_, err = sut.Get(ctx, "avatar.png")
+	require.ErrorIs(t, err, blobstore.ErrNotFound)
+}
+
+// TestLocalStore_InvalidKey tests if keys which could escape the store directory are rejected.
+func TestLocalStore_InvalidKey(t *testing.T) {
+	ctx := context.Background()
+	sut := blobstore.NewLocalStore(t.TempDir())
+
+	for _, key := range []string{"", ".", "..", "../avatar.png", "dir/avatar.png", ".hidden"} {
+		t.Run(key, func(t *testing.T) {
+			// Act
+			err := sut.Put(ctx, key, []byte("data"))
+			_, getErr := sut.Get(ctx, key)
+
+			// Assert
+			require.ErrorIs(t, err, blobstore.ErrInvalidKey)
+			require.ErrorIs(t, getErr, blobstore.ErrInvalidKey)
+		})
+	}
+}
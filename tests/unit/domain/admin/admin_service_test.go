@@ -0,0 +1,213 @@
+package admin_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/admin"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/grants"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/lockout"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/paymails"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/sessions"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/tokens"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userID      = 1
+	userEmail   = "homer.simpson@example.com"
+	userPaymail = "homer@example.com"
+)
+
+type mocks struct {
+	users             *mock.MockRepository
+	paymails          *mock.MockPaymailsRepository
+	lockout           *mock.MockLockoutRepository
+	sessions          *mock.MockSessionsRepository
+	tokens            *mock.MockTokensRepository
+	adminWalletClient *mock.MockAdminWalletClient
+	clientFactory     *mock.MockWalletClientFactory
+	grants            *grants.Service
+}
+
+func newService(t *testing.T, ctrl *gomock.Controller) (*admin.Service, *mocks) {
+	testLogger := zerolog.Nop()
+	m := &mocks{
+		users:             mock.NewMockRepository(ctrl),
+		paymails:          mock.NewMockPaymailsRepository(ctrl),
+		lockout:           mock.NewMockLockoutRepository(ctrl),
+		sessions:          mock.NewMockSessionsRepository(ctrl),
+		tokens:            mock.NewMockTokensRepository(ctrl),
+		adminWalletClient: mock.NewMockAdminWalletClient(ctrl),
+		clientFactory:     mock.NewMockWalletClientFactory(ctrl),
+		grants:            grants.NewGrantsService(&testLogger),
+	}
+	clientFctrMq := m.clientFactory
+
+	uService := users.NewUserService(m.users, m.adminWalletClient, clientFctrMq, nil, nil, nil, nil, nil, &testLogger)
+	pService := paymails.NewPaymailsService(m.paymails, m.users, m.adminWalletClient, config.NewConfigService(m.adminWalletClient, &testLogger), &testLogger)
+	lService := lockout.NewLockoutService(m.lockout, &testLogger)
+	sealer, err := encryption.NewSealer("secret", nil)
+	require.NoError(t, err)
+	sService := sessions.NewSessionsService(m.sessions, clientFctrMq, sealer, &testLogger)
+	tkService := tokens.NewTokensService(m.tokens, uService, clientFctrMq, sealer, &testLogger)
+
+	return admin.NewAdminService(uService, pService, lService, sService, tkService, m.grants, m.adminWalletClient, &testLogger), m
+}
+
+func TestSearchUsers(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sut, m := newService(t, ctrl)
+
+	// Wildcards typed by operator are matched literally
+	m.users.EXPECT().
+		SearchUsers(gomock.Any(), `%50\%\_off%`, 20, 20).
+		Return([]*users.User{{ID: userID, Email: "50%_off@example.com", Xpriv: "encrypted"}}, nil)
+	m.users.EXPECT().CountUsers(gomock.Any(), `%50\%\_off%`).Return(21, nil)
+
+	// Act
+	result, err := sut.SearchUsers(" 50%_off ", 2, 0)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 21, result.Total)
+	assert.Equal(t, 2, result.Page)
+	assert.Equal(t, 20, result.PageSize)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, userID, result.Content[0].ID)
+}
+
+func TestGetUserDetails(t *testing.T) {
+	t.Run("Active user with xPub and locks", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut, m := newService(t, ctrl)
+
+		lockedUntil := time.Now().Add(24 * time.Hour)
+		signInLockedUntil := time.Now().Add(time.Hour)
+		m.users.EXPECT().
+			GetUserByID(gomock.Any(), userID).
+			Return(&users.User{ID: userID, Email: userEmail, Paymail: userPaymail, Status: users.StatusActive, LockedUntil: &lockedUntil}, nil)
+		m.paymails.EXPECT().
+			GetUserPaymails(gomock.Any(), userID).
+			Return([]*paymails.Paymail{{ID: 1, UserID: userID, Address: userPaymail, Primary: true}, {ID: 2, UserID: userID, Address: "max.power@example.com"}}, nil)
+		m.sessions.EXPECT().
+			GetActiveUserSessions(gomock.Any(), userID).
+			Return([]*sessions.Session{{ID: 1, UserID: userID}}, nil)
+		m.lockout.EXPECT().
+			GetLockedUntil(gomock.Any(), "email:"+userEmail).
+			Return(signInLockedUntil, nil)
+
+		xpub := mock.NewMockPubKey(ctrl)
+		xpub.EXPECT().GetID().Return("xpubid")
+		xpub.EXPECT().GetCurrentBalance().Return(uint64(1000))
+		m.adminWalletClient.EXPECT().GetPaymailXPub(userPaymail).Return(xpub, nil)
+
+		// Act
+		result, err := sut.GetUserDetails(userID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, userEmail, result.Email)
+		assert.Equal(t, []string{userPaymail, "max.power@example.com"}, result.Paymails)
+		assert.Equal(t, "xpubid", result.XpubID)
+		assert.Equal(t, uint64(1000), result.Balance)
+		assert.Equal(t, 1, result.ActiveSessions)
+		require.NotNil(t, result.LockedUntil)
+		assert.Equal(t, lockedUntil, *result.LockedUntil)
+		require.NotNil(t, result.SignInLockedUntil)
+		assert.Equal(t, signInLockedUntil, *result.SignInLockedUntil)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut, m := newService(t, ctrl)
+		m.users.EXPECT().GetUserByID(gomock.Any(), userID).Return(nil, nil)
+
+		// Act
+		result, err := sut.GetUserDetails(userID)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrUserNotFound)
+		assert.Nil(t, result)
+	})
+}
+
+func TestLockUser(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sut, m := newService(t, ctrl)
+
+	until := time.Now().Add(24 * time.Hour)
+	m.users.EXPECT().
+		GetUserByID(gomock.Any(), userID).
+		Return(&users.User{ID: userID, Email: userEmail, Paymail: userPaymail, Status: users.StatusActive}, nil)
+	// Lock is stored with the user, so failed sign-in attempts can't shorten or remove it
+	m.users.EXPECT().LockUser(gomock.Any(), userID, until).Return(nil)
+
+	// User who is already signed in is signed out of the session
+	sealer, err := encryption.NewSealer("secret", nil)
+	require.NoError(t, err)
+	sealedAccessKey, err := sealer.Seal(context.Background(), "session-key")
+	require.NoError(t, err)
+	userWalletClient := mock.NewMockUserWalletClient(ctrl)
+	m.sessions.EXPECT().
+		GetActiveUserSessions(gomock.Any(), userID).
+		Return([]*sessions.Session{{ID: 1, UserID: userID, AccessKeyID: "session-key-id", AccessKey: sealedAccessKey}}, nil)
+	m.clientFactory.EXPECT().CreateWithAccessKey("session-key").Return(userWalletClient, nil)
+	userWalletClient.EXPECT().RevokeAccessKey("session-key-id").Return(nil, nil)
+	m.sessions.EXPECT().MarkSessionRevoked(gomock.Any(), "session-key-id", gomock.Any()).Return(nil)
+	m.sessions.EXPECT().MarkUserSessionsRevoked(gomock.Any(), userID, "", gomock.Any()).Return(nil)
+	m.tokens.EXPECT().DeleteUserTokens(gomock.Any(), userID).Return([]*tokens.Token{}, nil)
+
+	grant, err := m.grants.CreateGrant(userID, "xpriv")
+	require.NoError(t, err)
+
+	// Act
+	err = sut.LockUser(userID, until)
+
+	// Assert
+	require.NoError(t, err)
+	_, err = m.grants.GetXpriv(grant.ID, userID)
+	require.ErrorIs(t, err, spverrors.ErrSigningGrantRequired)
+}
+
+func TestUnlockUser(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sut, m := newService(t, ctrl)
+
+	lockedUntil := time.Now().Add(24 * time.Hour)
+	m.users.EXPECT().
+		GetUserByID(gomock.Any(), userID).
+		Return(&users.User{ID: userID, Email: userEmail, Status: users.StatusActive, LockedUntil: &lockedUntil}, nil)
+	m.users.EXPECT().UnlockUser(gomock.Any(), userID).Return(nil)
+	m.lockout.EXPECT().Reset(gomock.Any(), "email:"+userEmail).Return(nil)
+
+	// Act
+	err := sut.UnlockUser(userID)
+
+	// Assert
+	require.NoError(t, err)
+}
@@ -0,0 +1,274 @@
+package audit_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const userID = 1
+
+func newService(ctrl *gomock.Controller, hashChain bool) (*audit.Service, *mock.MockAuditRepository) {
+	testLogger := zerolog.Nop()
+	viper.Set(config.EnvAuditHashChain, hashChain)
+	repoMq := mock.NewMockAuditRepository(ctrl)
+
+	return audit.NewAuditService(repoMq, &testLogger), repoMq
+}
+
+// chain returns entries hash-chained the same way as the repository does.
+func chain(entries ...*audit.Entry) []*audit.Entry {
+	prevHash := ""
+	for i, entry := range entries {
+		entry.ID = int64(i + 1)
+		entry.CreatedAt = time.Date(2024, 1, 1, 0, 0, i, 0, time.UTC)
+		entry.PrevHash = prevHash
+		entry.Hash = entry.ComputeHash(prevHash)
+		prevHash = entry.Hash
+	}
+	return entries
+}
+
+func TestRecord(t *testing.T) {
+	id := userID
+	actor := audit.Actor{UserID: &id, Name: "alice@example.com", IP: "127.0.0.1"}
+
+	tests := map[string]struct {
+		err             error
+		expectedOutcome string
+		expectedDetails string
+	}{
+		"Successful action": {
+			expectedOutcome: audit.OutcomeSuccess,
+		},
+		"Failed action stores error code": {
+			err:             spverrors.ErrInvalidCredentials,
+			expectedOutcome: audit.OutcomeFailure,
+			expectedDetails: spverrors.ErrInvalidCredentials.Code,
+		},
+		"Internal error message is not stored": {
+			err:             errors.New("pq: connection refused"),
+			expectedOutcome: audit.OutcomeFailure,
+			expectedDetails: "error-unknown",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sut, repoMq := newService(ctrl, true)
+
+			var recorded *audit.Entry
+			repoMq.EXPECT().
+				InsertEntry(gomock.Any(), gomock.Any(), true).
+				DoAndReturn(func(_ any, entry *audit.Entry, _ bool) error {
+					recorded = entry
+					return nil
+				})
+
+			// Act
+			sut.Record(actor, audit.ActionSignIn, "", tc.err)
+
+			// Assert
+			require.NotNil(t, recorded)
+			assert.Equal(t, &id, recorded.UserID)
+			assert.Equal(t, actor.Name, recorded.Actor)
+			assert.Equal(t, actor.IP, recorded.IP)
+			assert.Equal(t, audit.ActionSignIn, recorded.Action)
+			assert.Equal(t, tc.expectedOutcome, recorded.Outcome)
+			assert.Equal(t, tc.expectedDetails, recorded.Details)
+		})
+	}
+
+	t.Run("Hash chain can be disabled", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut, repoMq := newService(ctrl, false)
+		repoMq.EXPECT().InsertEntry(gomock.Any(), gomock.Any(), false).Return(nil)
+
+		// Act
+		sut.Record(actor, audit.ActionSignOut, "", nil)
+	})
+
+	t.Run("Long actor and target are truncated", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut, repoMq := newService(ctrl, true)
+		var recorded *audit.Entry
+		repoMq.EXPECT().
+			InsertEntry(gomock.Any(), gomock.Any(), true).
+			DoAndReturn(func(_ any, entry *audit.Entry, _ bool) error {
+				recorded = entry
+				return nil
+			})
+		longActor := audit.Actor{Name: strings.Repeat("ž", 400) + "@example.com", IP: "127.0.0.1"}
+
+		// Act
+		sut.Record(longActor, audit.ActionSignIn, strings.Repeat("a", 400)+"\xff", nil)
+
+		// Assert
+		require.NotNil(t, recorded)
+		assert.Equal(t, strings.Repeat("ž", 320), recorded.Actor)
+		assert.Equal(t, strings.Repeat("a", 320), recorded.Target)
+	})
+
+	t.Run("Insert error doesn't fail the action", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut, repoMq := newService(ctrl, true)
+		repoMq.EXPECT().InsertEntry(gomock.Any(), gomock.Any(), true).Return(errors.New("db error"))
+
+		// Act & Assert
+		assert.NotPanics(t, func() { sut.Record(actor, audit.ActionSignOut, "", nil) })
+	})
+}
+
+func TestGetUserActivity(t *testing.T) {
+	t.Run("Page is converted to limit and offset", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut, repoMq := newService(ctrl, true)
+		repoMq.EXPECT().GetUserEntries(gomock.Any(), userID, 20, 40).Return([]*audit.Entry{}, nil)
+
+		// Act
+		_, err := sut.GetUserActivity(userID, 3, 20)
+
+		// Assert
+		require.NoError(t, err)
+	})
+
+	t.Run("Invalid paging falls back to defaults", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut, repoMq := newService(ctrl, true)
+		repoMq.EXPECT().GetUserEntries(gomock.Any(), userID, 50, 0).Return([]*audit.Entry{}, nil)
+
+		// Act
+		_, err := sut.GetUserActivity(userID, 0, 10000)
+
+		// Assert
+		require.NoError(t, err)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut, repoMq := newService(ctrl, true)
+		repoMq.EXPECT().GetUserEntries(gomock.Any(), userID, gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		// Act
+		_, err := sut.GetUserActivity(userID, 1, 10)
+
+		// Assert
+		assert.ErrorIs(t, err, spverrors.ErrGetAuditLog)
+	})
+}
+
+func TestVerifyChain(t *testing.T) {
+	newEntries := func() []*audit.Entry {
+		return chain(
+			&audit.Entry{Actor: "alice@example.com", Action: audit.ActionSignIn, Outcome: audit.OutcomeSuccess},
+			&audit.Entry{Actor: "alice@example.com", Action: audit.ActionSendTransaction, Target: "bob@example.com", Outcome: audit.OutcomeSuccess},
+			&audit.Entry{Actor: "alice@example.com", Action: audit.ActionSignOut, Outcome: audit.OutcomeSuccess},
+		)
+	}
+
+	tests := map[string]struct {
+		entries         func() []*audit.Entry
+		expectedValid   bool
+		expectedBroken  *int64
+		expectedChecked int
+	}{
+		"Valid chain": {
+			entries:         newEntries,
+			expectedValid:   true,
+			expectedChecked: 3,
+		},
+		"Changed entry": {
+			entries: func() []*audit.Entry {
+				entries := newEntries()
+				entries[1].Target = "mallory@example.com"
+				return entries
+			},
+			expectedBroken:  toPtr(int64(2)),
+			expectedChecked: 2,
+		},
+		"Removed entry": {
+			entries: func() []*audit.Entry {
+				entries := newEntries()
+				return append(entries[:1], entries[2:]...)
+			},
+			expectedBroken:  toPtr(int64(3)),
+			expectedChecked: 2,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sut, repoMq := newService(ctrl, true)
+			repoMq.EXPECT().GetEntriesAfter(gomock.Any(), int64(0), gomock.Any()).Return(tc.entries(), nil)
+
+			// Act
+			result, err := sut.VerifyChain()
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedValid, result.Valid)
+			assert.Equal(t, tc.expectedBroken, result.BrokenAtID)
+			assert.Equal(t, tc.expectedChecked, result.Checked)
+		})
+	}
+
+	t.Run("Entries recorded without hash chain are skipped", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		entries := newEntries()
+		unchained := &audit.Entry{ID: 4, Action: audit.ActionSignIn}
+		sut, repoMq := newService(ctrl, true)
+		repoMq.EXPECT().GetEntriesAfter(gomock.Any(), int64(0), gomock.Any()).Return(append(entries, unchained), nil)
+
+		// Act
+		result, err := sut.VerifyChain()
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, result.Valid)
+		assert.Equal(t, 1, result.Unchained)
+	})
+}
+
+func toPtr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,109 @@
+package exports_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/blobstore"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/exports"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/paymails"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/profiles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/bitcoin-sv/spv-wallet/models"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userID      = 1
+	accessKey   = "accesskey"
+	userPaymail = "homer@example.com"
+)
+
+var pngImage = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestExportZip(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usersRepoMq := mock.NewMockRepository(ctrl)
+	usersRepoMq.EXPECT().
+		GetUserByID(gomock.Any(), userID).
+		Return(&users.User{ID: userID, Email: "homer.simpson@example.com", Xpriv: "encrypted", Paymail: userPaymail, Status: users.StatusActive}, nil)
+
+	paymailsRepoMq := mock.NewMockPaymailsRepository(ctrl)
+	paymailsRepoMq.EXPECT().
+		GetUserPaymails(gomock.Any(), userID).
+		Return([]*paymails.Paymail{{ID: 1, UserID: userID, Address: userPaymail, Primary: true}}, nil)
+
+	store := blobstore.NewLocalStore(t.TempDir())
+	require.NoError(t, store.Put(context.Background(), "avatar.png", pngImage))
+	profilesRepoMq := mock.NewMockProfilesRepository(ctrl)
+	profilesRepoMq.EXPECT().
+		GetProfile(gomock.Any(), userID).
+		Return(&profiles.Profile{UserID: userID, PublicName: "Homer", AvatarKey: "avatar.png"}, nil)
+
+	transaction := mock.NewMockTransaction(ctrl)
+	mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
+	mockUserWalletClient.EXPECT().
+		GetContacts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&models.SearchContactsResponse{Content: []*models.Contact{{Paymail: "marge@example.com"}}}, nil)
+	mockUserWalletClient.EXPECT().
+		GetTransactions(gomock.Any(), userPaymail).
+		Return([]users.Transaction{transaction}, nil)
+
+	clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+	clientFctrMq.EXPECT().
+		CreateWithAccessKey(accessKey).
+		Return(mockUserWalletClient, nil)
+
+	adminWalletClient := mock.NewMockAdminWalletClient(ctrl)
+	uService := users.NewUserService(usersRepoMq, adminWalletClient, clientFctrMq, nil, nil, nil, nil, nil, &testLogger)
+	pService := paymails.NewPaymailsService(paymailsRepoMq, usersRepoMq, adminWalletClient, config.NewConfigService(adminWalletClient, &testLogger), &testLogger)
+	prService := profiles.NewProfilesService(profilesRepoMq, pService, store, &testLogger)
+
+	sut := exports.NewExportsService(uService, pService, prService, clientFctrMq, &testLogger)
+
+	// Act
+	result, err := sut.ExportZip(userID, accessKey)
+
+	// Assert
+	require.NoError(t, err)
+
+	archive, err := zip.NewReader(bytes.NewReader(result), int64(len(result)))
+	require.NoError(t, err)
+	files := readFiles(t, archive)
+	require.Len(t, files, 2)
+	assert.Equal(t, pngImage, files["avatar.png"])
+
+	var export map[string]any
+	require.NoError(t, json.Unmarshal(files["export.json"], &export))
+	assert.Equal(t, "homer.simpson@example.com", export["user"].(map[string]any)["email"])
+	assert.NotContains(t, string(files["export.json"]), "encrypted")
+	assert.Len(t, export["paymails"], 1)
+	assert.Len(t, export["contacts"], 1)
+	assert.Len(t, export["transactions"], 1)
+}
+
+func readFiles(t *testing.T, archive *zip.Reader) map[string][]byte {
+	files := make(map[string][]byte)
+	for _, f := range archive.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		files[f.Name] = data
+	}
+	return files
+}
@@ -0,0 +1,93 @@
+package grants_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/grants"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testXpriv = "xprivtest"
+
+func TestSigningGrant(t *testing.T) {
+	testLogger := zerolog.Nop()
+
+	t.Run("Grant returns xpriv to its owner", func(t *testing.T) {
+		// Arrange
+		viper.Set(config.EnvHTTPServerSessionSigningGrantTTL, time.Minute)
+		sut := grants.NewGrantsService(&testLogger)
+
+		// Act
+		grant, err := sut.CreateGrant(1, testXpriv)
+		require.NoError(t, err)
+		xpriv, err := sut.GetXpriv(grant.ID, 1)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, testXpriv, xpriv)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), grant.ExpiresAt, time.Second)
+	})
+
+	t.Run("Grant cannot be used by other user", func(t *testing.T) {
+		// Arrange
+		viper.Set(config.EnvHTTPServerSessionSigningGrantTTL, time.Minute)
+		sut := grants.NewGrantsService(&testLogger)
+		grant, err := sut.CreateGrant(1, testXpriv)
+		require.NoError(t, err)
+
+		// Act
+		xpriv, err := sut.GetXpriv(grant.ID, 2)
+
+		// Assert
+		assert.ErrorIs(t, err, spverrors.ErrSigningGrantRequired)
+		assert.Empty(t, xpriv)
+	})
+
+	t.Run("Expired grant cannot be used", func(t *testing.T) {
+		// Arrange
+		viper.Set(config.EnvHTTPServerSessionSigningGrantTTL, -time.Second)
+		sut := grants.NewGrantsService(&testLogger)
+		grant, err := sut.CreateGrant(1, testXpriv)
+		require.NoError(t, err)
+
+		// Act
+		_, err = sut.GetXpriv(grant.ID, 1)
+
+		// Assert
+		assert.ErrorIs(t, err, spverrors.ErrSigningGrantRequired)
+	})
+
+	t.Run("Revoked grants cannot be used", func(t *testing.T) {
+		// Arrange
+		viper.Set(config.EnvHTTPServerSessionSigningGrantTTL, time.Minute)
+		sut := grants.NewGrantsService(&testLogger)
+		first, err := sut.CreateGrant(1, testXpriv)
+		require.NoError(t, err)
+		second, err := sut.CreateGrant(1, testXpriv)
+		require.NoError(t, err)
+		third, err := sut.CreateGrant(1, testXpriv)
+		require.NoError(t, err)
+		other, err := sut.CreateGrant(2, testXpriv)
+		require.NoError(t, err)
+
+		// Act
+		sut.RevokeGrant(first.ID)
+		_, firstErr := sut.GetXpriv(first.ID, 1)
+		_, secondErr := sut.GetXpriv(second.ID, 1)
+		sut.RevokeUserGrants(1)
+		_, thirdErr := sut.GetXpriv(third.ID, 1)
+		_, otherErr := sut.GetXpriv(other.ID, 2)
+
+		// Assert
+		assert.ErrorIs(t, firstErr, spverrors.ErrSigningGrantRequired)
+		assert.NoError(t, secondErr)
+		assert.ErrorIs(t, thirdErr, spverrors.ErrSigningGrantRequired)
+		assert.NoError(t, otherErr)
+	})
+}
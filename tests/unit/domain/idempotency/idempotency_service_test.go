@@ -0,0 +1,187 @@
+package idempotency_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/idempotency"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBegin(t *testing.T) {
+	testLogger := zerolog.Nop()
+	userID := 1
+	key := "key"
+	fingerprint := "fingerprint"
+	previewToken := "preview-token"
+
+	tests := map[string]struct {
+		reserved    bool
+		existing    *idempotency.Key
+		expectedKey *idempotency.Key
+		expectedErr error
+	}{
+		"New key is reserved": {
+			reserved: true,
+		},
+		"Same request returns sent transaction": {
+			existing:    &idempotency.Key{Fingerprint: fingerprint, DraftID: "draft-id", TransactionID: "tx-id"},
+			expectedKey: &idempotency.Key{Fingerprint: fingerprint, DraftID: "draft-id", TransactionID: "tx-id"},
+		},
+		"Same request in progress": {
+			existing:    &idempotency.Key{Fingerprint: fingerprint},
+			expectedErr: spverrors.ErrIdempotencyKeyInProgress,
+		},
+		"Different request": {
+			existing:    &idempotency.Key{Fingerprint: "other", DraftID: "draft-id", TransactionID: "tx-id"},
+			expectedErr: spverrors.ErrIdempotencyKeyReused,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			viper.Set(config.EnvTransactionsIdempotencyKeyTTL, time.Hour)
+			viper.Set(config.EnvTransactionsIdempotencyKeyLease, time.Minute)
+
+			repoMq := mock.NewMockIdempotencyRepository(ctrl)
+			repoMq.EXPECT().
+				ReserveKey(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ any, k *idempotency.Key) (bool, error) {
+					assert.Equal(t, userID, k.UserID)
+					assert.Equal(t, key, k.Key)
+					assert.Equal(t, fingerprint, k.Fingerprint)
+					assert.Equal(t, previewToken, k.PreviewToken)
+					assert.Equal(t, time.Hour, k.ExpiresAt.Sub(k.CreatedAt))
+					assert.Equal(t, time.Minute, k.LockedUntil.Sub(k.CreatedAt))
+					return tc.reserved, nil
+				})
+			if !tc.reserved {
+				repoMq.EXPECT().
+					GetKey(gomock.Any(), userID, key).
+					Return(tc.existing, nil)
+			}
+
+			sut := idempotency.NewIdempotencyService(repoMq, &testLogger)
+
+			// Act
+			result, err := sut.Begin(userID, key, fingerprint, previewToken)
+
+			// Assert
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedKey, result)
+		})
+	}
+}
+
+func TestBegin_KeyReleasedInTheMeantime(t *testing.T) {
+	testLogger := zerolog.Nop()
+
+	t.Run("Key is reserved again", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockIdempotencyRepository(ctrl)
+		gomock.InOrder(
+			repoMq.EXPECT().
+				ReserveKey(gomock.Any(), gomock.Any()).
+				Return(false, nil),
+			repoMq.EXPECT().
+				GetKey(gomock.Any(), 1, "key").
+				Return(nil, nil),
+			repoMq.EXPECT().
+				ReserveKey(gomock.Any(), gomock.Any()).
+				Return(true, nil),
+		)
+
+		sut := idempotency.NewIdempotencyService(repoMq, &testLogger)
+
+		// Act
+		result, err := sut.Begin(1, "key", "fingerprint", "preview-token")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Key keeps being released", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockIdempotencyRepository(ctrl)
+		repoMq.EXPECT().
+			ReserveKey(gomock.Any(), gomock.Any()).
+			Return(false, nil).
+			Times(3)
+		repoMq.EXPECT().
+			GetKey(gomock.Any(), 1, "key").
+			Return(nil, nil).
+			Times(3)
+
+		sut := idempotency.NewIdempotencyService(repoMq, &testLogger)
+
+		// Act
+		result, err := sut.Begin(1, "key", "fingerprint", "preview-token")
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrIdempotencyKeyInProgress)
+		assert.Nil(t, result)
+	})
+}
+
+func TestBegin_ReturnsError(t *testing.T) {
+	testLogger := zerolog.Nop()
+
+	t.Run("Key is too long", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut := idempotency.NewIdempotencyService(mock.NewMockIdempotencyRepository(ctrl), &testLogger)
+
+		// Act
+		result, err := sut.Begin(1, strings.Repeat("k", idempotency.MaxKeyLength+1), "fingerprint", "preview-token")
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrInvalidIdempotencyKey)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Key can't be reserved", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockIdempotencyRepository(ctrl)
+		repoMq.EXPECT().
+			ReserveKey(gomock.Any(), gomock.Any()).
+			Return(false, errors.New("db is down"))
+
+		sut := idempotency.NewIdempotencyService(repoMq, &testLogger)
+
+		// Act
+		result, err := sut.Begin(1, "key", "fingerprint", "preview-token")
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrIdempotencyKey)
+		assert.Nil(t, result)
+	})
+}
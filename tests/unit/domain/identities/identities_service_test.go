@@ -0,0 +1,312 @@
+package identities_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/identities"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/oidc"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userID       = 1
+	sealerSecret = "test-sealer-secret"
+)
+
+type mocks struct {
+	repo          *mock.MockIdentitiesRepository
+	users         *mock.MockRepository
+	clientFactory *mock.MockWalletClientFactory
+	walletClient  *mock.MockUserWalletClient
+}
+
+func newService(t *testing.T, ctrl *gomock.Controller, provider oidc.Provider) (*identities.Service, *mocks) {
+	testLogger := zerolog.Nop()
+
+	m := &mocks{
+		repo:          mock.NewMockIdentitiesRepository(ctrl),
+		users:         mock.NewMockRepository(ctrl),
+		clientFactory: mock.NewMockWalletClientFactory(ctrl),
+		walletClient:  mock.NewMockUserWalletClient(ctrl),
+	}
+	uService := users.NewUserService(m.users, nil, m.clientFactory, nil, nil, nil, nil, nil, &testLogger)
+
+	return identities.NewIdentitiesService(m.repo, provider, uService, m.clientFactory, newSealer(t, sealerSecret), &testLogger), m
+}
+
+func newSealer(t *testing.T, secret string) *encryption.Sealer {
+	sealer, err := encryption.NewSealer(secret, nil)
+	require.NoError(t, err)
+	return sealer
+}
+
+func newAccessKey(ctrl *gomock.Controller, id, key string) *mock.MockAccKey {
+	accessKey := mock.NewMockAccKey(ctrl)
+	accessKey.EXPECT().GetAccessKeyID().Return(id).AnyTimes()
+	accessKey.EXPECT().GetAccessKey().Return(key).AnyTimes()
+	return accessKey
+}
+
+func TestStartSignIn(t *testing.T) {
+	t.Run("Disabled single sign-on", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, _ := newService(t, ctrl, nil)
+
+		// Act
+		result, err := sut.StartSignIn()
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrSSODisabled)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Sign-in with stub provider", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		provider := oidc.NewStubProvider("http://localhost/callback", oidc.Claims{Issuer: oidc.StubIssuer, Subject: "subject"})
+		sut, _ := newService(t, ctrl, provider)
+
+		// Act
+		result, err := sut.StartSignIn()
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.State)
+		assert.NotEmpty(t, result.Nonce)
+		assert.NotEqual(t, result.State, result.Nonce)
+		assert.Contains(t, result.URL, "state="+result.State)
+	})
+}
+
+func TestGetIdentity(t *testing.T) {
+	claims := &oidc.Claims{Issuer: oidc.StubIssuer, Subject: "subject"}
+
+	t.Run("Identity not linked", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(t, ctrl, nil)
+		m.repo.EXPECT().GetIdentity(gomock.Any(), claims.Issuer, claims.Subject).Return(nil, nil)
+
+		// Act
+		result, err := sut.GetIdentity(claims)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrIdentityNotLinked)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Linked identity", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(t, ctrl, nil)
+		identity := &identities.Identity{ID: 2, UserID: userID, Issuer: claims.Issuer, Subject: claims.Subject}
+		m.repo.EXPECT().GetIdentity(gomock.Any(), claims.Issuer, claims.Subject).Return(identity, nil)
+
+		// Act
+		result, err := sut.GetIdentity(claims)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, identity, result)
+	})
+}
+
+func TestSignIn(t *testing.T) {
+	t.Run("Revoked identity access key requires unlock", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(t, ctrl, nil)
+
+		encryptedAccessKey, err := newSealer(t, sealerSecret).Seal(context.Background(), "identity-access-key")
+		require.NoError(t, err)
+		identity := &identities.Identity{ID: 2, UserID: userID, AccessKeyID: "identity-key-id", AccessKey: encryptedAccessKey}
+
+		m.users.EXPECT().GetUserByID(gomock.Any(), userID).Return(&users.User{ID: userID}, nil)
+		m.clientFactory.EXPECT().CreateWithAccessKey("identity-access-key").Return(m.walletClient, nil)
+		m.walletClient.EXPECT().CreateAccessKey().Return(nil, errors.New("access key revoked"))
+
+		// Act
+		result, err := sut.SignIn(identity)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrIdentityUnlockRequired)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Account locked by operator", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(t, ctrl, nil)
+
+		encryptedAccessKey, err := newSealer(t, sealerSecret).Seal(context.Background(), "identity-access-key")
+		require.NoError(t, err)
+		identity := &identities.Identity{ID: 2, UserID: userID, AccessKeyID: "identity-key-id", AccessKey: encryptedAccessKey}
+
+		lockedUntil := time.Now().Add(time.Hour)
+		m.users.EXPECT().GetUserByID(gomock.Any(), userID).Return(&users.User{ID: userID, LockedUntil: &lockedUntil}, nil)
+
+		// Act
+		result, err := sut.SignIn(identity)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrAccountSuspended)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Access key not sealed by the sealer requires unlock", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(t, ctrl, nil)
+
+		encryptedAccessKey, err := encryption.Encrypt(sealerSecret, "identity-access-key")
+		require.NoError(t, err)
+		identity := &identities.Identity{ID: 2, UserID: userID, AccessKeyID: "identity-key-id", AccessKey: encryptedAccessKey}
+
+		m.users.EXPECT().GetUserByID(gomock.Any(), userID).Return(&users.User{ID: userID}, nil)
+
+		// Act
+		result, err := sut.SignIn(identity)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrIdentityUnlockRequired)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Access key encrypted with another secret requires unlock", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(t, ctrl, nil)
+
+		encryptedAccessKey, err := newSealer(t, "another-secret").Seal(context.Background(), "identity-access-key")
+		require.NoError(t, err)
+		identity := &identities.Identity{ID: 2, UserID: userID, AccessKeyID: "identity-key-id", AccessKey: encryptedAccessKey}
+
+		m.users.EXPECT().GetUserByID(gomock.Any(), userID).Return(&users.User{ID: userID}, nil)
+
+		// Act
+		result, err := sut.SignIn(identity)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrIdentityUnlockRequired)
+		assert.Nil(t, result)
+	})
+}
+
+func TestLink(t *testing.T) {
+	claims := &oidc.Claims{Issuer: oidc.StubIssuer, Subject: "subject", Email: "alice@example.com"}
+
+	t.Run("Link new identity", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(t, ctrl, nil)
+
+		var inserted *identities.Identity
+		m.repo.EXPECT().GetIdentity(gomock.Any(), claims.Issuer, claims.Subject).Return(nil, nil)
+		m.clientFactory.EXPECT().CreateWithXpriv("xpriv").Return(m.walletClient, nil)
+		m.walletClient.EXPECT().CreateAccessKey().Return(newAccessKey(ctrl, "identity-key-id", "identity-access-key"), nil)
+		m.repo.EXPECT().InsertIdentity(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, identity *identities.Identity) error {
+				inserted = identity
+				return nil
+			})
+
+		// Act
+		result, err := sut.Link(userID, claims, "xpriv")
+
+		// Assert
+		require.NoError(t, err)
+		require.NotNil(t, inserted)
+		assert.Equal(t, inserted, result)
+		assert.Equal(t, userID, result.UserID)
+		assert.Equal(t, claims.Subject, result.Subject)
+		assert.Equal(t, "identity-key-id", result.AccessKeyID)
+
+		// access key is stored sealed with the sealer secret
+		assert.NotEqual(t, "identity-access-key", result.AccessKey)
+		decrypted, err := newSealer(t, sealerSecret).Open(context.Background(), result.AccessKey)
+		require.NoError(t, err)
+		assert.Equal(t, "identity-access-key", decrypted)
+	})
+
+	t.Run("Relink replaces access key of the identity", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(t, ctrl, nil)
+
+		identity := &identities.Identity{ID: 2, UserID: userID, Issuer: claims.Issuer, Subject: claims.Subject, AccessKeyID: "previous-key-id"}
+		m.repo.EXPECT().GetIdentity(gomock.Any(), claims.Issuer, claims.Subject).Return(identity, nil)
+		m.clientFactory.EXPECT().CreateWithXpriv("xpriv").Return(m.walletClient, nil)
+		m.walletClient.EXPECT().CreateAccessKey().Return(newAccessKey(ctrl, "identity-key-id", "identity-access-key"), nil)
+		m.repo.EXPECT().UpdateIdentityAccessKey(gomock.Any(), identity.ID, "identity-key-id", gomock.Any()).Return(nil)
+		m.walletClient.EXPECT().RevokeAccessKey("previous-key-id").Return(nil, errors.New("already revoked"))
+
+		// Act
+		result, err := sut.Link(userID, claims, "xpriv")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "identity-key-id", result.AccessKeyID)
+	})
+
+	t.Run("Identity linked to another user", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(t, ctrl, nil)
+
+		identity := &identities.Identity{ID: 2, UserID: userID + 1, Issuer: claims.Issuer, Subject: claims.Subject}
+		m.repo.EXPECT().GetIdentity(gomock.Any(), claims.Issuer, claims.Subject).Return(identity, nil)
+
+		// Act
+		result, err := sut.Link(userID, claims, "xpriv")
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrIdentityLinkedToAnotherUser)
+		assert.Nil(t, result)
+	})
+}
+
+func TestUnlink(t *testing.T) {
+	t.Run("Identity not found", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(t, ctrl, nil)
+		m.repo.EXPECT().DeleteIdentity(gomock.Any(), userID, 2).Return(nil, nil)
+
+		// Act
+		err := sut.Unlink(userID, 2)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrIdentityNotFound)
+	})
+
+	t.Run("Unlink revokes access key of the identity", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(t, ctrl, nil)
+
+		encryptedAccessKey, err := newSealer(t, sealerSecret).Seal(context.Background(), "identity-access-key")
+		require.NoError(t, err)
+		identity := &identities.Identity{ID: 2, UserID: userID, AccessKeyID: "identity-key-id", AccessKey: encryptedAccessKey}
+
+		m.repo.EXPECT().DeleteIdentity(gomock.Any(), userID, identity.ID).Return(identity, nil)
+		m.clientFactory.EXPECT().CreateWithAccessKey("identity-access-key").Return(m.walletClient, nil)
+		m.walletClient.EXPECT().RevokeAccessKey("identity-key-id").Return(nil, nil)
+
+		// Act
+		err = sut.Unlink(userID, identity.ID)
+
+		// Assert
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,204 @@
+package lockout_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/lockout"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	email        = "Homer.Simpson@example.com"
+	emailKey     = "email:homer.simpson@example.com"
+	ip           = "127.0.0.1"
+	ipKey        = "ip:127.0.0.1"
+	lockDuration = 15 * time.Minute
+)
+
+func TestCheckSignIn(t *testing.T) {
+	testLogger := zerolog.Nop()
+	cases := []struct {
+		name             string
+		emailLockedUntil time.Time
+		ipLockedUntil    time.Time
+		expectedErr      error
+	}{
+		{
+			name:             "Not locked",
+			emailLockedUntil: time.Now().Add(-time.Minute),
+		},
+		{
+			name:             "Email locked",
+			emailLockedUntil: time.Now().Add(time.Minute),
+			expectedErr:      spverrors.ErrAccountLocked,
+		},
+		{
+			name:          "IP locked",
+			ipLockedUntil: time.Now().Add(time.Minute),
+			expectedErr:   spverrors.ErrTooManySignInAttempts,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockLockoutRepository(ctrl)
+			repoMq.EXPECT().GetLockedUntil(gomock.Any(), emailKey).Return(tc.emailLockedUntil, nil)
+			repoMq.EXPECT().GetLockedUntil(gomock.Any(), ipKey).Return(tc.ipLockedUntil, nil).MaxTimes(1)
+
+			sut := newLockoutService(repoMq, &testLogger)
+
+			// Act
+			retryAfter, err := sut.CheckSignIn(email, ip)
+
+			// Assert
+			if tc.expectedErr == nil {
+				require.NoError(t, err)
+				assert.Zero(t, retryAfter)
+			} else {
+				require.ErrorIs(t, err, tc.expectedErr)
+				assert.Positive(t, retryAfter)
+			}
+		})
+	}
+}
+
+func TestRecordFailure(t *testing.T) {
+	testLogger := zerolog.Nop()
+	cases := []struct {
+		name          string
+		failures      int
+		expectedDelay time.Duration
+	}{
+		{
+			name:     "Attempts below backoff are not delayed",
+			failures: 3,
+		},
+		{
+			name:          "First delayed attempt",
+			failures:      4,
+			expectedDelay: time.Second,
+		},
+		{
+			name:          "Delay doubles with each failure",
+			failures:      6,
+			expectedDelay: 4 * time.Second,
+		},
+		{
+			name:          "Account locked after max attempts",
+			failures:      10,
+			expectedDelay: lockDuration,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockLockoutRepository(ctrl)
+			repoMq.EXPECT().RecordFailure(gomock.Any(), emailKey, gomock.Any(), gomock.Any()).Return(tc.failures, nil)
+			repoMq.EXPECT().RecordFailure(gomock.Any(), ipKey, gomock.Any(), gomock.Any()).Return(1, nil)
+
+			var lockedUntil time.Time
+			if tc.expectedDelay > 0 {
+				repoMq.EXPECT().
+					Lock(gomock.Any(), emailKey, gomock.Any()).
+					DoAndReturn(func(_ any, _ string, until time.Time) error {
+						lockedUntil = until
+						return nil
+					})
+			}
+
+			sut := newLockoutService(repoMq, &testLogger)
+
+			// Act
+			sut.RecordFailure(email, ip)
+
+			// Assert
+			if tc.expectedDelay > 0 {
+				assert.WithinDuration(t, time.Now().Add(tc.expectedDelay), lockedUntil, time.Second)
+			}
+		})
+	}
+}
+
+func TestUnlock(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockLockoutRepository(ctrl)
+	repoMq.EXPECT().Reset(gomock.Any(), emailKey).Return(nil)
+
+	sut := newLockoutService(repoMq, &testLogger)
+
+	// Act
+	err := sut.Unlock(email)
+
+	// Assert
+	require.NoError(t, err)
+}
+
+func TestLockedUntil(t *testing.T) {
+	testLogger := zerolog.Nop()
+
+	t.Run("Locked account", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		lockedUntil := time.Now().Add(time.Hour)
+		repoMq := mock.NewMockLockoutRepository(ctrl)
+		repoMq.EXPECT().GetLockedUntil(gomock.Any(), emailKey).Return(lockedUntil, nil)
+
+		sut := newLockoutService(repoMq, &testLogger)
+
+		// Act
+		result, err := sut.LockedUntil(email)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, lockedUntil, result)
+	})
+
+	t.Run("Expired lock", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockLockoutRepository(ctrl)
+		repoMq.EXPECT().GetLockedUntil(gomock.Any(), emailKey).Return(time.Now().Add(-time.Minute), nil)
+
+		sut := newLockoutService(repoMq, &testLogger)
+
+		// Act
+		result, err := sut.LockedUntil(email)
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, result.IsZero())
+	})
+}
+
+func newLockoutService(repo lockout.Repository, log *zerolog.Logger) *lockout.Service {
+	viper.Set(config.EnvSignInLockoutBackoffAfter, 3)
+	viper.Set(config.EnvSignInLockoutBackoffBase, time.Second)
+	viper.Set(config.EnvSignInLockoutMaxAttempts, 10)
+	viper.Set(config.EnvSignInLockoutIPMaxAttempts, 100)
+	viper.Set(config.EnvSignInLockoutDuration, lockDuration)
+	return lockout.NewLockoutService(repo, log)
+}
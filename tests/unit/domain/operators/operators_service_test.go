@@ -0,0 +1,200 @@
+package operators_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/operators"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	email      = "ned.flanders@example.com"
+	password   = "okilydokily"
+	ip         = "127.0.0.1"
+	sessionTTL = 8 * time.Hour
+)
+
+var roles = []*operators.Role{
+	{Name: operators.RoleAdmin},
+	{Name: operators.RoleAuditor},
+	{Name: operators.RoleSupport},
+}
+
+func newService(repo operators.Repository) *operators.Service {
+	testLogger := zerolog.Nop()
+	viper.Set(config.EnvAdminSessionTTL, sessionTTL)
+	return operators.NewOperatorsService(repo, &testLogger)
+}
+
+func TestCreateOperator(t *testing.T) {
+	t.Run("Operator created with hashed password", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockOperatorsRepository(ctrl)
+		repoMq.EXPECT().GetRoles(gomock.Any()).Return(roles, nil)
+		repoMq.EXPECT().GetOperatorByEmail(gomock.Any(), email).Return(nil, nil)
+		repoMq.EXPECT().
+			InsertOperator(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, operator *operators.Operator) error {
+				valid, err := encryption.VerifyPassword(password, operator.PasswordHash)
+				require.NoError(t, err)
+				assert.True(t, valid)
+				operator.ID = 1
+				return nil
+			})
+
+		sut := newService(repoMq)
+
+		// Act
+		result, err := sut.CreateOperator(" Ned.Flanders@example.com ", password, operators.RoleSupport)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.ID)
+		assert.Equal(t, email, result.Email)
+		assert.Equal(t, operators.RoleSupport, result.Role)
+	})
+
+	t.Run("Role not defined", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockOperatorsRepository(ctrl)
+		repoMq.EXPECT().GetRoles(gomock.Any()).Return(roles, nil)
+
+		sut := newService(repoMq)
+
+		// Act
+		result, err := sut.CreateOperator(email, password, "superuser")
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrInvalidOperatorRole)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Operator already exists", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockOperatorsRepository(ctrl)
+		repoMq.EXPECT().GetRoles(gomock.Any()).Return(roles, nil)
+		repoMq.EXPECT().GetOperatorByEmail(gomock.Any(), email).Return(&operators.Operator{ID: 1, Email: email}, nil)
+
+		sut := newService(repoMq)
+
+		// Act
+		result, err := sut.CreateOperator(email, password, operators.RoleAdmin)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrOperatorAlreadyExists)
+		assert.Nil(t, result)
+	})
+}
+
+func TestSignIn(t *testing.T) {
+	passwordHash, err := encryption.HashPassword(password)
+	require.NoError(t, err)
+
+	t.Run("Session created, only token hash stored", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var storedHash string
+		repoMq := mock.NewMockOperatorsRepository(ctrl)
+		repoMq.EXPECT().
+			GetOperatorByEmail(gomock.Any(), email).
+			Return(&operators.Operator{ID: 1, Email: email, PasswordHash: passwordHash, Role: operators.RoleAdmin}, nil)
+		repoMq.EXPECT().
+			InsertSession(gomock.Any(), gomock.Any(), 1, ip, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, tokenHash string, _ int, _ string, createdAt, expiresAt time.Time) error {
+				storedHash = tokenHash
+				assert.Equal(t, sessionTTL, expiresAt.Sub(createdAt))
+				return nil
+			})
+
+		sut := newService(repoMq)
+
+		// Act
+		operator, session, err := sut.SignIn(email, password, ip)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, operator.ID)
+		assert.NotEmpty(t, session.Token)
+		assert.NotEqual(t, session.Token, storedHash)
+	})
+
+	t.Run("Wrong password", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockOperatorsRepository(ctrl)
+		repoMq.EXPECT().
+			GetOperatorByEmail(gomock.Any(), email).
+			Return(&operators.Operator{ID: 1, Email: email, PasswordHash: passwordHash, Role: operators.RoleAdmin}, nil)
+
+		sut := newService(repoMq)
+
+		// Act
+		operator, session, err := sut.SignIn(email, "hidilyho", ip)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrInvalidCredentials)
+		assert.Nil(t, operator)
+		assert.Nil(t, session)
+	})
+
+	t.Run("Disabled operator", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		disabledAt := time.Now()
+		repoMq := mock.NewMockOperatorsRepository(ctrl)
+		repoMq.EXPECT().
+			GetOperatorByEmail(gomock.Any(), email).
+			Return(&operators.Operator{ID: 1, Email: email, PasswordHash: passwordHash, Role: operators.RoleAdmin, DisabledAt: &disabledAt}, nil)
+
+		sut := newService(repoMq)
+
+		// Act
+		_, _, err := sut.SignIn(email, password, ip)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrInvalidCredentials)
+	})
+}
+
+func TestAuthenticate(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockOperatorsRepository(ctrl)
+	repoMq.EXPECT().GetOperatorBySession(gomock.Any(), gomock.Not("token"), gomock.Any()).Return(nil, nil)
+
+	sut := newService(repoMq)
+
+	// Act
+	operator, err := sut.Authenticate("token")
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrUnauthorized)
+	assert.Nil(t, operator)
+}
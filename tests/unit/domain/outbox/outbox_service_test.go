@@ -0,0 +1,280 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/outbox"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/spvwallet"
+	"github.com/bitcoin-sv/spv-wallet/models"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Coinbase transaction of the genesis block and its id.
+const (
+	genesisCoinbase = "01000000010000000000000000000000000000000000000000000000000000000000000000ffffffff4d04ffff001d0104455468652054696d6573203033" +
+		"2f4a616e2f32303039204368616e63656c6c6f72206f6e206272696e6b206f66207365636f6e64206261696c6f757420666f722062616e6b73ffffffff0100f2052a" +
+		"01000000434104678afdb0fe5548271967f1a67130b7105cd6a828e03909a67962e0ea1f61deb649f6bc3f4cef38c4f35504e51ec112de5c384df7ba0b8d578a4c70" +
+		"2b6bf11d5fac00000000"
+	genesisCoinbaseID = "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b"
+)
+
+func newSealer(t *testing.T) *encryption.Sealer {
+	sealer, err := encryption.NewSealer("secret", nil)
+	require.NoError(t, err)
+	return sealer
+}
+
+func TestEnqueue(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessKey := gofakeit.HexUint256()
+	expiresAt := time.Now().Add(time.Hour)
+	metadata := map[string]any{"sender": "paymail@example.com"}
+
+	mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
+	mockUserWalletClient.EXPECT().
+		CreateAccessKey().
+		Return(&spvwallet.AccessKey{ID: "access-key-id", Key: accessKey}, nil)
+
+	var inserted *outbox.Entry
+	repoMq := mock.NewMockOutboxRepository(ctrl)
+	repoMq.EXPECT().
+		InsertEntry(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, e *outbox.Entry) error {
+			inserted = e
+			return nil
+		})
+
+	sut := outbox.NewOutboxService(repoMq, mock.NewMockWalletClientFactory(ctrl), newSealer(t), &testLogger)
+
+	// Act
+	entry, err := sut.Enqueue(1, mockUserWalletClient, &spvwallet.DraftTransaction{TxDraftID: "draft-id", TxHex: genesisCoinbase, Fee: 2}, metadata, 500, expiresAt)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Same(t, inserted, entry)
+	assert.Equal(t, genesisCoinbaseID, entry.TransactionID)
+	assert.Equal(t, "draft-id", entry.DraftID)
+	assert.Equal(t, uint64(500), entry.TotalValue)
+	assert.Equal(t, uint64(2), entry.Fee)
+	assert.Equal(t, metadata, entry.Metadata)
+	assert.Equal(t, outbox.StatusPending, entry.Status)
+	assert.Equal(t, 1, entry.Attempts)
+	assert.True(t, entry.NextAttemptAt.After(time.Now()))
+	assert.Equal(t, expiresAt, entry.ExpiresAt)
+
+	assert.Equal(t, "access-key-id", entry.AccessKeyID)
+	decrypted, err := newSealer(t).Open(context.Background(), entry.AccessKey)
+	require.NoError(t, err)
+	assert.Equal(t, accessKey, decrypted)
+}
+
+func TestRecordPending(t *testing.T) {
+	testLogger := zerolog.Nop()
+	sender := "paymail@example.com"
+	errRecord := errors.New("spv wallet is down")
+
+	tests := map[string]struct {
+		attempts          int
+		expiresIn         time.Duration
+		recordErr         error
+		recordedBefore    bool
+		expectedStatus    string
+		expectedLastError string
+		expectedBackoff   time.Duration
+	}{
+		"Recorded": {
+			attempts:       2,
+			expiresIn:      time.Hour,
+			expectedStatus: outbox.StatusRecorded,
+		},
+		"Recorded by previous attempt": {
+			attempts:       2,
+			expiresIn:      time.Hour,
+			recordErr:      errors.New("draft is already used"),
+			recordedBefore: true,
+			expectedStatus: outbox.StatusRecorded,
+		},
+		"Retried with backoff": {
+			attempts:          3,
+			expiresIn:         time.Hour,
+			recordErr:         errRecord,
+			expectedStatus:    outbox.StatusPending,
+			expectedLastError: errRecord.Error(),
+			expectedBackoff:   20 * time.Second,
+		},
+		"Backoff is limited": {
+			attempts:          20,
+			expiresIn:         time.Hour,
+			recordErr:         errRecord,
+			expectedStatus:    outbox.StatusPending,
+			expectedLastError: errRecord.Error(),
+			expectedBackoff:   time.Minute,
+		},
+		"Given up when draft expired": {
+			attempts:          30,
+			expiresIn:         -time.Second,
+			recordErr:         errRecord,
+			expectedStatus:    outbox.StatusFailed,
+			expectedLastError: errRecord.Error(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			viper.Set(config.EnvTransactionsOutboxMaxBackoff, time.Minute)
+
+			accessKey := gofakeit.HexUint256()
+			encryptedAccessKey, err := newSealer(t).Seal(context.Background(), accessKey)
+			require.NoError(t, err)
+
+			entry := &outbox.Entry{
+				ID:            1,
+				UserID:        1,
+				DraftID:       "draft-id",
+				TransactionID: genesisCoinbaseID,
+				Hex:           genesisCoinbase,
+				Metadata:      map[string]any{"sender": sender},
+				AccessKeyID:   "access-key-id",
+				AccessKey:     encryptedAccessKey,
+				Status:        outbox.StatusPending,
+				Attempts:      tc.attempts,
+				ExpiresAt:     time.Now().Add(tc.expiresIn),
+			}
+
+			repoMq := mock.NewMockOutboxRepository(ctrl)
+			repoMq.EXPECT().
+				ClaimDueEntries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return([]*outbox.Entry{entry}, nil)
+			var updated outbox.Entry
+			repoMq.EXPECT().
+				UpdateEntry(gomock.Any(), entry).
+				DoAndReturn(func(_ any, e *outbox.Entry) error {
+					updated = *e
+					return nil
+				})
+
+			mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
+			if tc.recordErr != nil {
+				mockUserWalletClient.EXPECT().
+					RecordTransaction(genesisCoinbase, "draft-id", entry.Metadata).
+					Return(nil, tc.recordErr)
+				getTransaction := mockUserWalletClient.EXPECT().GetTransaction(genesisCoinbaseID, sender)
+				if tc.recordedBefore {
+					getTransaction.Return(&spvwallet.FullTransaction{ID: genesisCoinbaseID}, nil)
+				} else {
+					getTransaction.Return(nil, errors.New("not found"))
+				}
+			} else {
+				mockUserWalletClient.EXPECT().
+					RecordTransaction(genesisCoinbase, "draft-id", entry.Metadata).
+					Return(&models.Transaction{ID: genesisCoinbaseID}, nil)
+			}
+			if tc.expectedStatus != outbox.StatusPending {
+				mockUserWalletClient.EXPECT().
+					RevokeAccessKey("access-key-id").
+					Return(&spvwallet.AccessKey{ID: "access-key-id"}, nil)
+			}
+
+			clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+			clientFctrMq.EXPECT().
+				CreateWithAccessKey(accessKey).
+				Return(mockUserWalletClient, nil)
+
+			sut := outbox.NewOutboxService(repoMq, clientFctrMq, newSealer(t), &testLogger)
+
+			// Act
+			sut.RecordPending(context.Background())
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, updated.Status)
+			assert.Equal(t, tc.expectedLastError, updated.LastError)
+			if tc.expectedBackoff > 0 {
+				assert.WithinDuration(t, time.Now().Add(tc.expectedBackoff), updated.NextAttemptAt, time.Second)
+			}
+		})
+	}
+}
+
+func TestRecord_GivenUpReturnsError(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	entry := &outbox.Entry{
+		DraftID:       "draft-id",
+		TransactionID: genesisCoinbaseID,
+		Hex:           genesisCoinbase,
+		Metadata:      map[string]any{},
+		AccessKeyID:   "access-key-id",
+		Status:        outbox.StatusPending,
+		Attempts:      1,
+		ExpiresAt:     time.Now().Add(-time.Second),
+	}
+
+	mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
+	mockUserWalletClient.EXPECT().
+		RecordTransaction(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("draft expired"))
+	mockUserWalletClient.EXPECT().
+		GetTransaction(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("not found"))
+	mockUserWalletClient.EXPECT().
+		RevokeAccessKey("access-key-id").
+		Return(nil, errors.New("already revoked"))
+
+	repoMq := mock.NewMockOutboxRepository(ctrl)
+	repoMq.EXPECT().
+		UpdateEntry(gomock.Any(), entry).
+		Return(nil)
+
+	sut := outbox.NewOutboxService(repoMq, mock.NewMockWalletClientFactory(ctrl), newSealer(t), &testLogger)
+
+	// Act
+	tx, err := sut.Record(entry, mockUserWalletClient)
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrRecordTransaction)
+	assert.Nil(t, tx)
+	assert.Equal(t, outbox.StatusFailed, entry.Status)
+}
+
+func TestGetKeptAccessKeyIDs(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockOutboxRepository(ctrl)
+	repoMq.EXPECT().
+		GetUserEntries(gomock.Any(), 1, outbox.StatusPending).
+		Return([]*outbox.Entry{{AccessKeyID: "first"}, {AccessKeyID: "second"}}, nil)
+
+	sut := outbox.NewOutboxService(repoMq, mock.NewMockWalletClientFactory(ctrl), newSealer(t), &testLogger)
+
+	// Act
+	accessKeyIDs, err := sut.GetKeptAccessKeyIDs(1)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, accessKeyIDs)
+}
@@ -0,0 +1,296 @@
+package passkeys_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/passkeys"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/tests/authenticator"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userID   = 1
+	rpID     = "wallet.example.com"
+	origin   = "https://wallet.example.com"
+	password = "password"
+	xpriv    = "xprv-test"
+)
+
+func newService(t *testing.T, ctrl *gomock.Controller) (*passkeys.Service, *mock.MockPasskeysRepository) {
+	testLogger := zerolog.Nop()
+	viper.Set(config.EnvWebAuthnRPID, rpID)
+	viper.Set(config.EnvWebAuthnOrigins, []string{origin})
+	viper.Set(config.EnvWebAuthnChallengeTTL, time.Minute)
+
+	repoMq := mock.NewMockPasskeysRepository(ctrl)
+	usersMq := mock.NewMockRepository(ctrl)
+
+	encryptedXpriv, err := encryption.Encrypt(password, xpriv)
+	require.NoError(t, err)
+	user := &users.User{ID: userID, Email: "alice@example.com", Paymail: "alice@example.com", Xpriv: encryptedXpriv}
+	usersMq.EXPECT().GetUserByID(gomock.Any(), userID).Return(user, nil).AnyTimes()
+
+	uService := users.NewUserService(usersMq, nil, nil, nil, nil, nil, nil, nil, &testLogger)
+	return passkeys.NewPasskeysService(repoMq, uService, nil, &testLogger), repoMq
+}
+
+func newAuthenticator(t *testing.T) *authenticator.Authenticator {
+	a, err := authenticator.New(rpID, origin)
+	require.NoError(t, err)
+	return a
+}
+
+// register registers passkey of the authenticator and returns the stored passkey.
+func register(t *testing.T, sut *passkeys.Service, repoMq *mock.MockPasskeysRepository, a *authenticator.Authenticator) *passkeys.Passkey {
+	repoMq.EXPECT().GetUserPasskeys(gomock.Any(), userID).Return([]*passkeys.Passkey{}, nil)
+	options, err := sut.StartRegistration(userID)
+	require.NoError(t, err)
+
+	response, err := a.Create(options)
+	require.NoError(t, err)
+
+	var stored *passkeys.Passkey
+	repoMq.EXPECT().InsertPasskey(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, passkey *passkeys.Passkey) (bool, error) {
+			passkey.ID = 2
+			stored = passkey
+			return true, nil
+		})
+
+	_, err = sut.Register(userID, "Laptop", password, options.Challenge, options.User.ID, response)
+	require.NoError(t, err)
+	return stored
+}
+
+func TestRegister(t *testing.T) {
+	t.Run("Register passkey", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, repoMq := newService(t, ctrl)
+		a := newAuthenticator(t)
+
+		// Act
+		result := register(t, sut, repoMq, a)
+
+		// Assert
+		require.NotNil(t, result)
+		assert.Equal(t, userID, result.UserID)
+		assert.Equal(t, "Laptop", result.Name)
+		assert.Equal(t, a.CredentialID(), result.CredentialID)
+		assert.NotEmpty(t, result.UserHandle)
+
+		// xPriv is stored encrypted, neither in plain text nor with the password
+		assert.NotContains(t, result.Xpriv, xpriv)
+		_, err := encryption.Decrypt(password, result.Xpriv)
+		require.Error(t, err)
+	})
+
+	t.Run("Authenticator without PRF", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, repoMq := newService(t, ctrl)
+		a := newAuthenticator(t)
+		a.PRF = false
+
+		repoMq.EXPECT().GetUserPasskeys(gomock.Any(), userID).Return([]*passkeys.Passkey{}, nil)
+		options, err := sut.StartRegistration(userID)
+		require.NoError(t, err)
+		response, err := a.Create(options)
+		require.NoError(t, err)
+
+		// Act
+		result, err := sut.Register(userID, "Laptop", password, options.Challenge, options.User.ID, response)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrPasskeyPRFNotSupported)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Wrong password", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, repoMq := newService(t, ctrl)
+		a := newAuthenticator(t)
+
+		repoMq.EXPECT().GetUserPasskeys(gomock.Any(), userID).Return([]*passkeys.Passkey{}, nil)
+		options, err := sut.StartRegistration(userID)
+		require.NoError(t, err)
+		response, err := a.Create(options)
+		require.NoError(t, err)
+
+		// Act
+		result, err := sut.Register(userID, "Laptop", "wrong-password", options.Challenge, options.User.ID, response)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrInvalidCredentials)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Already registered credential", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, repoMq := newService(t, ctrl)
+		a := newAuthenticator(t)
+
+		repoMq.EXPECT().GetUserPasskeys(gomock.Any(), userID).Return([]*passkeys.Passkey{}, nil)
+		options, err := sut.StartRegistration(userID)
+		require.NoError(t, err)
+		response, err := a.Create(options)
+		require.NoError(t, err)
+		repoMq.EXPECT().InsertPasskey(gomock.Any(), gomock.Any()).Return(false, nil)
+
+		// Act
+		result, err := sut.Register(userID, "Laptop", password, options.Challenge, options.User.ID, response)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrPasskeyAlreadyRegistered)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Passkeys of the user share user handle", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, repoMq := newService(t, ctrl)
+		existing := &passkeys.Passkey{ID: 2, UserID: userID, CredentialID: []byte{1}, UserHandle: "handle"}
+		repoMq.EXPECT().GetUserPasskeys(gomock.Any(), userID).Return([]*passkeys.Passkey{existing}, nil)
+
+		// Act
+		result, err := sut.StartRegistration(userID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "handle", result.User.ID)
+		assert.Len(t, result.ExcludeCredentials, 1)
+	})
+}
+
+func TestAuthenticate(t *testing.T) {
+	t.Run("Passkey unlocks xPriv", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, repoMq := newService(t, ctrl)
+		a := newAuthenticator(t)
+		stored := register(t, sut, repoMq, a)
+
+		options, err := sut.StartAssertion(nil)
+		require.NoError(t, err)
+		response, err := a.Get(options)
+		require.NoError(t, err)
+
+		repoMq.EXPECT().GetPasskeyByCredentialID(gomock.Any(), a.CredentialID()).Return(stored, nil)
+		repoMq.EXPECT().UpdatePasskeyUse(gomock.Any(), stored.ID, a.SignCount, gomock.Any()).Return(nil)
+
+		// Act
+		result, err := sut.Authenticate(options.Challenge, response)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, xpriv, result.Xpriv)
+		assert.Equal(t, stored.ID, result.Passkey.ID)
+		assert.NotNil(t, result.Passkey.LastUsedAt)
+	})
+
+	t.Run("Unknown passkey", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, repoMq := newService(t, ctrl)
+		a := newAuthenticator(t)
+
+		options, err := sut.StartAssertion(nil)
+		require.NoError(t, err)
+		response, err := a.Get(options)
+		require.NoError(t, err)
+
+		repoMq.EXPECT().GetPasskeyByCredentialID(gomock.Any(), a.CredentialID()).Return(nil, nil)
+
+		// Act
+		result, err := sut.Authenticate(options.Challenge, response)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrPasskeyAuthentication)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Challenge of another ceremony", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, repoMq := newService(t, ctrl)
+		a := newAuthenticator(t)
+		stored := register(t, sut, repoMq, a)
+
+		options, err := sut.StartAssertion(nil)
+		require.NoError(t, err)
+		response, err := a.Get(options)
+		require.NoError(t, err)
+
+		repoMq.EXPECT().GetPasskeyByCredentialID(gomock.Any(), a.CredentialID()).Return(stored, nil)
+
+		// Act
+		result, err := sut.Authenticate("another-challenge", response)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrPasskeyAuthentication)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Assertion without PRF can't unlock xPriv", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, repoMq := newService(t, ctrl)
+		a := newAuthenticator(t)
+		stored := register(t, sut, repoMq, a)
+		a.PRF = false
+
+		options, err := sut.StartAssertion(nil)
+		require.NoError(t, err)
+		response, err := a.Get(options)
+		require.NoError(t, err)
+
+		repoMq.EXPECT().GetPasskeyByCredentialID(gomock.Any(), a.CredentialID()).Return(stored, nil)
+
+		// Act
+		result, err := sut.Authenticate(options.Challenge, response)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrPasskeyPRFNotSupported)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Unlock allows only passkeys of the user", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, repoMq := newService(t, ctrl)
+		repoMq.EXPECT().GetUserPasskeys(gomock.Any(), userID).Return([]*passkeys.Passkey{}, nil)
+		id := userID
+
+		// Act
+		result, err := sut.StartAssertion(&id)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrPasskeyNotFound)
+		assert.Nil(t, result)
+	})
+}
+
+func TestDeletePasskey(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	sut, repoMq := newService(t, ctrl)
+	repoMq.EXPECT().DeletePasskey(gomock.Any(), userID, 2).Return(nil, nil)
+
+	// Act
+	err := sut.DeletePasskey(userID, 2)
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrPasskeyNotFound)
+}
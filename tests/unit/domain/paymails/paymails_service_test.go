@@ -0,0 +1,280 @@
+package paymails_test
+
+import (
+	"testing"
+
+	backendconfig "github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/paymails"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/bitcoin-sv/spv-wallet/models"
+	"github.com/golang/mock/gomock"
+	"github.com/libsv/go-bk/bip32"
+	"github.com/libsv/go-bk/chaincfg"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userID        = 1
+	defaultDomain = "example.com"
+	otherDomain   = "example.org"
+)
+
+func newService(repo paymails.Repository, pending paymails.PendingAliasChecker, adminWalletClient *mock.MockAdminWalletClient) *paymails.Service {
+	testLogger := zerolog.Nop()
+	viper.Set(backendconfig.EnvPaymailDomain, defaultDomain)
+
+	adminWalletClient.EXPECT().
+		GetSharedConfig().
+		Return(&models.SharedConfig{PaymailDomains: []string{defaultDomain, otherDomain}}, nil).
+		AnyTimes()
+	configService := config.NewConfigService(adminWalletClient, &testLogger)
+
+	return paymails.NewPaymailsService(repo, pending, adminWalletClient, configService, &testLogger)
+}
+
+func newXpriv(t *testing.T) *bip32.ExtendedKey {
+	seed, err := bip32.GenerateSeed(bip32.RecommendedSeedLen)
+	require.NoError(t, err)
+	xpriv, err := bip32.NewMaster(seed, &chaincfg.MainNet)
+	require.NoError(t, err)
+	return xpriv
+}
+
+func TestAddPaymail(t *testing.T) {
+	xpriv := newXpriv(t)
+	xpub, err := xpriv.Neuter()
+	require.NoError(t, err)
+
+	t.Run("Default domain", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockPaymailsRepository(ctrl)
+		repoMq.EXPECT().
+			InsertPaymail(gomock.Any(), gomock.Any()).
+			Return(nil)
+		pendingMq := mock.NewMockPendingAliasChecker(ctrl)
+		pendingMq.EXPECT().IsAliasPending(gomock.Any(), "homer").Return(false, nil)
+		adminMq := mock.NewMockAdminWalletClient(ctrl)
+		adminMq.EXPECT().PaymailExists("homer", defaultDomain).Return(false, nil)
+		adminMq.EXPECT().
+			CreatePaymail("homer", defaultDomain, xpub.String()).
+			Return("homer@"+defaultDomain, nil)
+
+		sut := newService(repoMq, pendingMq, adminMq)
+
+		// Act
+		result, err := sut.AddPaymail(userID, xpriv.String(), " Homer ", "")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "homer@"+defaultDomain, result.Address)
+		assert.Equal(t, userID, result.UserID)
+		assert.False(t, result.Primary)
+	})
+
+	t.Run("Other supported domain", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockPaymailsRepository(ctrl)
+		repoMq.EXPECT().
+			InsertPaymail(gomock.Any(), gomock.Any()).
+			Return(nil)
+		adminMq := mock.NewMockAdminWalletClient(ctrl)
+		adminMq.EXPECT().PaymailExists("homer", otherDomain).Return(false, nil)
+		adminMq.EXPECT().
+			CreatePaymail("homer", otherDomain, xpub.String()).
+			Return("homer@"+otherDomain, nil)
+
+		sut := newService(repoMq, mock.NewMockPendingAliasChecker(ctrl), adminMq)
+
+		// Act
+		result, err := sut.AddPaymail(userID, xpriv.String(), "homer", otherDomain)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "homer@"+otherDomain, result.Address)
+	})
+
+	t.Run("Unsupported domain", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut := newService(mock.NewMockPaymailsRepository(ctrl), mock.NewMockPendingAliasChecker(ctrl), mock.NewMockAdminWalletClient(ctrl))
+
+		// Act
+		result, err := sut.AddPaymail(userID, xpriv.String(), "homer", "unknown.com")
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrPaymailDomainNotSupported)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Alias taken", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		adminMq := mock.NewMockAdminWalletClient(ctrl)
+		adminMq.EXPECT().PaymailExists("homer", otherDomain).Return(true, nil)
+
+		sut := newService(mock.NewMockPaymailsRepository(ctrl), mock.NewMockPendingAliasChecker(ctrl), adminMq)
+
+		// Act
+		result, err := sut.AddPaymail(userID, xpriv.String(), "homer", otherDomain)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrAliasTaken)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Alias pending verification", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		pendingMq := mock.NewMockPendingAliasChecker(ctrl)
+		pendingMq.EXPECT().IsAliasPending(gomock.Any(), "homer").Return(true, nil)
+
+		sut := newService(mock.NewMockPaymailsRepository(ctrl), pendingMq, mock.NewMockAdminWalletClient(ctrl))
+
+		// Act
+		result, err := sut.AddPaymail(userID, xpriv.String(), "homer", defaultDomain)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrAliasTaken)
+		assert.Nil(t, result)
+	})
+}
+
+func TestSetPrimaryPaymail(t *testing.T) {
+	t.Run("Set primary", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockPaymailsRepository(ctrl)
+		repoMq.EXPECT().
+			GetUserPaymail(gomock.Any(), userID, 2).
+			Return(&paymails.Paymail{ID: 2, UserID: userID, Address: "homer@" + otherDomain}, nil)
+		repoMq.EXPECT().
+			SetPrimaryPaymail(gomock.Any(), userID, 2).
+			Return(nil)
+
+		sut := newService(repoMq, mock.NewMockPendingAliasChecker(ctrl), mock.NewMockAdminWalletClient(ctrl))
+
+		// Act
+		result, err := sut.SetPrimaryPaymail(userID, 2)
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, result.Primary)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockPaymailsRepository(ctrl)
+		repoMq.EXPECT().
+			GetUserPaymail(gomock.Any(), userID, 2).
+			Return(nil, nil)
+
+		sut := newService(repoMq, mock.NewMockPendingAliasChecker(ctrl), mock.NewMockAdminWalletClient(ctrl))
+
+		// Act
+		result, err := sut.SetPrimaryPaymail(userID, 2)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrPaymailNotFound)
+		assert.Nil(t, result)
+	})
+}
+
+func TestRemovePaymail(t *testing.T) {
+	t.Run("Remove paymail", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockPaymailsRepository(ctrl)
+		repoMq.EXPECT().
+			GetUserPaymail(gomock.Any(), userID, 2).
+			Return(&paymails.Paymail{ID: 2, UserID: userID, Address: "homer@" + otherDomain}, nil)
+		repoMq.EXPECT().
+			DeletePaymail(gomock.Any(), userID, 2).
+			Return(nil)
+		adminMq := mock.NewMockAdminWalletClient(ctrl)
+		adminMq.EXPECT().DeletePaymail("homer@" + otherDomain).Return(nil)
+
+		sut := newService(repoMq, mock.NewMockPendingAliasChecker(ctrl), adminMq)
+
+		// Act
+		err := sut.RemovePaymail(userID, 2)
+
+		// Assert
+		require.NoError(t, err)
+	})
+
+	t.Run("Primary paymail can't be removed", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockPaymailsRepository(ctrl)
+		repoMq.EXPECT().
+			GetUserPaymail(gomock.Any(), userID, 1).
+			Return(&paymails.Paymail{ID: 1, UserID: userID, Address: "homer@" + defaultDomain, Primary: true}, nil)
+
+		sut := newService(repoMq, mock.NewMockPendingAliasChecker(ctrl), mock.NewMockAdminWalletClient(ctrl))
+
+		// Act
+		err := sut.RemovePaymail(userID, 1)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrRemovePrimaryPaymail)
+	})
+}
+
+func TestUpdateProfile(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	xpriv := newXpriv(t)
+	xpub, err := xpriv.Neuter()
+	require.NoError(t, err)
+
+	repoMq := mock.NewMockPaymailsRepository(ctrl)
+	repoMq.EXPECT().
+		GetUserPaymails(gomock.Any(), userID).
+		Return([]*paymails.Paymail{
+			{ID: 1, UserID: userID, Address: "homer@" + defaultDomain, Primary: true},
+			{ID: 2, UserID: userID, Address: "homer@" + otherDomain},
+		}, nil)
+	adminMq := mock.NewMockAdminWalletClient(ctrl)
+	adminMq.EXPECT().
+		UpdatePaymailProfile("homer@"+defaultDomain, xpub.String(), "Homer", "http://avatar").
+		Return(nil)
+	adminMq.EXPECT().
+		UpdatePaymailProfile("homer@"+otherDomain, xpub.String(), "Homer", "http://avatar").
+		Return(nil)
+
+	sut := newService(repoMq, mock.NewMockPendingAliasChecker(ctrl), adminMq)
+
+	// Act
+	err = sut.UpdateProfile(userID, xpriv.String(), "Homer", "http://avatar")
+
+	// Assert
+	require.NoError(t, err)
+}
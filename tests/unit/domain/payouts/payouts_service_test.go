@@ -0,0 +1,358 @@
+package payouts_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-go-client/commands"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/payouts"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/notification"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userID  = 1
+	sender  = "sender@example.com"
+	balance = 10000
+)
+
+type mocks struct {
+	repo          *mock.MockPayoutsRepository
+	clientFactory *mock.MockWalletClientFactory
+	walletClient  *mock.MockUserWalletClient
+}
+
+func newService(ctrl *gomock.Controller, chunkSize int) (*payouts.Service, *mocks) {
+	testLogger := zerolog.Nop()
+	viper.Set(config.EnvPayoutsChunkSize, chunkSize)
+	viper.Set(config.EnvPayoutsMaxRows, 3)
+
+	m := &mocks{
+		repo:          mock.NewMockPayoutsRepository(ctrl),
+		clientFactory: mock.NewMockWalletClientFactory(ctrl),
+		walletClient:  mock.NewMockUserWalletClient(ctrl),
+	}
+	return payouts.NewPayoutsService(m.repo, m.clientFactory, &testLogger), m
+}
+
+func expectBalance(ctrl *gomock.Controller, m *mocks) {
+	xpub := mock.NewMockPubKey(ctrl)
+	xpub.EXPECT().GetCurrentBalance().Return(uint64(balance)).AnyTimes()
+	m.walletClient.EXPECT().GetXPub().Return(xpub, nil)
+}
+
+func newTransaction(ctrl *gomock.Controller, id string) *mock.MockTransaction {
+	tx := mock.NewMockTransaction(ctrl)
+	tx.EXPECT().GetTransactionID().Return(id).AnyTimes()
+	return tx
+}
+
+func newBatch(rows ...*payouts.Row) *payouts.Batch {
+	batch := &payouts.Batch{ID: 7, UserID: userID, Status: payouts.BatchStatusDraft, Rows: rows}
+	for i, row := range rows {
+		row.ID = i + 1
+		row.Number = i + 1
+		if row.Status == "" {
+			row.Status = payouts.RowStatusPending
+		}
+		batch.TotalSatoshis += row.Satoshis
+	}
+	return batch
+}
+
+func waitForEvents(t *testing.T, events chan notification.PayoutBatchEvent) []notification.PayoutBatchEvent {
+	result := make([]notification.PayoutBatchEvent, 0)
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return result
+			}
+			result = append(result, event)
+		case <-timeout:
+			require.FailNow(t, "batch wasn't finished")
+		}
+	}
+}
+
+func TestPreview(t *testing.T) {
+	t.Run("Save valid batch", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(ctrl, 2)
+		m.clientFactory.EXPECT().CreateWithAccessKey("access-key").Return(m.walletClient, nil)
+		expectBalance(ctrl, m)
+
+		var saved *payouts.Batch
+		m.repo.EXPECT().InsertBatch(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, batch *payouts.Batch) error {
+			batch.ID = 7
+			saved = batch
+			return nil
+		})
+		csv := "paymail,satoshis,memo\nAlice@Example.com, 100, March salary\nbob@example.com,200\ncarol@example.com,300,\n"
+
+		// Act
+		preview, err := sut.Preview(userID, "access-key", strings.NewReader(csv))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, preview.InvalidRows)
+		assert.Equal(t, uint64(600), preview.Total)
+		assert.Equal(t, uint64(balance), preview.Balance)
+		assert.Equal(t, 2, preview.Transactions)
+		require.NotNil(t, preview.Batch)
+		assert.Equal(t, saved, preview.Batch)
+		assert.Equal(t, userID, saved.UserID)
+		assert.Equal(t, payouts.BatchStatusDraft, saved.Status)
+		require.Len(t, saved.Rows, 3)
+		assert.Equal(t, "alice@example.com", saved.Rows[0].Paymail)
+		assert.Equal(t, "March salary", saved.Rows[0].Memo)
+		assert.Equal(t, 2, saved.Rows[0].Number)
+		assert.Equal(t, payouts.RowStatusPending, saved.Rows[2].Status)
+	})
+
+	t.Run("Report invalid rows without saving", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(ctrl, 2)
+		m.clientFactory.EXPECT().CreateWithAccessKey("access-key").Return(m.walletClient, nil)
+		expectBalance(ctrl, m)
+		csv := "alice@example.com,100\nnot-a-paymail,100\nALICE@example.com,50\n"
+
+		// Act
+		preview, err := sut.Preview(userID, "access-key", strings.NewReader(csv))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Nil(t, preview.Batch)
+		require.Len(t, preview.InvalidRows, 2)
+		assert.Equal(t, 2, preview.InvalidRows[0].Number)
+		assert.Equal(t, "invalid paymail", preview.InvalidRows[0].Error)
+		assert.Equal(t, 3, preview.InvalidRows[1].Number)
+		assert.Contains(t, preview.InvalidRows[1].Error, "row 1")
+	})
+
+	t.Run("Don't save batch over balance", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(ctrl, 2)
+		m.clientFactory.EXPECT().CreateWithAccessKey("access-key").Return(m.walletClient, nil)
+		expectBalance(ctrl, m)
+
+		// Act
+		preview, err := sut.Preview(userID, "access-key", strings.NewReader("alice@example.com,6000\nbob@example.com,5000\n"))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Nil(t, preview.Batch)
+		assert.Equal(t, uint64(11000), preview.Total)
+		assert.Equal(t, spverrors.ErrInsufficientBalance.Message, preview.Error)
+	})
+
+	t.Run("Reject too many rows", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, _ := newService(ctrl, 2)
+		csv := "a@example.com,1\nb@example.com,1\nc@example.com,1\nd@example.com,1\n"
+
+		// Act
+		preview, err := sut.Preview(userID, "access-key", strings.NewReader(csv))
+
+		// Assert
+		assert.Nil(t, preview)
+		assert.ErrorIs(t, err, spverrors.ErrPayoutBatchTooLarge)
+	})
+
+	t.Run("Reject empty CSV", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, _ := newService(ctrl, 2)
+
+		// Act
+		preview, err := sut.Preview(userID, "access-key", strings.NewReader("paymail,satoshis,memo\n"))
+
+		// Assert
+		assert.Nil(t, preview)
+		assert.ErrorIs(t, err, spverrors.ErrInvalidPayoutCSV)
+	})
+}
+
+func TestExecute(t *testing.T) {
+	t.Run("Pay batch in chunks", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(ctrl, 2)
+		batch := newBatch(
+			&payouts.Row{Paymail: "alice@example.com", Satoshis: 100},
+			&payouts.Row{Paymail: "bob@example.com", Satoshis: 200},
+			&payouts.Row{Paymail: "carol@example.com", Satoshis: 300},
+		)
+
+		m.repo.EXPECT().ClaimBatch(gomock.Any(), userID, batch.ID, gomock.Any(), gomock.Any()).Return(true, nil)
+		m.clientFactory.EXPECT().CreateWithXpriv("xpriv").Return(m.walletClient, nil)
+		expectBalance(ctrl, m)
+		m.repo.EXPECT().UpdateRows(gomock.Any(), gomock.Any()).Return(nil).Times(4)
+		m.repo.EXPECT().UpdateBatchStatus(gomock.Any(), batch.ID, payouts.BatchStatusRunning, gomock.Any()).Return(nil).Times(2)
+		m.repo.EXPECT().UpdateBatchStatus(gomock.Any(), batch.ID, payouts.BatchStatusCompleted, gomock.Any()).Return(nil)
+
+		references := make([]any, 0)
+		m.walletClient.EXPECT().SendToRecipients(gomock.Len(2), gomock.Any()).DoAndReturn(
+			func(recipients []*commands.Recipients, metadata map[string]any) (users.Transaction, error) {
+				assert.Equal(t, "alice@example.com", recipients[0].To)
+				assert.Equal(t, sender, metadata["sender"])
+				assert.Equal(t, []string{"alice@example.com", "bob@example.com"}, metadata["receivers"])
+				references = append(references, metadata["payoutReference"])
+				return newTransaction(ctrl, "tx1"), nil
+			})
+		m.walletClient.EXPECT().SendToRecipients(gomock.Len(1), gomock.Any()).DoAndReturn(
+			func(_ []*commands.Recipients, metadata map[string]any) (users.Transaction, error) {
+				references = append(references, metadata["payoutReference"])
+				return newTransaction(ctrl, "tx2"), nil
+			})
+		events := make(chan notification.PayoutBatchEvent)
+
+		// Act
+		err := sut.Execute(batch, sender, "xpriv", events)
+		received := waitForEvents(t, events)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, received, 3)
+		assert.Equal(t, 2, received[0].Batch.Sent)
+		assert.Len(t, received[0].Batch.Rows, 2)
+		assert.Equal(t, payouts.BatchStatusCompleted, received[2].Batch.Status)
+		assert.Equal(t, 3, received[2].Batch.Sent)
+		assert.Equal(t, payouts.BatchStatusCompleted, batch.Status)
+		assert.Equal(t, "tx1", batch.Rows[1].TransactionID)
+		assert.Equal(t, "tx2", batch.Rows[2].TransactionID)
+		require.Len(t, references, 2)
+		assert.NotEqual(t, references[0], references[1])
+		assert.Equal(t, references[0], batch.Rows[0].Reference)
+	})
+
+	t.Run("Keep reference of failed rows", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(ctrl, 2)
+		batch := newBatch(&payouts.Row{Paymail: "alice@example.com", Satoshis: 100})
+
+		m.repo.EXPECT().ClaimBatch(gomock.Any(), userID, batch.ID, gomock.Any(), gomock.Any()).Return(true, nil)
+		m.clientFactory.EXPECT().CreateWithXpriv("xpriv").Return(m.walletClient, nil)
+		expectBalance(ctrl, m)
+		m.repo.EXPECT().UpdateRows(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		m.repo.EXPECT().UpdateBatchStatus(gomock.Any(), batch.ID, payouts.BatchStatusRunning, gomock.Any()).Return(nil)
+		m.repo.EXPECT().UpdateBatchStatus(gomock.Any(), batch.ID, payouts.BatchStatusFailed, gomock.Any()).Return(nil)
+		m.walletClient.EXPECT().SendToRecipients(gomock.Any(), gomock.Any()).Return(nil, spverrors.ErrCreateTransaction)
+		events := make(chan notification.PayoutBatchEvent)
+
+		// Act
+		err := sut.Execute(batch, sender, "xpriv", events)
+		received := waitForEvents(t, events)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 1, received[len(received)-1].Batch.Failed)
+		assert.Equal(t, payouts.BatchStatusFailed, batch.Status)
+		assert.Equal(t, payouts.RowStatusFailed, batch.Rows[0].Status)
+		assert.NotEmpty(t, batch.Rows[0].Reference)
+		assert.NotEmpty(t, batch.Rows[0].Error)
+	})
+
+	t.Run("Retry doesn't pay rows already sent", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(ctrl, 5)
+		batch := newBatch(
+			&payouts.Row{Paymail: "alice@example.com", Satoshis: 100, Status: payouts.RowStatusSent, TransactionID: "tx1"},
+			&payouts.Row{Paymail: "bob@example.com", Satoshis: 200, Status: payouts.RowStatusSending, Reference: "lost"},
+			&payouts.Row{Paymail: "carol@example.com", Satoshis: 300, Status: payouts.RowStatusFailed, Reference: "failed"},
+		)
+		batch.Status = payouts.BatchStatusFailed
+
+		m.repo.EXPECT().ClaimBatch(gomock.Any(), userID, batch.ID, gomock.Any(), gomock.Any()).Return(true, nil)
+		m.clientFactory.EXPECT().CreateWithXpriv("xpriv").Return(m.walletClient, nil)
+		m.walletClient.EXPECT().GetTransactionsWithMetadata(map[string]any{"payoutReference": "lost"}, sender).
+			Return([]users.Transaction{newTransaction(ctrl, "tx2")}, nil)
+		m.walletClient.EXPECT().GetTransactionsWithMetadata(map[string]any{"payoutReference": "failed"}, sender).Return(nil, nil)
+		expectBalance(ctrl, m)
+		m.repo.EXPECT().UpdateRows(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+		m.repo.EXPECT().UpdateBatchStatus(gomock.Any(), batch.ID, payouts.BatchStatusRunning, gomock.Any()).Return(nil)
+		m.repo.EXPECT().UpdateBatchStatus(gomock.Any(), batch.ID, payouts.BatchStatusCompleted, gomock.Any()).Return(nil)
+		m.walletClient.EXPECT().SendToRecipients(gomock.Len(1), gomock.Any()).DoAndReturn(
+			func(recipients []*commands.Recipients, metadata map[string]any) (users.Transaction, error) {
+				assert.Equal(t, "carol@example.com", recipients[0].To)
+				assert.NotEqual(t, "failed", metadata["payoutReference"])
+				return newTransaction(ctrl, "tx3"), nil
+			})
+		events := make(chan notification.PayoutBatchEvent)
+
+		// Act
+		err := sut.Execute(batch, sender, "xpriv", events)
+		waitForEvents(t, events)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, payouts.BatchStatusCompleted, batch.Status)
+		assert.Equal(t, "tx1", batch.Rows[0].TransactionID)
+		assert.Equal(t, "tx2", batch.Rows[1].TransactionID)
+		assert.Equal(t, "tx3", batch.Rows[2].TransactionID)
+	})
+
+	t.Run("Don't execute running batch", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(ctrl, 2)
+		batch := newBatch(&payouts.Row{Paymail: "alice@example.com", Satoshis: 100})
+		m.repo.EXPECT().ClaimBatch(gomock.Any(), userID, batch.ID, gomock.Any(), gomock.Any()).Return(false, nil)
+
+		// Act
+		err := sut.Execute(batch, sender, "xpriv", make(chan notification.PayoutBatchEvent))
+
+		// Assert
+		assert.ErrorIs(t, err, spverrors.ErrPayoutBatchRunning)
+	})
+
+	t.Run("Don't pay anything if transactions can't be matched", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(ctrl, 2)
+		batch := newBatch(&payouts.Row{Paymail: "alice@example.com", Satoshis: 100, Status: payouts.RowStatusSending, Reference: "lost"})
+		batch.Status = payouts.BatchStatusFailed
+
+		m.repo.EXPECT().ClaimBatch(gomock.Any(), userID, batch.ID, gomock.Any(), gomock.Any()).Return(true, nil)
+		m.clientFactory.EXPECT().CreateWithXpriv("xpriv").Return(m.walletClient, nil)
+		m.walletClient.EXPECT().GetTransactionsWithMetadata(gomock.Any(), sender).Return(nil, spverrors.ErrGetTransactions)
+		m.repo.EXPECT().UpdateBatchStatus(gomock.Any(), batch.ID, payouts.BatchStatusFailed, gomock.Any()).Return(nil)
+
+		// Act
+		err := sut.Execute(batch, sender, "xpriv", make(chan notification.PayoutBatchEvent))
+
+		// Assert
+		assert.ErrorIs(t, err, spverrors.ErrExecutePayoutBatch)
+		assert.Equal(t, payouts.RowStatusSending, batch.Rows[0].Status)
+	})
+
+	t.Run("Don't execute completed batch", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, _ := newService(ctrl, 2)
+		batch := newBatch(&payouts.Row{Paymail: "alice@example.com", Satoshis: 100, Status: payouts.RowStatusSent})
+		batch.Status = payouts.BatchStatusCompleted
+
+		// Act
+		err := sut.Execute(batch, sender, "xpriv", make(chan notification.PayoutBatchEvent))
+
+		// Assert
+		assert.ErrorIs(t, err, spverrors.ErrPayoutBatchCompleted)
+	})
+}
@@ -0,0 +1,183 @@
+package profiles_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/blobstore"
+	backendconfig "github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/profiles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userID        = 1
+	xpriv         = "xprivtest"
+	avatarURL     = "http://localhost:8180/api/v1/avatars"
+	defaultAvatar = "http://localhost:3003/static/paymail/avatar.jpg"
+)
+
+var pngImage = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func newService(repo profiles.Repository, paymails profiles.PaymailsUpdater, store blobstore.Store) *profiles.Service {
+	testLogger := zerolog.Nop()
+	viper.Set(backendconfig.EnvAvatarURL, avatarURL)
+	viper.Set(backendconfig.EnvPaymailAvatar, defaultAvatar)
+	viper.Set(backendconfig.EnvAvatarMaxSize, 1024)
+
+	return profiles.NewProfilesService(repo, paymails, store, &testLogger)
+}
+
+func TestGetProfile_Default(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockProfilesRepository(ctrl)
+	repoMq.EXPECT().GetProfile(gomock.Any(), userID).Return(nil, nil)
+
+	sut := newService(repoMq, mock.NewMockPaymailsUpdater(ctrl), blobstore.NewLocalStore(t.TempDir()))
+
+	// Act
+	result, err := sut.GetProfile(userID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, result.PublicName)
+	assert.Equal(t, defaultAvatar, result.AvatarURL)
+}
+
+func TestUpdatePublicName(t *testing.T) {
+	t.Run("Public name updated, uploaded avatar kept", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockProfilesRepository(ctrl)
+		repoMq.EXPECT().
+			GetProfile(gomock.Any(), userID).
+			Return(&profiles.Profile{UserID: userID, PublicName: "Homer", AvatarKey: "avatar.png"}, nil)
+		repoMq.EXPECT().
+			UpsertProfile(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, profile *profiles.Profile) error {
+				assert.Equal(t, "Homer J. Simpson", profile.PublicName)
+				assert.Equal(t, "avatar.png", profile.AvatarKey)
+				return nil
+			})
+		paymailsMq := mock.NewMockPaymailsUpdater(ctrl)
+		paymailsMq.EXPECT().
+			UpdateProfile(userID, xpriv, "Homer J. Simpson", avatarURL+"/avatar.png").
+			Return(nil)
+
+		sut := newService(repoMq, paymailsMq, blobstore.NewLocalStore(t.TempDir()))
+
+		// Act
+		result, err := sut.UpdatePublicName(userID, xpriv, " Homer J. Simpson ")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "Homer J. Simpson", result.PublicName)
+	})
+
+	t.Run("Invalid public name", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut := newService(mock.NewMockProfilesRepository(ctrl), mock.NewMockPaymailsUpdater(ctrl), blobstore.NewLocalStore(t.TempDir()))
+
+		for _, publicName := range []string{strings.Repeat("h", 101), "Homer\nSimpson"} {
+			// Act
+			result, err := sut.UpdatePublicName(userID, xpriv, publicName)
+
+			// Assert
+			require.ErrorIs(t, err, spverrors.ErrInvalidPublicName)
+			assert.Nil(t, result)
+		}
+	})
+
+	t.Run("SPV Wallet update fails, profile not stored", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockProfilesRepository(ctrl)
+		repoMq.EXPECT().GetProfile(gomock.Any(), userID).Return(nil, nil)
+		paymailsMq := mock.NewMockPaymailsUpdater(ctrl)
+		paymailsMq.EXPECT().
+			UpdateProfile(userID, xpriv, "Homer", "").
+			Return(spverrors.ErrUpdatePaymail)
+
+		sut := newService(repoMq, paymailsMq, blobstore.NewLocalStore(t.TempDir()))
+
+		// Act
+		result, err := sut.UpdatePublicName(userID, xpriv, "Homer")
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrUpdatePaymail)
+		assert.Nil(t, result)
+	})
+}
+
+func TestUploadAvatar(t *testing.T) {
+	t.Run("Avatar stored and previous one removed", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := blobstore.NewLocalStore(t.TempDir())
+		require.NoError(t, store.Put(context.Background(), "previous.png", pngImage))
+
+		repoMq := mock.NewMockProfilesRepository(ctrl)
+		repoMq.EXPECT().
+			GetProfile(gomock.Any(), userID).
+			Return(&profiles.Profile{UserID: userID, PublicName: "Homer", AvatarKey: "previous.png"}, nil)
+		repoMq.EXPECT().UpsertProfile(gomock.Any(), gomock.Any()).Return(nil)
+		paymailsMq := mock.NewMockPaymailsUpdater(ctrl)
+		paymailsMq.EXPECT().
+			UpdateProfile(userID, xpriv, "Homer", gomock.Not(avatarURL+"/previous.png")).
+			Return(nil)
+
+		sut := newService(repoMq, paymailsMq, store)
+
+		// Act
+		result, err := sut.UploadAvatar(userID, xpriv, pngImage)
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, strings.HasSuffix(result.AvatarKey, ".png"))
+		assert.Equal(t, avatarURL+"/"+result.AvatarKey, result.AvatarURL)
+
+		data, contentType, err := sut.GetAvatar(result.AvatarKey)
+		require.NoError(t, err)
+		assert.Equal(t, pngImage, data)
+		assert.Equal(t, "image/png", contentType)
+
+		_, _, err = sut.GetAvatar("previous.png")
+		require.ErrorIs(t, err, spverrors.ErrAvatarNotFound)
+	})
+
+	t.Run("Invalid avatar", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut := newService(mock.NewMockProfilesRepository(ctrl), mock.NewMockPaymailsUpdater(ctrl), blobstore.NewLocalStore(t.TempDir()))
+
+		for _, data := range [][]byte{nil, []byte("<svg></svg>"), append(pngImage, make([]byte, 1024)...)} {
+			// Act
+			result, err := sut.UploadAvatar(userID, xpriv, data)
+
+			// Assert
+			require.ErrorIs(t, err, spverrors.ErrInvalidAvatar)
+			assert.Nil(t, result)
+		}
+	})
+}
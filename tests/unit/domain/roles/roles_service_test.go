@@ -0,0 +1,155 @@
+package roles_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const userID = 1
+
+var allRoles = []*roles.Role{
+	{Name: roles.RoleAuditor, Permissions: []string{roles.PermissionWalletRead, roles.PermissionAccountManage}},
+	{Name: roles.RoleUser, Default: true, Permissions: []string{roles.PermissionWalletRead, roles.PermissionWalletSpend}},
+}
+
+func newService(ctrl *gomock.Controller) (*roles.Service, *mock.MockRolesRepository, *mock.MockRepository) {
+	testLogger := zerolog.Nop()
+	repoMq := mock.NewMockRolesRepository(ctrl)
+	usersRepoMq := mock.NewMockRepository(ctrl)
+	uService := users.NewUserService(usersRepoMq, mock.NewMockAdminWalletClient(ctrl), mock.NewMockWalletClientFactory(ctrl), nil, nil, nil, nil, nil, &testLogger)
+
+	return roles.NewRolesService(repoMq, uService, &testLogger), repoMq, usersRepoMq
+}
+
+func TestGetPermissions(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sut, repoMq, _ := newService(ctrl)
+	repoMq.EXPECT().
+		GetUserPermissions(gomock.Any(), userID).
+		Return([]string{roles.PermissionWalletRead, roles.PermissionAccountManage}, nil)
+
+	// Act
+	result, err := sut.GetPermissions(userID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, result.Has(roles.PermissionAccountManage))
+	assert.False(t, result.Has(roles.PermissionWalletSpend))
+}
+
+func TestGetUserRoles(t *testing.T) {
+	t.Run("User without assigned role has the default role", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut, repoMq, _ := newService(ctrl)
+		repoMq.EXPECT().GetUserRoles(gomock.Any(), userID).Return([]string{}, nil)
+		repoMq.EXPECT().GetUserPermissions(gomock.Any(), userID).Return([]string{roles.PermissionWalletRead, roles.PermissionWalletSpend}, nil)
+		repoMq.EXPECT().GetRoles(gomock.Any()).Return(allRoles, nil)
+
+		// Act
+		result, err := sut.GetUserRoles(userID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{roles.RoleUser}, result.Roles)
+		assert.Equal(t, []string{roles.PermissionWalletRead, roles.PermissionWalletSpend}, result.Permissions)
+	})
+
+	t.Run("Assigned roles replace the default role", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut, repoMq, _ := newService(ctrl)
+		repoMq.EXPECT().GetUserRoles(gomock.Any(), userID).Return([]string{roles.RoleAuditor}, nil)
+		repoMq.EXPECT().GetUserPermissions(gomock.Any(), userID).Return([]string{roles.PermissionAccountManage, roles.PermissionWalletRead}, nil)
+
+		// Act
+		result, err := sut.GetUserRoles(userID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{roles.RoleAuditor}, result.Roles)
+		assert.NotContains(t, result.Permissions, roles.PermissionWalletSpend)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut, repoMq, _ := newService(ctrl)
+		repoMq.EXPECT().GetUserRoles(gomock.Any(), userID).Return(nil, errors.New("db error"))
+
+		// Act
+		result, err := sut.GetUserRoles(userID)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrGetRoles)
+		assert.Nil(t, result)
+	})
+}
+
+func TestAssignRole(t *testing.T) {
+	t.Run("Role assigned", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut, repoMq, usersRepoMq := newService(ctrl)
+		usersRepoMq.EXPECT().GetUserByID(gomock.Any(), userID).Return(&users.User{ID: userID}, nil)
+		repoMq.EXPECT().GetRoles(gomock.Any()).Return(allRoles, nil)
+		repoMq.EXPECT().InsertUserRole(gomock.Any(), userID, roles.RoleAuditor, gomock.Any()).Return(nil)
+
+		// Act
+		err := sut.AssignRole(userID, roles.RoleAuditor)
+
+		// Assert
+		require.NoError(t, err)
+	})
+
+	t.Run("Role not defined", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut, repoMq, usersRepoMq := newService(ctrl)
+		usersRepoMq.EXPECT().GetUserByID(gomock.Any(), userID).Return(&users.User{ID: userID}, nil)
+		repoMq.EXPECT().GetRoles(gomock.Any()).Return(allRoles, nil)
+
+		// Act
+		err := sut.AssignRole(userID, "superuser")
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrInvalidRole)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut, _, usersRepoMq := newService(ctrl)
+		usersRepoMq.EXPECT().GetUserByID(gomock.Any(), userID).Return(nil, nil)
+
+		// Act
+		err := sut.AssignRole(userID, roles.RoleAuditor)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrUserNotFound)
+	})
+}
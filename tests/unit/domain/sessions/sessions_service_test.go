@@ -0,0 +1,243 @@
+package sessions_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/sessions"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const maxAge = 1800
+
+func TestSignOut(t *testing.T) {
+	testLogger := zerolog.Nop()
+
+	t.Run("Revokes access key and marks session as revoked", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockSessionsRepository(ctrl)
+		clientMq := mock.NewMockUserWalletClient(ctrl)
+		factoryMq := mock.NewMockWalletClientFactory(ctrl)
+
+		factoryMq.EXPECT().CreateWithAccessKey("key").Return(clientMq, nil)
+		clientMq.EXPECT().RevokeAccessKey("keyID").Return(nil, nil)
+		repoMq.EXPECT().MarkSessionRevoked(gomock.Any(), "keyID", gomock.Any()).Return(nil)
+
+		sut := newSessionsService(t, repoMq, factoryMq, &testLogger)
+
+		// Act
+		err := sut.SignOut("keyID", "key")
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Session stays tracked when access key cannot be revoked", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockSessionsRepository(ctrl)
+		clientMq := mock.NewMockUserWalletClient(ctrl)
+		factoryMq := mock.NewMockWalletClientFactory(ctrl)
+
+		factoryMq.EXPECT().CreateWithAccessKey("key").Return(clientMq, nil)
+		clientMq.EXPECT().RevokeAccessKey("keyID").Return(nil, errors.New("spv-wallet unavailable"))
+
+		sut := newSessionsService(t, repoMq, factoryMq, &testLogger)
+
+		// Act
+		err := sut.SignOut("keyID", "key")
+
+		// Assert
+		assert.ErrorIs(t, err, spverrors.ErrSessionTerminate)
+	})
+}
+
+func TestCreateSession(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockSessionsRepository(ctrl)
+	repoMq.EXPECT().InsertSession(gomock.Any(), gomock.Any()).Return(nil)
+
+	sut := newSessionsService(t, repoMq, mock.NewMockWalletClientFactory(ctrl), &testLogger)
+
+	// Act
+	session, err := sut.CreateSession(1, users.AccessKey{ID: "keyID", Key: "key"}, "127.0.0.1", strings.Repeat("a", 600))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "keyID", session.AccessKeyID)
+	assert.NotEqual(t, "key", session.AccessKey)
+	accessKey, err := newSealer(t).Open(context.Background(), session.AccessKey)
+	require.NoError(t, err)
+	assert.Equal(t, "key", accessKey)
+	assert.Equal(t, "127.0.0.1", session.IP)
+	assert.Len(t, session.UserAgent, 512)
+	assert.Equal(t, session.CreatedAt, session.LastSeenAt)
+	assert.Equal(t, session.CreatedAt.Add(maxAge*time.Second), session.ExpiresAt)
+}
+
+func TestTouchSession(t *testing.T) {
+	testLogger := zerolog.Nop()
+	cases := []struct {
+		name        string
+		active      bool
+		expectedErr error
+	}{
+		{
+			name:   "Active session",
+			active: true,
+		},
+		{
+			name:        "Revoked or expired session",
+			active:      false,
+			expectedErr: spverrors.ErrSessionNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockSessionsRepository(ctrl)
+			repoMq.EXPECT().TouchSession(gomock.Any(), 1, "keyID", gomock.Any()).Return(tc.active, nil)
+
+			sut := newSessionsService(t, repoMq, mock.NewMockWalletClientFactory(ctrl), &testLogger)
+
+			// Act
+			err := sut.TouchSession(1, "keyID")
+
+			// Assert
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestRevokeSession_NotFound(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockSessionsRepository(ctrl)
+	repoMq.EXPECT().GetActiveUserSession(gomock.Any(), 1, 2).Return(nil, nil)
+
+	sut := newSessionsService(t, repoMq, mock.NewMockWalletClientFactory(ctrl), &testLogger)
+
+	// Act
+	session, err := sut.RevokeSession(1, 2)
+
+	// Assert
+	assert.ErrorIs(t, err, spverrors.ErrSessionNotFound)
+	assert.Nil(t, session)
+}
+
+func TestTerminateUserSessions(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockSessionsRepository(ctrl)
+	clientMq := mock.NewMockUserWalletClient(ctrl)
+	factoryMq := mock.NewMockWalletClientFactory(ctrl)
+
+	active := []*sessions.Session{
+		{ID: 1, UserID: 1, AccessKeyID: "revoked", AccessKey: seal(t, "key1")},
+		{ID: 2, UserID: 1, AccessKeyID: "failed", AccessKey: seal(t, "key2")},
+	}
+	repoMq.EXPECT().GetActiveUserSessions(gomock.Any(), 1).Return(active, nil)
+
+	factoryMq.EXPECT().CreateWithAccessKey("key1").Return(clientMq, nil)
+	factoryMq.EXPECT().CreateWithAccessKey("key2").Return(clientMq, nil)
+	clientMq.EXPECT().RevokeAccessKey("revoked").Return(nil, nil)
+	clientMq.EXPECT().RevokeAccessKey("failed").Return(nil, errors.New("spv-wallet unavailable"))
+	repoMq.EXPECT().MarkSessionRevoked(gomock.Any(), "revoked", gomock.Any()).Return(nil)
+
+	// Session whose access key cannot be revoked is rejected by the backend anyway
+	repoMq.EXPECT().MarkUserSessionsRevoked(gomock.Any(), 1, "", gomock.Any()).Return(nil)
+
+	sut := newSessionsService(t, repoMq, factoryMq, &testLogger)
+
+	// Act
+	terminated, err := sut.TerminateUserSessions(1)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, terminated)
+}
+
+func TestRevokeExpiredSessions(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockSessionsRepository(ctrl)
+	clientMq := mock.NewMockUserWalletClient(ctrl)
+	factoryMq := mock.NewMockWalletClientFactory(ctrl)
+
+	now := time.Now()
+	expired := []*sessions.Session{
+		{ID: 1, AccessKeyID: "revoked", AccessKey: seal(t, "key1"), ExpiresAt: now.Add(-time.Minute)},
+		{ID: 2, AccessKeyID: "retried", AccessKey: seal(t, "key2"), ExpiresAt: now.Add(-time.Minute)},
+		{ID: 3, AccessKeyID: "abandoned", AccessKey: seal(t, "key3"), ExpiresAt: now.Add(-2 * maxAge * time.Second)},
+	}
+	repoMq.EXPECT().GetExpiredSessions(gomock.Any(), gomock.Any(), gomock.Any()).Return(expired, nil)
+
+	factoryMq.EXPECT().CreateWithAccessKey(gomock.Any()).Return(clientMq, nil).Times(3)
+	clientMq.EXPECT().RevokeAccessKey("revoked").Return(nil, nil)
+	clientMq.EXPECT().RevokeAccessKey("retried").Return(nil, errors.New("spv-wallet unavailable"))
+	clientMq.EXPECT().RevokeAccessKey("abandoned").Return(nil, errors.New("unauthorized"))
+
+	// Session which failed recently is left for the next run
+	repoMq.EXPECT().MarkSessionRevoked(gomock.Any(), "revoked", gomock.Any()).Return(nil)
+	repoMq.EXPECT().MarkSessionRevoked(gomock.Any(), "abandoned", gomock.Any()).Return(nil)
+
+	sut := newSessionsService(t, repoMq, factoryMq, &testLogger)
+
+	// Act & Assert
+	sut.RevokeExpiredSessions(context.Background())
+}
+
+func newSessionsService(t *testing.T, repo sessions.Repository, factory *mock.MockWalletClientFactory, log *zerolog.Logger) *sessions.Service {
+	viper.Set(config.EnvHTTPServerSessionMaxAge, maxAge)
+	return sessions.NewSessionsService(repo, factory, newSealer(t), log)
+}
+
+func newSealer(t *testing.T) *encryption.Sealer {
+	sealer, err := encryption.NewSealer("secret", nil)
+	require.NoError(t, err)
+	return sealer
+}
+
+func seal(t *testing.T, accessKey string) string {
+	sealed, err := newSealer(t).Seal(context.Background(), accessKey)
+	require.NoError(t, err)
+	return sealed
+}
@@ -0,0 +1,295 @@
+package tokens_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/tokens"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userID       = 1
+	sealerSecret = "test-sealer-secret"
+)
+
+type mocks struct {
+	repo          *mock.MockTokensRepository
+	users         *mock.MockRepository
+	clientFactory *mock.MockWalletClientFactory
+	walletClient  *mock.MockUserWalletClient
+}
+
+func newService(t *testing.T, ctrl *gomock.Controller) (*tokens.Service, *mocks) {
+	testLogger := zerolog.Nop()
+	sealer, err := encryption.NewSealer(sealerSecret, nil)
+	require.NoError(t, err)
+
+	m := &mocks{
+		repo:          mock.NewMockTokensRepository(ctrl),
+		users:         mock.NewMockRepository(ctrl),
+		clientFactory: mock.NewMockWalletClientFactory(ctrl),
+		walletClient:  mock.NewMockUserWalletClient(ctrl),
+	}
+	uService := users.NewUserService(m.users, nil, m.clientFactory, nil, nil, nil, nil, nil, &testLogger)
+
+	return tokens.NewTokensService(m.repo, uService, m.clientFactory, sealer, &testLogger), m
+}
+
+func newAccessKey(ctrl *gomock.Controller, id, key string) *mock.MockAccKey {
+	accessKey := mock.NewMockAccKey(ctrl)
+	accessKey.EXPECT().GetAccessKeyID().Return(id).AnyTimes()
+	accessKey.EXPECT().GetAccessKey().Return(key).AnyTimes()
+	return accessKey
+}
+
+// create creates token with the scopes and returns it as stored in db together with its secret.
+func create(t *testing.T, ctrl *gomock.Controller, sut *tokens.Service, m *mocks, scopes []string, expiresAt *time.Time) (*tokens.Token, string) {
+	m.clientFactory.EXPECT().CreateWithXpriv("xpriv").Return(m.walletClient, nil)
+	m.walletClient.EXPECT().CreateAccessKey().Return(newAccessKey(ctrl, "token-key-id", "token-access-key"), nil)
+
+	var stored *tokens.Token
+	m.repo.EXPECT().InsertToken(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, token *tokens.Token) error {
+			token.ID = 2
+			stored = token
+			return nil
+		})
+
+	result, err := sut.CreateToken(userID, "Accounting", scopes, expiresAt, "xpriv")
+	require.NoError(t, err)
+	return stored, result.Secret
+}
+
+func TestCreateToken(t *testing.T) {
+	t.Run("Create token", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(t, ctrl)
+
+		// Act
+		stored, secret := create(t, ctrl, sut, m, []string{tokens.ScopeReadOnly, tokens.ScopeSend, tokens.ScopeReadOnly}, nil)
+
+		// Assert
+		require.NotNil(t, stored)
+		assert.True(t, strings.HasPrefix(secret, "spvw_"))
+		assert.True(t, strings.HasPrefix(secret, stored.Prefix))
+		assert.Less(t, len(stored.Prefix), len(secret))
+		assert.Equal(t, []string{tokens.ScopeReadOnly, tokens.ScopeSend}, stored.Scopes)
+		assert.Equal(t, "token-key-id", stored.AccessKeyID)
+
+		// Neither token nor access key is stored in plain text
+		assert.NotContains(t, stored.Hash, secret)
+		assert.Len(t, stored.Hash, 64)
+		assert.NotContains(t, stored.AccessKey, "token-access-key")
+	})
+
+	tests := map[string]struct {
+		scopes      []string
+		expiresAt   time.Time
+		expectedErr error
+	}{
+		"No scopes": {
+			expectedErr: spverrors.ErrInvalidAPITokenScopes,
+		},
+		"Unknown scope": {
+			scopes:      []string{tokens.ScopeReadOnly, "admin"},
+			expectedErr: spverrors.ErrInvalidAPITokenScopes,
+		},
+		"Expiration in the past": {
+			scopes:      []string{tokens.ScopeReadOnly},
+			expiresAt:   time.Now().Add(-time.Hour),
+			expectedErr: spverrors.ErrInvalidAPITokenExpiration,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			sut, _ := newService(t, ctrl)
+			var expiresAt *time.Time
+			if !tc.expiresAt.IsZero() {
+				expiresAt = &tc.expiresAt
+			}
+
+			// Act
+			result, err := sut.CreateToken(userID, "Accounting", tc.scopes, expiresAt, "xpriv")
+
+			// Assert
+			require.ErrorIs(t, err, tc.expectedErr)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	t.Run("Valid token", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(t, ctrl)
+		stored, secret := create(t, ctrl, sut, m, []string{tokens.ScopeReadOnly}, nil)
+
+		m.repo.EXPECT().GetTokenByHash(gomock.Any(), stored.Hash).Return(stored, nil)
+		m.users.EXPECT().GetUserByID(gomock.Any(), userID).Return(&users.User{ID: userID, Paymail: "alice@example.com"}, nil)
+		m.repo.EXPECT().TouchToken(gomock.Any(), stored.ID, gomock.Any()).Return(nil)
+
+		// Act
+		result, err := sut.Authenticate(secret)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "token-access-key", result.AccessKey)
+		assert.Equal(t, "alice@example.com", result.Paymail)
+		assert.Equal(t, stored.ID, result.Token.ID)
+		assert.NotNil(t, result.Token.LastUsedAt)
+	})
+
+	t.Run("Recently used token isn't touched", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(t, ctrl)
+		stored, secret := create(t, ctrl, sut, m, []string{tokens.ScopeReadOnly}, nil)
+		usedAt := time.Now().Add(-time.Second)
+		stored.LastUsedAt = &usedAt
+
+		m.repo.EXPECT().GetTokenByHash(gomock.Any(), stored.Hash).Return(stored, nil)
+		m.users.EXPECT().GetUserByID(gomock.Any(), userID).Return(&users.User{ID: userID}, nil)
+
+		// Act
+		result, err := sut.Authenticate(secret)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, &usedAt, result.Token.LastUsedAt)
+	})
+
+	t.Run("Access key not sealed by the sealer is refused", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(t, ctrl)
+		stored, secret := create(t, ctrl, sut, m, []string{tokens.ScopeReadOnly}, nil)
+		encrypted, err := encryption.Encrypt(sealerSecret, "token-access-key")
+		require.NoError(t, err)
+		stored.AccessKey = encrypted
+
+		m.repo.EXPECT().GetTokenByHash(gomock.Any(), stored.Hash).Return(stored, nil)
+
+		// Act
+		result, err := sut.Authenticate(secret)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrInvalidAPIToken)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Unknown token", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(t, ctrl)
+		m.repo.EXPECT().GetTokenByHash(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		// Act
+		result, err := sut.Authenticate("spvw_unknown")
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrInvalidAPIToken)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(t, ctrl)
+		expiresAt := time.Now().Add(time.Hour)
+		stored, secret := create(t, ctrl, sut, m, []string{tokens.ScopeReadOnly}, &expiresAt)
+		expired := time.Now().Add(-time.Second)
+		stored.ExpiresAt = &expired
+
+		m.repo.EXPECT().GetTokenByHash(gomock.Any(), stored.Hash).Return(stored, nil)
+
+		// Act
+		result, err := sut.Authenticate(secret)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrInvalidAPIToken)
+		assert.Nil(t, result)
+	})
+}
+
+func TestRevokeToken(t *testing.T) {
+	t.Run("Revoke token and its access key", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(t, ctrl)
+		stored, _ := create(t, ctrl, sut, m, []string{tokens.ScopeReadOnly}, nil)
+
+		m.repo.EXPECT().DeleteToken(gomock.Any(), userID, stored.ID).Return(stored, nil)
+		m.clientFactory.EXPECT().CreateWithAccessKey("token-access-key").Return(m.walletClient, nil)
+		m.walletClient.EXPECT().RevokeAccessKey("token-key-id").Return(nil, nil)
+
+		// Act
+		err := sut.RevokeToken(userID, stored.ID)
+
+		// Assert
+		require.NoError(t, err)
+	})
+
+	t.Run("Token of another user", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		sut, m := newService(t, ctrl)
+		m.repo.EXPECT().DeleteToken(gomock.Any(), userID, 2).Return(nil, nil)
+
+		// Act
+		err := sut.RevokeToken(userID, 2)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrAPITokenNotFound)
+	})
+}
+
+func TestRevokeUserTokens(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	sut, m := newService(t, ctrl)
+	stored, _ := create(t, ctrl, sut, m, []string{tokens.ScopeReadOnly}, nil)
+	other := *stored
+	other.ID, other.AccessKeyID = 3, "other-key-id"
+
+	m.repo.EXPECT().DeleteUserTokens(gomock.Any(), userID).Return([]*tokens.Token{stored, &other}, nil)
+	m.clientFactory.EXPECT().CreateWithAccessKey("token-access-key").Return(m.walletClient, nil).Times(2)
+	m.walletClient.EXPECT().RevokeAccessKey("token-key-id").Return(nil, nil)
+	m.walletClient.EXPECT().RevokeAccessKey("other-key-id").Return(nil, errors.New("already revoked"))
+
+	// Act
+	revoked, err := sut.RevokeUserTokens(userID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, revoked)
+}
+
+func TestPermissions(t *testing.T) {
+	// Arrange
+	token := &tokens.Token{Scopes: []string{tokens.ScopeReadOnly, tokens.ScopeContacts}}
+
+	// Act
+	result := token.Permissions()
+
+	// Assert
+	assert.True(t, result.Has(roles.PermissionWalletRead))
+	assert.True(t, result.Has(roles.PermissionContactsWrite))
+	assert.False(t, result.Has(roles.PermissionWalletSpend))
+	assert.False(t, result.Has(roles.PermissionAccountManage))
+}
@@ -1,63 +1,593 @@
 package transactions_test
 
 import (
+	"context"
 	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/bitcoin-sv/spv-wallet-go-client/commands"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/outbox"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/rates"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/transactions"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/notification"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/tests/data"
 	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/tests/utils"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/spvwallet"
+	"github.com/bitcoin-sv/spv-wallet/models"
+	"github.com/bitcoin-sv/spv-wallet/models/filter"
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/golang/mock/gomock"
 	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func TestCreateTransaction(t *testing.T) {
+func newSealer(t *testing.T) *encryption.Sealer {
+	sealer, err := encryption.NewSealer("secret", nil)
+	require.NoError(t, err)
+	return sealer
+}
+
+// newPreviewsRepo returns repository mock which keeps previews in memory like the database does.
+func newPreviewsRepo(ctrl *gomock.Controller) *mock.MockTransactionsRepository {
+	var mutex sync.Mutex
+	previews := make(map[string]transactions.Preview)
+	sending := make(map[string]bool)
+
+	repo := mock.NewMockTransactionsRepository(ctrl)
+	repo.EXPECT().
+		InsertPreview(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, preview *transactions.Preview, now time.Time) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			for token, p := range previews {
+				if (p.UserID == preview.UserID && !sending[token]) || !p.ExpiresAt.After(now) {
+					delete(previews, token)
+				}
+			}
+			previews[preview.Token] = *preview
+			return nil
+		}).
+		AnyTimes()
+	repo.EXPECT().
+		GetPreview(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, userID int, token string) (*transactions.Preview, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			p, ok := previews[token]
+			if !ok || p.UserID != userID {
+				return nil, nil
+			}
+			return &p, nil
+		}).
+		AnyTimes()
+	repo.EXPECT().
+		ClaimPreview(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, userID int, token string, now time.Time) (*transactions.Preview, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			p, ok := previews[token]
+			if !ok || p.UserID != userID || sending[token] || !p.ExpiresAt.After(now) {
+				return nil, nil
+			}
+			sending[token] = true
+			return &p, nil
+		}).
+		AnyTimes()
+	repo.EXPECT().
+		ReleasePreview(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, token string) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			delete(sending, token)
+			return nil
+		}).
+		AnyTimes()
+	repo.EXPECT().
+		DeletePreview(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, token string) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			delete(previews, token)
+			delete(sending, token)
+			return nil
+		}).
+		AnyTimes()
+	return repo
+}
+
+func TestPreviewTransaction(t *testing.T) {
 	testLogger := zerolog.Nop()
-	t.Run("Create transaction", func(t *testing.T) {
+	paymail := "paymail@example.com"
+	accessKey := gofakeit.HexUint256()
+	userID := 1
+
+	t.Run("Preview transaction", func(t *testing.T) {
 		// Arrange
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
 
-		paymail := "paymail@example.com"
-		xpriv := gofakeit.HexUint256()
-		recipient := "recipient.paymail@example.com"
-		txValueInSatoshis := uint64(500)
+		rateServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"rate": 50}`))
+		}))
+		defer rateServer.Close()
+		viper.Set(config.EnvEndpointsExchangeRate, rateServer.URL)
+		viper.Set(config.EnvTransactionsPreviewTTL, time.Minute)
+		viper.Set(config.EnvTransactionsRecordWindow, 10*time.Minute)
 
-		tr := spvwallet.DraftTransaction{}
+		recipients := []transactions.Recipient{
+			{Recipient: "alice@example.com", Satoshis: 300},
+			{Recipient: "bob@example.com", Satoshis: 200, OpReturn: "invoice 42"},
+		}
+		draft := &spvwallet.DraftTransaction{
+			TxDraftID: "draft-id",
+			Fee:       1,
+			Change:    99,
+			Inputs:    []users.DraftInput{{TransactionID: "input-tx", Satoshis: 600}},
+			Outputs: []users.DraftOutput{
+				{To: "alice@example.com", Satoshis: 300},
+				{To: "bob@example.com", Satoshis: 200},
+				{To: "change-address", Satoshis: 99, Change: true},
+			},
+		}
 
 		mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
 		mockUserWalletClient.EXPECT().
-			CreateAndFinalizeTransaction(gomock.Any(), gomock.Any()).
-			Return(&tr, nil)
+			GetXPub().
+			Return(&spvwallet.XPub{CurrentBalance: 600}, nil)
 
+		var outputs []*commands.Recipients
+		var metadata map[string]any
 		mockUserWalletClient.EXPECT().
-			RecordTransaction(gomock.Any(), gomock.Any(), gomock.Any()).
-			AnyTimes()
+			DraftToRecipients(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(r []*commands.Recipients, m map[string]any, expiresIn time.Duration) (users.DraftTransaction, error) {
+				outputs, metadata = r, m
+				assert.Equal(t, 11*time.Minute, expiresIn)
+				return draft, nil
+			})
 
 		clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
 		clientFctrMq.EXPECT().
-			CreateWithXpriv(xpriv).
+			CreateWithAccessKey(accessKey).
 			Return(mockUserWalletClient, nil)
 
-		sut := transactions.NewTransactionService(mock.NewMockAdminWalletClient(ctrl), clientFctrMq, &testLogger)
+		sut := transactions.NewTransactionService(newPreviewsRepo(ctrl), mock.NewMockAdminWalletClient(ctrl), clientFctrMq, rates.NewRatesService(&testLogger), nil, &testLogger)
 
 		// Act
-		txs := make(chan notification.TransactionEvent, 1)
-		err := sut.CreateTransaction(paymail, xpriv, recipient, txValueInSatoshis, txs)
-		if err != nil {
-			t.Fatal(err)
+		preview, err := sut.PreviewTransaction(userID, paymail, accessKey, recipients)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotEmpty(t, preview.Token)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), preview.ExpiresAt, time.Second)
+		assert.Equal(t, uint64(500), preview.Amount)
+		assert.Equal(t, uint64(1), preview.Fee)
+		assert.Equal(t, uint64(99), preview.Change)
+		assert.Equal(t, draft.Inputs, preview.Inputs)
+		assert.Equal(t, draft.Outputs, preview.Outputs)
+		require.NotNil(t, preview.USD)
+		assert.InDelta(t, 0.00025, preview.USD.Amount, 1e-12)
+		assert.InDelta(t, 0.0000005, preview.USD.Fee, 1e-12)
+		assert.InDelta(t, 0.0002505, preview.USD.Total, 1e-12)
+
+		require.Len(t, outputs, 2)
+		assert.Equal(t, "alice@example.com", outputs[0].To)
+		assert.Equal(t, uint64(300), outputs[0].Satoshis)
+		assert.Nil(t, outputs[0].OpReturn)
+		assert.Equal(t, "bob@example.com", outputs[1].To)
+		require.NotNil(t, outputs[1].OpReturn)
+		assert.Equal(t, []string{"invoice 42"}, outputs[1].OpReturn.StringParts)
+
+		assert.Equal(t, paymail, metadata["sender"])
+		assert.Equal(t, "alice@example.com", metadata["receiver"])
+		assert.Equal(t, []string{"alice@example.com", "bob@example.com"}, metadata["receivers"])
+
+		stored, err := sut.GetPreview(userID, preview.Token)
+		require.NoError(t, err)
+		assert.True(t, stored.SameRecipients([]transactions.Recipient{recipients[1], recipients[0]}))
+		assert.False(t, stored.SameRecipients(recipients[:1]))
+
+		_, err = sut.GetPreview(userID+1, preview.Token)
+		require.ErrorIs(t, err, spverrors.ErrInvalidTransactionPreview)
+	})
+
+	t.Run("Total amount exceeds balance", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
+		mockUserWalletClient.EXPECT().
+			GetXPub().
+			Return(&spvwallet.XPub{CurrentBalance: 499}, nil)
+
+		clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+		clientFctrMq.EXPECT().
+			CreateWithAccessKey(accessKey).
+			Return(mockUserWalletClient, nil)
+
+		sut := transactions.NewTransactionService(nil, mock.NewMockAdminWalletClient(ctrl), clientFctrMq, nil, nil, &testLogger)
+		recipients := []transactions.Recipient{
+			{Recipient: "alice@example.com", Satoshis: 300},
+			{Recipient: "bob@example.com", Satoshis: 200},
 		}
+
+		// Act
+		preview, err := sut.PreviewTransaction(userID, paymail, accessKey, recipients)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrInsufficientBalance)
+		assert.Nil(t, preview)
+	})
+}
+
+func TestCreateTransaction(t *testing.T) {
+	testLogger := zerolog.Nop()
+	paymail := "paymail@example.com"
+	accessKey := gofakeit.HexUint256()
+	xpriv := gofakeit.HexUint256()
+	userID := 1
+	recipients := []transactions.Recipient{{Recipient: "recipient.paymail@example.com", Satoshis: 500}}
+
+	// preview creates service with the previewed transaction.
+	preview := func(ctrl *gomock.Controller, draft users.DraftTransaction, ttl time.Duration) (*transactions.TransactionService, *mock.MockUserWalletClient, *mock.MockOutboxRepository, string) {
+		viper.Set(config.EnvTransactionsPreviewTTL, ttl)
+		viper.Set(config.EnvTransactionsRecordWindow, time.Minute)
+		viper.Set(config.EnvTransactionsOutboxMaxBackoff, time.Minute)
+
+		mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
+		mockUserWalletClient.EXPECT().
+			GetXPub().
+			Return(&spvwallet.XPub{CurrentBalance: 1000}, nil)
+		mockUserWalletClient.EXPECT().
+			DraftToRecipients(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(draft, nil)
+
+		clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+		clientFctrMq.EXPECT().
+			CreateWithAccessKey(accessKey).
+			Return(mockUserWalletClient, nil)
+		clientFctrMq.EXPECT().
+			CreateWithXpriv(xpriv).
+			Return(mockUserWalletClient, nil).
+			AnyTimes()
+
+		outboxRepoMq := mock.NewMockOutboxRepository(ctrl)
+		outboxService := outbox.NewOutboxService(outboxRepoMq, clientFctrMq, newSealer(t), &testLogger)
+
+		sut := transactions.NewTransactionService(newPreviewsRepo(ctrl), mock.NewMockAdminWalletClient(ctrl), clientFctrMq, nil, outboxService, &testLogger)
+		p, err := sut.PreviewTransaction(userID, paymail, accessKey, recipients)
+		require.NoError(t, err)
+		return sut, mockUserWalletClient, outboxRepoMq, p.Token
+	}
+
+	// enqueue expects the signed transaction to be written to the outbox.
+	enqueue := func(mockUserWalletClient *mock.MockUserWalletClient, outboxRepoMq *mock.MockOutboxRepository) *outbox.Entry {
+		entry := &outbox.Entry{}
+		mockUserWalletClient.EXPECT().
+			CreateAccessKey().
+			Return(&spvwallet.AccessKey{ID: "access-key-id", Key: gofakeit.HexUint256()}, nil)
+		outboxRepoMq.EXPECT().
+			InsertEntry(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, e *outbox.Entry) error {
+				*entry = *e
+				return nil
+			})
+		return entry
+	}
+
+	t.Run("Send previewed transaction", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		draft := &spvwallet.DraftTransaction{TxDraftID: "draft-id"}
+		sut, mockUserWalletClient, outboxRepoMq, token := preview(ctrl, draft, time.Minute)
+		mockUserWalletClient.EXPECT().
+			FinalizeTransaction(draft).
+			Return(&spvwallet.DraftTransaction{TxDraftID: "draft-id", TxHex: "0100"}, nil)
+		entry := enqueue(mockUserWalletClient, outboxRepoMq)
+		mockUserWalletClient.EXPECT().
+			RecordTransaction("0100", "draft-id", gomock.Any()).
+			Return(&models.Transaction{ID: "tx-id"}, nil)
+		mockUserWalletClient.EXPECT().
+			RevokeAccessKey("access-key-id").
+			Return(&spvwallet.AccessKey{ID: "access-key-id"}, nil)
+		outboxRepoMq.EXPECT().
+			UpdateEntry(gomock.Any(), gomock.Any()).
+			Return(nil)
+		events := make(chan notification.TransactionEvent, 1)
+
+		// Act
+		sent, err := sut.CreateTransaction(userID, xpriv, token, events)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "draft-id", sent.DraftID)
+		assert.Equal(t, entry.TransactionID, sent.TransactionID)
+		event := <-events
+		assert.Equal(t, "success", event.Status)
+		assert.Equal(t, "tx-id", event.Transaction.ID)
+
+		assert.Equal(t, userID, entry.UserID)
+		assert.Equal(t, "draft-id", entry.DraftID)
+		assert.Equal(t, "0100", entry.Hex)
+		assert.Equal(t, uint64(500), entry.TotalValue)
+		assert.Equal(t, paymail, entry.Metadata["sender"])
+		assert.Equal(t, outbox.StatusPending, entry.Status)
+		assert.NotEqual(t, "access-key-id", entry.AccessKey)
+		assert.WithinDuration(t, time.Now().Add(2*time.Minute), entry.ExpiresAt, time.Second)
+
+		_, err = sut.GetPreview(userID, token)
+		require.ErrorIs(t, err, spverrors.ErrInvalidTransactionPreview)
+	})
+
+	t.Run("Transaction which is not recorded is pending", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		draft := &spvwallet.DraftTransaction{TxDraftID: "draft-id"}
+		sut, mockUserWalletClient, outboxRepoMq, token := preview(ctrl, draft, time.Minute)
+		mockUserWalletClient.EXPECT().
+			FinalizeTransaction(draft).
+			Return(&spvwallet.DraftTransaction{TxDraftID: "draft-id", TxHex: "0100"}, nil)
+		enqueue(mockUserWalletClient, outboxRepoMq)
+		mockUserWalletClient.EXPECT().
+			RecordTransaction(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("spv wallet is down"))
+		mockUserWalletClient.EXPECT().
+			GetTransaction(gomock.Any(), paymail).
+			Return(nil, errors.New("spv wallet is down"))
+		var updated outbox.Entry
+		outboxRepoMq.EXPECT().
+			UpdateEntry(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, e *outbox.Entry) error {
+				updated = *e
+				return nil
+			})
+		events := make(chan notification.TransactionEvent, 1)
+
+		// Act
+		_, err := sut.CreateTransaction(userID, xpriv, token, events)
+
+		// Assert
+		require.NoError(t, err)
+		event := <-events
+		assert.Equal(t, "pending", event.Status)
+		assert.Equal(t, outbox.TransactionStatusPendingRecord, event.Transaction.Status)
+		assert.Equal(t, uint64(500), event.Transaction.TotalValue)
+		assert.Equal(t, outbox.StatusPending, updated.Status)
+		assert.Equal(t, "spv wallet is down", updated.LastError)
+		assert.WithinDuration(t, time.Now().Add(5*time.Second), updated.NextAttemptAt, time.Second)
+	})
+
+	t.Run("Transaction is not sent if it can't be written to the outbox", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		draft := &spvwallet.DraftTransaction{TxDraftID: "draft-id"}
+		sut, mockUserWalletClient, outboxRepoMq, token := preview(ctrl, draft, time.Minute)
+		mockUserWalletClient.EXPECT().
+			FinalizeTransaction(draft).
+			Return(&spvwallet.DraftTransaction{TxDraftID: "draft-id", TxHex: "0100"}, nil)
+		mockUserWalletClient.EXPECT().
+			CreateAccessKey().
+			Return(&spvwallet.AccessKey{ID: "access-key-id", Key: gofakeit.HexUint256()}, nil)
+		outboxRepoMq.EXPECT().
+			InsertEntry(gomock.Any(), gomock.Any()).
+			Return(errors.New("db is down"))
+
+		// Act
+		_, err := sut.CreateTransaction(userID, xpriv, token, make(chan notification.TransactionEvent, 1))
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrCreateTransaction)
+		_, err = sut.GetPreview(userID, token)
+		require.NoError(t, err)
+	})
+
+	t.Run("Preview can be sent again if signing fails", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		draft := &spvwallet.DraftTransaction{TxDraftID: "draft-id"}
+		sut, mockUserWalletClient, outboxRepoMq, token := preview(ctrl, draft, time.Minute)
+		gomock.InOrder(
+			mockUserWalletClient.EXPECT().
+				FinalizeTransaction(draft).
+				Return(nil, errors.New("spv wallet is down")),
+			mockUserWalletClient.EXPECT().
+				FinalizeTransaction(draft).
+				Return(&spvwallet.DraftTransaction{TxDraftID: "draft-id", TxHex: "0100"}, nil),
+		)
+		enqueue(mockUserWalletClient, outboxRepoMq)
+		mockUserWalletClient.EXPECT().
+			RecordTransaction(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&models.Transaction{ID: "tx-id"}, nil)
+		mockUserWalletClient.EXPECT().
+			RevokeAccessKey(gomock.Any()).
+			Return(&spvwallet.AccessKey{}, nil)
+		outboxRepoMq.EXPECT().
+			UpdateEntry(gomock.Any(), gomock.Any()).
+			Return(nil)
+		_, failErr := sut.CreateTransaction(userID, xpriv, token, make(chan notification.TransactionEvent, 1))
+		events := make(chan notification.TransactionEvent, 1)
+
+		// Act
+		sent, err := sut.CreateTransaction(userID, xpriv, token, events)
+
+		// Assert
+		require.ErrorIs(t, failErr, spverrors.ErrCreateTransaction)
+		require.NoError(t, err)
+		assert.Equal(t, "draft-id", sent.DraftID)
+		<-events
+	})
+
+	t.Run("Preview is sent only once", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		draft := &spvwallet.DraftTransaction{TxDraftID: "draft-id"}
+		sut, mockUserWalletClient, outboxRepoMq, token := preview(ctrl, draft, time.Minute)
+		mockUserWalletClient.EXPECT().
+			FinalizeTransaction(draft).
+			Return(&spvwallet.DraftTransaction{TxDraftID: "draft-id", TxHex: "0100"}, nil).
+			Times(1)
+		enqueue(mockUserWalletClient, outboxRepoMq)
+		mockUserWalletClient.EXPECT().
+			RecordTransaction(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&models.Transaction{ID: "tx-id"}, nil)
+		mockUserWalletClient.EXPECT().
+			RevokeAccessKey(gomock.Any()).
+			Return(&spvwallet.AccessKey{}, nil)
+		outboxRepoMq.EXPECT().
+			UpdateEntry(gomock.Any(), gomock.Any()).
+			Return(nil)
+		events := make(chan notification.TransactionEvent, 1)
+		_, err := sut.CreateTransaction(userID, xpriv, token, events)
+		require.NoError(t, err)
+		<-events
+
+		// Act
+		_, err = sut.CreateTransaction(userID, xpriv, token, make(chan notification.TransactionEvent, 1))
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrInvalidTransactionPreview)
+	})
+
+	t.Run("Preview which is being sent is not sent again", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		draft := &spvwallet.DraftTransaction{TxDraftID: "draft-id"}
+		sut, mockUserWalletClient, _, token := preview(ctrl, draft, time.Minute)
+		signing := make(chan struct{})
+		release := make(chan struct{})
+		mockUserWalletClient.EXPECT().
+			FinalizeTransaction(draft).
+			DoAndReturn(func(users.DraftTransaction) (users.DraftTransaction, error) {
+				close(signing)
+				<-release
+				return nil, errors.New("spv wallet is down")
+			}).
+			Times(1)
+		failed := make(chan error, 1)
+		go func() {
+			_, err := sut.CreateTransaction(userID, xpriv, token, make(chan notification.TransactionEvent, 1))
+			failed <- err
+		}()
+		<-signing
+
+		// Act
+		_, err := sut.CreateTransaction(userID, xpriv, token, make(chan notification.TransactionEvent, 1))
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrInvalidTransactionPreview)
+		close(release)
+		require.ErrorIs(t, <-failed, spverrors.ErrCreateTransaction)
+	})
+
+	t.Run("Preview of another user is not sent", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut, _, _, token := preview(ctrl, &spvwallet.DraftTransaction{TxDraftID: "draft-id"}, time.Minute)
+
+		// Act
+		_, err := sut.CreateTransaction(userID+1, xpriv, token, make(chan notification.TransactionEvent, 1))
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrInvalidTransactionPreview)
+	})
+
+	t.Run("Expired preview is not sent", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut, _, _, token := preview(ctrl, &spvwallet.DraftTransaction{TxDraftID: "draft-id"}, -time.Second)
+
+		// Act
+		_, err := sut.CreateTransaction(userID, xpriv, token, make(chan notification.TransactionEvent, 1))
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrInvalidTransactionPreview)
 	})
 }
 
+func TestValidateRecipients(t *testing.T) {
+	tests := map[string]struct {
+		recipients    []transactions.Recipient
+		expectedTotal uint64
+		expectedErr   error
+	}{
+		"Several recipients": {
+			recipients: []transactions.Recipient{
+				{Recipient: "alice@example.com", Satoshis: 300},
+				{Recipient: "bob@example.com", Satoshis: 200},
+			},
+			expectedTotal: 500,
+		},
+		"No recipients": {
+			expectedErr: spverrors.ErrInvalidRecipients,
+		},
+		"Recipient without satoshis": {
+			recipients:  []transactions.Recipient{{Recipient: "alice@example.com"}},
+			expectedErr: spverrors.ErrInvalidRecipients,
+		},
+		"Recipient without paymail": {
+			recipients:  []transactions.Recipient{{Recipient: " ", Satoshis: 100}},
+			expectedErr: spverrors.ErrInvalidRecipients,
+		},
+		"Duplicate recipient": {
+			recipients: []transactions.Recipient{
+				{Recipient: "alice@example.com", Satoshis: 300},
+				{Recipient: "Alice@Example.com ", Satoshis: 200},
+			},
+			expectedErr: spverrors.ErrDuplicateRecipient,
+		},
+		"Total overflows": {
+			recipients: []transactions.Recipient{
+				{Recipient: "alice@example.com", Satoshis: math.MaxUint64},
+				{Recipient: "bob@example.com", Satoshis: 1},
+			},
+			expectedErr: spverrors.ErrInvalidRecipients,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			total, err := transactions.ValidateRecipients(tc.recipients)
+
+			// Assert
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTotal, total)
+		})
+	}
+}
+
 func TestGetTransaction_ReturnsTransactionDetails(t *testing.T) {
 	testLogger := zerolog.Nop()
 	ts := data.CreateTestTransactions(10)
@@ -91,10 +621,10 @@ func TestGetTransaction_ReturnsTransactionDetails(t *testing.T) {
 				CreateWithAccessKey(accessKey).
 				Return(mockUserWalletClient, nil)
 
-			sut := transactions.NewTransactionService(mock.NewMockAdminWalletClient(ctrl), clientFctrMq, &testLogger)
+			sut := transactions.NewTransactionService(nil, mock.NewMockAdminWalletClient(ctrl), clientFctrMq, nil, nil, &testLogger)
 
 			// Act
-			result, err := sut.GetTransaction(accessKey, tc.transactionID, paymail)
+			result, err := sut.GetTransaction(1, accessKey, tc.transactionID, paymail)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -140,10 +670,16 @@ func TestGetTransaction_ReturnsError(t *testing.T) {
 				CreateWithAccessKey(accessKey).
 				Return(mockUserWalletClient, nil)
 
-			sut := transactions.NewTransactionService(mock.NewMockAdminWalletClient(ctrl), clientFctrMq, &testLogger)
+			outboxRepoMq := mock.NewMockOutboxRepository(ctrl)
+			outboxRepoMq.EXPECT().
+				GetUserEntry(gomock.Any(), 1, tc.transactionID).
+				Return(nil, nil)
+			outboxService := outbox.NewOutboxService(outboxRepoMq, clientFctrMq, newSealer(t), &testLogger)
+
+			sut := transactions.NewTransactionService(nil, mock.NewMockAdminWalletClient(ctrl), clientFctrMq, nil, outboxService, &testLogger)
 
 			// Act
-			result, err := sut.GetTransaction(accessKey, tc.transactionID, paymail)
+			result, err := sut.GetTransaction(1, accessKey, tc.transactionID, paymail)
 
 			// Assert
 			require.EqualError(t, tc.expectdErr, err.Error())
@@ -152,6 +688,173 @@ func TestGetTransaction_ReturnsError(t *testing.T) {
 	}
 }
 
+func TestGetTransaction_ReturnsPendingTransaction(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	paymail := "paymail@example.com"
+	accessKey := gofakeit.HexUint256()
+	transactionID := gofakeit.HexUint256()
+
+	mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
+	mockUserWalletClient.EXPECT().
+		GetTransaction(transactionID, paymail).
+		Return(nil, errors.New("Not found"))
+
+	clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+	clientFctrMq.EXPECT().
+		CreateWithAccessKey(accessKey).
+		Return(mockUserWalletClient, nil)
+
+	outboxRepoMq := mock.NewMockOutboxRepository(ctrl)
+	outboxRepoMq.EXPECT().
+		GetUserEntry(gomock.Any(), 1, transactionID).
+		Return(&outbox.Entry{
+			TransactionID: transactionID,
+			TotalValue:    500,
+			Metadata:      map[string]any{"sender": paymail, "receiver": "alice@example.com"},
+			Status:        outbox.StatusPending,
+		}, nil)
+	outboxService := outbox.NewOutboxService(outboxRepoMq, clientFctrMq, newSealer(t), &testLogger)
+
+	sut := transactions.NewTransactionService(nil, mock.NewMockAdminWalletClient(ctrl), clientFctrMq, nil, outboxService, &testLogger)
+
+	// Act
+	result, err := sut.GetTransaction(1, accessKey, transactionID, paymail)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, transactionID, result.GetTransactionID())
+	assert.Equal(t, outbox.TransactionStatusPendingRecord, result.GetTransactionStatus())
+	assert.Equal(t, uint64(500), result.GetTransactionTotalValue())
+	assert.Equal(t, paymail, result.GetTransactionSender())
+	assert.Equal(t, "alice@example.com", result.GetTransactionReceiver())
+}
+
+func TestGetTransactions_PrependsPendingTransactions(t *testing.T) {
+	testLogger := zerolog.Nop()
+	paymail := "paymail@example.com"
+	accessKey := gofakeit.HexUint256()
+	recorded := &spvwallet.Transaction{ID: gofakeit.HexUint256()}
+	pending := &outbox.Entry{TransactionID: gofakeit.HexUint256(), Status: outbox.StatusPending, Metadata: map[string]any{}}
+	// recordedPending was recorded without its response being received, so it's listed by SPV Wallet already.
+	recordedPending := &outbox.Entry{TransactionID: recorded.ID, Status: outbox.StatusPending, Metadata: map[string]any{}}
+
+	tests := map[string]struct {
+		page        int
+		expectedIDs []string
+	}{
+		"First page": {
+			page:        1,
+			expectedIDs: []string{pending.TransactionID, recorded.ID},
+		},
+		"Next page": {
+			page:        2,
+			expectedIDs: []string{recorded.ID},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			queryParams := &filter.QueryParams{Page: tc.page, PageSize: 10}
+
+			mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
+			mockUserWalletClient.EXPECT().
+				GetTransactionsCount().
+				Return(int64(11), nil)
+			mockUserWalletClient.EXPECT().
+				GetTransactions(queryParams, paymail).
+				Return([]users.Transaction{recorded}, nil)
+
+			clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+			clientFctrMq.EXPECT().
+				CreateWithAccessKey(accessKey).
+				Return(mockUserWalletClient, nil)
+
+			outboxRepoMq := mock.NewMockOutboxRepository(ctrl)
+			outboxRepoMq.EXPECT().
+				GetUserEntries(gomock.Any(), 1, outbox.StatusPending).
+				Return([]*outbox.Entry{pending, recordedPending}, nil).
+				MaxTimes(1)
+			outboxService := outbox.NewOutboxService(outboxRepoMq, clientFctrMq, newSealer(t), &testLogger)
+
+			sut := transactions.NewTransactionService(nil, mock.NewMockAdminWalletClient(ctrl), clientFctrMq, nil, outboxService, &testLogger)
+
+			// Act
+			result, err := sut.GetTransactions(1, accessKey, paymail, queryParams)
+
+			// Assert
+			require.NoError(t, err)
+			ids := make([]string, 0, len(result.Transactions))
+			for _, tx := range result.Transactions {
+				ids = append(ids, tx.GetTransactionID())
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+			assert.Equal(t, 2, result.Pages)
+		})
+	}
+}
+
+func TestGetPaymailTransactions(t *testing.T) {
+	testLogger := zerolog.Nop()
+	paymail := "paymail@example.com"
+	xpubID := gofakeit.HexUint256()
+	queryParams := &filter.QueryParams{Page: 1, PageSize: 10}
+
+	t.Run("Transactions of the paymail xPub", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		txs := []users.Transaction{&spvwallet.Transaction{ID: gofakeit.HexUint256()}}
+
+		adminWalletClientMq := mock.NewMockAdminWalletClient(ctrl)
+		adminWalletClientMq.EXPECT().
+			GetPaymailXPub(paymail).
+			Return(&spvwallet.XPub{ID: xpubID}, nil)
+		adminWalletClientMq.EXPECT().
+			GetXPubTransactions(xpubID, queryParams, paymail).
+			Return(txs, int64(21), nil)
+
+		sut := transactions.NewTransactionService(nil, adminWalletClientMq, mock.NewMockWalletClientFactory(ctrl), nil, nil, &testLogger)
+
+		// Act
+		result, err := sut.GetPaymailTransactions(paymail, queryParams)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(21), result.Count)
+		assert.Equal(t, 3, result.Pages)
+		assert.Equal(t, txs, result.Transactions)
+	})
+
+	t.Run("xPub not found", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		adminWalletClientMq := mock.NewMockAdminWalletClient(ctrl)
+		adminWalletClientMq.EXPECT().
+			GetPaymailXPub(paymail).
+			Return(nil, errors.New("paymail not found"))
+
+		sut := transactions.NewTransactionService(nil, adminWalletClientMq, mock.NewMockWalletClientFactory(ctrl), nil, nil, &testLogger)
+
+		// Act
+		result, err := sut.GetPaymailTransactions(paymail, queryParams)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrGetTransactions)
+		assert.Nil(t, result)
+	})
+}
+
 func findByID(collection []spvwallet.FullTransaction, id string) (users.FullTransaction, error) {
 	result := utils.Find(collection, func(t spvwallet.FullTransaction) bool { return t.ID == id })
 
@@ -0,0 +1,242 @@
+package twofactor_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/twofactor"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/pquerna/otp/totp"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userID       = 1
+	testSecret   = "JBSWY3DPEHPK3PXP"
+	sealerSecret = "test-sealer-secret"
+)
+
+func TestEnroll(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockTwoFactorRepository(ctrl)
+	repoMq.EXPECT().GetTwoFactor(gomock.Any(), userID).Return(nil, nil)
+
+	var stored *twofactor.TwoFactor
+	var storedHashes []string
+	repoMq.EXPECT().
+		ReplaceTwoFactor(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, tf *twofactor.TwoFactor, hashes []string) error {
+			stored, storedHashes = tf, hashes
+			return nil
+		})
+
+	viper.Set(config.EnvTwoFactorIssuer, "SPV Wallet")
+	sut := twofactor.NewTwoFactorService(repoMq, newSealer(t), &testLogger)
+
+	// Act
+	enrollment, err := sut.Enroll(userID, "homer@example.com")
+
+	// Assert
+	require.NoError(t, err)
+	uri, err := url.Parse(enrollment.URI)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, enrollment.Secret, uri.Query().Get("secret"))
+
+	assert.False(t, stored.Enabled)
+	assert.NotContains(t, stored.Secret, enrollment.Secret)
+	secret, err := newSealer(t).Open(context.Background(), stored.Secret)
+	require.NoError(t, err)
+	assert.Equal(t, enrollment.Secret, secret)
+	assert.Len(t, enrollment.RecoveryCodes, len(storedHashes))
+	for i, code := range enrollment.RecoveryCodes {
+		assert.NotContains(t, storedHashes[i], code)
+	}
+}
+
+func TestEnroll_AlreadyEnabled(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockTwoFactorRepository(ctrl)
+	repoMq.EXPECT().GetTwoFactor(gomock.Any(), userID).Return(enabledTwoFactor(t, nil), nil)
+
+	sut := twofactor.NewTwoFactorService(repoMq, newSealer(t), &testLogger)
+
+	// Act
+	_, err := sut.Enroll(userID, "homer@example.com")
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrTwoFactorAlreadyEnabled)
+}
+
+func TestVerifySignIn(t *testing.T) {
+	testLogger := zerolog.Nop()
+	code, err := totp.GenerateCode(testSecret, time.Now())
+	require.NoError(t, err)
+
+	t.Run("Not enabled", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockTwoFactorRepository(ctrl)
+		repoMq.EXPECT().GetTwoFactor(gomock.Any(), userID).Return(&twofactor.TwoFactor{UserID: userID, Secret: testSecret}, nil)
+
+		sut := twofactor.NewTwoFactorService(repoMq, newSealer(t), &testLogger)
+
+		// Act & Assert
+		require.NoError(t, sut.VerifySignIn(userID, ""))
+	})
+
+	t.Run("Code is required", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockTwoFactorRepository(ctrl)
+		repoMq.EXPECT().GetTwoFactor(gomock.Any(), userID).Return(enabledTwoFactor(t, nil), nil)
+
+		sut := twofactor.NewTwoFactorService(repoMq, newSealer(t), &testLogger)
+
+		// Act & Assert
+		require.ErrorIs(t, sut.VerifySignIn(userID, ""), spverrors.ErrTwoFactorRequired)
+	})
+
+	t.Run("Valid code", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockTwoFactorRepository(ctrl)
+		repoMq.EXPECT().GetTwoFactor(gomock.Any(), userID).Return(enabledTwoFactor(t, nil), nil)
+		repoMq.EXPECT().UpdateLastUsedStep(gomock.Any(), userID, gomock.Any()).Return(true, nil)
+
+		sut := twofactor.NewTwoFactorService(repoMq, newSealer(t), &testLogger)
+
+		// Act & Assert
+		require.NoError(t, sut.VerifySignIn(userID, code))
+	})
+
+	t.Run("Replayed code", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockTwoFactorRepository(ctrl)
+		repoMq.EXPECT().GetTwoFactor(gomock.Any(), userID).Return(enabledTwoFactor(t, nil), nil)
+		repoMq.EXPECT().UpdateLastUsedStep(gomock.Any(), userID, gomock.Any()).Return(false, nil)
+
+		sut := twofactor.NewTwoFactorService(repoMq, newSealer(t), &testLogger)
+
+		// Act & Assert
+		require.ErrorIs(t, sut.VerifySignIn(userID, code), spverrors.ErrInvalidTwoFactorCode)
+	})
+
+	t.Run("Secret not sealed by the sealer is refused", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		tf := enabledTwoFactor(t, nil)
+		tf.Secret = testSecret
+		repoMq := mock.NewMockTwoFactorRepository(ctrl)
+		repoMq.EXPECT().GetTwoFactor(gomock.Any(), userID).Return(tf, nil)
+
+		sut := twofactor.NewTwoFactorService(repoMq, newSealer(t), &testLogger)
+
+		// Act & Assert
+		require.ErrorIs(t, sut.VerifySignIn(userID, code), spverrors.ErrTwoFactor)
+	})
+
+	t.Run("Recovery code", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockTwoFactorRepository(ctrl)
+		repoMq.EXPECT().GetTwoFactor(gomock.Any(), userID).Return(enabledTwoFactor(t, nil), nil)
+		repoMq.EXPECT().UseRecoveryCode(gomock.Any(), userID, gomock.Any(), gomock.Any()).Return(true, nil)
+
+		sut := twofactor.NewTwoFactorService(repoMq, newSealer(t), &testLogger)
+
+		// Act & Assert
+		require.NoError(t, sut.VerifySignIn(userID, "0a1b2-c3d4e"))
+	})
+}
+
+func TestVerifyTransaction(t *testing.T) {
+	testLogger := zerolog.Nop()
+	threshold := uint64(1000)
+	cases := []struct {
+		name        string
+		satoshis    uint64
+		expectedErr error
+	}{
+		{
+			name:     "Below threshold",
+			satoshis: threshold,
+		},
+		{
+			name:        "Above threshold",
+			satoshis:    threshold + 1,
+			expectedErr: spverrors.ErrTwoFactorRequired,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockTwoFactorRepository(ctrl)
+			repoMq.EXPECT().GetTwoFactor(gomock.Any(), userID).Return(enabledTwoFactor(t, &threshold), nil)
+
+			sut := twofactor.NewTwoFactorService(repoMq, newSealer(t), &testLogger)
+
+			// Act
+			err := sut.VerifyTransaction(userID, tc.satoshis, "")
+
+			// Assert
+			if tc.expectedErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func newSealer(t *testing.T) *encryption.Sealer {
+	sealer, err := encryption.NewSealer(sealerSecret, nil)
+	require.NoError(t, err)
+	return sealer
+}
+
+func enabledTwoFactor(t *testing.T, threshold *uint64) *twofactor.TwoFactor {
+	sealedSecret, err := newSealer(t).Seal(context.Background(), testSecret)
+	require.NoError(t, err)
+
+	return &twofactor.TwoFactor{
+		UserID:               userID,
+		Secret:               sealedSecret,
+		Enabled:              true,
+		TransactionThreshold: threshold,
+	}
+}
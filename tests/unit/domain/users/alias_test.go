@@ -0,0 +1,187 @@
+package users_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAlias(t *testing.T) {
+	cases := []struct {
+		name        string
+		alias       string
+		expectedErr error
+	}{
+		{name: "Letters and digits", alias: "homer42"},
+		{name: "Separators inside", alias: "homer.j_simpson-1"},
+		{name: "Single character", alias: "h"},
+		{name: "Max length", alias: strings.Repeat("h", 64)},
+		{name: "Empty", alias: "", expectedErr: spverrors.ErrInvalidAlias},
+		{name: "Too long", alias: strings.Repeat("h", 65), expectedErr: spverrors.ErrInvalidAlias},
+		{name: "Uppercase", alias: "Homer", expectedErr: spverrors.ErrInvalidAlias},
+		{name: "Starts with separator", alias: ".homer", expectedErr: spverrors.ErrInvalidAlias},
+		{name: "Ends with separator", alias: "homer-", expectedErr: spverrors.ErrInvalidAlias},
+		{name: "Consecutive dots", alias: "homer..simpson", expectedErr: spverrors.ErrInvalidAlias},
+		{name: "Not allowed character", alias: "homer+test", expectedErr: spverrors.ErrInvalidAlias},
+		{name: "Reserved", alias: "admin", expectedErr: spverrors.ErrReservedAlias},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			err := users.ValidateAlias(tc.alias)
+
+			// Assert
+			if tc.expectedErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestCheckAliasAvailability(t *testing.T) {
+	testLogger := zerolog.Nop()
+
+	t.Run("Available alias", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockRepository(ctrl)
+		repoMq.EXPECT().IsAliasPending(gomock.Any(), "homer").Return(false, nil)
+		mockAdminWalletClient := mock.NewMockAdminWalletClient(ctrl)
+		mockAdminWalletClient.EXPECT().PaymailExists("homer", gomock.Any()).Return(false, nil)
+
+		sut := users.NewUserService(repoMq, mockAdminWalletClient, nil, nil, nil, nil, nil, nil, &testLogger)
+
+		// Act
+		result, err := sut.CheckAliasAvailability(" Homer ")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "homer", result.Alias)
+		assert.True(t, result.Available)
+		assert.Empty(t, result.Suggestions)
+	})
+
+	t.Run("Taken alias, free variants suggested", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockRepository(ctrl)
+		repoMq.EXPECT().IsAliasPending(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+		mockAdminWalletClient := mock.NewMockAdminWalletClient(ctrl)
+		mockAdminWalletClient.EXPECT().PaymailExists("homer", gomock.Any()).Return(true, nil)
+		mockAdminWalletClient.EXPECT().PaymailExists(gomock.Not("homer"), gomock.Any()).Return(false, nil).AnyTimes()
+
+		sut := users.NewUserService(repoMq, mockAdminWalletClient, nil, nil, nil, nil, nil, nil, &testLogger)
+
+		// Act
+		result, err := sut.CheckAliasAvailability("homer")
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, result.Available)
+		assert.Len(t, result.Suggestions, 3)
+		for _, suggestion := range result.Suggestions {
+			assert.True(t, strings.HasPrefix(suggestion, "homer"))
+			require.NoError(t, users.ValidateAlias(suggestion))
+		}
+	})
+
+	t.Run("Reserved alias, free variants suggested", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockRepository(ctrl)
+		repoMq.EXPECT().IsAliasPending(gomock.Any(), gomock.Not("admin")).Return(false, nil).AnyTimes()
+		mockAdminWalletClient := mock.NewMockAdminWalletClient(ctrl)
+		mockAdminWalletClient.EXPECT().PaymailExists(gomock.Not("admin"), gomock.Any()).Return(false, nil).AnyTimes()
+
+		sut := users.NewUserService(repoMq, mockAdminWalletClient, nil, nil, nil, nil, nil, nil, &testLogger)
+
+		// Act
+		result, err := sut.CheckAliasAvailability("admin")
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, result.Available)
+		assert.NotEmpty(t, result.Suggestions)
+	})
+
+	t.Run("Invalid alias", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut := users.NewUserService(mock.NewMockRepository(ctrl), mock.NewMockAdminWalletClient(ctrl), nil, nil, nil, nil, nil, nil, &testLogger)
+
+		// Act
+		result, err := sut.CheckAliasAvailability("homer..simpson")
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrInvalidAlias)
+		assert.Nil(t, result)
+	})
+}
+
+func TestCreateNewUser_AliasTaken(t *testing.T) {
+	testLogger := zerolog.Nop()
+	email := "homer.simpson@example.com"
+
+	t.Run("Chosen alias taken", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockRepository(ctrl)
+		repoMq.EXPECT().GetUserByEmail(gomock.Any(), email).Return(nil, nil)
+		repoMq.EXPECT().IsAliasPending(gomock.Any(), "homer").Return(true, nil)
+
+		sut := users.NewUserService(repoMq, mock.NewMockAdminWalletClient(ctrl), nil, nil, nil, nil, nil, nil, &testLogger)
+
+		// Act
+		result, err := sut.CreateNewUser(email, "strongP4$$word", "homer")
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrAliasTaken)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Alias from email taken, free variant used", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockRepository(ctrl)
+		repoMq.EXPECT().GetUserByEmail(gomock.Any(), email).Return(nil, nil)
+		repoMq.EXPECT().IsAliasPending(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+		repoMq.EXPECT().InsertPendingUser(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockAdminWalletClient := mock.NewMockAdminWalletClient(ctrl)
+		mockAdminWalletClient.EXPECT().PaymailExists("homer.simpson", gomock.Any()).Return(true, nil)
+		mockAdminWalletClient.EXPECT().PaymailExists(gomock.Not("homer.simpson"), gomock.Any()).Return(false, nil).AnyTimes()
+		mailerMq := mock.NewMockMailer(ctrl)
+		mailerMq.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+		sut := users.NewUserService(repoMq, mockAdminWalletClient, nil, nil, nil, nil, nil, mailerMq, &testLogger)
+
+		// Act
+		result, err := sut.CreateNewUser(email, "strongP4$$word", "")
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotEqual(t, "homer.simpson", result.User.Alias)
+		assert.True(t, strings.HasPrefix(result.User.Alias, "homer.simpson"))
+	})
+}
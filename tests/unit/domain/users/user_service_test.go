@@ -1,13 +1,26 @@
 package users_test
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/mailer"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
 	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
 	"github.com/golang/mock/gomock"
+	"github.com/libsv/go-bk/bip32"
+	"github.com/libsv/go-bk/bip39"
+	"github.com/libsv/go-bk/chaincfg"
 	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -26,8 +39,9 @@ func TestCreateNewUser_ReturnsUser(t *testing.T) {
 			userPswd:  "strongP4$$word",
 			expectedUser: &users.CreatedUser{
 				User: &users.User{
-					Email:   "homer.simpson@example.com",
-					Paymail: "homer.simpson@homer.simpson.space",
+					Email:  "homer.simpson@example.com",
+					Alias:  "homer.simpson",
+					Status: users.StatusPending,
 				},
 			},
 		},
@@ -40,25 +54,34 @@ func TestCreateNewUser_ReturnsUser(t *testing.T) {
 			defer ctrl.Finish()
 
 			repoMq := mock.NewMockRepository(ctrl)
+			// xPub and paymail must not be registered before email is verified
 			mockAdminWalletClient := mock.NewMockAdminWalletClient(ctrl)
+			mailerMq := mock.NewMockMailer(ctrl)
 
 			repoMq.EXPECT().
 				GetUserByEmail(gomock.Any(), tc.userEmail).
 				Return(nil, nil)
+			repoMq.EXPECT().
+				IsAliasPending(gomock.Any(), tc.expectedUser.User.Alias).
+				Return(false, nil)
+			mockAdminWalletClient.EXPECT().
+				PaymailExists(tc.expectedUser.User.Alias, gomock.Any()).
+				Return(false, nil)
 
-			repoMq.EXPECT().InsertUser(gomock.Any(), gomock.Any())
+			repoMq.EXPECT().InsertPendingUser(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
 
-			mockAdminWalletClient.EXPECT().
-				RegisterXpub(gomock.Any()).
-				Return(gomock.Any().String(), nil)
-			mockAdminWalletClient.EXPECT().
-				RegisterPaymail(gomock.Any(), gomock.Any()).
-				Return(tc.expectedUser.User.Paymail, nil)
+			mailerMq.EXPECT().
+				Send(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, msg *mailer.Message) error {
+					assert.Equal(t, tc.userEmail, msg.To)
+					assert.Contains(t, msg.Body, "token=")
+					return nil
+				})
 
-			sut := users.NewUserService(repoMq, mockAdminWalletClient, nil, nil, &testLogger)
+			sut := users.NewUserService(repoMq, mockAdminWalletClient, nil, nil, nil, nil, nil, mailerMq, &testLogger)
 
 			// Act
-			result, err := sut.CreateNewUser(tc.userEmail, tc.userPswd)
+			result, err := sut.CreateNewUser(tc.userEmail, tc.userPswd, "")
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -111,10 +134,10 @@ func TestCreateNewUser_InvalidData_ReturnsError(t *testing.T) {
 				Return(&users.User{}, nil).
 				AnyTimes()
 
-			sut := users.NewUserService(repoMq, mockAdminWalletClient, nil, nil, &testLogger)
+			sut := users.NewUserService(repoMq, mockAdminWalletClient, nil, nil, nil, nil, nil, nil, &testLogger)
 
 			// Act
-			result, err := sut.CreateNewUser(tc.userEmail, tc.userPswd)
+			result, err := sut.CreateNewUser(tc.userEmail, tc.userPswd, "")
 
 			// Assert
 			require.EqualError(t, err, tc.expectedErr.Error())
@@ -123,10 +146,821 @@ func TestCreateNewUser_InvalidData_ReturnsError(t *testing.T) {
 	}
 }
 
+func TestCreateNewUser_SendEmailFails_RemovesUser(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	email := "homer.simpson@example.com"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockRepository(ctrl)
+	repoMq.EXPECT().
+		GetUserByEmail(gomock.Any(), email).
+		Return(nil, nil)
+	repoMq.EXPECT().
+		IsAliasPending(gomock.Any(), "homer.simpson").
+		Return(false, nil)
+	repoMq.EXPECT().
+		InsertPendingUser(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, user *users.User, _ string, _ time.Time) error {
+			user.ID = 1
+			return nil
+		})
+	repoMq.EXPECT().
+		DeleteUser(gomock.Any(), 1).
+		Return(nil)
+
+	mailerMq := mock.NewMockMailer(ctrl)
+	mailerMq.EXPECT().
+		Send(gomock.Any(), gomock.Any()).
+		Return(errors.New("smtp unavailable"))
+
+	mockAdminWalletClient := mock.NewMockAdminWalletClient(ctrl)
+	mockAdminWalletClient.EXPECT().
+		PaymailExists("homer.simpson", gomock.Any()).
+		Return(false, nil)
+
+	sut := users.NewUserService(repoMq, mockAdminWalletClient, nil, nil, nil, nil, nil, mailerMq, &testLogger)
+
+	// Act
+	result, err := sut.CreateNewUser(email, "strongP4$$word", "")
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrSendVerificationEmail)
+	assert.Nil(t, result)
+}
+
+func TestVerifyUser(t *testing.T) {
+	testLogger := zerolog.Nop()
+	email := "homer.simpson@example.com"
+	paymail := "homer.simpson@example.com"
+
+	t.Run("Verify with token from email, register xPub and paymail", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var pendingUser *users.User
+		var tokenHash, token string
+
+		repoMq := mock.NewMockRepository(ctrl)
+		repoMq.EXPECT().
+			GetUserByEmail(gomock.Any(), email).
+			Return(nil, nil)
+		repoMq.EXPECT().
+			IsAliasPending(gomock.Any(), "homer.simpson").
+			Return(false, nil)
+		repoMq.EXPECT().
+			InsertPendingUser(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, user *users.User, hash string, _ time.Time) error {
+				user.ID = 1
+				pendingUser, tokenHash = user, hash
+				return nil
+			})
+		repoMq.EXPECT().
+			GetPendingUserByVerificationToken(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, hash string, _ time.Time) (*users.User, error) {
+				if hash != tokenHash {
+					return nil, nil
+				}
+				return pendingUser, nil
+			})
+		repoMq.EXPECT().
+			MarkXpubRegistered(gomock.Any(), 1).
+			Return(nil)
+		repoMq.EXPECT().
+			ActivateUser(gomock.Any(), 1, paymail).
+			Return(nil)
+
+		mailerMq := mock.NewMockMailer(ctrl)
+		mailerMq.EXPECT().
+			Send(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, msg *mailer.Message) error {
+				token = regexp.MustCompile(`token=([0-9a-f]+)`).FindStringSubmatch(msg.Body)[1]
+				return nil
+			})
+
+		mockAdminWalletClient := mock.NewMockAdminWalletClient(ctrl)
+		mockAdminWalletClient.EXPECT().
+			PaymailExists("homer.simpson", gomock.Any()).
+			Return(false, nil)
+		mockAdminWalletClient.EXPECT().
+			RegisterXpub(gomock.Any()).
+			DoAndReturn(func(xpub *bip32.ExtendedKey) (string, error) {
+				assert.False(t, xpub.IsPrivate())
+				return xpub.String(), nil
+			})
+		mockAdminWalletClient.EXPECT().
+			RegisterPaymail("homer.simpson", gomock.Any()).
+			Return(paymail, nil)
+
+		sut := users.NewUserService(repoMq, mockAdminWalletClient, nil, nil, nil, nil, nil, mailerMq, &testLogger)
+		_, err := sut.CreateNewUser(email, "strongP4$$word", "")
+		require.NoError(t, err)
+
+		// Act
+		user, err := sut.VerifyUser(token)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, paymail, user.Paymail)
+		assert.Equal(t, users.StatusActive, user.Status)
+	})
+
+	t.Run("Retry after activation failed, xPub and paymail are already registered", func(t *testing.T) {
+		// Arrange
+		viper.Set(config.EnvPaymailDomain, "example.com")
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var pendingUser *users.User
+		var token string
+
+		repoMq := mock.NewMockRepository(ctrl)
+		repoMq.EXPECT().
+			GetUserByEmail(gomock.Any(), email).
+			Return(nil, nil)
+		repoMq.EXPECT().
+			IsAliasPending(gomock.Any(), "homer.simpson").
+			Return(false, nil)
+		repoMq.EXPECT().
+			InsertPendingUser(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, user *users.User, _ string, _ time.Time) error {
+				user.ID = 1
+				pendingUser = user
+				return nil
+			})
+		repoMq.EXPECT().
+			GetPendingUserByVerificationToken(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ time.Time) (*users.User, error) {
+				// Copy as the row would be read again from db.
+				user := *pendingUser
+				return &user, nil
+			}).
+			Times(2)
+		repoMq.EXPECT().
+			MarkXpubRegistered(gomock.Any(), 1).
+			DoAndReturn(func(_ context.Context, _ int) error {
+				pendingUser.XpubRegistered = true
+				return nil
+			})
+		repoMq.EXPECT().
+			ActivateUser(gomock.Any(), 1, paymail).
+			Return(errors.New("db error"))
+		repoMq.EXPECT().
+			ActivateUser(gomock.Any(), 1, paymail).
+			Return(nil)
+
+		mailerMq := mock.NewMockMailer(ctrl)
+		mailerMq.EXPECT().
+			Send(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, msg *mailer.Message) error {
+				token = regexp.MustCompile(`token=([0-9a-f]+)`).FindStringSubmatch(msg.Body)[1]
+				return nil
+			})
+
+		paymailXpubMq := mock.NewMockPubKey(ctrl)
+		paymailXpubMq.EXPECT().
+			GetID().
+			DoAndReturn(func() string {
+				xpubHash := sha256.Sum256([]byte(pendingUser.Xpub))
+				return hex.EncodeToString(xpubHash[:])
+			})
+
+		mockAdminWalletClient := mock.NewMockAdminWalletClient(ctrl)
+		mockAdminWalletClient.EXPECT().
+			PaymailExists("homer.simpson", gomock.Any()).
+			Return(false, nil)
+		mockAdminWalletClient.EXPECT().
+			RegisterXpub(gomock.Any()).
+			DoAndReturn(func(xpub *bip32.ExtendedKey) (string, error) {
+				return xpub.String(), nil
+			})
+		mockAdminWalletClient.EXPECT().
+			RegisterPaymail("homer.simpson", gomock.Any()).
+			Return(paymail, nil)
+		mockAdminWalletClient.EXPECT().
+			RegisterPaymail("homer.simpson", gomock.Any()).
+			Return("", errors.New("paymail already exists"))
+		mockAdminWalletClient.EXPECT().
+			GetPaymailXPub(gomock.Any()).
+			Return(paymailXpubMq, nil)
+
+		sut := users.NewUserService(repoMq, mockAdminWalletClient, nil, nil, nil, nil, nil, mailerMq, &testLogger)
+		_, err := sut.CreateNewUser(email, "strongP4$$word", "")
+		require.NoError(t, err)
+
+		_, err = sut.VerifyUser(token)
+		require.ErrorIs(t, err, spverrors.ErrUpdateUser)
+
+		// Act
+		user, err := sut.VerifyUser(token)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, users.StatusActive, user.Status)
+	})
+
+	t.Run("Paymail registered for another xPub", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		xpub := newXpub(t)
+
+		repoMq := mock.NewMockRepository(ctrl)
+		repoMq.EXPECT().
+			GetPendingUserByVerificationToken(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&users.User{ID: 1, Email: email, Xpub: xpub, Status: users.StatusPending, XpubRegistered: true}, nil)
+
+		paymailXpubMq := mock.NewMockPubKey(ctrl)
+		paymailXpubMq.EXPECT().
+			GetID().
+			Return("another-xpub-id")
+
+		mockAdminWalletClient := mock.NewMockAdminWalletClient(ctrl)
+		mockAdminWalletClient.EXPECT().
+			RegisterPaymail("homer.simpson", xpub).
+			Return("", errors.New("paymail already exists"))
+		mockAdminWalletClient.EXPECT().
+			GetPaymailXPub(gomock.Any()).
+			Return(paymailXpubMq, nil)
+
+		sut := users.NewUserService(repoMq, mockAdminWalletClient, nil, nil, nil, nil, nil, nil, &testLogger)
+
+		// Act
+		user, err := sut.VerifyUser("token")
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrRegisterPaymail)
+		assert.Nil(t, user)
+	})
+
+	t.Run("Unknown or expired token", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockRepository(ctrl)
+		repoMq.EXPECT().
+			GetPendingUserByVerificationToken(gomock.Any(), gomock.Not("unknown"), gomock.Any()).
+			Return(nil, nil)
+
+		sut := users.NewUserService(repoMq, mock.NewMockAdminWalletClient(ctrl), nil, nil, nil, nil, nil, nil, &testLogger)
+
+		// Act
+		user, err := sut.VerifyUser("unknown")
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrInvalidVerificationToken)
+		assert.Nil(t, user)
+	})
+}
+
+func TestSignInUser_EmailNotVerified(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	email := "homer.simpson@example.com"
+	password := "strongP4$$word"
+	encryptedXpriv, err := encryption.Encrypt(password, "xprivtest")
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockRepository(ctrl)
+	repoMq.EXPECT().
+		GetUserByEmail(gomock.Any(), email).
+		Return(&users.User{ID: 1, Email: email, Xpriv: encryptedXpriv, Status: users.StatusPending}, nil)
+
+	sut := users.NewUserService(repoMq, mock.NewMockAdminWalletClient(ctrl), mock.NewMockWalletClientFactory(ctrl), nil, nil, nil, nil, nil, &testLogger)
+
+	// Act
+	result, err := sut.SignInUser(email, password, "")
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrEmailNotVerified)
+	assert.Nil(t, result)
+}
+
+func TestSignInUser_AccountLockedByOperator(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	email := "homer.simpson@example.com"
+	password := "strongP4$$word"
+	encryptedXpriv, err := encryption.Encrypt(password, "xprivtest")
+	require.NoError(t, err)
+	lockedUntil := time.Now().Add(time.Hour)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockRepository(ctrl)
+	repoMq.EXPECT().
+		GetUserByEmail(gomock.Any(), email).
+		Return(&users.User{ID: 1, Email: email, Xpriv: encryptedXpriv, Status: users.StatusActive, LockedUntil: &lockedUntil}, nil)
+
+	sut := users.NewUserService(repoMq, mock.NewMockAdminWalletClient(ctrl), mock.NewMockWalletClientFactory(ctrl), nil, nil, nil, nil, nil, &testLogger)
+
+	// Act
+	result, err := sut.SignInUser(email, password, "")
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrAccountSuspended)
+	assert.Nil(t, result)
+}
+
+func TestChangePassword(t *testing.T) {
+	testLogger := zerolog.Nop()
+	userID := 1
+	oldPassword := "strongP4$$word"
+	xpriv := "xprivtest"
+	encryptedXpriv := encryptXpriv(t, oldPassword, xpriv)
+
+	t.Run("Change password, delete passkeys, revoke other sessions", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockRepository(ctrl)
+		repoMq.EXPECT().
+			GetUserByID(gomock.Any(), userID).
+			Return(&users.User{ID: userID, Xpriv: encryptedXpriv}, nil)
+		repoMq.EXPECT().
+			ResetUserXpriv(gomock.Any(), userID, gomock.Not(encryptedXpriv)).
+			Return(nil)
+
+		currentKey := mock.NewMockAccKey(ctrl)
+		currentKey.EXPECT().GetAccessKeyID().Return("current").AnyTimes()
+		otherKey := mock.NewMockAccKey(ctrl)
+		otherKey.EXPECT().GetAccessKeyID().Return("other").AnyTimes()
+
+		mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
+		mockUserWalletClient.EXPECT().
+			GetAccessKeys().
+			Return([]users.AccKey{currentKey, otherKey}, nil)
+		mockUserWalletClient.EXPECT().
+			RevokeAccessKey("other").
+			Return(otherKey, nil)
+
+		clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+		clientFctrMq.EXPECT().
+			CreateWithXpriv(xpriv).
+			Return(mockUserWalletClient, nil)
+
+		sut := users.NewUserService(repoMq, mock.NewMockAdminWalletClient(ctrl), clientFctrMq, nil, nil, nil, nil, nil, &testLogger)
+
+		// Act
+		err := sut.ChangePassword(userID, "current", oldPassword, "newStrongP4$$word")
+
+		// Assert
+		require.NoError(t, err)
+	})
+
+	t.Run("Access keys of pending transactions aren't revoked", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockRepository(ctrl)
+		repoMq.EXPECT().
+			GetUserByID(gomock.Any(), userID).
+			Return(&users.User{ID: userID, Xpriv: encryptedXpriv}, nil)
+		repoMq.EXPECT().
+			ResetUserXpriv(gomock.Any(), userID, gomock.Not(encryptedXpriv)).
+			Return(nil)
+
+		pendingKey := mock.NewMockAccKey(ctrl)
+		pendingKey.EXPECT().GetAccessKeyID().Return("pending").AnyTimes()
+		otherKey := mock.NewMockAccKey(ctrl)
+		otherKey.EXPECT().GetAccessKeyID().Return("other").AnyTimes()
+
+		mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
+		mockUserWalletClient.EXPECT().
+			GetAccessKeys().
+			Return([]users.AccKey{pendingKey, otherKey}, nil)
+		mockUserWalletClient.EXPECT().
+			RevokeAccessKey("other").
+			Return(otherKey, nil)
+
+		clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+		clientFctrMq.EXPECT().
+			CreateWithXpriv(xpriv).
+			Return(mockUserWalletClient, nil)
+
+		keeperMq := mock.NewMockAccessKeysKeeper(ctrl)
+		keeperMq.EXPECT().
+			GetKeptAccessKeyIDs(userID).
+			Return([]string{"pending"}, nil)
+
+		sut := users.NewUserService(repoMq, mock.NewMockAdminWalletClient(ctrl), clientFctrMq, nil, nil, nil, keeperMq, nil, &testLogger)
+
+		// Act
+		err := sut.ChangePassword(userID, "current", oldPassword, "newStrongP4$$word")
+
+		// Assert
+		require.NoError(t, err)
+	})
+
+	t.Run("Password isn't changed if other sessions can't be terminated", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockRepository(ctrl)
+		repoMq.EXPECT().
+			GetUserByID(gomock.Any(), userID).
+			Return(&users.User{ID: userID, Xpriv: encryptedXpriv}, nil)
+
+		otherKey := mock.NewMockAccKey(ctrl)
+		otherKey.EXPECT().GetAccessKeyID().Return("other").AnyTimes()
+
+		mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
+		mockUserWalletClient.EXPECT().
+			GetAccessKeys().
+			Return([]users.AccKey{otherKey}, nil)
+		mockUserWalletClient.EXPECT().
+			RevokeAccessKey("other").
+			Return(nil, errors.New("spv wallet unavailable"))
+
+		clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+		clientFctrMq.EXPECT().
+			CreateWithXpriv(xpriv).
+			Return(mockUserWalletClient, nil)
+
+		sut := users.NewUserService(repoMq, mock.NewMockAdminWalletClient(ctrl), clientFctrMq, nil, nil, nil, nil, nil, &testLogger)
+
+		// Act
+		err := sut.ChangePassword(userID, "current", oldPassword, "newStrongP4$$word")
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrSessionTerminate)
+	})
+
+	t.Run("Invalid old password", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockRepository(ctrl)
+		repoMq.EXPECT().
+			GetUserByID(gomock.Any(), userID).
+			Return(&users.User{ID: userID, Xpriv: encryptedXpriv}, nil)
+
+		sut := users.NewUserService(repoMq, mock.NewMockAdminWalletClient(ctrl), nil, nil, nil, nil, nil, nil, &testLogger)
+
+		// Act
+		err := sut.ChangePassword(userID, "current", "wrongPassword", "newStrongP4$$word")
+
+		// Assert
+		require.EqualError(t, err, spverrors.ErrInvalidCredentials.Error())
+	})
+}
+
+func TestWalletPassphrase(t *testing.T) {
+	testLogger := zerolog.Nop()
+	userID := 1
+	email := "homer.simpson@example.com"
+	password := "strongP4$$word"
+	passphrase := "wallet passphrase"
+	xpriv := "xprivtest"
+	encryptedXpriv := encryptXpriv(t, password, xpriv)
+
+	t.Run("Set passphrase and unlock wallet with it", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var passphraseXpriv string
+		repoMq := mock.NewMockRepository(ctrl)
+		repoMq.EXPECT().
+			GetUserByID(gomock.Any(), userID).
+			Return(&users.User{ID: userID, Xpriv: encryptedXpriv}, nil)
+		repoMq.EXPECT().
+			UpdateUserPassphraseXpriv(gomock.Any(), userID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int, xpriv string) error {
+				passphraseXpriv = xpriv
+				return nil
+			})
+		repoMq.EXPECT().
+			GetUserPassphraseXpriv(gomock.Any(), userID).
+			DoAndReturn(func(context.Context, int) (string, error) {
+				return passphraseXpriv, nil
+			}).
+			Times(2)
+
+		sut := users.NewUserService(repoMq, mock.NewMockAdminWalletClient(ctrl), nil, nil, nil, nil, nil, nil, &testLogger)
+
+		// Act
+		err := sut.SetWalletPassphrase(userID, password, passphrase)
+		require.NoError(t, err)
+		unlocked, unlockErr := sut.UnlockWallet(userID, passphrase)
+		_, passwordErr := sut.UnlockWallet(userID, password)
+
+		// Assert
+		require.NoError(t, unlockErr)
+		assert.Equal(t, xpriv, unlocked)
+		assert.NotEqual(t, encryptedXpriv, passphraseXpriv)
+		require.ErrorIs(t, passwordErr, spverrors.ErrInvalidCredentials)
+	})
+
+	t.Run("Passphrase same as password", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut := users.NewUserService(mock.NewMockRepository(ctrl), mock.NewMockAdminWalletClient(ctrl), nil, nil, nil, nil, nil, nil, &testLogger)
+
+		// Act
+		err := sut.SetWalletPassphrase(userID, password, password)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrWalletPassphraseSameAsPassword)
+	})
+
+	t.Run("Passphrase not set", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockRepository(ctrl)
+		repoMq.EXPECT().
+			GetUserPassphraseXpriv(gomock.Any(), userID).
+			Return("", nil).
+			Times(2)
+		repoMq.EXPECT().
+			GetUserByEmail(gomock.Any(), email).
+			Return(&users.User{ID: userID, Email: email, Xpriv: encryptedXpriv}, nil)
+
+		sut := users.NewUserService(repoMq, mock.NewMockAdminWalletClient(ctrl), nil, nil, nil, nil, nil, nil, &testLogger)
+
+		// Act
+		_, err := sut.UnlockWallet(userID, passphrase)
+		_, _, byEmailErr := sut.UnlockWalletByEmail(email, password, "")
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrWalletPassphraseNotSet)
+		require.ErrorIs(t, byEmailErr, spverrors.ErrInvalidCredentials)
+	})
+}
+
+func TestRecoverUser(t *testing.T) {
+	testLogger := zerolog.Nop()
+	email := "homer.simpson@example.com"
+	userPaymail := "homer.simpson@example.com"
+	mnemonic, xpriv := generateMnemonic(t)
+
+	lockedUntil := time.Now().Add(time.Hour)
+
+	cases := []struct {
+		name        string
+		paymails    []string
+		lockedUntil *time.Time
+		expectedErr error
+	}{
+		{
+			name:     "Mnemonic matches registered xPub, passkeys are deleted",
+			paymails: []string{userPaymail},
+		},
+		{
+			name:        "Mnemonic derives other xPub",
+			paymails:    []string{"bart.simpson@example.com"},
+			expectedErr: spverrors.ErrInvalidCredentials,
+		},
+		{
+			name:        "Account locked by operator",
+			paymails:    []string{userPaymail},
+			lockedUntil: &lockedUntil,
+			expectedErr: spverrors.ErrAccountSuspended,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockRepository(ctrl)
+			repoMq.EXPECT().
+				GetUserByEmail(gomock.Any(), email).
+				Return(&users.User{ID: 1, Email: email, Paymail: userPaymail, LockedUntil: tc.lockedUntil}, nil)
+
+			mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
+			mockUserWalletClient.EXPECT().
+				GetPaymails().
+				Return(tc.paymails, nil)
+
+			if tc.expectedErr == nil {
+				// Passkeys keep their own copies of the xPriv, so they're deleted together with the old one
+				repoMq.EXPECT().
+					ResetUserXpriv(gomock.Any(), 1, gomock.Any()).
+					DoAndReturn(func(_ any, _ int, encryptedXpriv string) error {
+						decrypted, err := encryption.Decrypt("newStrongP4$$word", encryptedXpriv)
+						require.NoError(t, err)
+						assert.Equal(t, xpriv, decrypted)
+						return nil
+					})
+				mockUserWalletClient.EXPECT().
+					GetAccessKeys().
+					Return([]users.AccKey{}, nil)
+			}
+
+			clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+			clientFctrMq.EXPECT().
+				CreateWithXpriv(xpriv).
+				Return(mockUserWalletClient, nil).
+				AnyTimes()
+
+			sut := users.NewUserService(repoMq, mock.NewMockAdminWalletClient(ctrl), clientFctrMq, nil, nil, nil, nil, nil, &testLogger)
+
+			// Act
+			user, err := sut.RecoverUser(email, mnemonic, "newStrongP4$$word")
+
+			// Assert
+			if tc.expectedErr != nil {
+				require.EqualError(t, err, tc.expectedErr.Error())
+				assert.Nil(t, user)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, email, user.Email)
+			}
+		})
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	testLogger := zerolog.Nop()
+	userID := 1
+	password := "strongP4$$word"
+	xpriv := "xprivtest"
+	userPaymail := "homer@example.com"
+	encryptedXpriv := encryptXpriv(t, password, xpriv)
+
+	t.Run("Funds swept, access keys revoked, paymails and user deleted", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockRepository(ctrl)
+		repoMq.EXPECT().
+			GetUserByID(gomock.Any(), userID).
+			Return(&users.User{ID: userID, Xpriv: encryptedXpriv, Paymail: userPaymail}, nil).
+			Times(2)
+
+		accessKey := mock.NewMockAccKey(ctrl)
+		accessKey.EXPECT().GetAccessKeyID().Return("current").AnyTimes()
+		xpub := mock.NewMockPubKey(ctrl)
+		xpub.EXPECT().GetCurrentBalance().Return(uint64(1000))
+		sweepTx := mock.NewMockTransaction(ctrl)
+
+		mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
+		mockUserWalletClient.EXPECT().GetPaymails().Return([]string{userPaymail, "homer@example.org"}, nil)
+		mockUserWalletClient.EXPECT().GetXPub().Return(xpub, nil)
+		mockUserWalletClient.EXPECT().SendAllTo("marge@example.com", userPaymail).Return(sweepTx, nil)
+		mockUserWalletClient.EXPECT().GetAccessKeys().Return([]users.AccKey{accessKey}, nil)
+		mockUserWalletClient.EXPECT().RevokeAccessKey("current").Return(accessKey, nil)
+
+		clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+		clientFctrMq.EXPECT().
+			CreateWithXpriv(xpriv).
+			Return(mockUserWalletClient, nil).
+			AnyTimes()
+
+		mockAdminWalletClient := mock.NewMockAdminWalletClient(ctrl)
+		mockAdminWalletClient.EXPECT().DeletePaymail(userPaymail).Return(nil)
+		mockAdminWalletClient.EXPECT().DeletePaymail("homer@example.org").Return(nil)
+
+		repoMq.EXPECT().DeleteUser(gomock.Any(), userID).Return(nil)
+
+		sut := users.NewUserService(repoMq, mockAdminWalletClient, clientFctrMq, nil, nil, nil, nil, nil, &testLogger)
+
+		// Act
+		result, err := sut.DeleteUser(userID, password, " Marge@example.com ")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, sweepTx, result)
+	})
+
+	t.Run("Sweep to own paymail", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockRepository(ctrl)
+		repoMq.EXPECT().
+			GetUserByID(gomock.Any(), userID).
+			Return(&users.User{ID: userID, Xpriv: encryptedXpriv, Paymail: userPaymail}, nil).
+			Times(2)
+
+		mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
+		mockUserWalletClient.EXPECT().GetPaymails().Return([]string{userPaymail}, nil)
+
+		clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+		clientFctrMq.EXPECT().
+			CreateWithXpriv(xpriv).
+			Return(mockUserWalletClient, nil)
+
+		sut := users.NewUserService(repoMq, mock.NewMockAdminWalletClient(ctrl), clientFctrMq, nil, nil, nil, nil, nil, &testLogger)
+
+		// Act
+		result, err := sut.DeleteUser(userID, password, userPaymail)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrInvalidSweepPaymail)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Invalid password", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockRepository(ctrl)
+		repoMq.EXPECT().
+			GetUserByID(gomock.Any(), userID).
+			Return(&users.User{ID: userID, Xpriv: encryptedXpriv}, nil)
+
+		sut := users.NewUserService(repoMq, mock.NewMockAdminWalletClient(ctrl), nil, nil, nil, nil, nil, nil, &testLogger)
+
+		// Act
+		result, err := sut.DeleteUser(userID, "wrongPassword", "")
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrInvalidCredentials)
+		assert.Nil(t, result)
+	})
+}
+
+func generateMnemonic(t *testing.T) (string, string) {
+	entropy, err := bip39.GenerateEntropy(160)
+	require.NoError(t, err)
+	mnemonic, seed, err := bip39.Mnemonic(entropy, "")
+	require.NoError(t, err)
+	xpriv, err := bip32.NewMaster(seed, &chaincfg.MainNet)
+	require.NoError(t, err)
+	return mnemonic, xpriv.String()
+}
+
+func newXpub(t *testing.T) string {
+	_, xpriv := generateMnemonic(t)
+	key, err := bip32.NewKeyFromString(xpriv)
+	require.NoError(t, err)
+	xpub, err := key.Neuter()
+	require.NoError(t, err)
+	return xpub.String()
+}
+
+func encryptXpriv(t *testing.T, password, xpriv string) string {
+	encryptedXpriv, err := encryption.Encrypt(password, xpriv)
+	require.NoError(t, err)
+	return encryptedXpriv
+}
+
 func assertNewUser(t *testing.T, expectedUser, newUser *users.CreatedUser) {
 	assert.Equal(t, expectedUser.User.Email, newUser.User.Email)
 	assert.Equal(t, expectedUser.User.Paymail, newUser.User.Paymail)
+	assert.Equal(t, expectedUser.User.Alias, newUser.User.Alias)
+	assert.Equal(t, expectedUser.User.Status, newUser.User.Status)
+	assert.NotEmpty(t, newUser.User.Xpub)
 	assert.NotEmpty(t, newUser.User.Xpriv)
 	assert.NotEmpty(t, newUser.User.CreatedAt)
 	assert.NotEmpty(t, newUser.Mnemonic)
 }
+
+func TestSignInUser_SecondFactor(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	email := "homer.simpson@example.com"
+	password := "strongP4$$word"
+	encryptedXpriv, err := encryption.Encrypt(password, "xprivtest")
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockRepository(ctrl)
+	repoMq.EXPECT().
+		GetUserByEmail(gomock.Any(), email).
+		Return(&users.User{ID: 1, Email: email, Xpriv: encryptedXpriv}, nil)
+
+	secondFactorMq := mock.NewMockSecondFactorVerifier(ctrl)
+	secondFactorMq.EXPECT().
+		VerifySignIn(1, "").
+		Return(spverrors.ErrTwoFactorRequired)
+
+	// Access key must not be created before second factor is verified
+	clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+
+	sut := users.NewUserService(repoMq, mock.NewMockAdminWalletClient(ctrl), clientFctrMq, nil, nil, secondFactorMq, nil, nil, &testLogger)
+
+	// Act
+	result, err := sut.SignInUser(email, password, "")
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrTwoFactorRequired)
+	assert.Nil(t, result)
+}
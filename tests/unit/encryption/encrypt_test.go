@@ -1,12 +1,17 @@
 package encryption_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// legacyCiphertext is "Lorem ipsum dolor sit amet" encrypted with passphrase "example" in the legacy format.
+const legacyCiphertext = "-2de46ad146863d08bc9dfed4-c9c4b8a12352069019e06508d202225b0661327792d654b5f4d247d67286737676c771fc0c53fa4f4514"
+
 // TestEncryptionDecryption tests if we use same algorithm for encryption and decryption data.
 func TestEncryptionDecryption(t *testing.T) {
 	cases := []struct {
@@ -36,12 +41,14 @@ func TestEncryptionDecryption(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Act
 			ciphertext, _ := encryption.Encrypt(tc.passphraseForEncryption, tc.plaintext)
-			decodedtext := encryption.Decrypt(tc.passphraseForDecryption, ciphertext)
+			decodedtext, err := encryption.Decrypt(tc.passphraseForDecryption, ciphertext)
 
 			// Assert
 			if tc.expectedDecodedTextSameAsInput {
+				require.NoError(t, err)
 				assert.Equal(t, tc.plaintext, decodedtext)
 			} else {
+				require.ErrorIs(t, err, encryption.ErrDecrypt)
 				assert.NotEqual(t, tc.plaintext, decodedtext)
 			}
 
@@ -49,6 +56,85 @@ func TestEncryptionDecryption(t *testing.T) {
 	}
 }
 
+// TestEncryptUsesRandomSalt tests if the same plaintext encrypted twice gives different ciphertexts.
+func TestEncryptUsesRandomSalt(t *testing.T) {
+	// Act
+	first, err := encryption.Encrypt("example", "Lorem ipsum dolor sit amet")
+	require.NoError(t, err)
+	second, err := encryption.Encrypt("example", "Lorem ipsum dolor sit amet")
+	require.NoError(t, err)
+
+	// Assert
+	assert.NotEqual(t, first, second)
+	assert.True(t, strings.HasPrefix(first, "v2$argon2id$"))
+	assert.False(t, encryption.IsLegacy(first))
+}
+
+// TestDecryptRejectsExcessiveParams tests if ciphertexts with argon2id parameters above the limit are refused without derivation.
+func TestDecryptRejectsExcessiveParams(t *testing.T) {
+	cases := map[string]string{
+		"Memory":  "v2$argon2id$m=4294967295,t=3,p=4$00$00$00",
+		"Time":    "v2$argon2id$m=65536,t=4294967295,p=4$00$00$00",
+		"Threads": "v2$argon2id$m=65536,t=3,p=255$00$00$00",
+	}
+
+	for name, ciphertext := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := encryption.Decrypt("example", ciphertext)
+
+			// Assert
+			require.ErrorIs(t, err, encryption.ErrInvalidCiphertext)
+		})
+	}
+}
+
+// TestDecryptLegacyFormat tests if ciphertexts created with the legacy format can still be decrypted.
+func TestDecryptLegacyFormat(t *testing.T) {
+	cases := []struct {
+		name        string
+		ciphertext  string
+		passphrase  string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:       "Valid passphrase",
+			ciphertext: legacyCiphertext,
+			passphrase: "example",
+			expected:   "Lorem ipsum dolor sit amet",
+		},
+		{
+			name:        "Invalid passphrase",
+			ciphertext:  legacyCiphertext,
+			passphrase:  "otherword",
+			expectedErr: encryption.ErrDecrypt,
+		},
+		{
+			name:        "Malformed ciphertext",
+			ciphertext:  "-zz-zz",
+			passphrase:  "example",
+			expectedErr: encryption.ErrInvalidCiphertext,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			plaintext, err := encryption.Decrypt(tc.passphrase, tc.ciphertext)
+
+			// Assert
+			assert.True(t, encryption.IsLegacy(tc.ciphertext))
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, plaintext)
+		})
+	}
+}
+
 // TestHash tests if SHA256 is used correctly.
 func TestHash(t *testing.T) {
 	tc := struct {
@@ -0,0 +1,124 @@
+package encryption_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWrapWithDataKey tests if ciphertext wrapped with a data key can be unwrapped by every key custody provider.
+func TestWrapWithDataKey(t *testing.T) {
+	plaintext := "v2$argon2id$m=65536,t=3,p=4$00$00$00"
+	vault := newVaultStub(t, "vault-token")
+
+	cases := []struct {
+		name    string
+		custody encryption.KeyCustody
+	}{
+		{
+			name:    "Local key custody",
+			custody: newLocalKeyCustody(t, strings.Repeat("ab", 32)),
+		},
+		{
+			name:    "Vault key custody",
+			custody: encryption.NewVaultKeyCustody(vault.URL, "vault-token", "test-key", vault.Client()),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			wrapped, err := encryption.WrapWithDataKey(context.Background(), tc.custody, plaintext)
+			require.NoError(t, err)
+			unwrapped, err := encryption.UnwrapWithDataKey(context.Background(), tc.custody, wrapped)
+			require.NoError(t, err)
+
+			// Assert
+			assert.True(t, encryption.IsWrapped(wrapped))
+			assert.False(t, encryption.IsLegacy(wrapped))
+			assert.NotContains(t, wrapped, plaintext)
+			assert.Equal(t, plaintext, unwrapped)
+		})
+	}
+}
+
+// TestUnwrapWithDataKey_WrongKEK tests if data key cannot be unwrapped without the KEK used to wrap it.
+func TestUnwrapWithDataKey_WrongKEK(t *testing.T) {
+	// Arrange
+	custody := newLocalKeyCustody(t, strings.Repeat("ab", 32))
+	otherCustody := newLocalKeyCustody(t, strings.Repeat("cd", 32))
+	wrapped, err := encryption.WrapWithDataKey(context.Background(), custody, "secret")
+	require.NoError(t, err)
+
+	// Act
+	_, err = encryption.UnwrapWithDataKey(context.Background(), otherCustody, wrapped)
+
+	// Assert
+	require.ErrorIs(t, err, encryption.ErrDecrypt)
+}
+
+// TestVaultKeyCustody_InvalidToken tests if Vault errors are returned to the caller.
+func TestVaultKeyCustody_InvalidToken(t *testing.T) {
+	// Arrange
+	vault := newVaultStub(t, "vault-token")
+	custody := encryption.NewVaultKeyCustody(vault.URL, "wrong-token", "test-key", vault.Client())
+
+	// Act
+	_, err := custody.WrapKey(context.Background(), []byte("data key"))
+
+	// Assert
+	require.ErrorContains(t, err, "permission denied")
+}
+
+func newLocalKeyCustody(t *testing.T, kek string) *encryption.LocalKeyCustody {
+	path := filepath.Join(t.TempDir(), "kek")
+	require.NoError(t, os.WriteFile(path, []byte(kek+"\n"), 0o600))
+
+	custody, err := encryption.NewLocalKeyCustody(path)
+	require.NoError(t, err)
+	return custody
+}
+
+// newVaultStub starts a server which imitates the Vault transit secrets engine.
+// It "encrypts" by hex encoding the plaintext, which is enough to check the protocol.
+func newVaultStub(t *testing.T, token string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != token {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+
+		var req map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		data := map[string]string{}
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/test-key":
+			data["ciphertext"] = "vault:v1:" + hex.EncodeToString([]byte(req["plaintext"]))
+		case "/v1/transit/decrypt/test-key":
+			plaintext, _ := hex.DecodeString(strings.TrimPrefix(req["ciphertext"], "vault:v1:"))
+			data["plaintext"] = string(plaintext)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
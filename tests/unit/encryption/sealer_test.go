@@ -0,0 +1,100 @@
+package encryption_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSealer tests if sealed ciphertext can be opened only by sealer with the same secret, with and without key custody.
+func TestSealer(t *testing.T) {
+	cases := []struct {
+		name    string
+		custody encryption.KeyCustody
+	}{
+		{
+			name: "Without key custody",
+		},
+		{
+			name:    "With key custody",
+			custody: newLocalKeyCustody(t, strings.Repeat("ab", 32)),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			sut, err := encryption.NewSealer("secret", tc.custody)
+			require.NoError(t, err)
+			other, err := encryption.NewSealer("other-secret", tc.custody)
+			require.NoError(t, err)
+
+			// Act
+			sealed, err := sut.Seal(context.Background(), "access-key")
+			require.NoError(t, err)
+			opened, err := sut.Open(context.Background(), sealed)
+			require.NoError(t, err)
+			_, otherErr := other.Open(context.Background(), sealed)
+
+			// Assert
+			assert.Equal(t, "access-key", opened)
+			assert.NotContains(t, sealed, "access-key")
+			assert.Equal(t, tc.custody != nil, encryption.IsWrapped(sealed))
+			require.ErrorIs(t, otherErr, encryption.ErrDecrypt)
+		})
+	}
+}
+
+// TestSealer_OpenEncrypted tests if ciphertext encrypted by Encrypt with the same secret isn't opened by the sealer.
+func TestSealer_OpenEncrypted(t *testing.T) {
+	// Arrange
+	sut, err := encryption.NewSealer("secret", nil)
+	require.NoError(t, err)
+	encrypted, err := encryption.Encrypt("secret", "access-key")
+	require.NoError(t, err)
+
+	// Act
+	opened, err := sut.Open(context.Background(), encrypted)
+
+	// Assert
+	require.ErrorIs(t, err, encryption.ErrInvalidCiphertext)
+	assert.Empty(t, opened)
+}
+
+// TestNewServerSealer tests if the sealer requires its own secret and doesn't fall back to the session secret.
+func TestNewServerSealer(t *testing.T) {
+	// Arrange
+	viper.Set(config.EnvHTTPServerSessionSecret, "session-secret")
+	viper.Set(config.EnvSealerSecret, "")
+
+	// Act
+	sut, err := encryption.NewServerSealer(nil)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, sut)
+}
+
+// TestSealer_OpenWrappedWithoutCustody tests if ciphertext wrapped with a data key is rejected when key custody is disabled.
+func TestSealer_OpenWrappedWithoutCustody(t *testing.T) {
+	// Arrange
+	sealer, err := encryption.NewSealer("secret", newLocalKeyCustody(t, strings.Repeat("ab", 32)))
+	require.NoError(t, err)
+	sealed, err := sealer.Seal(context.Background(), "access-key")
+	require.NoError(t, err)
+	sut, err := encryption.NewSealer("secret", nil)
+	require.NoError(t, err)
+
+	// Act
+	opened, err := sut.Open(context.Background(), sealed)
+
+	// Assert
+	require.Error(t, err)
+	assert.Empty(t, opened)
+}
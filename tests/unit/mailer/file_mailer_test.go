@@ -0,0 +1,48 @@
+package mailer_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/mailer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFileMailer tests if emails are appended to the file.
+func TestFileMailer(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "mails.log")
+	sut := mailer.NewFileMailer(path, "no-reply@example.com")
+
+	// Act
+	err1 := sut.Send(context.Background(), &mailer.Message{To: "homer.simpson@example.com", Subject: "First", Body: "first body"})
+	err2 := sut.Send(context.Background(), &mailer.Message{To: "marge.simpson@example.com", Subject: "Second", Body: "second body"})
+
+	// Assert
+	require.NoError(t, err1)
+	require.NoError(t, err2)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "From: no-reply@example.com\r\nTo: homer.simpson@example.com\r\nSubject: First\r\n")
+	assert.Contains(t, string(content), "first body")
+	assert.Contains(t, string(content), "To: marge.simpson@example.com\r\nSubject: Second\r\n")
+	assert.Contains(t, string(content), "second body")
+}
+
+// TestFileMailer_HeaderInjection tests if header values with line breaks are rejected.
+func TestFileMailer_HeaderInjection(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "mails.log")
+	sut := mailer.NewFileMailer(path, "no-reply@example.com")
+
+	// Act
+	err := sut.Send(context.Background(), &mailer.Message{To: "homer.simpson@example.com\r\nBcc: bart.simpson@example.com", Subject: "Subject", Body: "body"})
+
+	// Assert
+	require.Error(t, err)
+	assert.NoFileExists(t, path)
+}
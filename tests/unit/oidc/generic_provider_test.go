@@ -0,0 +1,238 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	clientID     = "wallet"
+	clientSecret = "secret"
+	redirectURL  = "http://localhost/callback"
+	keyID        = "key-1"
+	nonce        = "nonce"
+	code         = "code"
+)
+
+// testIssuer is a minimal OpenID Connect provider which issues ID tokens with the given claims for the code.
+type testIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	claims map[string]any
+	// signingKey signs issued tokens, it differs from the published key to forge them.
+	signingKey *rsa.PrivateKey
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	issuer := &testIssuer{key: key, signingKey: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]string{
+			"issuer":                 issuer.server.URL,
+			"authorization_endpoint": issuer.server.URL + "/authorize",
+			"token_endpoint":         issuer.server.URL + "/token",
+			"jwks_uri":               issuer.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": keyID,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if !ok || user != clientID || password != clientSecret || r.FormValue("code") != code {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		writeJSON(w, map[string]string{"id_token": issuer.sign(t, issuer.claims)})
+	})
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+
+	issuer.claims = map[string]any{
+		"iss":            issuer.server.URL,
+		"sub":            "subject",
+		"aud":            clientID,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          nonce,
+		"email":          "alice@example.com",
+		"email_verified": true,
+	}
+	return issuer
+}
+
+func (i *testIssuer) sign(t *testing.T, claims map[string]any) string {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": keyID})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, i.signingKey, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	// Arrange
+	issuer := newTestIssuer(t)
+	sut := oidc.NewGenericProvider(issuer.server.URL, clientID, clientSecret, redirectURL, nil)
+
+	// Act
+	result, err := sut.AuthCodeURL(context.Background(), "state", nonce)
+
+	// Assert
+	require.NoError(t, err)
+	authURL, err := url.Parse(result)
+	require.NoError(t, err)
+	assert.Equal(t, "/authorize", authURL.Path)
+	assert.Equal(t, clientID, authURL.Query().Get("client_id"))
+	assert.Equal(t, redirectURL, authURL.Query().Get("redirect_uri"))
+	assert.Equal(t, "state", authURL.Query().Get("state"))
+	assert.Equal(t, nonce, authURL.Query().Get("nonce"))
+	assert.Contains(t, authURL.Query().Get("scope"), "openid")
+}
+
+func TestExchange(t *testing.T) {
+	tests := map[string]struct {
+		claims      map[string]any
+		code        string
+		nonce       string
+		expectedErr string
+	}{
+		"Valid ID token": {},
+		"Invalid code": {
+			code:        "another-code",
+			expectedErr: "token request failed",
+		},
+		"Nonce of another sign-in": {
+			nonce:       "another-nonce",
+			expectedErr: "nonce",
+		},
+		"Token issued for another client": {
+			claims:      map[string]any{"aud": []string{"another-client"}},
+			expectedErr: "another client",
+		},
+		"Token issued by another issuer": {
+			claims:      map[string]any{"iss": "https://issuer.example.com"},
+			expectedErr: "issued by",
+		},
+		"Expired token": {
+			claims:      map[string]any{"exp": time.Now().Add(-time.Hour).Unix()},
+			expectedErr: "expired",
+		},
+		"Token without subject": {
+			claims:      map[string]any{"sub": ""},
+			expectedErr: "subject",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			issuer := newTestIssuer(t)
+			for k, v := range tc.claims {
+				issuer.claims[k] = v
+			}
+			sut := oidc.NewGenericProvider(issuer.server.URL, clientID, clientSecret, redirectURL, nil)
+
+			requestCode, requestNonce := code, nonce
+			if tc.code != "" {
+				requestCode = tc.code
+			}
+			if tc.nonce != "" {
+				requestNonce = tc.nonce
+			}
+
+			// Act
+			result, err := sut.Exchange(context.Background(), requestCode, requestNonce)
+
+			// Assert
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, issuer.server.URL, result.Issuer)
+			assert.Equal(t, "subject", result.Subject)
+			assert.Equal(t, "alice@example.com", result.Email)
+			assert.True(t, result.EmailVerified)
+		})
+	}
+}
+
+func TestExchangeRejectsForgedSignature(t *testing.T) {
+	// Arrange
+	issuer := newTestIssuer(t)
+	forgedKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	issuer.signingKey = forgedKey
+
+	sut := oidc.NewGenericProvider(issuer.server.URL, clientID, clientSecret, redirectURL, nil)
+
+	// Act
+	result, err := sut.Exchange(context.Background(), code, nonce)
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "signature")
+	assert.Nil(t, result)
+}
+
+func TestStubProvider(t *testing.T) {
+	// Arrange
+	sut := oidc.NewStubProvider(redirectURL, oidc.Claims{Subject: "stub-user", Email: "alice@example.com", EmailVerified: true})
+
+	// Act
+	authCodeURL, err := sut.AuthCodeURL(context.Background(), "state", nonce)
+	require.NoError(t, err)
+	callbackURL, err := url.Parse(authCodeURL)
+	require.NoError(t, err)
+
+	claims, err := sut.Exchange(context.Background(), callbackURL.Query().Get("code"), nonce)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "state", callbackURL.Query().Get("state"))
+	assert.Equal(t, oidc.StubIssuer, claims.Issuer)
+	assert.Equal(t, "stub-user", claims.Subject)
+
+	_, err = sut.Exchange(context.Background(), "another-code", nonce)
+	require.Error(t, err)
+}
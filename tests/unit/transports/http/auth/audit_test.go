@@ -0,0 +1,117 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/auth"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudit(t *testing.T) {
+	const userID = 7
+	testLogger := zerolog.Nop()
+
+	signedIn := func(c *gin.Context) {
+		c.Set(auth.SessionUserID, userID)
+		c.Set(auth.SessionUserPaymail, "alice@example.com")
+		c.Set(auth.UserPermissions, roles.Permissions{roles.PermissionWalletRead: {}})
+	}
+
+	tests := map[string]struct {
+		handlers        []gin.HandlerFunc
+		expectedUserID  *int
+		expectedActor   string
+		expectedTarget  string
+		expectedOutcome string
+		expectedDetails string
+	}{
+		"Successful action of signed-in user": {
+			handlers: []gin.HandlerFunc{
+				signedIn,
+				func(c *gin.Context) { c.Status(http.StatusOK) },
+			},
+			expectedUserID:  toPtr(userID),
+			expectedActor:   "alice@example.com",
+			expectedTarget:  "id=42",
+			expectedOutcome: audit.OutcomeSuccess,
+		},
+		"Forbidden attempt is recorded": {
+			handlers: []gin.HandlerFunc{
+				signedIn,
+				auth.RequirePermission(roles.PermissionWalletSpend),
+				func(c *gin.Context) { c.Status(http.StatusOK) },
+			},
+			expectedUserID:  toPtr(userID),
+			expectedActor:   "alice@example.com",
+			expectedTarget:  "id=42",
+			expectedOutcome: audit.OutcomeFailure,
+			expectedDetails: spverrors.ErrForbidden.Code,
+		},
+		"Actor and target set by handler": {
+			handlers: []gin.HandlerFunc{
+				func(c *gin.Context) {
+					auth.SetAuditActor(c, nil, "bob@example.com")
+					auth.SetAuditTarget(c, "carol@example.com")
+					spverrors.ErrorResponse(c, spverrors.ErrInvalidCredentials, &testLogger)
+				},
+			},
+			expectedActor:   "bob@example.com",
+			expectedTarget:  "carol@example.com",
+			expectedOutcome: audit.OutcomeFailure,
+			expectedDetails: spverrors.ErrInvalidCredentials.Code,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockAuditRepository(ctrl)
+			var recorded *audit.Entry
+			repoMq.EXPECT().
+				InsertEntry(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ any, entry *audit.Entry, _ bool) error {
+					recorded = entry
+					return nil
+				})
+
+			gin.SetMode(gin.TestMode)
+			engine := gin.New()
+			handlers := append([]gin.HandlerFunc{auth.Audit(audit.NewAuditService(repoMq, &testLogger), audit.ActionViewTransaction)}, tc.handlers...)
+			engine.GET("/test/:id", handlers...)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/test/42", nil)
+			req.RemoteAddr = "10.0.0.1:1234"
+
+			// Act
+			engine.ServeHTTP(w, req)
+
+			// Assert
+			require.NotNil(t, recorded)
+			assert.Equal(t, audit.ActionViewTransaction, recorded.Action)
+			assert.Equal(t, tc.expectedUserID, recorded.UserID)
+			assert.Equal(t, tc.expectedActor, recorded.Actor)
+			assert.Equal(t, "10.0.0.1", recorded.IP)
+			assert.Equal(t, tc.expectedTarget, recorded.Target)
+			assert.Equal(t, tc.expectedOutcome, recorded.Outcome)
+			assert.Equal(t, tc.expectedDetails, recorded.Details)
+		})
+	}
+}
+
+func toPtr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,131 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/auth"
+	router "github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/routes"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequirePermission(t *testing.T) {
+	auditor := roles.Permissions{
+		roles.PermissionWalletRead:    {},
+		roles.PermissionAccountManage: {},
+	}
+
+	tests := map[string]struct {
+		permissions roles.Permissions
+		required    string
+		expected    int
+	}{
+		"Permission granted": {
+			permissions: auditor,
+			required:    roles.PermissionWalletRead,
+			expected:    http.StatusOK,
+		},
+		"Auditor can't spend": {
+			permissions: auditor,
+			required:    roles.PermissionWalletSpend,
+			expected:    http.StatusForbidden,
+		},
+		"Permissions not loaded": {
+			required: roles.PermissionWalletRead,
+			expected: http.StatusForbidden,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			gin.SetMode(gin.TestMode)
+			engine := gin.New()
+			engine.GET("/test",
+				func(c *gin.Context) {
+					if tc.permissions != nil {
+						c.Set(auth.UserPermissions, tc.permissions)
+					}
+				},
+				auth.RequirePermission(tc.required),
+				func(c *gin.Context) { c.Status(http.StatusOK) },
+			)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+
+			// Act
+			engine.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tc.expected, w.Code)
+		})
+	}
+}
+
+func TestPermissionsMiddleware(t *testing.T) {
+	tests := map[string]struct {
+		path       string
+		permission string
+		expected   int
+	}{
+		"Route declares granted permission": {
+			path:       "/read",
+			permission: roles.PermissionWalletRead,
+			expected:   http.StatusOK,
+		},
+		"Route declares permission which isn't granted": {
+			path:       "/spend",
+			permission: roles.PermissionWalletSpend,
+			expected:   http.StatusForbidden,
+		},
+		"Route is allowed to every signed-in user": {
+			path:       "/signed-in",
+			permission: auth.PermissionSignedIn,
+			expected:   http.StatusOK,
+		},
+		"Route without declared permission is forbidden": {
+			path:     "/undeclared",
+			expected: http.StatusForbidden,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			testLogger := zerolog.Nop()
+			rolesMq := mock.NewMockRolesRepository(ctrl)
+			rolesMq.EXPECT().GetUserPermissions(gomock.Any(), gomock.Any()).Return([]string{roles.PermissionWalletRead}, nil).AnyTimes()
+			s := &domain.Services{RolesService: roles.NewRolesService(rolesMq, nil, &testLogger)}
+
+			gin.SetMode(gin.TestMode)
+			engine := gin.New()
+			group := engine.Group("/api/v1", router.ToHandlers(auth.NewPermissionsMiddleware(s, &testLogger))...)
+			handler := func(c *gin.Context) { c.Status(http.StatusOK) }
+			if tc.permission != "" {
+				auth.NewRoutes(group).GET(tc.path, tc.permission, handler)
+			} else {
+				group.GET(tc.path, handler)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1"+tc.path, nil)
+
+			// Act
+			engine.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tc.expected, w.Code)
+		})
+	}
+}
@@ -3,14 +3,27 @@ package auth_test
 import (
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/grants"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/passkeys"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/tests/authenticator"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/auth"
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-contrib/sessions/memstore"
 	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTerminateSession(t *testing.T) {
@@ -50,8 +63,11 @@ func TestUpdateSession(t *testing.T) {
 		Xpriv: "xprivtest",
 	}
 
+	sessionID := gofakeit.IntRange(0, 1000)
+	grantID := gofakeit.HexUint256()
+
 	// Act
-	auth.UpdateSession(ctx, &user)
+	auth.UpdateSession(ctx, &user, sessionID, grantID)
 
 	// Assert
 	session := sessions.Default(ctx)
@@ -60,7 +76,159 @@ func TestUpdateSession(t *testing.T) {
 	assert.Equal(t, user.AccessKey.Key, session.Get(auth.SessionAccessKey))
 	assert.Equal(t, user.User.ID, session.Get(auth.SessionUserID))
 	assert.Equal(t, user.User.Paymail, session.Get(auth.SessionUserPaymail))
-	assert.Equal(t, user.Xpriv, session.Get(auth.SessionXPriv))
+	assert.Equal(t, sessionID, session.Get(auth.SessionID))
+	assert.Equal(t, grantID, session.Get(auth.SessionSigningGrantID))
+	assert.Nil(t, session.Get("xPriv"))
+}
+
+func TestSigner_UnlockXpriv(t *testing.T) {
+	const userID = 7
+	const xpriv = "xprivtest"
+	const passphrase = "wallet-passphrase"
+
+	passphraseXpriv, err := encryption.Encrypt(passphrase, xpriv)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		passphrase    string
+		withGrant     bool
+		expectedXpriv string
+		expectedErr   error
+	}{
+		"Session signed in with single sign-on spends with the wallet passphrase": {
+			passphrase:    passphrase,
+			expectedXpriv: xpriv,
+		},
+		"Session signed in with single sign-on spends with the signing grant": {
+			withGrant:     true,
+			expectedXpriv: xpriv,
+		},
+		"Wrong wallet passphrase": {
+			passphrase:  "wrong-passphrase",
+			withGrant:   true,
+			expectedErr: spverrors.ErrInvalidCredentials,
+		},
+		"Session without signing grant can't spend without password or passphrase": {
+			expectedErr: spverrors.ErrSigningGrantRequired,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			testLogger := zerolog.Nop()
+			repoMq := mock.NewMockRepository(ctrl)
+			repoMq.EXPECT().GetUserPassphraseXpriv(gomock.Any(), userID).Return(passphraseXpriv, nil).AnyTimes()
+
+			viper.Set(config.EnvHTTPServerSessionSigningGrantTTL, time.Minute)
+			gService := grants.NewGrantsService(&testLogger)
+			sut := auth.NewSigner(&domain.Services{
+				UsersService:  users.NewUserService(repoMq, nil, nil, nil, nil, nil, nil, nil, &testLogger),
+				GrantsService: gService,
+			})
+
+			ctx := setupTest()
+			ctx.Set(auth.SessionUserID, userID)
+			if tc.withGrant {
+				grant, grantErr := gService.CreateGrant(userID, xpriv)
+				require.NoError(t, grantErr)
+				ctx.Set(auth.SessionSigningGrantID, grant.ID)
+			}
+
+			// Act
+			result, err := sut.UnlockXpriv(ctx, "", tc.passphrase)
+
+			// Assert
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedXpriv, result)
+		})
+	}
+}
+
+func TestSigner_UnlockXpriv_PasskeyGrant(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const userID = 7
+	const xpriv = "xprivtest"
+	const password = "password"
+	const rpID = "wallet.example.com"
+	const origin = "https://wallet.example.com"
+
+	testLogger := zerolog.Nop()
+	viper.Set(config.EnvWebAuthnRPID, rpID)
+	viper.Set(config.EnvWebAuthnOrigins, []string{origin})
+	viper.Set(config.EnvWebAuthnChallengeTTL, time.Minute)
+	viper.Set(config.EnvHTTPServerSessionSigningGrantTTL, time.Minute)
+
+	encryptedXpriv, err := encryption.Encrypt(password, xpriv)
+	require.NoError(t, err)
+	usersMq := mock.NewMockRepository(ctrl)
+	usersMq.EXPECT().GetUserByID(gomock.Any(), userID).Return(&users.User{ID: userID, Email: "alice@example.com", Xpriv: encryptedXpriv}, nil).AnyTimes()
+	uService := users.NewUserService(usersMq, nil, nil, nil, nil, nil, nil, nil, &testLogger)
+
+	var stored *passkeys.Passkey
+	passkeysMq := mock.NewMockPasskeysRepository(ctrl)
+	passkeysMq.EXPECT().GetUserPasskeys(gomock.Any(), userID).DoAndReturn(
+		func(_ any, _ int) ([]*passkeys.Passkey, error) {
+			if stored == nil {
+				return []*passkeys.Passkey{}, nil
+			}
+			return []*passkeys.Passkey{stored}, nil
+		}).Times(2)
+	passkeysMq.EXPECT().InsertPasskey(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, passkey *passkeys.Passkey) (bool, error) {
+			passkey.ID = 2
+			stored = passkey
+			return true, nil
+		})
+	passkeysMq.EXPECT().GetPasskeyByCredentialID(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, _ []byte) (*passkeys.Passkey, error) {
+			return stored, nil
+		})
+	passkeysMq.EXPECT().UpdatePasskeyUse(gomock.Any(), 2, gomock.Any(), gomock.Any()).Return(nil)
+	pService := passkeys.NewPasskeysService(passkeysMq, uService, nil, &testLogger)
+
+	a, err := authenticator.New(rpID, origin)
+	require.NoError(t, err)
+	creation, err := pService.StartRegistration(userID)
+	require.NoError(t, err)
+	registration, err := a.Create(creation)
+	require.NoError(t, err)
+	_, err = pService.Register(userID, "Laptop", password, creation.Challenge, creation.User.ID, registration)
+	require.NoError(t, err)
+
+	// Session is unlocked with the passkey as by POST /api/v1/signing-grant/passkey.
+	request, err := pService.StartAssertion(toPtr(userID))
+	require.NoError(t, err)
+	assertion, err := a.Get(request)
+	require.NoError(t, err)
+	unlocked, err := pService.Authenticate(request.Challenge, assertion)
+	require.NoError(t, err)
+
+	gService := grants.NewGrantsService(&testLogger)
+	grant, err := gService.CreateGrant(userID, unlocked.Xpriv)
+	require.NoError(t, err)
+
+	ctx := setupTest()
+	ctx.Set(auth.SessionUserID, userID)
+	ctx.Set(auth.SessionSigningGrantID, grant.ID)
+	sut := auth.NewSigner(&domain.Services{UsersService: uService, GrantsService: gService})
+
+	// Act
+	result, err := sut.UnlockXpriv(ctx, "", "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, xpriv, result)
 }
 
 func setupTest() (ctx *gin.Context) {
@@ -0,0 +1,188 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/tokens"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/encryption"
+	mock "github.com/bitcoin-sv/spv-wallet-web-backend/tests/mocks"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/auth"
+	router "github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/routes"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tokenSessionSecret = "test-session-secret"
+
+// setupTokenEngine returns engine with token and permissions middlewares, which responds with variables set by them.
+// Requests without token are authorized by the session of the user 7.
+func setupTokenEngine(t *testing.T, ctrl *gomock.Controller) (*gin.Engine, *mock.MockTokensRepository) {
+	testLogger := zerolog.Nop()
+	viper.Set(config.EnvHTTPServerSessionSecret, tokenSessionSecret)
+
+	tokensMq := mock.NewMockTokensRepository(ctrl)
+	usersMq := mock.NewMockRepository(ctrl)
+	usersMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(&users.User{ID: 1, Paymail: "alice@example.com"}, nil).AnyTimes()
+	rolesMq := mock.NewMockRolesRepository(ctrl)
+	rolesMq.EXPECT().GetUserPermissions(gomock.Any(), gomock.Any()).Return([]string{
+		roles.PermissionWalletRead,
+		roles.PermissionWalletSpend,
+		roles.PermissionContactsWrite,
+		roles.PermissionAccountManage,
+	}, nil).AnyTimes()
+
+	sealer, err := encryption.NewSealer(tokenSessionSecret, nil)
+	require.NoError(t, err)
+	uService := users.NewUserService(usersMq, nil, nil, nil, nil, nil, nil, nil, &testLogger)
+	s := &domain.Services{
+		TokensService: tokens.NewTokensService(tokensMq, uService, nil, sealer, &testLogger),
+		RolesService:  roles.NewRolesService(rolesMq, uService, &testLogger),
+	}
+
+	sessionAuth := router.APIMiddlewareFunc(func(c *gin.Context) {
+		c.Set(auth.SessionUserID, 7)
+	})
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(router.ToHandlers(
+		auth.NewTokenMiddleware(s, sessionAuth, &testLogger),
+		auth.NewPermissionsMiddleware(s, &testLogger),
+	)...)
+	routes := auth.NewRoutes(&engine.RouterGroup)
+	routes.GET("/test", auth.PermissionSignedIn, func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"userId":    c.GetInt(auth.SessionUserID),
+			"accessKey": c.GetString(auth.SessionAccessKey),
+			"paymail":   c.GetString(auth.SessionUserPaymail),
+			"read":      auth.HasPermission(c, roles.PermissionWalletRead),
+			"spend":     auth.HasPermission(c, roles.PermissionWalletSpend),
+			"manage":    auth.HasPermission(c, roles.PermissionAccountManage),
+		})
+	})
+	routes.POST("/sign-out", auth.PermissionSignedIn, auth.RequireSession(), func(c *gin.Context) { c.Status(http.StatusOK) })
+	return engine, tokensMq
+}
+
+func newStoredToken(t *testing.T, scopes ...string) *tokens.Token {
+	sealer, err := encryption.NewSealer(tokenSessionSecret, nil)
+	require.NoError(t, err)
+	encryptedAccessKey, err := sealer.Seal(context.Background(), "token-access-key")
+	require.NoError(t, err)
+	return &tokens.Token{ID: 2, UserID: 1, Scopes: scopes, AccessKeyID: "token-key-id", AccessKey: encryptedAccessKey}
+}
+
+func serve(engine *gin.Engine, method, path, authorization string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestTokenMiddleware(t *testing.T) {
+	t.Run("Request without token is authorized by session", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		engine, _ := setupTokenEngine(t, ctrl)
+
+		// Act
+		w := serve(engine, http.MethodGet, "/test", "")
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.InDelta(t, 7, body["userId"], 0)
+		assert.Equal(t, true, body["manage"])
+	})
+
+	t.Run("Token sets session variables and limits permissions to its scopes", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		engine, tokensMq := setupTokenEngine(t, ctrl)
+		tokensMq.EXPECT().GetTokenByHash(gomock.Any(), gomock.Any()).Return(newStoredToken(t, tokens.ScopeReadOnly), nil)
+		tokensMq.EXPECT().TouchToken(gomock.Any(), 2, gomock.Any()).Return(nil)
+
+		// Act
+		w := serve(engine, http.MethodGet, "/test", "Bearer spvw_token")
+
+		// Assert
+		require.Equal(t, http.StatusOK, w.Code)
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.InDelta(t, 1, body["userId"], 0)
+		assert.Equal(t, "token-access-key", body["accessKey"])
+		assert.Equal(t, "alice@example.com", body["paymail"])
+		assert.Equal(t, true, body["read"])
+		assert.Equal(t, false, body["spend"])
+		assert.Equal(t, false, body["manage"])
+	})
+
+	t.Run("Unknown token", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		engine, tokensMq := setupTokenEngine(t, ctrl)
+		tokensMq.EXPECT().GetTokenByHash(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		// Act
+		w := serve(engine, http.MethodGet, "/test", "Bearer spvw_token")
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Authorization header of another scheme", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		engine, _ := setupTokenEngine(t, ctrl)
+
+		// Act
+		w := serve(engine, http.MethodGet, "/test", "Basic YWxpY2U6cGFzc3dvcmQ=")
+
+		// Assert
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestRequireSession(t *testing.T) {
+	t.Run("Session", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		engine, _ := setupTokenEngine(t, ctrl)
+
+		// Act
+		w := serve(engine, http.MethodPost, "/sign-out", "")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("API token", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		engine, tokensMq := setupTokenEngine(t, ctrl)
+		tokensMq.EXPECT().GetTokenByHash(gomock.Any(), gomock.Any()).Return(newStoredToken(t, tokens.ScopeReadOnly), nil)
+		tokensMq.EXPECT().TouchToken(gomock.Any(), 2, gomock.Any()).Return(nil)
+
+		// Act
+		w := serve(engine, http.MethodPost, "/sign-out", "Bearer spvw_token")
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -0,0 +1,58 @@
+package spvwallet_test
+
+import (
+	"testing"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/spvwallet"
+	"github.com/bitcoin-sv/spv-wallet/models/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPaymailsFromMetadata(t *testing.T) {
+	tests := map[string]struct {
+		metadata         map[string]any
+		direction        string
+		expectedSender   string
+		expectedReceiver string
+	}{
+		"Single recipient": {
+			metadata:         map[string]any{"sender": "me@example.com", "receiver": "alice@example.com"},
+			direction:        "outgoing",
+			expectedSender:   "me@example.com",
+			expectedReceiver: "alice@example.com",
+		},
+		"Several recipients": {
+			metadata: map[string]any{
+				"sender":    "me@example.com",
+				"receiver":  "alice@example.com",
+				"receivers": []any{"alice@example.com", "bob@example.com"},
+			},
+			direction:        "outgoing",
+			expectedSender:   "me@example.com",
+			expectedReceiver: "alice@example.com, bob@example.com",
+		},
+		"Transaction made outside SPV Wallet": {
+			metadata:         map[string]any{"p2p_tx_metadata": map[string]any{"sender": "bob@example.com"}},
+			direction:        "incoming",
+			expectedSender:   "bob@example.com",
+			expectedReceiver: "me@example.com",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			transaction := &response.Transaction{
+				Model:                response.Model{Metadata: tc.metadata},
+				TransactionDirection: tc.direction,
+			}
+
+			// Act
+			sender, receiver := spvwallet.GetPaymailsFromMetadata(transaction, "me@example.com")
+
+			// Assert
+			assert.Equal(t, tc.expectedSender, sender)
+			assert.Equal(t, tc.expectedReceiver, receiver)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package spvwallet_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/spvwallet"
+	"github.com/bitcoin-sv/spv-wallet/models/response"
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccessKeys_ReadsAllPages(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	revokedAt := time.Now()
+	pages := [][]*response.AccessKey{
+		{{ID: "first"}, {ID: "revoked", RevokedAt: &revokedAt}},
+		{{ID: "second"}},
+	}
+
+	requested := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		requested = append(requested, page)
+
+		number, err := strconv.Atoi(page)
+		if err != nil || number < 1 || number > len(pages) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response.PageModel[response.AccessKey]{
+			Content: pages[number-1],
+			Page:    response.PageDescription{Number: number, Size: 2, TotalElements: 3, TotalPages: len(pages)},
+		})
+	}))
+	defer server.Close()
+	viper.Set(config.EnvServerURL, server.URL)
+
+	userWalletClient, err := spvwallet.NewWalletClientFactory(&testLogger).CreateWithAccessKey(gofakeit.HexUint256()[2:])
+	require.NoError(t, err)
+
+	// Act
+	accessKeys, err := userWalletClient.GetAccessKeys()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, requested)
+	require.Len(t, accessKeys, 2)
+	assert.Equal(t, "first", accessKeys[0].GetAccessKeyID())
+	assert.Equal(t, "second", accessKeys[1].GetAccessKeyID())
+}
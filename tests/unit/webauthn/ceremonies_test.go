@@ -0,0 +1,210 @@
+package webauthn_test
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/tests/authenticator"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/webauthn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	rpID   = "wallet.example.com"
+	origin = "https://wallet.example.com"
+)
+
+func newRelyingParty() *webauthn.RelyingParty {
+	return webauthn.NewRelyingParty(rpID, "SPV Wallet", []string{origin}, 5*time.Minute)
+}
+
+func newAuthenticator(t *testing.T) *authenticator.Authenticator {
+	a, err := authenticator.New(rpID, origin)
+	require.NoError(t, err)
+	return a
+}
+
+// register registers credential of the authenticator at the relying party.
+func register(t *testing.T, rp *webauthn.RelyingParty, a *authenticator.Authenticator) *webauthn.Credential {
+	challenge, err := rp.NewChallenge()
+	require.NoError(t, err)
+	options := rp.CreationOptions(challenge, webauthn.UserEntity{ID: "handle", Name: "alice@example.com"}, nil)
+
+	response, err := a.Create(options)
+	require.NoError(t, err)
+
+	credential, err := rp.VerifyRegistration(challenge, response)
+	require.NoError(t, err)
+	return credential
+}
+
+func TestCreationOptions(t *testing.T) {
+	// Arrange
+	rp := newRelyingParty()
+	exclude := [][]byte{{1, 2, 3}}
+
+	// Act
+	result := rp.CreationOptions("challenge", webauthn.UserEntity{ID: "handle"}, exclude)
+
+	// Assert
+	assert.Equal(t, rpID, result.RP.ID)
+	assert.Equal(t, "challenge", result.Challenge)
+	assert.Equal(t, "required", result.AuthenticatorSelection.UserVerification)
+	assert.Equal(t, int64(5*time.Minute/time.Millisecond), result.Timeout)
+	require.Len(t, result.ExcludeCredentials, 1)
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(exclude[0]), result.ExcludeCredentials[0].ID)
+	require.NotNil(t, result.Extensions.PRF)
+	assert.NotEmpty(t, result.Extensions.PRF.Eval.First)
+}
+
+func TestVerifyRegistration(t *testing.T) {
+	tests := map[string]struct {
+		modify      func(a *authenticator.Authenticator)
+		challenge   string
+		expectedErr string
+	}{
+		"Valid registration": {},
+		"Challenge of another ceremony": {
+			challenge:   "another-challenge",
+			expectedErr: "challenge",
+		},
+		"Another origin": {
+			modify:      func(a *authenticator.Authenticator) { a.Origin = "https://evil.example.com" },
+			expectedErr: "origin",
+		},
+		"Credential of another relying party": {
+			modify:      func(a *authenticator.Authenticator) { a.RPID = "evil.example.com" },
+			expectedErr: "relying party",
+		},
+		"User not verified": {
+			modify:      func(a *authenticator.Authenticator) { a.UserVerified = false },
+			expectedErr: "verified",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			rp := newRelyingParty()
+			a := newAuthenticator(t)
+			if tc.modify != nil {
+				tc.modify(a)
+			}
+
+			challenge, err := rp.NewChallenge()
+			require.NoError(t, err)
+			response, err := a.Create(rp.CreationOptions(challenge, webauthn.UserEntity{ID: "handle"}, nil))
+			require.NoError(t, err)
+
+			if tc.challenge != "" {
+				challenge = tc.challenge
+			}
+
+			// Act
+			result, err := rp.VerifyRegistration(challenge, response)
+
+			// Assert
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, a.CredentialID(), result.ID)
+			assert.NotEmpty(t, result.PublicKey)
+			assert.Equal(t, []string{"internal"}, result.Transports)
+
+			secret, err := response.ClientExtensionResults.PRFSecret()
+			require.NoError(t, err)
+			assert.Len(t, secret, 32)
+		})
+	}
+}
+
+func TestVerifyAssertion(t *testing.T) {
+	t.Run("Valid assertion", func(t *testing.T) {
+		// Arrange
+		rp := newRelyingParty()
+		a := newAuthenticator(t)
+		credential := register(t, rp, a)
+
+		challenge, err := rp.NewChallenge()
+		require.NoError(t, err)
+		response, err := a.Get(rp.RequestOptions(challenge, [][]byte{credential.ID}))
+		require.NoError(t, err)
+
+		// Act
+		signCount, err := rp.VerifyAssertion(challenge, response, credential)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, a.SignCount, signCount)
+	})
+
+	t.Run("Signature of another credential", func(t *testing.T) {
+		// Arrange
+		rp := newRelyingParty()
+		credential := register(t, rp, newAuthenticator(t))
+		another := newAuthenticator(t)
+		register(t, rp, another)
+
+		challenge, err := rp.NewChallenge()
+		require.NoError(t, err)
+		response, err := another.Get(rp.RequestOptions(challenge, nil))
+		require.NoError(t, err)
+
+		// Act
+		_, err = rp.VerifyAssertion(challenge, response, credential)
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "signature")
+	})
+
+	t.Run("Replayed assertion", func(t *testing.T) {
+		// Arrange
+		rp := newRelyingParty()
+		a := newAuthenticator(t)
+		credential := register(t, rp, a)
+
+		challenge, err := rp.NewChallenge()
+		require.NoError(t, err)
+		response, err := a.Get(rp.RequestOptions(challenge, nil))
+		require.NoError(t, err)
+
+		signCount, err := rp.VerifyAssertion(challenge, response, credential)
+		require.NoError(t, err)
+		credential.SignCount = signCount
+
+		// Act
+		_, err = rp.VerifyAssertion(challenge, response, credential)
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cloned")
+	})
+
+	t.Run("Same credential derives same PRF secret", func(t *testing.T) {
+		// Arrange
+		rp := newRelyingParty()
+		a := newAuthenticator(t)
+		challenge, err := rp.NewChallenge()
+		require.NoError(t, err)
+		created, err := a.Create(rp.CreationOptions(challenge, webauthn.UserEntity{ID: "handle"}, nil))
+		require.NoError(t, err)
+
+		// Act
+		asserted, err := a.Get(rp.RequestOptions(challenge, nil))
+		require.NoError(t, err)
+
+		// Assert
+		createdSecret, err := created.ClientExtensionResults.PRFSecret()
+		require.NoError(t, err)
+		assertedSecret, err := asserted.ClientExtensionResults.PRFSecret()
+		require.NoError(t, err)
+		assert.Equal(t, createdSecret, assertedSecret)
+	})
+}
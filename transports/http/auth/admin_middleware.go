@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"strings"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/operators"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// Operator variables set by AdminMiddleware.
+const (
+	OperatorID    = "operatorId"
+	OperatorEmail = "operatorEmail"
+	OperatorRole  = "operatorRole"
+	// OperatorToken is the bearer token of the operator session, it's needed to sign out.
+	OperatorToken = "operatorToken"
+)
+
+const bearerPrefix = "Bearer "
+
+// AdminMiddleware middleware that is checking the operator bearer token of admin API requests.
+// Operators are never authenticated by the user session cookie.
+type AdminMiddleware struct {
+	operatorsService *operators.Service
+	log              *zerolog.Logger
+}
+
+// NewAdminMiddleware create middleware that is checking the operator bearer token.
+func NewAdminMiddleware(s *domain.Services, logger *zerolog.Logger) *AdminMiddleware {
+	log := logger.With().Str("service", "admin-middleware").Logger()
+	return &AdminMiddleware{
+		operatorsService: s.OperatorsService,
+		log:              &log,
+	}
+}
+
+// ApplyToAPI is a middleware which authenticates operator by the bearer token.
+func (h *AdminMiddleware) ApplyToAPI(c *gin.Context) {
+	token, found := strings.CutPrefix(c.GetHeader("Authorization"), bearerPrefix)
+	if !found {
+		spverrors.AbortWithErrorResponse(c, spverrors.ErrUnauthorized, h.log)
+		return
+	}
+
+	operator, err := h.operatorsService.Authenticate(token)
+	if err != nil {
+		spverrors.AbortWithErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Set(OperatorID, operator.ID)
+	c.Set(OperatorEmail, operator.Email)
+	c.Set(OperatorRole, operator.Role)
+	c.Set(OperatorToken, token)
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
+	"github.com/gin-gonic/gin"
+)
+
+// Audit variables which can be set by handlers, when they're not known from session or route.
+const (
+	auditActorID   = "auditActorId"
+	auditActorName = "auditActorName"
+	auditTarget    = "auditTarget"
+)
+
+// Audit declares action of the route which is recorded in the audit log after the route is handled. Permission of the route
+// is checked after it, so also forbidden attempts are recorded:
+//
+//	routes.GET("/user", roles.PermissionWalletRead, auth.Audit(s, audit.ActionViewAccount), h.getUser)
+//
+// Action fails if handler responded with error (see spverrors.ErrorResponse) or with error status.
+// Target are route params, unless the handler sets it with SetAuditTarget.
+func Audit(s *audit.Service, action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		s.Record(actor(c), action, target(c), outcome(c))
+	}
+}
+
+// SetAuditActor sets actor of the action performed by user who isn't signed in, e.g. email which the user tried to sign in with.
+// If the user is known, e.g. after successful sign-in, it's passed as userID, otherwise it's nil.
+func SetAuditActor(c *gin.Context, userID *int, name string) {
+	if userID != nil {
+		c.Set(auditActorID, *userID)
+	}
+	c.Set(auditActorName, name)
+}
+
+// SetAuditTarget sets target of the action, when it's not given by route params, e.g. recipient of the transaction.
+func SetAuditTarget(c *gin.Context, target string) {
+	c.Set(auditTarget, target)
+}
+
+func actor(c *gin.Context) audit.Actor {
+	result := audit.Actor{IP: c.ClientIP()}
+
+	if name, ok := c.Get(auditActorName); ok {
+		result.Name = name.(string)
+		if _, ok := c.Get(auditActorID); ok {
+			id := c.GetInt(auditActorID)
+			result.UserID = &id
+		}
+		return result
+	}
+
+	if _, ok := c.Get(SessionUserID); ok {
+		id := c.GetInt(SessionUserID)
+		result.UserID = &id
+		result.Name = c.GetString(SessionUserPaymail)
+	}
+	return result
+}
+
+func target(c *gin.Context) string {
+	if t, ok := c.Get(auditTarget); ok {
+		return t.(string)
+	}
+
+	params := make([]string, 0, len(c.Params))
+	for _, p := range c.Params {
+		params = append(params, p.Key+"="+p.Value)
+	}
+	return strings.Join(params, " ")
+}
+
+func outcome(c *gin.Context) error {
+	if err := c.Errors.Last(); err != nil {
+		return err.Err
+	}
+	if status := c.Writer.Status(); status >= http.StatusBadRequest {
+		return errors.New(http.StatusText(status))
+	}
+	return nil
+}
@@ -42,25 +42,38 @@ func NewAuthMiddleware(s *domain.Services, logger *zerolog.Logger) *Middleware {
 func (h *Middleware) ApplyToAPI(c *gin.Context) {
 	session := sessions.Default(c)
 
-	accessKeyID, accessKey, userID, paymail, xPriv, err := h.authorizeSession(session)
+	accessKeyID, accessKey, userID, paymail, signingGrantID, err := h.authorizeSession(session)
 	if err != nil {
 		spverrors.AbortWithErrorResponse(c, spverrors.ErrUnauthorized, h.log)
 		return
 	}
 
+	// Sessions created before sessions tracking have no record, they're checked only by access key.
+	if sessionID, ok := session.Get(SessionID).(int); ok {
+		if err = h.services.SessionsService.TouchSession(sessionID, accessKeyID.(string)); err != nil {
+			spverrors.AbortWithErrorResponse(c, spverrors.ErrUnauthorized, h.log)
+			return
+		}
+		c.Set(SessionID, sessionID)
+	}
+
+	h.removeLegacyXPriv(session)
+
 	c.Set(SessionAccessKeyID, accessKeyID)
 	c.Set(SessionAccessKey, accessKey)
 	c.Set(SessionUserID, userID)
 	c.Set(SessionUserPaymail, paymail)
-	c.Set(SessionXPriv, xPriv)
+	if signingGrantID != nil {
+		c.Set(SessionSigningGrantID, signingGrantID)
+	}
 }
 
-func (h *Middleware) authorizeSession(s sessions.Session) (accessKeyID, accessKey, userID, paymail, xPriv interface{}, err error) {
+func (h *Middleware) authorizeSession(s sessions.Session) (accessKeyID, accessKey, userID, paymail, signingGrantID interface{}, err error) {
 	accessKeyID = s.Get(SessionAccessKeyID)
 	accessKey = s.Get(SessionAccessKey)
 	userID = s.Get(SessionUserID)
 	paymail = s.Get(SessionUserPaymail)
-	xPriv = s.Get(SessionXPriv)
+	signingGrantID = s.Get(SessionSigningGrantID)
 
 	if isNilOrEmpty(accessKeyID) ||
 		isNilOrEmpty(accessKey) ||
@@ -77,6 +90,18 @@ func (h *Middleware) authorizeSession(s sessions.Session) (accessKeyID, accessKe
 	return
 }
 
+// removeLegacyXPriv removes decrypted xPriv from sessions created by previous versions.
+func (h *Middleware) removeLegacyXPriv(s sessions.Session) {
+	if s.Get(legacySessionXPriv) == nil {
+		return
+	}
+
+	s.Delete(legacySessionXPriv)
+	if err := s.Save(); err != nil {
+		h.log.Error().Msgf("Cannot remove xPriv from session: %v", err)
+	}
+}
+
 func isNilOrEmpty(s interface{}) bool {
 	return s == nil || s == ""
 }
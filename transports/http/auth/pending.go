@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"encoding/json"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// Pending is a value of a multi-step sign-in kept in session between requests, like a challenge or a state.
+type Pending interface {
+	Expired() bool
+}
+
+// SavePending saves the value in session under the key.
+func SavePending(c *gin.Context, key string, value Pending) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+
+	session := sessions.Default(c)
+	session.Set(key, string(data))
+	if err = session.Save(); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// LoadPending reads value saved by SavePending, false is returned if there is no such value or it expired.
+func LoadPending(c *gin.Context, key string, value Pending) bool {
+	data, ok := sessions.Default(c).Get(key).(string)
+	if !ok {
+		return false
+	}
+
+	if err := json.Unmarshal([]byte(data), value); err != nil || value.Expired() {
+		_ = ClearPending(c, key)
+		return false
+	}
+	return true
+}
+
+// ClearPending removes value saved by SavePending, so it can't be used again.
+func ClearPending(c *gin.Context, key string) error {
+	session := sessions.Default(c)
+	if session.Get(key) == nil {
+		return nil
+	}
+
+	session.Delete(key)
+	if err := session.Save(); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
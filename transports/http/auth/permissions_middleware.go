@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"net/http"
+	"path"
+	"sync"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// UserPermissions is a key of permissions granted to the signed-in user, they're set by PermissionsMiddleware.
+const UserPermissions = "permissions"
+
+// PermissionSignedIn is declared by API routes available to every signed-in user regardless of the permissions, e.g. sign-out.
+const PermissionSignedIn = "signed-in"
+
+// declaredPermissions are permissions required by API routes, keyed by method and full path of the route.
+// They're declared when the routes are registered with Routes.
+var declaredPermissions = struct {
+	sync.RWMutex
+	byRoute map[string]string
+}{byRoute: make(map[string]string)}
+
+// PermissionsMiddleware middleware that is checking permission declared by API route registered with Routes.
+// It must be applied after the auth middleware, which sets the signed-in user.
+type PermissionsMiddleware struct {
+	rolesService *roles.Service
+	log          *zerolog.Logger
+}
+
+// NewPermissionsMiddleware create middleware that is checking permissions of the signed-in user.
+func NewPermissionsMiddleware(s *domain.Services, logger *zerolog.Logger) *PermissionsMiddleware {
+	log := logger.With().Str("service", "permissions-middleware").Logger()
+	return &PermissionsMiddleware{
+		rolesService: s.RolesService,
+		log:          &log,
+	}
+}
+
+// ApplyToAPI is a middleware which loads permissions granted to the signed-in user by its roles.
+// If the request is authenticated by API token, they're limited to permissions granted by scopes of the token.
+// Permission itself is checked by the route right before its handler, see Routes.
+// Routes which weren't registered with Routes declare no permission and they're forbidden, so a forgotten declaration doesn't open the route.
+func (h *PermissionsMiddleware) ApplyToAPI(c *gin.Context) {
+	if _, ok := declaredPermission(c.Request.Method, c.FullPath()); !ok {
+		h.log.Error().Msgf("Route %s %s doesn't declare required permission", c.Request.Method, c.FullPath())
+		spverrors.AbortWithErrorResponse(c, spverrors.ErrForbidden, h.log)
+		return
+	}
+
+	permissions, err := h.rolesService.GetPermissions(c.GetInt(SessionUserID))
+	if err != nil {
+		spverrors.AbortWithErrorResponse(c, err, h.log)
+		return
+	}
+
+	if scoped, ok := c.Get(tokenPermissions); ok {
+		for permission := range permissions {
+			if !scoped.(roles.Permissions).Has(permission) {
+				delete(permissions, permission)
+			}
+		}
+	}
+
+	c.Set(UserPermissions, permissions)
+}
+
+// Routes registers API routes together with the permission each of them requires:
+//
+//	routes := auth.NewRoutes(router)
+//	routes.GET("/user", roles.PermissionWalletRead, auth.Audit(s, audit.ActionViewAccount), h.getUser)
+//
+// Routes registered directly on the router group declare no permission, so PermissionsMiddleware forbids them.
+type Routes struct {
+	group *gin.RouterGroup
+}
+
+// NewRoutes creates Routes registering API routes in the router group.
+func NewRoutes(group *gin.RouterGroup) *Routes {
+	return &Routes{group: group}
+}
+
+// GET registers GET route requiring the permission, see Handle.
+func (r *Routes) GET(relativePath, permission string, handlers ...gin.HandlerFunc) {
+	r.Handle(http.MethodGet, relativePath, permission, handlers...)
+}
+
+// POST registers POST route requiring the permission, see Handle.
+func (r *Routes) POST(relativePath, permission string, handlers ...gin.HandlerFunc) {
+	r.Handle(http.MethodPost, relativePath, permission, handlers...)
+}
+
+// PUT registers PUT route requiring the permission, see Handle.
+func (r *Routes) PUT(relativePath, permission string, handlers ...gin.HandlerFunc) {
+	r.Handle(http.MethodPut, relativePath, permission, handlers...)
+}
+
+// PATCH registers PATCH route requiring the permission, see Handle.
+func (r *Routes) PATCH(relativePath, permission string, handlers ...gin.HandlerFunc) {
+	r.Handle(http.MethodPatch, relativePath, permission, handlers...)
+}
+
+// DELETE registers DELETE route requiring the permission, see Handle.
+func (r *Routes) DELETE(relativePath, permission string, handlers ...gin.HandlerFunc) {
+	r.Handle(http.MethodDelete, relativePath, permission, handlers...)
+}
+
+// Handle declares the permission required by the route and registers it. The last handler is the route handler,
+// permission is checked right before it, so middlewares like Audit put before it record forbidden attempts too.
+// The permission isn't checked if it's PermissionSignedIn.
+func (r *Routes) Handle(method, relativePath, permission string, handlers ...gin.HandlerFunc) {
+	declaredPermissions.Lock()
+	declaredPermissions.byRoute[routeKey(method, joinPaths(r.group.BasePath(), relativePath))] = permission
+	declaredPermissions.Unlock()
+
+	if permission != PermissionSignedIn {
+		last := len(handlers) - 1
+		handlers = append(handlers[:last:last], RequirePermission(permission), handlers[last])
+	}
+	r.group.Handle(method, relativePath, handlers...)
+}
+
+// RequirePermission returns handler which aborts the request if the signed-in user doesn't have the permission.
+// Routes registered with Routes check their permission already.
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasPermission(c, permission) {
+			spverrors.AbortWithErrorResponse(c, spverrors.ErrForbidden, nil)
+		}
+	}
+}
+
+// HasPermission returns true if the signed-in user has the permission. It's used when permission depends on request data.
+func HasPermission(c *gin.Context, permission string) bool {
+	permissions, ok := c.Get(UserPermissions)
+	if !ok {
+		return false
+	}
+	return permissions.(roles.Permissions).Has(permission)
+}
+
+// declaredPermission returns permission declared by the route with the method and full path.
+func declaredPermission(method, fullPath string) (string, bool) {
+	declaredPermissions.RLock()
+	defer declaredPermissions.RUnlock()
+
+	permission, ok := declaredPermissions.byRoute[routeKey(method, fullPath)]
+	return permission, ok
+}
+
+func routeKey(method, fullPath string) string {
+	return method + " " + fullPath
+}
+
+// joinPaths joins paths as gin does for routes registered in the group, so the result matches gin.Context.FullPath.
+func joinPaths(basePath, relativePath string) string {
+	if relativePath == "" {
+		return basePath
+	}
+
+	joined := path.Join(basePath, relativePath)
+	if relativePath[len(relativePath)-1] == '/' && joined[len(joined)-1] != '/' {
+		return joined + "/"
+	}
+	return joined
+}
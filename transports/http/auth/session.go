@@ -1,20 +1,23 @@
 package auth
 
 import (
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/grants"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 )
 
-// UpdateSession updates session with accessKeyId and userId.
-func UpdateSession(c *gin.Context, authUser *users.AuthenticatedUser) error {
+// UpdateSession updates session with accessKeyId, userId, id of the tracked session record and signing grant id.
+func UpdateSession(c *gin.Context, authUser *users.AuthenticatedUser, sessionID int, signingGrantID string) error {
 	session := sessions.Default(c)
+	session.Set(SessionID, sessionID)
 	session.Set(SessionAccessKeyID, authUser.AccessKey.ID)
 	session.Set(SessionAccessKey, authUser.AccessKey.Key)
 	session.Set(SessionUserID, authUser.User.ID)
 	session.Set(SessionUserPaymail, authUser.User.Paymail)
-	session.Set(SessionXPriv, authUser.Xpriv)
+	session.Set(SessionSigningGrantID, signingGrantID)
 	err := session.Save()
 	if err != nil {
 		return errors.Wrap(err, "internal error")
@@ -23,6 +26,28 @@ func UpdateSession(c *gin.Context, authUser *users.AuthenticatedUser) error {
 	return nil
 }
 
+// UpdateSigningGrant replaces signing grant id in session.
+func UpdateSigningGrant(c *gin.Context, signingGrantID string) error {
+	session := sessions.Default(c)
+	session.Set(SessionSigningGrantID, signingGrantID)
+	err := session.Save()
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// UpdateSessionPaymail replaces user paymail in session.
+func UpdateSessionPaymail(c *gin.Context, paymail string) error {
+	session := sessions.Default(c)
+	session.Set(SessionUserPaymail, paymail)
+	err := session.Save()
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
 // TerminateSession terminates current (default) session.
 func TerminateSession(c *gin.Context) error {
 	session := sessions.Default(c)
@@ -35,3 +60,36 @@ func TerminateSession(c *gin.Context) error {
 
 	return nil
 }
+
+// Signer unlocks xpriv of the signed-in user for endpoints which sign with it.
+type Signer struct {
+	uService      *users.UserService
+	grantsService *grants.Service
+}
+
+// NewSigner creates Signer unlocking xpriv with the password, the wallet passphrase or the signing grant of the session.
+func NewSigner(s *domain.Services) *Signer {
+	return &Signer{
+		uService:      s.UsersService,
+		grantsService: s.GrantsService,
+	}
+}
+
+// Xpriv decrypts xpriv of the signed-in user with the password if provided, otherwise it uses the signing grant from session.
+func (s *Signer) Xpriv(c *gin.Context, password string) (string, error) {
+	userID := c.GetInt(SessionUserID)
+	if password != "" {
+		return s.uService.GetUserXpriv(userID, password) //nolint:wrapcheck // error wrapped higher in call stack
+	}
+	return s.grantsService.GetXpriv(c.GetString(SessionSigningGrantID), userID) //nolint:wrapcheck // error wrapped higher in call stack
+}
+
+// UnlockXpriv decrypts xpriv of the signed-in user with the password or, if it's empty, with the wallet passphrase.
+// If neither is provided it uses the signing grant from session, so sessions signed in with single sign-on or passkey,
+// which have no password, can spend too.
+func (s *Signer) UnlockXpriv(c *gin.Context, password, passphrase string) (string, error) {
+	if password == "" && passphrase != "" {
+		return s.uService.UnlockWallet(c.GetInt(SessionUserID), passphrase) //nolint:wrapcheck // error wrapped higher in call stack
+	}
+	return s.Xpriv(c, password)
+}
@@ -18,9 +18,15 @@ const (
 	SessionAccessKey   = "accessKey"
 	SessionUserID      = "userId"
 	SessionUserPaymail = "paymail"
-	SessionXPriv       = "xPriv"
+	// SessionID is an id of the tracked session record with its metadata.
+	SessionID = "sessionId"
+	// SessionSigningGrantID is an id of the in-memory signing grant, the xPriv itself is never stored in session.
+	SessionSigningGrantID = "signingGrantId"
 )
 
+// legacySessionXPriv is a key under which sessions created by previous versions stored decrypted xPriv.
+const legacySessionXPriv = "xPriv"
+
 // NewSessionMiddleware create Session middleware that is retrieving auth token from cookie.
 func NewSessionMiddleware(db *sql.DB, engine *gin.Engine) router.APIMiddlewareFunc {
 	secret := viper.GetString(config.EnvHTTPServerSessionSecret)
@@ -38,7 +44,7 @@ func NewSessionMiddleware(db *sql.DB, engine *gin.Engine) router.APIMiddlewareFu
 	secure := viper.GetBool(config.EnvHTTPServerCookieSecure)
 
 	options := sessions.Options{
-		MaxAge:   1800,
+		MaxAge:   viper.GetInt(config.EnvHTTPServerSessionMaxAge),
 		Path:     "/",
 		HttpOnly: true,
 		Secure:   secure,
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"strings"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/tokens"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	router "github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/routes"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// APITokenID is an id of the API token which authenticated the request, it's set only by TokenMiddleware.
+const APITokenID = "apiTokenId"
+
+// tokenPermissions is a key of permissions granted by scopes of the API token, PermissionsMiddleware limits user permissions to them.
+const tokenPermissions = "tokenPermissions"
+
+// TokenMiddleware middleware that is authenticating requests with personal API token sent in the Authorization header.
+// It sets the same variables as the session is authorized with, so handlers work unchanged.
+// Requests without the header are passed to the session auth middleware.
+type TokenMiddleware struct {
+	tokensService *tokens.Service
+	sessionAuth   router.APIMiddleware
+	log           *zerolog.Logger
+}
+
+// NewTokenMiddleware create middleware that is checking API token and falls back to sessionAuth when no token is sent.
+func NewTokenMiddleware(s *domain.Services, sessionAuth router.APIMiddleware, logger *zerolog.Logger) *TokenMiddleware {
+	log := logger.With().Str("service", "token-middleware").Logger()
+	return &TokenMiddleware{
+		tokensService: s.TokensService,
+		sessionAuth:   sessionAuth,
+		log:           &log,
+	}
+}
+
+// ApplyToAPI is a middleware which authenticates the user by API token or by session.
+func (h *TokenMiddleware) ApplyToAPI(c *gin.Context) {
+	header := c.GetHeader("Authorization")
+	if header == "" {
+		h.sessionAuth.ApplyToAPI(c)
+		return
+	}
+
+	secret, found := strings.CutPrefix(header, bearerPrefix)
+	if !found {
+		spverrors.AbortWithErrorResponse(c, spverrors.ErrUnauthorized, h.log)
+		return
+	}
+
+	authenticated, err := h.tokensService.Authenticate(secret)
+	if err != nil {
+		spverrors.AbortWithErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Set(SessionAccessKeyID, authenticated.Token.AccessKeyID)
+	c.Set(SessionAccessKey, authenticated.AccessKey)
+	c.Set(SessionUserID, authenticated.Token.UserID)
+	c.Set(SessionUserPaymail, authenticated.Paymail)
+	c.Set(APITokenID, authenticated.Token.ID)
+	c.Set(tokenPermissions, authenticated.Token.Permissions())
+}
+
+// RequireSession declares API route which works only in the session signed in by the user, e.g. sign-out,
+// which would revoke access key of the API token. It's put before the route handler.
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get(APITokenID); ok {
+			spverrors.AbortWithErrorResponse(c, spverrors.ErrAPITokenNotAllowed, nil)
+		}
+	}
+}
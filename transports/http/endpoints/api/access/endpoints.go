@@ -1,9 +1,17 @@
 package access
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/grants"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/lockout"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/sessions"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/auth"
@@ -13,33 +21,44 @@ import (
 )
 
 type handler struct {
-	service *users.UserService
-	log     *zerolog.Logger
+	auditService    *audit.Service
+	service         *users.UserService
+	grantsService   *grants.Service
+	sessionsService *sessions.Service
+	lockoutService  *lockout.Service
+	log             *zerolog.Logger
 }
 
 // NewHandler creates new endpoint handler.
 func NewHandler(s *domain.Services, log *zerolog.Logger) (router.RootEndpoints, router.APIEndpoints) {
 	h := &handler{
-		service: s.UsersService,
-		log:     log,
+		auditService:    s.AuditService,
+		service:         s.UsersService,
+		grantsService:   s.GrantsService,
+		sessionsService: s.SessionsService,
+		lockoutService:  s.LockoutService,
+		log:             log,
 	}
 
 	prefix := "/api/v1"
 
 	// Register root endpoints which are authorized by admin token.
 	rootEndpoints := router.RootEndpointsFunc(func(router *gin.RouterGroup) {
-		router.POST(prefix+"/sign-in", h.signIn)
+		router.POST(prefix+"/sign-in", auth.Audit(h.auditService, audit.ActionSignIn), h.signIn)
 	})
 
 	// Register api endpoints which are authorized by session token.
 	apiEndpoints := router.APIEndpointsFunc(func(router *gin.RouterGroup) {
-		router.POST("/sign-out", h.signOut)
+		routes := auth.NewRoutes(router)
+		routes.POST("/sign-out", auth.PermissionSignedIn, auth.Audit(h.auditService, audit.ActionSignOut), auth.RequireSession(), h.signOut)
+		routes.POST("/signing-grant", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionCreateSigningGrant), h.createSigningGrant)
 	})
 
 	return rootEndpoints, apiEndpoints
 }
 
 // Sign in user.
+// @Description If the user has two-factor authentication enabled and code is not provided, error-2fa-required is returned and request must be repeated with the code.
 //
 //	@Summary Sign in user
 //	@Tags user
@@ -55,13 +74,40 @@ func (h *handler) signIn(c *gin.Context) {
 		return
 	}
 
-	signInUser, err := h.service.SignInUser(reqUser.Email, reqUser.Password)
+	auth.SetAuditActor(c, nil, reqUser.Email)
+
+	ip := c.ClientIP()
+	if retryAfter, err := h.lockoutService.CheckSignIn(reqUser.Email, ip); err != nil {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	signInUser, err := h.service.SignInUser(reqUser.Email, reqUser.Password, reqUser.Code)
+	if err != nil {
+		if errors.Is(err, spverrors.ErrInvalidCredentials) || errors.Is(err, spverrors.ErrInvalidTwoFactorCode) {
+			h.lockoutService.RecordFailure(reqUser.Email, ip)
+		}
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	h.lockoutService.RecordSuccess(reqUser.Email)
+	auth.SetAuditActor(c, &signInUser.User.ID, reqUser.Email)
+
+	session, err := h.sessionsService.CreateSession(signInUser.User.ID, signInUser.AccessKey, c.ClientIP(), c.Request.UserAgent())
 	if err != nil {
 		spverrors.ErrorResponse(c, err, h.log)
 		return
 	}
 
-	err = auth.UpdateSession(c, signInUser)
+	grant, err := h.grantsService.CreateGrant(signInUser.User.ID, signInUser.Xpriv)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	err = auth.UpdateSession(c, signInUser, session.ID, grant.ID)
 	if err != nil {
 		h.log.Error().Msgf("Sign-in error. Session wasn't saved: %s", err)
 		spverrors.ErrorResponse(c, spverrors.ErrSessionUpdate, h.log)
@@ -84,8 +130,10 @@ func (h *handler) signIn(c *gin.Context) {
 //	@Success 200
 //	@Router /api/v1/sign-out [post]
 func (h *handler) signOut(c *gin.Context) {
-	// Right now we cannot revoke access key without authentication with XPriv
-	// All we can do is to terminate session
+	h.grantsService.RevokeGrant(c.GetString(auth.SessionSigningGrantID))
+
+	// Session is terminated even if access key cannot be revoked now, it will be revoked by sessions reaper when it expires.
+	_ = h.sessionsService.SignOut(c.GetString(auth.SessionAccessKeyID), c.GetString(auth.SessionAccessKey))
 
 	err := auth.TerminateSession(c)
 	if err != nil {
@@ -96,3 +144,55 @@ func (h *handler) signOut(c *gin.Context) {
 
 	c.Status(http.StatusOK)
 }
+
+// Create signing grant.
+// @Description Unlock signing with xPriv for a short time, so actions like contact confirmation don't require the password.
+// @Description Wallet is unlocked with the password or, if it's empty, with the wallet passphrase.
+//
+//	@Summary Create signing grant
+//	@Tags user
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} SigningGrantResponse
+//	@Router /api/v1/signing-grant [post]
+//	@Param data body CreateSigningGrant true "User password or wallet passphrase"
+func (h *handler) createSigningGrant(c *gin.Context) {
+	var req CreateSigningGrant
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	userID := c.GetInt(auth.SessionUserID)
+	var xpriv string
+	var err error
+	if req.Password == "" && req.Passphrase != "" {
+		xpriv, err = h.service.UnlockWallet(userID, req.Passphrase)
+	} else {
+		xpriv, err = h.service.GetUserXpriv(userID, req.Password)
+	}
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	grant, err := h.grantsService.CreateGrant(userID, xpriv)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	// Previous grant of this session is no longer needed
+	h.grantsService.RevokeGrant(c.GetString(auth.SessionSigningGrantID))
+
+	if err = auth.UpdateSigningGrant(c, grant.ID); err != nil {
+		h.log.Error().Msgf("Signing grant wasn't saved in session: %s", err)
+		spverrors.ErrorResponse(c, spverrors.ErrSessionUpdate, h.log)
+		return
+	}
+
+	// Saving the session refreshes its cookie, so access key must stay valid for next max age.
+	_ = h.sessionsService.ExtendSession(c.GetString(auth.SessionAccessKeyID))
+
+	c.JSON(http.StatusOK, SigningGrantResponse{ExpiresAt: grant.ExpiresAt})
+}
@@ -1,11 +1,17 @@
 package access
 
-import "github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+import (
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+)
 
 // SignInUser is a struct that contains user sign in data.
 type SignInUser struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	// Code is TOTP or recovery code, required only if the user has two-factor authentication enabled.
+	Code string `json:"code,omitempty"`
 }
 
 // SignInResponse is a struct that represents struct sended after user sign in.
@@ -13,3 +19,15 @@ type SignInResponse struct {
 	Paymail string        `json:"paymail"`
 	Balance users.Balance `json:"balance"`
 }
+
+// CreateSigningGrant is a struct that contains data required to unlock signing. Either password or wallet passphrase is required.
+type CreateSigningGrant struct {
+	Password string `json:"password,omitempty"`
+	// Passphrase is the wallet passphrase, it's used if password is empty, e.g. in sessions signed in with single sign-on.
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+// SigningGrantResponse is a struct that represents created signing grant.
+type SigningGrantResponse struct {
+	ExpiresAt time.Time `json:"expiresAt"`
+}
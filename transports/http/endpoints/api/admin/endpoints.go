@@ -0,0 +1,641 @@
+package admin
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/admin"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/lockout"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/operators"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/transactions"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/auth"
+	router "github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/routes"
+	"github.com/bitcoin-sv/spv-wallet/models/filter"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// indefiniteLock is used when operator doesn't say until when the account should be locked.
+	indefiniteLock = 100 * 365 * 24 * time.Hour
+	// operatorLockoutPrefix keeps failed sign-in attempts of operators apart from users with the same email.
+	operatorLockoutPrefix = "operator:"
+)
+
+type handler struct {
+	service          *admin.Service
+	operatorsService *operators.Service
+	lockoutService   *lockout.Service
+	rolesService     *roles.Service
+	auditService     *audit.Service
+	uService         *users.UserService
+	tService         *transactions.TransactionService
+	log              *zerolog.Logger
+}
+
+// NewHandler creates new endpoint handler.
+func NewHandler(s *domain.Services, log *zerolog.Logger) (router.RootEndpoints, router.AdminEndpoints) {
+	h := &handler{
+		service:          s.AdminService,
+		operatorsService: s.OperatorsService,
+		lockoutService:   s.LockoutService,
+		rolesService:     s.RolesService,
+		auditService:     s.AuditService,
+		uService:         s.UsersService,
+		tService:         s.TransactionsService,
+		log:              log,
+	}
+
+	prefix := "/api/admin/v1"
+
+	// Register root endpoints, operator gets its bearer token by signing in.
+	rootEndpoints := router.RootEndpointsFunc(func(router *gin.RouterGroup) {
+		router.POST(prefix+"/sign-in", h.signIn)
+	})
+
+	readers := h.requireRole(operators.RoleAdmin, operators.RoleSupport, operators.RoleAuditor)
+	support := h.requireRole(operators.RoleAdmin, operators.RoleSupport)
+	admins := h.requireRole(operators.RoleAdmin)
+	auditors := h.requireRole(operators.RoleAdmin, operators.RoleAuditor)
+
+	// Register admin endpoints which are authorized by operator token.
+	// Staff access to other users is given only here by operator roles, roles of users grant access to their own wallet.
+	adminEndpoints := router.AdminEndpointsFunc(func(router *gin.RouterGroup) {
+		router.POST("/sign-out", h.signOut)
+		router.GET("/roles", readers, h.getRoles)
+		router.GET("/user-roles", readers, h.getWalletRoles)
+		router.POST("/operators", admins, h.createOperator)
+		router.GET("/audit-log", auditors, h.getAuditLog)
+		router.GET("/security-log", auditors, h.getSecurityLog)
+		router.GET("/security-log/verify", auditors, h.verifySecurityLog)
+
+		group := router.Group("/users")
+		{
+			group.GET("", readers, h.searchUsers)
+			group.GET("/:id", readers, h.getUser)
+			group.POST("/:id/transactions/search", readers, h.getUserTransactions)
+			group.POST("/:id/lock", support, h.lockUser)
+			group.POST("/:id/unlock", support, h.unlockUser)
+			group.DELETE("/:id/sessions", support, h.terminateSessions)
+			group.GET("/:id/roles", readers, h.getUserRoles)
+			group.PUT("/:id/roles/:role", admins, h.assignRole)
+			group.DELETE("/:id/roles/:role", admins, h.removeRole)
+		}
+	})
+
+	return rootEndpoints, adminEndpoints
+}
+
+// Sign in operator.
+//
+//	@Summary Sign in operator to the admin API
+//	@Tags admin
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} SignInResponse
+//	@Router /api/admin/v1/sign-in [post]
+//	@Param data body SignInOperator true "Operator sign in data"
+func (h *handler) signIn(c *gin.Context) {
+	var req SignInOperator
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	ip := c.ClientIP()
+	lockoutKey := operatorLockoutPrefix + req.Email
+	if retryAfter, err := h.lockoutService.CheckSignIn(lockoutKey, ip); err != nil {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	operator, session, err := h.operatorsService.SignIn(req.Email, req.Password, ip)
+	if err != nil {
+		if errors.Is(err, spverrors.ErrInvalidCredentials) {
+			h.lockoutService.RecordFailure(lockoutKey, ip)
+		}
+		h.operatorsService.RecordAction(&operators.AuditEntry{
+			OperatorEmail: req.Email,
+			IP:            ip,
+			Action:        operators.ActionSignIn,
+			Outcome:       operators.OutcomeFailure,
+			Details:       err.Error(),
+		})
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	h.lockoutService.RecordSuccess(lockoutKey)
+	h.operatorsService.RecordAction(&operators.AuditEntry{
+		OperatorID:    operator.ID,
+		OperatorEmail: operator.Email,
+		IP:            ip,
+		Action:        operators.ActionSignIn,
+		Outcome:       operators.OutcomeSuccess,
+	})
+
+	c.JSON(http.StatusOK, SignInResponse{
+		Operator:  operator,
+		Token:     session.Token,
+		ExpiresAt: session.ExpiresAt,
+	})
+}
+
+// Sign out operator.
+//
+//	@Summary Sign out operator from the admin API
+//	@Tags admin
+//	@Produce json
+//	@Success 200
+//	@Router /api/admin/v1/sign-out [post]
+//	@Security BearerAuth
+func (h *handler) signOut(c *gin.Context) {
+	err := h.operatorsService.SignOut(c.GetString(auth.OperatorToken))
+	h.record(c, operators.ActionSignOut, nil, "", err)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// Get operator roles.
+//
+//	@Summary Get roles which can be assigned to operators
+//	@Tags admin
+//	@Produce json
+//	@Success 200 {object} []operators.Role
+//	@Router /api/admin/v1/roles [get]
+//	@Security BearerAuth
+func (h *handler) getRoles(c *gin.Context) {
+	operatorRoles, err := h.operatorsService.GetRoles()
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, operatorRoles)
+}
+
+// Create operator.
+//
+//	@Summary Create operator of the admin API
+//	@Tags admin
+//	@Accept json
+//	@Produce json
+//	@Success 201 {object} operators.Operator
+//	@Router /api/admin/v1/operators [post]
+//	@Param data body CreateOperator true "Operator data"
+//	@Security BearerAuth
+func (h *handler) createOperator(c *gin.Context) {
+	var req CreateOperator
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	operator, err := h.operatorsService.CreateOperator(req.Email, req.Password, req.Role)
+	h.record(c, operators.ActionCreateOperator, nil, req.Email+" as "+req.Role, err)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusCreated, operator)
+}
+
+// Search users.
+//
+//	@Summary Search users by email or paymail
+//	@Tags admin
+//	@Produce json
+//	@Success 200 {object} admin.UsersPage
+//	@Router /api/admin/v1/users [get]
+//	@Param query query string false "Part of email or paymail"
+//	@Param page query int false "Page number"
+//	@Param pageSize query int false "Page size"
+//	@Security BearerAuth
+func (h *handler) searchUsers(c *gin.Context) {
+	var req SearchUsers
+	if err := c.BindQuery(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	page, err := h.service.SearchUsers(req.Query, req.Page, req.PageSize)
+	h.record(c, operators.ActionSearchUsers, nil, req.Query, err)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// Get user.
+//
+//	@Summary Get user with its xPub ID, paymails and balance
+//	@Tags admin
+//	@Produce json
+//	@Success 200 {object} admin.UserDetails
+//	@Router /api/admin/v1/users/{id} [get]
+//	@Param id path int true "User id"
+//	@Security BearerAuth
+func (h *handler) getUser(c *gin.Context) {
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	details, err := h.service.GetUserDetails(userID)
+	h.record(c, operators.ActionViewUser, &userID, "", err)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, details)
+}
+
+// Get user transactions.
+//
+//	@Summary Get transaction history of the user
+//	@Tags admin
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} transactions.PaginatedTransactions
+//	@Router /api/admin/v1/users/{id}/transactions/search [post]
+//	@Param id path int true "User id"
+//	@Param data body SearchTransactions false "Query params"
+//	@Security BearerAuth
+func (h *handler) getUserTransactions(c *gin.Context) {
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req SearchTransactions
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	if req.QueryParams == nil {
+		req.QueryParams = &filter.QueryParams{
+			Page:     1,
+			PageSize: 10,
+		}
+	}
+
+	txs, err := h.searchUserTransactions(userID, req.QueryParams)
+	h.record(c, operators.ActionViewUserTransactions, &userID, "", err)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, txs)
+}
+
+// Lock user.
+// @Description Locked user can't sign in with password, single sign-on or passkey, use API tokens or recover the wallet.
+// @Description The lock replaces previous lock set by operator, failed sign-in attempts don't change it.
+//
+//	@Summary Lock sign-in to the user account and sign the user out of all sessions
+//	@Tags admin
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} LockUserResponse
+//	@Router /api/admin/v1/users/{id}/lock [post]
+//	@Param id path int true "User id"
+//	@Param data body LockUser false "Lock data"
+//	@Security BearerAuth
+func (h *handler) lockUser(c *gin.Context) {
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req LockUser
+	if c.Request.ContentLength != 0 {
+		if err := c.Bind(&req); err != nil {
+			spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+			return
+		}
+	}
+
+	until := time.Now().Add(indefiniteLock)
+	if req.Until != nil {
+		if !req.Until.After(time.Now()) {
+			spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+			return
+		}
+		until = *req.Until
+	}
+
+	err := h.service.LockUser(userID, until)
+	h.record(c, operators.ActionLockUser, &userID, "until "+until.UTC().Format(time.RFC3339), err)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, LockUserResponse{LockedUntil: until})
+}
+
+// Unlock user.
+// @Description Removes the lock set by operator and resets failed sign-in attempts of the user.
+//
+//	@Summary Unlock sign-in to the user account
+//	@Tags admin
+//	@Produce json
+//	@Success 200
+//	@Router /api/admin/v1/users/{id}/unlock [post]
+//	@Param id path int true "User id"
+//	@Security BearerAuth
+func (h *handler) unlockUser(c *gin.Context) {
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	err := h.service.UnlockUser(userID)
+	h.record(c, operators.ActionUnlockUser, &userID, "", err)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// Terminate user sessions.
+//
+//	@Summary Sign the user out of all sessions and revoke their access keys, signing grants and API tokens
+//	@Tags admin
+//	@Produce json
+//	@Success 200 {object} TerminateSessionsResponse
+//	@Router /api/admin/v1/users/{id}/sessions [delete]
+//	@Param id path int true "User id"
+//	@Security BearerAuth
+func (h *handler) terminateSessions(c *gin.Context) {
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	terminated, err := h.service.TerminateSessions(userID)
+	h.record(c, operators.ActionTerminateSessions, &userID, strconv.Itoa(terminated)+" sessions", err)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, TerminateSessionsResponse{Terminated: terminated})
+}
+
+// Get wallet roles.
+//
+//	@Summary Get roles which can be assigned to users in the wallet
+//	@Tags admin
+//	@Produce json
+//	@Success 200 {object} []roles.Role
+//	@Router /api/admin/v1/user-roles [get]
+//	@Security BearerAuth
+func (h *handler) getWalletRoles(c *gin.Context) {
+	walletRoles, err := h.rolesService.GetRoles()
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, walletRoles)
+}
+
+// Get user roles.
+//
+//	@Summary Get roles of the user in the wallet and permissions they grant
+//	@Tags admin
+//	@Produce json
+//	@Success 200 {object} roles.UserRoles
+//	@Router /api/admin/v1/users/{id}/roles [get]
+//	@Param id path int true "User id"
+//	@Security BearerAuth
+func (h *handler) getUserRoles(c *gin.Context) {
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	userRoles, err := h.rolesService.GetUserRoles(userID)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, userRoles)
+}
+
+// Assign user role.
+// @Description Assigned roles replace the default role, so it must be assigned too if the user should keep it.
+//
+//	@Summary Assign role in the wallet to the user
+//	@Tags admin
+//	@Produce json
+//	@Success 200
+//	@Router /api/admin/v1/users/{id}/roles/{role} [put]
+//	@Param id path int true "User id"
+//	@Param role path string true "Role name"
+//	@Security BearerAuth
+func (h *handler) assignRole(c *gin.Context) {
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	role := c.Param("role")
+	err := h.rolesService.AssignRole(userID, role)
+	h.record(c, operators.ActionAssignRole, &userID, role, err)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// Remove user role.
+// @Description User without any role has the default role.
+//
+//	@Summary Remove role in the wallet from the user
+//	@Tags admin
+//	@Produce json
+//	@Success 200
+//	@Router /api/admin/v1/users/{id}/roles/{role} [delete]
+//	@Param id path int true "User id"
+//	@Param role path string true "Role name"
+//	@Security BearerAuth
+func (h *handler) removeRole(c *gin.Context) {
+	userID, ok := h.getUserID(c)
+	if !ok {
+		return
+	}
+
+	role := c.Param("role")
+	err := h.rolesService.RemoveRole(userID, role)
+	h.record(c, operators.ActionRemoveRole, &userID, role, err)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// Get audit log.
+//
+//	@Summary Get actions performed by operators, newest first
+//	@Tags admin
+//	@Produce json
+//	@Success 200 {object} []operators.AuditEntry
+//	@Router /api/admin/v1/audit-log [get]
+//	@Param userId query int false "User id"
+//	@Param page query int false "Page number"
+//	@Param pageSize query int false "Page size"
+//	@Security BearerAuth
+func (h *handler) getAuditLog(c *gin.Context) {
+	var req GetAuditLog
+	if err := c.BindQuery(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	entries, err := h.operatorsService.GetAuditLog(req.UserID, req.Page, req.PageSize)
+	h.record(c, operators.ActionViewAuditLog, req.UserID, "", err)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// Get security log.
+//
+//	@Summary Get actions performed by wallet users, newest first
+//	@Tags admin
+//	@Produce json
+//	@Success 200 {object} []audit.Entry
+//	@Router /api/admin/v1/security-log [get]
+//	@Param userId query int false "User id"
+//	@Param page query int false "Page number"
+//	@Param pageSize query int false "Page size"
+//	@Security BearerAuth
+func (h *handler) getSecurityLog(c *gin.Context) {
+	var req GetAuditLog
+	if err := c.BindQuery(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	entries, err := h.auditService.GetEntries(req.UserID, req.Page, req.PageSize)
+	h.record(c, operators.ActionViewSecurityLog, req.UserID, "", err)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// Verify security log.
+// @Description Recomputes hash chain of the security log to check that no entry was changed or removed.
+//
+//	@Summary Verify hash chain of the security log
+//	@Tags admin
+//	@Produce json
+//	@Success 200 {object} audit.ChainVerification
+//	@Router /api/admin/v1/security-log/verify [get]
+//	@Security BearerAuth
+func (h *handler) verifySecurityLog(c *gin.Context) {
+	result, err := h.auditService.VerifyChain()
+	h.record(c, operators.ActionVerifySecurityLog, nil, "", err)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// requireRole returns handler which rejects operators with role other than given ones.
+func (h *handler) requireRole(allowed ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if slices.Contains(allowed, c.GetString(auth.OperatorRole)) {
+			return
+		}
+
+		h.record(c, operators.ActionForbidden, nil, c.Request.Method+" "+c.FullPath(), spverrors.ErrForbidden)
+		spverrors.AbortWithErrorResponse(c, spverrors.ErrForbidden, h.log)
+	}
+}
+
+// searchUserTransactions returns transactions of the user's paymail. Users who didn't verify email yet have nothing registered in SPV Wallet.
+func (h *handler) searchUserTransactions(userID int, queryParams *filter.QueryParams) (*transactions.PaginatedTransactions, error) {
+	user, err := h.uService.GetUserByID(userID)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	if user.Paymail == "" {
+		return &transactions.PaginatedTransactions{Transactions: []users.Transaction{}}, nil
+	}
+
+	return h.tService.GetPaymailTransactions(user.Paymail, queryParams) //nolint:wrapcheck // error wrapped higher in call stack
+}
+
+func (h *handler) getUserID(c *gin.Context) (int, bool) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrUserNotFound, h.log)
+		return 0, false
+	}
+	return userID, true
+}
+
+// record appends the action of signed in operator to the admin audit log.
+func (h *handler) record(c *gin.Context, action string, targetUserID *int, details string, err error) {
+	outcome := operators.OutcomeSuccess
+	if err != nil {
+		outcome = operators.OutcomeFailure
+		details = joinDetails(details, err.Error())
+	}
+
+	h.operatorsService.RecordAction(&operators.AuditEntry{
+		OperatorID:    c.GetInt(auth.OperatorID),
+		OperatorEmail: c.GetString(auth.OperatorEmail),
+		IP:            c.ClientIP(),
+		Action:        action,
+		TargetUserID:  targetUserID,
+		Outcome:       outcome,
+		Details:       details,
+	})
+}
+
+func joinDetails(details, errMessage string) string {
+	if details == "" {
+		return errMessage
+	}
+	return details + ": " + errMessage
+}
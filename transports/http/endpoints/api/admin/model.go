@@ -0,0 +1,66 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/operators"
+	"github.com/bitcoin-sv/spv-wallet/models/filter"
+)
+
+// SignInOperator is a struct that contains operator sign in data.
+type SignInOperator struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// SignInResponse is a struct that represents signed in operator and its bearer token.
+type SignInResponse struct {
+	Operator *operators.Operator `json:"operator"`
+	Token    string              `json:"token"`
+	// ExpiresAt is time after which operator needs to sign in again.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// CreateOperator is a struct that contains data of a new operator.
+type CreateOperator struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Role     string `json:"role"`
+}
+
+// SearchUsers is a struct that contains users search query.
+type SearchUsers struct {
+	// Query is matched against email and paymails of users, all users are returned if it's empty.
+	Query    string `form:"query"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+}
+
+// SearchTransactions represents request for searching transactions of the user.
+type SearchTransactions struct {
+	QueryParams *filter.QueryParams `json:"params,omitempty"`
+}
+
+// LockUser is a struct that contains data required to lock the user account.
+type LockUser struct {
+	// Until is time until which sign-in is locked, account is locked until it's unlocked by operator if it's empty.
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// LockUserResponse is a struct that represents locked user account.
+type LockUserResponse struct {
+	LockedUntil time.Time `json:"lockedUntil"`
+}
+
+// TerminateSessionsResponse is a struct that represents number of terminated sessions.
+type TerminateSessionsResponse struct {
+	Terminated int `json:"terminated"`
+}
+
+// GetAuditLog is a struct that contains audit log query.
+type GetAuditLog struct {
+	// UserID limits entries to the user, i.e. actions performed on the user in audit log and by the user in security log.
+	UserID   *int `form:"userId"`
+	Page     int  `form:"page"`
+	PageSize int  `form:"pageSize"`
+}
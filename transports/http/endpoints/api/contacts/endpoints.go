@@ -4,8 +4,9 @@ import (
 	"net/http"
 
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/contacts"
-	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/auth"
 	router "github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/routes"
@@ -16,17 +17,19 @@ import (
 )
 
 type handler struct {
-	uService users.UserService
-	cService contacts.Service
-	log      *zerolog.Logger
+	auditService *audit.Service
+	cService     contacts.Service
+	signer       *auth.Signer
+	log          *zerolog.Logger
 }
 
 // NewHandler creates new endpoint handler.
 func NewHandler(s *domain.Services, log *zerolog.Logger) router.APIEndpoints {
 	return &handler{
-		uService: *s.UsersService,
-		cService: *s.ContactsService,
-		log:      log,
+		auditService: s.AuditService,
+		cService:     *s.ContactsService,
+		signer:       auth.NewSigner(s),
+		log:          log,
 	}
 }
 
@@ -34,12 +37,13 @@ func NewHandler(s *domain.Services, log *zerolog.Logger) router.APIEndpoints {
 func (h *handler) RegisterAPIEndpoints(router *gin.RouterGroup) {
 	user := router.Group("/contact")
 
-	user.PUT("/:paymail", h.upsertContact)
-	user.PATCH("/accepted/:paymail", h.acceptContact)
-	user.PATCH("/rejected/:paymail", h.rejectContact)
-	user.PATCH("/confirmed", h.confirmContact)
-	user.POST("/search", h.getContacts)
-	user.POST("/totp", h.generateTotp)
+	routes := auth.NewRoutes(user)
+	routes.PUT("/:paymail", roles.PermissionContactsWrite, auth.Audit(h.auditService, audit.ActionUpsertContact), h.upsertContact)
+	routes.PATCH("/accepted/:paymail", roles.PermissionContactsWrite, auth.Audit(h.auditService, audit.ActionAcceptContact), h.acceptContact)
+	routes.PATCH("/rejected/:paymail", roles.PermissionContactsWrite, auth.Audit(h.auditService, audit.ActionRejectContact), h.rejectContact)
+	routes.PATCH("/confirmed", roles.PermissionContactsWrite, auth.Audit(h.auditService, audit.ActionConfirmContact), h.confirmContact)
+	routes.POST("/search", roles.PermissionWalletRead, auth.Audit(h.auditService, audit.ActionViewContacts), h.getContacts)
+	routes.POST("/totp", roles.PermissionContactsWrite, auth.Audit(h.auditService, audit.ActionGenerateTotp), h.generateTotp)
 }
 
 // Get all user contacts.
@@ -84,7 +88,13 @@ func (h *handler) upsertContact(c *gin.Context) {
 		return
 	}
 
-	_, err := h.cService.UpsertContact(c.Request.Context(), c.GetString(auth.SessionXPriv), paymail, req.FullName, c.GetString(auth.SessionUserPaymail), req.Metadata)
+	xpriv, err := h.signer.Xpriv(c, req.Password)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	_, err = h.cService.UpsertContact(c.Request.Context(), xpriv, paymail, req.FullName, c.GetString(auth.SessionUserPaymail), req.Metadata)
 	if err != nil {
 		spverrors.ErrorResponse(c, err, h.log)
 		return
@@ -152,7 +162,13 @@ func (h *handler) confirmContact(c *gin.Context) {
 
 	requesterPaymail := c.GetString(auth.SessionUserPaymail)
 
-	err := h.cService.ConfirmContact(c.Request.Context(), c.GetString(auth.SessionXPriv), req.Contact, req.Passcode, requesterPaymail)
+	xpriv, err := h.signer.Xpriv(c, req.Password)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	err = h.cService.ConfirmContact(c.Request.Context(), xpriv, req.Contact, req.Passcode, requesterPaymail)
 	if err != nil {
 		spverrors.ErrorResponse(c, err, h.log)
 		return
@@ -176,7 +192,13 @@ func (h *handler) generateTotp(c *gin.Context) {
 		return
 	}
 
-	passcode, err := h.cService.GenerateTotpForContact(c.Request.Context(), c.GetString(auth.SessionXPriv), &contact)
+	xpriv, err := h.signer.Xpriv(c, "")
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	passcode, err := h.cService.GenerateTotpForContact(c.Request.Context(), xpriv, &contact)
 	if err != nil {
 		spverrors.ErrorResponse(c, err, h.log)
 		return
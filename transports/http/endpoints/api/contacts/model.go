@@ -9,6 +9,8 @@ import (
 type UpsertContact struct {
 	FullName string         `json:"fullName"`
 	Metadata map[string]any `json:"metadata"`
+	// Password is optional, signing grant from session is used if it's empty.
+	Password string `json:"password,omitempty"`
 }
 
 // SearchContact represents a request for searching contacts.
@@ -22,6 +24,8 @@ type SearchContact struct {
 type ConfirmContact struct {
 	Passcode string          `json:"passcode"`
 	Contact  *models.Contact `json:"contact,omitempty"`
+	// Password is optional, signing grant from session is used if it's empty.
+	Password string `json:"password,omitempty"`
 }
 
 // TotpResponse represents a response with generated passcode.
@@ -17,7 +17,8 @@ func Middleware() gin.HandlerFunc {
 			if allowedOrigin == origin {
 				c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
 				c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, PATCH, OPTIONS")
-				c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Cache-Control")
+				c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Cache-Control, Idempotency-Key")
+				c.Writer.Header().Set("Access-Control-Expose-Headers", "Idempotent-Replayed")
 				c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 				break
 			}
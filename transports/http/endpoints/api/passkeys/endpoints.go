@@ -0,0 +1,382 @@
+package passkeys
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/grants"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/lockout"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/passkeys"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/sessions"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/auth"
+	router "github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/routes"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/webauthn"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+)
+
+// Keys of the session under which started ceremonies are kept.
+const (
+	sessionPendingRegistration = "passkeyPendingRegistration"
+	sessionPendingSignIn       = "passkeyPendingSignIn"
+	sessionPendingUnlock       = "passkeyPendingUnlock"
+)
+
+type handler struct {
+	auditService    *audit.Service
+	service         *passkeys.Service
+	uService        *users.UserService
+	grantsService   *grants.Service
+	sessionsService *sessions.Service
+	lockoutService  *lockout.Service
+	challengeTTL    time.Duration
+	log             *zerolog.Logger
+}
+
+// NewHandler creates new endpoint handler.
+// Passkeys sign users in and unlock the wallet for spending without the password.
+func NewHandler(s *domain.Services, log *zerolog.Logger) (router.RootEndpoints, router.APIEndpoints) {
+	h := &handler{
+		auditService:    s.AuditService,
+		service:         s.PasskeysService,
+		uService:        s.UsersService,
+		grantsService:   s.GrantsService,
+		sessionsService: s.SessionsService,
+		lockoutService:  s.LockoutService,
+		challengeTTL:    viper.GetDuration(config.EnvWebAuthnChallengeTTL),
+		log:             log,
+	}
+
+	prefix := "/api/v1"
+
+	// Register root endpoints, user is signed in by them.
+	rootEndpoints := router.RootEndpointsFunc(func(router *gin.RouterGroup) {
+		router.POST(prefix+"/passkeys/sign-in/options", h.startSignIn)
+		router.POST(prefix+"/passkeys/sign-in", auth.Audit(h.auditService, audit.ActionPasskeySignIn), h.signIn)
+	})
+
+	// Register api endpoints which are authorized by session token.
+	apiEndpoints := router.APIEndpointsFunc(func(router *gin.RouterGroup) {
+		routes := auth.NewRoutes(router)
+		routes.POST("/user/passkeys/options", roles.PermissionAccountManage, h.startRegistration)
+		routes.POST("/user/passkeys", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionRegisterPasskey), h.register)
+		routes.GET("/user/passkeys", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionViewPasskeys), h.getPasskeys)
+		routes.DELETE("/user/passkeys/:id", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionDeletePasskey), h.deletePasskey)
+		routes.POST("/signing-grant/passkey/options", roles.PermissionAccountManage, h.startUnlock)
+		routes.POST("/signing-grant/passkey", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionPasskeyUnlock), h.unlock)
+	})
+
+	return rootEndpoints, apiEndpoints
+}
+
+// Start passkey registration.
+// @Description Returns options for navigator.credentials.create, they request the PRF extension which is required to unlock the wallet.
+//
+//	@Summary Start passkey registration
+//	@Tags passkeys
+//	@Produce json
+//	@Success 200 {object} webauthn.CreationOptions
+//	@Router /api/v1/user/passkeys/options [post]
+func (h *handler) startRegistration(c *gin.Context) {
+	options, err := h.service.StartRegistration(c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	if err = h.savePending(c, sessionPendingRegistration, options.Challenge, options.User.ID); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrSessionUpdate, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, options)
+}
+
+// Register passkey.
+// @Description Verifies the created credential and encrypts the xPriv with the secret derived by the passkey with the PRF extension.
+// @Description If the authenticator doesn't return PRF results on creation, the frontend gets them with navigator.credentials.get
+// @Description with the same extension inputs and sets them in clientExtensionResults of the credential.
+//
+//	@Summary Register passkey
+//	@Tags passkeys
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} passkeys.Passkey
+//	@Router /api/v1/user/passkeys [post]
+//	@Param data body RegisterPasskey true "Created credential and wallet password"
+func (h *handler) register(c *gin.Context) {
+	var req RegisterPasskey
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	var pending pendingCeremony
+	if !auth.LoadPending(c, sessionPendingRegistration, &pending) {
+		spverrors.ErrorResponse(c, spverrors.ErrNoPendingPasskeyCeremony, h.log)
+		return
+	}
+	// Challenge can be used only once.
+	h.clearPending(c, sessionPendingRegistration)
+
+	passkey, err := h.service.Register(c.GetInt(auth.SessionUserID), req.Name, req.Password, pending.Challenge, pending.UserHandle, &req.Credential)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+	auth.SetAuditTarget(c, strconv.Itoa(passkey.ID))
+
+	c.JSON(http.StatusOK, passkey)
+}
+
+// Get passkeys.
+//
+//	@Summary Get passkeys of the user
+//	@Tags passkeys
+//	@Produce json
+//	@Success 200 {object} []passkeys.Passkey
+//	@Router /api/v1/user/passkeys [get]
+func (h *handler) getPasskeys(c *gin.Context) {
+	result, err := h.service.GetUserPasskeys(c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Delete passkey.
+//
+//	@Summary Delete passkey
+//	@Tags passkeys
+//	@Produce json
+//	@Success 200
+//	@Router /api/v1/user/passkeys/{id} [delete]
+//	@Param id path int true "Passkey id"
+func (h *handler) deletePasskey(c *gin.Context) {
+	auth.SetAuditTarget(c, c.Param("id"))
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrPasskeyNotFound, h.log)
+		return
+	}
+
+	if err = h.service.DeletePasskey(c.GetInt(auth.SessionUserID), id); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// Start sign-in with passkey.
+// @Description Returns options for navigator.credentials.get, the user chooses one of passkeys registered for the wallet.
+//
+//	@Summary Start sign-in with passkey
+//	@Tags passkeys
+//	@Produce json
+//	@Success 200 {object} webauthn.RequestOptions
+//	@Router /api/v1/passkeys/sign-in/options [post]
+func (h *handler) startSignIn(c *gin.Context) {
+	options, err := h.service.StartAssertion(nil)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	if err = h.savePending(c, sessionPendingSignIn, options.Challenge, ""); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrSessionUpdate, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, options)
+}
+
+// Sign in with passkey.
+// @Description Passkey unlocks the wallet, so signing grant is created as on sign-in with password.
+//
+//	@Summary Sign in with passkey
+//	@Tags passkeys
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} SignInResponse
+//	@Router /api/v1/passkeys/sign-in [post]
+//	@Param data body webauthn.AssertionResponse true "Result of navigator.credentials.get"
+func (h *handler) signIn(c *gin.Context) {
+	var req webauthn.AssertionResponse
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	var pending pendingCeremony
+	if !auth.LoadPending(c, sessionPendingSignIn, &pending) {
+		spverrors.ErrorResponse(c, spverrors.ErrNoPendingPasskeyCeremony, h.log)
+		return
+	}
+	h.clearPending(c, sessionPendingSignIn)
+
+	unlocked, err := h.service.Authenticate(pending.Challenge, &req)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	user, err := h.uService.GetUserByID(unlocked.Passkey.UserID)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+	auth.SetAuditActor(c, &user.ID, user.Email)
+	auth.SetAuditTarget(c, strconv.Itoa(unlocked.Passkey.ID))
+
+	// Locked account can't be signed in with a passkey either, failures aren't counted because assertions can't be guessed.
+	if retryAfter, err := h.lockoutService.CheckSignIn(user.Email, c.ClientIP()); err != nil {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	signInUser, err := h.uService.SignInWithXpriv(user, unlocked.Xpriv)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+	h.lockoutService.RecordSuccess(user.Email)
+
+	session, err := h.sessionsService.CreateSession(user.ID, signInUser.AccessKey, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	grant, err := h.grantsService.CreateGrant(user.ID, signInUser.Xpriv)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	if err = auth.UpdateSession(c, signInUser, session.ID, grant.ID); err != nil {
+		h.log.Error().Msgf("Sign-in error. Session wasn't saved: %s", err)
+		spverrors.ErrorResponse(c, spverrors.ErrSessionUpdate, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, SignInResponse{
+		Paymail: signInUser.User.Paymail,
+		Balance: signInUser.Balance,
+	})
+}
+
+// Start unlock with passkey.
+// @Description Returns options for navigator.credentials.get, only passkeys of the signed-in user are allowed.
+//
+//	@Summary Start unlock with passkey
+//	@Tags passkeys
+//	@Produce json
+//	@Success 200 {object} webauthn.RequestOptions
+//	@Router /api/v1/signing-grant/passkey/options [post]
+func (h *handler) startUnlock(c *gin.Context) {
+	userID := c.GetInt(auth.SessionUserID)
+	options, err := h.service.StartAssertion(&userID)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	if err = h.savePending(c, sessionPendingUnlock, options.Challenge, ""); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrSessionUpdate, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, options)
+}
+
+// Create signing grant with passkey.
+// @Description Unlock signing with xPriv for a short time with a passkey instead of the password. The signing grant is used
+// @Description by endpoints which sign with xPriv, e.g. sending transactions, when the request has no password or wallet passphrase.
+//
+//	@Summary Create signing grant with passkey
+//	@Tags passkeys
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} SigningGrantResponse
+//	@Router /api/v1/signing-grant/passkey [post]
+//	@Param data body webauthn.AssertionResponse true "Result of navigator.credentials.get"
+func (h *handler) unlock(c *gin.Context) {
+	var req webauthn.AssertionResponse
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	var pending pendingCeremony
+	if !auth.LoadPending(c, sessionPendingUnlock, &pending) {
+		spverrors.ErrorResponse(c, spverrors.ErrNoPendingPasskeyCeremony, h.log)
+		return
+	}
+	h.clearPending(c, sessionPendingUnlock)
+
+	userID := c.GetInt(auth.SessionUserID)
+	unlocked, err := h.service.Authenticate(pending.Challenge, &req)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+	auth.SetAuditTarget(c, strconv.Itoa(unlocked.Passkey.ID))
+
+	if unlocked.Passkey.UserID != userID {
+		spverrors.ErrorResponse(c, spverrors.ErrPasskeyAuthentication, h.log)
+		return
+	}
+
+	grant, err := h.grantsService.CreateGrant(userID, unlocked.Xpriv)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	// Previous grant of this session is no longer needed
+	h.grantsService.RevokeGrant(c.GetString(auth.SessionSigningGrantID))
+
+	if err = auth.UpdateSigningGrant(c, grant.ID); err != nil {
+		h.log.Error().Msgf("Signing grant wasn't saved in session: %s", err)
+		spverrors.ErrorResponse(c, spverrors.ErrSessionUpdate, h.log)
+		return
+	}
+
+	// Saving the session refreshes its cookie, so access key must stay valid for next max age.
+	_ = h.sessionsService.ExtendSession(c.GetString(auth.SessionAccessKeyID))
+
+	c.JSON(http.StatusOK, SigningGrantResponse{ExpiresAt: grant.ExpiresAt})
+}
+
+func (h *handler) savePending(c *gin.Context, key, challenge, userHandle string) error {
+	err := auth.SavePending(c, key, &pendingCeremony{
+		Challenge:  challenge,
+		UserHandle: userHandle,
+		ExpiresAt:  time.Now().Add(h.challengeTTL),
+	})
+	if err != nil {
+		h.log.Error().Msgf("Passkey ceremony wasn't saved in session: %s", err)
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+	return nil
+}
+
+func (h *handler) clearPending(c *gin.Context, key string) {
+	if err := auth.ClearPending(c, key); err != nil {
+		h.log.Error().Msgf("Passkey ceremony wasn't removed from session: %s", err)
+	}
+}
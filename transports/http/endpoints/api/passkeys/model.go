@@ -0,0 +1,41 @@
+package passkeys
+
+import (
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/webauthn"
+)
+
+// RegisterPasskey is a struct that contains data required to register a passkey.
+type RegisterPasskey struct {
+	// Name is shown in the list of passkeys, e.g. name of the device.
+	Name string `json:"name"`
+	// Password unlocks the wallet, so its xPriv can be encrypted with the secret derived by the passkey.
+	Password string `json:"password"`
+	// Credential is the result of navigator.credentials.create serialized with PublicKeyCredential.toJSON.
+	Credential webauthn.RegistrationResponse `json:"credential"`
+}
+
+// SignInResponse is a struct that represents struct sended after user sign in.
+type SignInResponse struct {
+	Paymail string        `json:"paymail"`
+	Balance users.Balance `json:"balance"`
+}
+
+// SigningGrantResponse is a struct that represents signing grant created with a passkey.
+type SigningGrantResponse struct {
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// pendingCeremony is a passkey ceremony started by the client, kept in session until the client sends its result.
+type pendingCeremony struct {
+	Challenge  string    `json:"challenge"`
+	UserHandle string    `json:"userHandle,omitempty"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+// Expired implements auth.Pending.
+func (p *pendingCeremony) Expired() bool {
+	return time.Now().After(p.ExpiresAt)
+}
@@ -0,0 +1,151 @@
+package paymails
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/paymails"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/auth"
+	router "github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/routes"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type handler struct {
+	auditService *audit.Service
+	service      *paymails.Service
+	signer       *auth.Signer
+	log          *zerolog.Logger
+}
+
+// NewHandler creates new endpoint handler.
+func NewHandler(s *domain.Services, log *zerolog.Logger) router.APIEndpoints {
+	return &handler{
+		auditService: s.AuditService,
+		service:      s.PaymailsService,
+		signer:       auth.NewSigner(s),
+		log:          log,
+	}
+}
+
+// RegisterAPIEndpoints registers routes that are part of service API.
+func (h *handler) RegisterAPIEndpoints(router *gin.RouterGroup) {
+	group := router.Group("/user/paymails")
+	{
+		routes := auth.NewRoutes(group)
+		routes.GET("", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionViewPaymails), h.getPaymails)
+		routes.POST("", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionAddPaymail), h.addPaymail)
+		routes.PUT("/:id/primary", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionSetPrimaryPaymail), h.setPrimaryPaymail)
+		routes.DELETE("/:id", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionRemovePaymail), h.removePaymail)
+	}
+}
+
+// Get user paymails.
+//
+//	@Summary Get paymails of the user
+//	@Tags paymails
+//	@Produce json
+//	@Success 200 {object} []paymails.Paymail
+//	@Router /api/v1/user/paymails [get]
+func (h *handler) getPaymails(c *gin.Context) {
+	userPaymails, err := h.service.GetPaymails(c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, userPaymails)
+}
+
+// Add paymail.
+//
+//	@Summary Add paymail on any domain served by SPV Wallet
+//	@Tags paymails
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} paymails.Paymail
+//	@Router /api/v1/user/paymails [post]
+//	@Param data body AddPaymail true "Paymail data"
+func (h *handler) addPaymail(c *gin.Context) {
+	var req AddPaymail
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+	auth.SetAuditTarget(c, req.Alias)
+
+	userID := c.GetInt(auth.SessionUserID)
+
+	// xPub which owns the paymail is derived from xPriv.
+	xpriv, err := h.signer.Xpriv(c, req.Password)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	paymail, err := h.service.AddPaymail(userID, xpriv, req.Alias, req.Domain)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+	auth.SetAuditTarget(c, paymail.Address)
+
+	c.JSON(http.StatusOK, paymail)
+}
+
+// Set primary paymail.
+//
+//	@Summary Set primary paymail used as sender of transactions
+//	@Tags paymails
+//	@Produce json
+//	@Success 200 {object} paymails.Paymail
+//	@Router /api/v1/user/paymails/{id}/primary [put]
+//	@Param id path int true "Paymail id"
+func (h *handler) setPrimaryPaymail(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrPaymailNotFound, h.log)
+		return
+	}
+
+	paymail, err := h.service.SetPrimaryPaymail(c.GetInt(auth.SessionUserID), id)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	if err = auth.UpdateSessionPaymail(c, paymail.Address); err != nil {
+		h.log.Error().Msgf("Session wasn't updated: %s", err)
+		spverrors.ErrorResponse(c, spverrors.ErrSessionUpdate, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, paymail)
+}
+
+// Remove paymail.
+//
+//	@Summary Remove paymail which is not primary
+//	@Tags paymails
+//	@Produce json
+//	@Success 200
+//	@Router /api/v1/user/paymails/{id} [delete]
+//	@Param id path int true "Paymail id"
+func (h *handler) removePaymail(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrPaymailNotFound, h.log)
+		return
+	}
+
+	if err = h.service.RemovePaymail(c.GetInt(auth.SessionUserID), id); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,10 @@
+package paymails
+
+// AddPaymail represents a request for adding a new paymail.
+type AddPaymail struct {
+	Alias string `json:"alias"`
+	// Domain is optional, configured paymail domain is used if it's empty.
+	Domain string `json:"domain,omitempty"`
+	// Password is optional, signing grant from session is used if it's empty.
+	Password string `json:"password,omitempty"`
+}
@@ -0,0 +1,171 @@
+package profiles
+
+import (
+	"io"
+	"net/http"
+
+	backendconfig "github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/profiles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/auth"
+	router "github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/routes"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+)
+
+type handler struct {
+	auditService *audit.Service
+	service      *profiles.Service
+	signer       *auth.Signer
+	log          *zerolog.Logger
+}
+
+// NewHandler creates new endpoint handler.
+func NewHandler(s *domain.Services, log *zerolog.Logger) (router.RootEndpoints, router.APIEndpoints) {
+	h := &handler{
+		auditService: s.AuditService,
+		service:      s.ProfilesService,
+		signer:       auth.NewSigner(s),
+		log:          log,
+	}
+
+	prefix := "/api/v1"
+
+	// Register root endpoints, avatars are fetched by wallets of other users.
+	rootEndpoints := router.RootEndpointsFunc(func(router *gin.RouterGroup) {
+		router.GET(prefix+"/avatars/:key", h.getAvatar)
+	})
+
+	// Register api endpoints which are athorized by session token.
+	apiEndpoints := router.APIEndpointsFunc(func(router *gin.RouterGroup) {
+		group := router.Group("/user/profile")
+		{
+			routes := auth.NewRoutes(group)
+			routes.GET("", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionViewProfile), h.getProfile)
+			routes.PATCH("", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionUpdateProfile), h.updateProfile)
+			routes.POST("/avatar", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionUploadAvatar), h.uploadAvatar)
+		}
+	})
+
+	return rootEndpoints, apiEndpoints
+}
+
+// Get user profile.
+//
+//	@Summary Get public name and avatar of the user paymails
+//	@Tags profile
+//	@Produce json
+//	@Success 200 {object} profiles.Profile
+//	@Router /api/v1/user/profile [get]
+func (h *handler) getProfile(c *gin.Context) {
+	profile, err := h.service.GetProfile(c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// Update user profile.
+//
+//	@Summary Update public name of the user paymails
+//	@Tags profile
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} profiles.Profile
+//	@Router /api/v1/user/profile [patch]
+//	@Param data body UpdateProfile true "Profile data"
+func (h *handler) updateProfile(c *gin.Context) {
+	var req UpdateProfile
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	// Paymails are registered again with the new profile, so xPriv is needed to derive their xPub.
+	xpriv, err := h.signer.Xpriv(c, req.Password)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	profile, err := h.service.UpdatePublicName(c.GetInt(auth.SessionUserID), xpriv, req.PublicName)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// Upload avatar.
+//
+//	@Summary Upload avatar of the user paymails
+//	@Tags profile
+//	@Accept multipart/form-data
+//	@Produce json
+//	@Success 200 {object} profiles.Profile
+//	@Router /api/v1/user/profile/avatar [post]
+//	@Param avatar formData file true "PNG, JPEG, GIF or WebP image"
+//	@Param password formData string false "Password, signing grant from session is used if it's empty"
+func (h *handler) uploadAvatar(c *gin.Context) {
+	file, err := c.FormFile("avatar")
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+	defer f.Close() //nolint:all
+
+	// One byte over the limit is read, so too big images are rejected by the service.
+	data, err := io.ReadAll(io.LimitReader(f, viper.GetInt64(backendconfig.EnvAvatarMaxSize)+1))
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	xpriv, err := h.signer.Xpriv(c, c.PostForm("password"))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	profile, err := h.service.UploadAvatar(c.GetInt(auth.SessionUserID), xpriv, data)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// Get avatar.
+//
+//	@Summary Get avatar image
+//	@Tags profile
+//	@Produce png,jpeg,gif,image/webp
+//	@Success 200 {file} binary
+//	@Router /api/v1/avatars/{key} [get]
+//	@Param key path string true "Avatar key"
+func (h *handler) getAvatar(c *gin.Context) {
+	data, contentType, err := h.service.GetAvatar(c.Param("key"))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	// Avatar is stored under a new key on each upload, so it never changes.
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, contentType, data)
+}
@@ -0,0 +1,9 @@
+package profiles
+
+// UpdateProfile represents a request for updating the user profile.
+type UpdateProfile struct {
+	// PublicName is shown by wallets for the user paymails, empty value resets it to the paymail alias.
+	PublicName string `json:"publicName"`
+	// Password is optional, signing grant from session is used if it's empty.
+	Password string `json:"password,omitempty"`
+}
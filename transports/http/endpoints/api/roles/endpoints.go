@@ -0,0 +1,54 @@
+package roles
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/auth"
+	router "github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/routes"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type handler struct {
+	auditService *audit.Service
+	service      *roles.Service
+	log          *zerolog.Logger
+}
+
+// NewHandler creates new endpoint handler.
+func NewHandler(s *domain.Services, log *zerolog.Logger) router.APIEndpoints {
+	return &handler{
+		auditService: s.AuditService,
+		service:      s.RolesService,
+		log:          log,
+	}
+}
+
+// RegisterAPIEndpoints registers routes that are part of service API.
+// Roles of other users are managed by operators in the admin API.
+func (h *handler) RegisterAPIEndpoints(router *gin.RouterGroup) {
+	routes := auth.NewRoutes(router)
+	routes.GET("/user/roles", auth.PermissionSignedIn, auth.Audit(h.auditService, audit.ActionViewOwnRoles), h.getOwnRoles)
+}
+
+// Get roles of the signed-in user.
+// @Description Frontend can use permissions to hide actions which are not allowed to the user.
+//
+//	@Summary Get roles and permissions of the signed-in user
+//	@Tags roles
+//	@Produce json
+//	@Success 200 {object} roles.UserRoles
+//	@Router /api/v1/user/roles [get]
+func (h *handler) getOwnRoles(c *gin.Context) {
+	userRoles, err := h.service.GetUserRoles(c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, userRoles)
+}
@@ -0,0 +1,99 @@
+package sessions
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/sessions"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/auth"
+	router "github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/routes"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type handler struct {
+	auditService *audit.Service
+	service      *sessions.Service
+	log          *zerolog.Logger
+}
+
+// NewHandler creates new endpoint handler.
+func NewHandler(s *domain.Services, log *zerolog.Logger) router.APIEndpoints {
+	return &handler{
+		auditService: s.AuditService,
+		service:      s.SessionsService,
+		log:          log,
+	}
+}
+
+// RegisterAPIEndpoints registers routes that are part of service API.
+func (h *handler) RegisterAPIEndpoints(router *gin.RouterGroup) {
+	group := router.Group("/sessions")
+	{
+		routes := auth.NewRoutes(group)
+		routes.GET("", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionViewSessions), h.getSessions)
+		routes.DELETE("/:id", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionRevokeSession), h.revokeSession)
+	}
+}
+
+// Get active sessions.
+//
+//	@Summary Get active sessions of the user
+//	@Tags sessions
+//	@Produce json
+//	@Success 200 {object} []Session
+//	@Router /api/v1/sessions [get]
+func (h *handler) getSessions(c *gin.Context) {
+	userSessions, err := h.service.GetActiveSessions(c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	currentAccessKeyID := c.GetString(auth.SessionAccessKeyID)
+	response := make([]Session, 0, len(userSessions))
+	for _, session := range userSessions {
+		response = append(response, Session{
+			Session: session,
+			Current: session.AccessKeyID == currentAccessKeyID,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Revoke session.
+//
+//	@Summary Revoke session and its access key
+//	@Tags sessions
+//	@Produce json
+//	@Success 200
+//	@Router /api/v1/sessions/{id} [delete]
+//	@Param id path int true "Session id"
+func (h *handler) revokeSession(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrSessionNotFound, h.log)
+		return
+	}
+
+	session, err := h.service.RevokeSession(c.GetInt(auth.SessionUserID), id)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	if session.AccessKeyID == c.GetString(auth.SessionAccessKeyID) {
+		if err = auth.TerminateSession(c); err != nil {
+			h.log.Error().Msgf("Session wasn't terminated: %s", err)
+			spverrors.ErrorResponse(c, spverrors.ErrSessionTerminate, h.log)
+			return
+		}
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,9 @@
+package sessions
+
+import "github.com/bitcoin-sv/spv-wallet-web-backend/domain/sessions"
+
+// Session represents an active user session.
+type Session struct {
+	*sessions.Session
+	Current bool `json:"current"`
+}
@@ -0,0 +1,390 @@
+package sso
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/grants"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/identities"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/lockout"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/sessions"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/oidc"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/auth"
+	router "github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/routes"
+	"github.com/bitcoin-sv/spv-wallet/models"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+)
+
+// Keys of the session under which sign-in in progress is kept.
+const (
+	sessionPendingSignIn   = "oidcPendingSignIn"
+	sessionPendingIdentity = "oidcPendingIdentity"
+)
+
+// pendingTTL is how long the user has to finish sign-in with the provider or linking of the identity.
+const pendingTTL = 10 * time.Minute
+
+// resultSignedIn is a result passed to the frontend after successful sign-in.
+const resultSignedIn = "signed-in"
+
+type handler struct {
+	auditService    *audit.Service
+	service         *identities.Service
+	uService        *users.UserService
+	grantsService   *grants.Service
+	sessionsService *sessions.Service
+	lockoutService  *lockout.Service
+	frontendURL     string
+	log             *zerolog.Logger
+}
+
+// NewHandler creates new endpoint handler.
+// Users sign in with the OpenID Connect provider and unlock the wallet with its passphrase only to link the identity and to spend.
+func NewHandler(s *domain.Services, log *zerolog.Logger) (router.RootEndpoints, router.APIEndpoints) {
+	h := &handler{
+		auditService:    s.AuditService,
+		service:         s.IdentitiesService,
+		uService:        s.UsersService,
+		grantsService:   s.GrantsService,
+		sessionsService: s.SessionsService,
+		lockoutService:  s.LockoutService,
+		frontendURL:     viper.GetString(config.EnvOIDCFrontendURL),
+		log:             log,
+	}
+
+	prefix := "/api/v1"
+
+	// Register root endpoints, user is signed in by them.
+	rootEndpoints := router.RootEndpointsFunc(func(router *gin.RouterGroup) {
+		router.GET(prefix+"/oidc/sign-in", h.startSignIn)
+		router.GET(prefix+"/oidc/callback", auth.Audit(h.auditService, audit.ActionSSOSignIn), h.callback)
+		router.POST(prefix+"/oidc/link", auth.Audit(h.auditService, audit.ActionLinkIdentity), h.link)
+	})
+
+	// Register api endpoints which are authorized by session token.
+	apiEndpoints := router.APIEndpointsFunc(func(router *gin.RouterGroup) {
+		routes := auth.NewRoutes(router)
+		routes.GET("/user/identities", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionViewIdentities), h.getIdentities)
+		routes.DELETE("/user/identities/:id", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionUnlinkIdentity), h.unlinkIdentity)
+	})
+
+	return rootEndpoints, apiEndpoints
+}
+
+// Start single sign-on.
+// @Description Redirects to the sign-in page of the OpenID Connect provider, which redirects back to the callback.
+//
+//	@Summary Start sign-in with the OpenID Connect provider
+//	@Tags sso
+//	@Success 302
+//	@Router /api/v1/oidc/sign-in [get]
+func (h *handler) startSignIn(c *gin.Context) {
+	request, err := h.service.StartSignIn()
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	err = h.savePending(c, sessionPendingSignIn, &pendingSignIn{
+		State:     request.State,
+		Nonce:     request.Nonce,
+		ExpiresAt: time.Now().Add(pendingTTL),
+	})
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrSessionUpdate, h.log)
+		return
+	}
+
+	c.Redirect(http.StatusFound, request.URL)
+}
+
+// Single sign-on callback.
+// @Description Signs in the user of the identity linked to the wallet account. If the identity isn't linked yet
+// @Description or it must be unlocked again, it's kept in session and /api/v1/oidc/link must be called with the wallet passphrase.
+// @Description If frontend url is configured, user is redirected to it with result or error query parameter.
+//
+//	@Summary Finish sign-in with the OpenID Connect provider
+//	@Tags sso
+//	@Produce json
+//	@Success 200 {object} SignInResponse
+//	@Success 302
+//	@Router /api/v1/oidc/callback [get]
+//	@Param code query string true "Authorization code"
+//	@Param state query string true "State of the sign-in"
+func (h *handler) callback(c *gin.Context) {
+	claims, err := h.authenticate(c)
+	if err != nil {
+		h.finishCallback(c, nil, err)
+		return
+	}
+	auth.SetAuditActor(c, nil, claims.Email)
+	auth.SetAuditTarget(c, claims.Subject)
+
+	identity, err := h.service.GetIdentity(claims)
+	if err != nil {
+		h.finishCallback(c, nil, h.keepIdentityIfUnlockRequired(c, claims, err))
+		return
+	}
+
+	user, err := h.uService.GetUserByID(identity.UserID)
+	if err != nil {
+		h.finishCallback(c, nil, err)
+		return
+	}
+	auth.SetAuditActor(c, &user.ID, user.Email)
+
+	if retryAfter, err := h.lockoutService.CheckSignIn(user.Email, c.ClientIP()); err != nil {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		h.finishCallback(c, nil, err)
+		return
+	}
+
+	signInUser, err := h.service.SignIn(identity)
+	if err != nil {
+		h.finishCallback(c, nil, h.keepIdentityIfUnlockRequired(c, claims, err))
+		return
+	}
+
+	// Wallet stays locked, so no signing grant is created until the user unlocks it with passphrase.
+	if err = h.startSession(c, signInUser, ""); err != nil {
+		h.finishCallback(c, nil, err)
+		return
+	}
+
+	h.finishCallback(c, signInUser, nil)
+}
+
+// Link identity.
+// @Description Links the identity signed in with the OpenID Connect provider to the wallet account and signs the user in.
+// @Description Wallet is unlocked with its passphrase, which is set by the signed-in user and is separate from the password of the account,
+// @Description so signing grant is created as on sign-in with password.
+//
+//	@Summary Link identity to the wallet account
+//	@Tags sso
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} SignInResponse
+//	@Router /api/v1/oidc/link [post]
+//	@Param data body LinkIdentity true "Wallet passphrase"
+func (h *handler) link(c *gin.Context) {
+	var req LinkIdentity
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	var pending pendingIdentity
+	if !auth.LoadPending(c, sessionPendingIdentity, &pending) {
+		spverrors.ErrorResponse(c, spverrors.ErrNoPendingIdentity, h.log)
+		return
+	}
+	auth.SetAuditTarget(c, pending.Subject)
+
+	email := req.Email
+	if email == "" && pending.EmailVerified {
+		email = pending.Email
+	}
+	auth.SetAuditActor(c, nil, email)
+
+	ip := c.ClientIP()
+	if retryAfter, err := h.lockoutService.CheckSignIn(email, ip); err != nil {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	user, xpriv, err := h.uService.UnlockWalletByEmail(email, req.Passphrase, req.Code)
+	if err != nil {
+		if errors.Is(err, spverrors.ErrInvalidCredentials) || errors.Is(err, spverrors.ErrInvalidTwoFactorCode) {
+			h.lockoutService.RecordFailure(email, ip)
+		}
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	h.lockoutService.RecordSuccess(email)
+	auth.SetAuditActor(c, &user.ID, email)
+
+	claims := &oidc.Claims{
+		Issuer:        pending.Issuer,
+		Subject:       pending.Subject,
+		Email:         pending.Email,
+		EmailVerified: pending.EmailVerified,
+	}
+	identity, err := h.service.Link(user.ID, claims, xpriv)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	// Session is signed in with the identity as on callback, the wallet is unlocked only by the signing grant.
+	signInUser, err := h.service.SignIn(identity)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	grant, err := h.grantsService.CreateGrant(user.ID, xpriv)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	if err = h.startSession(c, signInUser, grant.ID); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, SignInResponse{
+		Paymail: signInUser.User.Paymail,
+		Balance: signInUser.Balance,
+	})
+}
+
+// Get identities.
+//
+//	@Summary Get identities linked to the wallet account
+//	@Tags sso
+//	@Produce json
+//	@Success 200 {object} []identities.Identity
+//	@Router /api/v1/user/identities [get]
+func (h *handler) getIdentities(c *gin.Context) {
+	result, err := h.service.GetUserIdentities(c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Unlink identity.
+// @Description Identity can't be used to sign in anymore, sessions already signed in with it are kept.
+//
+//	@Summary Unlink identity from the wallet account
+//	@Tags sso
+//	@Produce json
+//	@Success 200
+//	@Router /api/v1/user/identities/{id} [delete]
+//	@Param id path int true "Identity id"
+func (h *handler) unlinkIdentity(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrIdentityNotFound, h.log)
+		return
+	}
+
+	if err = h.service.Unlink(c.GetInt(auth.SessionUserID), id); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// authenticate checks that the callback belongs to the sign-in started by this client and exchanges the code for claims.
+func (h *handler) authenticate(c *gin.Context) (*oidc.Claims, error) {
+	var pending pendingSignIn
+	if !auth.LoadPending(c, sessionPendingSignIn, &pending) || c.Query("state") != pending.State {
+		return nil, spverrors.ErrSSOAuthentication
+	}
+
+	// State can be used only once.
+	h.clearPending(c, sessionPendingSignIn)
+
+	if c.Query("error") != "" {
+		h.log.Debug().Msgf("Sign-in with provider failed: %s", c.Query("error"))
+		return nil, spverrors.ErrSSOAuthentication
+	}
+
+	return h.service.Authenticate(c.Query("code"), pending.Nonce) //nolint:wrapcheck // error wrapped higher in call stack
+}
+
+// keepIdentityIfUnlockRequired keeps the identity in session if it can be linked with the wallet passphrase.
+func (h *handler) keepIdentityIfUnlockRequired(c *gin.Context, claims *oidc.Claims, err error) error {
+	if !errors.Is(err, spverrors.ErrIdentityNotLinked) && !errors.Is(err, spverrors.ErrIdentityUnlockRequired) {
+		return err
+	}
+
+	saveErr := h.savePending(c, sessionPendingIdentity, &pendingIdentity{
+		Issuer:        claims.Issuer,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		ExpiresAt:     time.Now().Add(pendingTTL),
+	})
+	if saveErr != nil {
+		return spverrors.ErrSessionUpdate
+	}
+	return err
+}
+
+func (h *handler) startSession(c *gin.Context, signInUser *users.AuthenticatedUser, grantID string) error {
+	session, err := h.sessionsService.CreateSession(signInUser.User.ID, signInUser.AccessKey, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	h.clearPending(c, sessionPendingIdentity)
+	if err = auth.UpdateSession(c, signInUser, session.ID, grantID); err != nil {
+		h.log.Error().Msgf("Sign-in error. Session wasn't saved: %s", err)
+		return spverrors.ErrSessionUpdate
+	}
+	return nil
+}
+
+// finishCallback redirects the user to the frontend with the result, or responds with JSON if frontend url is not configured.
+func (h *handler) finishCallback(c *gin.Context, signInUser *users.AuthenticatedUser, err error) {
+	if h.frontendURL == "" {
+		if err != nil {
+			spverrors.ErrorResponse(c, err, h.log)
+			return
+		}
+		c.JSON(http.StatusOK, SignInResponse{
+			Paymail: signInUser.User.Paymail,
+			Balance: signInUser.Balance,
+		})
+		return
+	}
+
+	query := url.Values{}
+	if err != nil {
+		_ = c.Error(err)
+		code := models.UnknownErrorCode
+		var extendedErr models.ExtendedError
+		if errors.As(err, &extendedErr) {
+			code = extendedErr.GetCode()
+		}
+		query.Set("error", code)
+	} else {
+		query.Set("result", resultSignedIn)
+	}
+
+	c.Redirect(http.StatusFound, h.frontendURL+"?"+query.Encode())
+}
+
+func (h *handler) savePending(c *gin.Context, key string, value auth.Pending) error {
+	if err := auth.SavePending(c, key, value); err != nil {
+		h.log.Error().Msgf("Pending sign-in wasn't saved in session: %s", err)
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+	return nil
+}
+
+func (h *handler) clearPending(c *gin.Context, key string) {
+	if err := auth.ClearPending(c, key); err != nil {
+		h.log.Error().Msgf("Pending sign-in wasn't removed from session: %s", err)
+	}
+}
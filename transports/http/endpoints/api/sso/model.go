@@ -0,0 +1,49 @@
+package sso
+
+import (
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+)
+
+// LinkIdentity is a struct that contains data required to link the identity to the wallet account.
+type LinkIdentity struct {
+	// Email is optional, verified email of the identity is used if it's empty.
+	Email string `json:"email,omitempty"`
+	// Passphrase is the wallet passphrase, it's separate from the password of the account.
+	Passphrase string `json:"passphrase"`
+	// Code is TOTP or recovery code, required only if the user has two-factor authentication enabled.
+	Code string `json:"code,omitempty"`
+}
+
+// SignInResponse is a struct that represents struct sended after user sign in.
+type SignInResponse struct {
+	Paymail string        `json:"paymail"`
+	Balance users.Balance `json:"balance"`
+}
+
+// pendingSignIn is a sign-in started with the provider, kept in session until the user returns to the callback.
+type pendingSignIn struct {
+	State     string    `json:"state"`
+	Nonce     string    `json:"nonce"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// pendingIdentity is an identity signed in with the provider, kept in session until it's linked to the wallet account.
+type pendingIdentity struct {
+	Issuer        string    `json:"issuer"`
+	Subject       string    `json:"subject"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"emailVerified"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+}
+
+// Expired implements auth.Pending.
+func (p *pendingSignIn) Expired() bool {
+	return time.Now().After(p.ExpiresAt)
+}
+
+// Expired implements auth.Pending.
+func (p *pendingIdentity) Expired() bool {
+	return time.Now().After(p.ExpiresAt)
+}
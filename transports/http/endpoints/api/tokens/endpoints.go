@@ -0,0 +1,126 @@
+package tokens
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/tokens"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/auth"
+	router "github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/routes"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type handler struct {
+	auditService *audit.Service
+	service      *tokens.Service
+	uService     *users.UserService
+	log          *zerolog.Logger
+}
+
+// NewHandler creates new endpoint handler.
+func NewHandler(s *domain.Services, log *zerolog.Logger) router.APIEndpoints {
+	return &handler{
+		auditService: s.AuditService,
+		service:      s.TokensService,
+		uService:     s.UsersService,
+		log:          log,
+	}
+}
+
+// RegisterAPIEndpoints registers routes that are part of service API.
+// Tokens are managed with account management permission, which is never granted to tokens themselves.
+func (h *handler) RegisterAPIEndpoints(router *gin.RouterGroup) {
+	group := router.Group("/user/tokens")
+	{
+		routes := auth.NewRoutes(group)
+		routes.POST("", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionCreateAPIToken), h.createToken)
+		routes.GET("", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionViewAPITokens), h.getTokens)
+		routes.DELETE("/:id", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionRevokeAPIToken), h.revokeToken)
+	}
+}
+
+// Create API token.
+// @Description Token is returned only once, it's sent in Authorization header as Bearer token instead of session cookie.
+// @Description Scopes limit what the token can do: read-only, send and contacts. Sending still requires the wallet password.
+//
+//	@Summary Create API token
+//	@Tags tokens
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} CreatedToken
+//	@Router /api/v1/user/tokens [post]
+//	@Param data body CreateToken true "Token name, scopes and wallet password"
+func (h *handler) createToken(c *gin.Context) {
+	var req CreateToken
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	userID := c.GetInt(auth.SessionUserID)
+	xpriv, err := h.uService.GetUserXpriv(userID, req.Password)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	created, err := h.service.CreateToken(userID, req.Name, req.Scopes, req.ExpiresAt, xpriv)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+	auth.SetAuditTarget(c, strconv.Itoa(created.Token.ID))
+
+	c.JSON(http.StatusOK, CreatedToken{
+		Token: created.Token,
+		Value: created.Secret,
+	})
+}
+
+// Get API tokens.
+//
+//	@Summary Get API tokens of the user
+//	@Tags tokens
+//	@Produce json
+//	@Success 200 {object} []tokens.Token
+//	@Router /api/v1/user/tokens [get]
+func (h *handler) getTokens(c *gin.Context) {
+	result, err := h.service.GetUserTokens(c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Revoke API token.
+//
+//	@Summary Revoke API token and its access key
+//	@Tags tokens
+//	@Produce json
+//	@Success 200
+//	@Router /api/v1/user/tokens/{id} [delete]
+//	@Param id path int true "Token id"
+func (h *handler) revokeToken(c *gin.Context) {
+	auth.SetAuditTarget(c, c.Param("id"))
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrAPITokenNotFound, h.log)
+		return
+	}
+
+	if err = h.service.RevokeToken(c.GetInt(auth.SessionUserID), id); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
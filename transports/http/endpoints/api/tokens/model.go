@@ -0,0 +1,26 @@
+package tokens
+
+import (
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/tokens"
+)
+
+// CreateToken is a struct that contains data required to create an API token.
+type CreateToken struct {
+	// Name is shown in the list of tokens, e.g. name of the integration using the token.
+	Name string `json:"name"`
+	// Scopes are any of read-only, send and contacts.
+	Scopes []string `json:"scopes"`
+	// ExpiresAt is optional, the token is valid until it's revoked if it's not set.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// Password unlocks the wallet, so access key of the token can be created.
+	Password string `json:"password"`
+}
+
+// CreatedToken represents a new API token, its value is returned only once.
+type CreatedToken struct {
+	*tokens.Token
+	// Value is the token itself, it isn't stored and can't be shown again.
+	Value string `json:"token"`
+}
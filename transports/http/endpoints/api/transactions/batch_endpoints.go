@@ -0,0 +1,161 @@
+package transactions
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/payouts"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/notification"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// maxBatchFileSize limits size of the uploaded CSV, number of its rows is limited by the payouts service.
+const maxBatchFileSize = 1 << 20
+
+// Upload payout batch.
+// @Description Validates CSV with rows of paymail,satoshis,memo against the balance. Nothing is paid, the batch is saved
+// @Description only if all rows are valid, so it can be executed. CSV is sent as file form field or as text/csv body.
+//
+//	@Summary Upload payout batch.
+//	@Tags transaction
+//	@Accept multipart/form-data,text/csv
+//	@Produce json
+//	@Success 200 {object} payouts.Preview
+//	@Router /api/v1/transaction/batch [post]
+//	@Param file formData file false "CSV with rows of paymail,satoshis,memo"
+func (h *handler) uploadBatch(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBatchFileSize)
+
+	var csv io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		file, err := c.FormFile("file")
+		if err != nil {
+			spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+			return
+		}
+		f, err := file.Open()
+		if err != nil {
+			spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+			return
+		}
+		defer f.Close() //nolint:all
+		csv = f
+	}
+
+	preview, err := h.pService.Preview(c.GetInt(auth.SessionUserID), c.GetString(auth.SessionAccessKey), csv)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+	if preview.Batch != nil {
+		auth.SetAuditTarget(c, strconv.Itoa(preview.Batch.ID))
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// Get payout batch.
+//
+//	@Summary Get payout batch with status of its rows.
+//	@Tags transaction
+//	@Produce json
+//	@Success 200 {object} payouts.Batch
+//	@Router /api/v1/transaction/batch/{id} [get]
+//	@Param id path int true "Payout batch id"
+func (h *handler) getBatch(c *gin.Context) {
+	batch, ok := h.findBatch(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, batch)
+}
+
+// Execute payout batch.
+// @Description Pays rows of the batch which are not paid yet, in chunks paid by one transaction each. Progress is sent
+// @Description over websocket. Failed batch can be executed again, rows already paid are never paid twice.
+// @Description Wallet is unlocked with the password, the wallet passphrase or the signing grant of the session.
+// @Description Two-factor code is required if unpaid total is above the user threshold.
+//
+//	@Summary Execute payout batch.
+//	@Tags transaction
+//	@Produce json
+//	@Success 202 {object} payouts.Batch
+//	@Router /api/v1/transaction/batch/{id}/execute [post]
+//	@Param id path int true "Payout batch id"
+//	@Param data body ExecuteBatch true "Execute payout batch data"
+func (h *handler) executeBatch(c *gin.Context) {
+	var req ExecuteBatch
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	batch, ok := h.findBatch(c)
+	if !ok {
+		return
+	}
+
+	userID := c.GetInt(auth.SessionUserID)
+	xpriv, err := h.signer.UnlockXpriv(c, req.Password, req.Passphrase)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	if err = h.tfService.VerifyTransaction(userID, batch.UnpaidSatoshis(), req.Code); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	// Primary paymail could be changed in another session, so it's taken from db instead of session.
+	user, err := h.uService.GetUserByID(userID)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	// Rows are updated in the background, so the response is prepared before the batch is executed.
+	response := payouts.Batch{
+		ID:            batch.ID,
+		Status:        payouts.BatchStatusRunning,
+		TotalSatoshis: batch.TotalSatoshis,
+		CreatedAt:     batch.CreatedAt,
+		UpdatedAt:     batch.UpdatedAt,
+	}
+
+	events := make(chan notification.PayoutBatchEvent)
+	if err = h.pService.Execute(batch, user.Paymail, xpriv, events); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+	go func() {
+		for event := range events {
+			h.ws.GetSocket(strconv.Itoa(userID)).Notify(event)
+		}
+	}()
+
+	c.JSON(http.StatusAccepted, response)
+}
+
+// findBatch returns batch of the user by id from path, error response is written if it's not found.
+func (h *handler) findBatch(c *gin.Context) (*payouts.Batch, bool) {
+	auth.SetAuditTarget(c, c.Param("id"))
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrPayoutBatchNotFound, h.log)
+		return nil, false
+	}
+
+	batch, err := h.pService.GetBatch(c.GetInt(auth.SessionUserID), id)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return nil, false
+	}
+	return batch, true
+}
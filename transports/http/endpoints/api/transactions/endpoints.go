@@ -3,9 +3,15 @@ package transactions
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/idempotency"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/payouts"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/transactions"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/twofactor"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/notification"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
@@ -18,11 +24,23 @@ import (
 	"github.com/rs/zerolog"
 )
 
+const (
+	// idempotencyKeyHeader is the header with the key which makes repeated request return the original outcome.
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotentReplayedHeader is set on the response returning the original outcome.
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
 type handler struct {
-	uService users.UserService
-	tService transactions.TransactionService
-	log      *zerolog.Logger
-	ws       websocket.Server
+	auditService *audit.Service
+	uService     users.UserService
+	tService     *transactions.TransactionService
+	tfService    *twofactor.Service
+	pService     *payouts.Service
+	iService     *idempotency.Service
+	signer       *auth.Signer
+	log          *zerolog.Logger
+	ws           websocket.Server
 }
 
 // FullTransaction is used for swagger generation
@@ -31,10 +49,15 @@ type FullTransaction = spvwallet.FullTransaction
 // NewHandler creates new endpoint handler.
 func NewHandler(s *domain.Services, log *zerolog.Logger, ws websocket.Server) router.APIEndpoints {
 	return &handler{
-		uService: *s.UsersService,
-		tService: *s.TransactionsService,
-		log:      log,
-		ws:       ws,
+		auditService: s.AuditService,
+		uService:     *s.UsersService,
+		tService:     s.TransactionsService,
+		tfService:    s.TwoFactorService,
+		pService:     s.PayoutsService,
+		iService:     s.IdempotencyService,
+		signer:       auth.NewSigner(s),
+		log:          log,
+		ws:           ws,
 	}
 }
 
@@ -42,13 +65,19 @@ func NewHandler(s *domain.Services, log *zerolog.Logger, ws websocket.Server) ro
 func (h *handler) RegisterAPIEndpoints(router *gin.RouterGroup) {
 	user := router.Group("/transaction")
 	{
-		user.POST("", h.createTransaction)
-		user.POST("/search", h.getTransactions)
-		user.GET("/:id", h.getTransaction)
+		routes := auth.NewRoutes(user)
+		routes.POST("", roles.PermissionWalletSpend, auth.Audit(h.auditService, audit.ActionSendTransaction), h.createTransaction)
+		routes.POST("/preview", roles.PermissionWalletSpend, auth.Audit(h.auditService, audit.ActionPreviewTransaction), h.previewTransaction)
+		routes.POST("/batch", roles.PermissionWalletSpend, auth.Audit(h.auditService, audit.ActionUploadPayoutBatch), h.uploadBatch)
+		routes.GET("/batch/:id", roles.PermissionWalletRead, auth.Audit(h.auditService, audit.ActionViewPayoutBatch), h.getBatch)
+		routes.POST("/batch/:id/execute", roles.PermissionWalletSpend, auth.Audit(h.auditService, audit.ActionExecutePayoutBatch), h.executeBatch)
+		routes.POST("/search", roles.PermissionWalletRead, auth.Audit(h.auditService, audit.ActionViewTransactions), h.getTransactions)
+		routes.GET("/:id", roles.PermissionWalletRead, auth.Audit(h.auditService, audit.ActionViewTransaction), h.getTransaction)
 	}
 }
 
 // Get all user transactions.
+// @Description Sent transactions which are not recorded in SPV Wallet yet are listed first on the first page with "pending record" status.
 //
 //	@Summary Get all transactions.
 //	@Tags transaction
@@ -70,7 +99,7 @@ func (h *handler) getTransactions(c *gin.Context) {
 	}
 
 	// Get user transactions.
-	txs, err := h.tService.GetTransactions(c.GetString(auth.SessionAccessKey), c.GetString(auth.SessionUserPaymail), req.QueryParams)
+	txs, err := h.tService.GetTransactions(c.GetInt(auth.SessionUserID), c.GetString(auth.SessionAccessKey), c.GetString(auth.SessionUserPaymail), req.QueryParams)
 	if err != nil {
 		spverrors.ErrorResponse(c, err, h.log)
 		return
@@ -80,6 +109,7 @@ func (h *handler) getTransactions(c *gin.Context) {
 }
 
 // Get specific transactions.
+// @Description Sent transaction which is not recorded in SPV Wallet yet has "pending record" status, "record failed" if it was given up.
 //
 //	@Summary Get transaction by id.
 //	@Tags transaction
@@ -91,7 +121,7 @@ func (h *handler) getTransaction(c *gin.Context) {
 	transactionID := c.Param("id")
 
 	// Get transaction by id.
-	transaction, err := h.tService.GetTransaction(c.GetString(auth.SessionAccessKey), transactionID, c.GetString(auth.SessionUserPaymail))
+	transaction, err := h.tService.GetTransaction(c.GetInt(auth.SessionUserID), c.GetString(auth.SessionAccessKey), transactionID, c.GetString(auth.SessionUserPaymail))
 	if err != nil {
 		spverrors.ErrorResponse(c, err, h.log)
 		return
@@ -100,14 +130,55 @@ func (h *handler) getTransaction(c *gin.Context) {
 	c.JSON(http.StatusOK, transaction)
 }
 
+// Preview transaction.
+// @Description Drafts transaction paying all recipients without sending it and returns its fee, inputs, outputs, change
+// @Description and value in USD. The transaction is sent by its preview token until the preview expires.
+//
+//	@Summary Preview transaction.
+//	@Tags transaction
+//	@Produce json
+//	@Success 200 {object} transactions.Preview
+//	@Router /api/v1/transaction/preview [post]
+//	@Param data body PreviewTransaction true "Preview transaction data"
+func (h *handler) previewTransaction(c *gin.Context) {
+	var req PreviewTransaction
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	recipients := req.toRecipients()
+	auth.SetAuditTarget(c, auditTarget(recipients))
+
+	// Primary paymail could be changed in another session, so it's taken from db instead of session.
+	user, err := h.uService.GetUserByID(c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	preview, err := h.tService.PreviewTransaction(user.ID, user.Paymail, c.GetString(auth.SessionAccessKey), recipients)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
 // Create transactions.
+// @Description Sends the previewed transaction, so the user pays exactly the previewed fee. The preview can be sent only once.
+// @Description Wallet is unlocked with the password, the wallet passphrase or the signing grant of the session, so sessions signed in
+// @Description with single sign-on or passkey can spend too. Two-factor code is required if total amount is above the user threshold.
+// @Description Request repeated with the same Idempotency-Key returns the originally sent transaction, the key can't be reused with a different request.
 //
 //	@Summary Create transaction.
 //	@Tags transaction
 //	@Produce json
-//	@Success 200 {object} FullTransaction
+//	@Success 200 {object} transactions.SentTransaction
 //	@Router /api/v1/transaction [post]
 //	@Param data body CreateTransaction true "Create transaction data"
+//	@Param Idempotency-Key header string false "Key which makes repeated request return the original outcome"
 func (h *handler) createTransaction(c *gin.Context) {
 	var reqTransaction CreateTransaction
 	if err := c.Bind(&reqTransaction); err != nil {
@@ -115,23 +186,78 @@ func (h *handler) createTransaction(c *gin.Context) {
 		return
 	}
 
-	// Validate user.
-	xpriv, err := h.uService.GetUserXpriv(c.GetInt(auth.SessionUserID), reqTransaction.Password)
+	userID := c.GetInt(auth.SessionUserID)
+	idempotencyKey := c.GetHeader(idempotencyKeyHeader)
+	if idempotencyKey != "" {
+		replayed, err := h.iService.Begin(userID, idempotencyKey, reqTransaction.fingerprint(), reqTransaction.PreviewToken)
+		if err != nil {
+			spverrors.ErrorResponse(c, err, h.log)
+			return
+		}
+		if replayed != nil {
+			c.Header(idempotentReplayedHeader, "true")
+			c.JSON(http.StatusOK, transactions.SentTransaction{DraftID: replayed.DraftID, TransactionID: replayed.TransactionID})
+			return
+		}
+	}
+
+	sent, err := h.sendTransaction(c, userID, &reqTransaction)
+	if idempotencyKey != "" {
+		if err != nil {
+			h.iService.Release(userID, idempotencyKey)
+		} else {
+			h.iService.Complete(userID, idempotencyKey, sent.DraftID, sent.TransactionID)
+		}
+	}
 	if err != nil {
 		spverrors.ErrorResponse(c, err, h.log)
 		return
 	}
 
-	events := make(chan notification.TransactionEvent)
-	err = h.tService.CreateTransaction(c.GetString(auth.SessionUserPaymail), xpriv, reqTransaction.Recipient, reqTransaction.Satoshis, events)
+	c.JSON(http.StatusOK, sent)
+}
+
+// sendTransaction sends the previewed transaction after the user is validated.
+func (h *handler) sendTransaction(c *gin.Context, userID int, reqTransaction *CreateTransaction) (*transactions.SentTransaction, error) {
+	preview, err := h.tService.GetPreview(userID, reqTransaction.PreviewToken)
 	if err != nil {
-		spverrors.ErrorResponse(c, err, h.log)
-		return
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+	auth.SetAuditTarget(c, auditTarget(preview.Recipients))
+
+	if recipients := reqTransaction.toRecipients(); len(recipients) > 0 && !preview.SameRecipients(recipients) {
+		return nil, spverrors.ErrTransactionPreviewMismatch
+	}
+
+	// Validate user.
+	xpriv, err := h.signer.UnlockXpriv(c, reqTransaction.Password, reqTransaction.Passphrase)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	if err = h.tfService.VerifyTransaction(userID, preview.Amount, reqTransaction.Code); err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	// The channel is buffered, so the event is not lost if it comes before the socket goroutine reads it.
+	events := make(chan notification.TransactionEvent, 1)
+	sent, err := h.tService.CreateTransaction(userID, xpriv, reqTransaction.PreviewToken, events)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped higher in call stack
 	}
 	go func() {
 		transaction := <-events
-		h.ws.GetSocket(strconv.Itoa(c.GetInt(auth.SessionUserID))).Notify(transaction)
+		h.ws.GetSocket(strconv.Itoa(userID)).Notify(transaction)
 	}()
 
-	c.Status(http.StatusOK)
+	return sent, nil
+}
+
+// auditTarget returns recipients of the transaction recorded in the audit log.
+func auditTarget(recipients []transactions.Recipient) string {
+	paymails := make([]string, 0, len(recipients))
+	for _, r := range recipients {
+		paymails = append(paymails, r.Recipient)
+	}
+	return strings.Join(paymails, ",")
 }
@@ -1,15 +1,74 @@
 package transactions
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/transactions"
 	"github.com/bitcoin-sv/spv-wallet/models"
 	"github.com/bitcoin-sv/spv-wallet/models/filter"
 )
 
-// CreateTransaction represents request for creating new transaction.
+// PreviewTransaction represents request for previewing new transaction.
+type PreviewTransaction struct {
+	// Recipients are paid by one transaction, every recipient can be listed only once.
+	Recipients []Recipient `json:"recipients,omitempty"`
+	// Recipient and Satoshis pay a single recipient, they're used only if Recipients are not set.
+	Recipient string `json:"recipient,omitempty"`
+	Satoshis  uint64 `json:"satoshis,omitempty"`
+}
+
+// CreateTransaction represents request for sending the previewed transaction.
+// Wallet is unlocked with the password, the wallet passphrase if the password is empty, or the signing grant of the session if both are empty.
 type CreateTransaction struct {
-	Password  string `json:"password"`
+	Password string `json:"password,omitempty"`
+	// Passphrase is the wallet passphrase, it's used if password is empty, e.g. in sessions signed in with single sign-on.
+	Passphrase string `json:"passphrase,omitempty"`
+	// PreviewToken is the token of the preview which is sent.
+	PreviewToken string `json:"previewToken"`
+	// PreviewTransaction is optional, if recipients are set, they must be the previewed ones.
+	PreviewTransaction
+	// Code is TOTP or recovery code, required only above the user two-factor transaction threshold.
+	Code string `json:"code,omitempty"`
+}
+
+// Recipient represents output of the transaction.
+type Recipient struct {
 	Recipient string `json:"recipient"`
 	Satoshis  uint64 `json:"satoshis"`
+	// OpReturn is optional text attached to the output.
+	OpReturn string `json:"opReturn,omitempty"`
+}
+
+// toRecipients returns recipients of the transaction, single recipient is converted to the list.
+func (t *PreviewTransaction) toRecipients() []transactions.Recipient {
+	if len(t.Recipients) == 0 {
+		if t.Recipient == "" {
+			return nil
+		}
+		return []transactions.Recipient{{Recipient: t.Recipient, Satoshis: t.Satoshis}}
+	}
+
+	result := make([]transactions.Recipient, 0, len(t.Recipients))
+	for _, r := range t.Recipients {
+		result = append(result, transactions.Recipient{
+			Recipient: r.Recipient,
+			Satoshis:  r.Satoshis,
+			OpReturn:  r.OpReturn,
+		})
+	}
+	return result
+}
+
+// ExecuteBatch represents request for paying the payout batch.
+// Wallet is unlocked with the password, the wallet passphrase if the password is empty, or the signing grant of the session if both are empty.
+type ExecuteBatch struct {
+	Password string `json:"password,omitempty"`
+	// Passphrase is the wallet passphrase, it's used if password is empty, e.g. in sessions signed in with single sign-on.
+	Passphrase string `json:"passphrase,omitempty"`
+	// Code is TOTP or recovery code, required only if unpaid total of the batch is above the user two-factor transaction threshold.
+	Code string `json:"code,omitempty"`
 }
 
 // SearchTransaction represents request for searching transactions.
@@ -18,3 +77,14 @@ type SearchTransaction struct {
 	Metadata    models.Metadata        `json:"metadata,omitempty"`
 	QueryParams *filter.QueryParams    `json:"params,omitempty"`
 }
+
+// fingerprint identifies the request sent with idempotency key. Password, passphrase and code are left out, so they're never stored.
+func (t *CreateTransaction) fingerprint() string {
+	request, _ := json.Marshal(struct {
+		PreviewToken string
+		Recipients   []transactions.Recipient
+	}{t.PreviewToken, t.toRecipients()})
+
+	hash := sha256.Sum256(request)
+	return hex.EncodeToString(hash[:])
+}
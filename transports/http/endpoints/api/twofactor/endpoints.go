@@ -0,0 +1,173 @@
+package twofactor
+
+import (
+	"net/http"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/twofactor"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/auth"
+	router "github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/routes"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+type handler struct {
+	auditService *audit.Service
+	uService     *users.UserService
+	tfService    *twofactor.Service
+	log          *zerolog.Logger
+}
+
+// NewHandler creates new endpoint handler.
+func NewHandler(s *domain.Services, log *zerolog.Logger) router.APIEndpoints {
+	return &handler{
+		auditService: s.AuditService,
+		uService:     s.UsersService,
+		tfService:    s.TwoFactorService,
+		log:          log,
+	}
+}
+
+// RegisterAPIEndpoints registers routes that are part of service API.
+func (h *handler) RegisterAPIEndpoints(router *gin.RouterGroup) {
+	group := router.Group("/user/2fa")
+	{
+		routes := auth.NewRoutes(group)
+		routes.GET("", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionViewTwoFactor), h.getStatus)
+		routes.POST("/enroll", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionEnrollTwoFactor), h.enroll)
+		routes.POST("/confirm", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionConfirmTwoFactor), h.confirm)
+		routes.DELETE("", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionDisableTwoFactor), h.disable)
+		routes.PUT("/transaction-threshold", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionSetTransactionThreshold), h.setTransactionThreshold)
+	}
+}
+
+// Get two-factor status.
+//
+//	@Summary Get two-factor authentication settings
+//	@Tags 2fa
+//	@Produce json
+//	@Success 200 {object} twofactor.Status
+//	@Router /api/v1/user/2fa [get]
+func (h *handler) getStatus(c *gin.Context) {
+	status, err := h.tfService.GetStatus(c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// Enroll two-factor.
+// @Description Generates TOTP secret and recovery codes. Recovery codes are returned only once and each can be used instead of the code one time.
+//
+//	@Summary Enroll two-factor authentication
+//	@Tags 2fa
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} twofactor.Enrollment
+//	@Router /api/v1/user/2fa/enroll [post]
+//	@Param data body Enroll true "User password"
+func (h *handler) enroll(c *gin.Context) {
+	var req Enroll
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	userID := c.GetInt(auth.SessionUserID)
+	if _, err := h.uService.GetUserXpriv(userID, req.Password); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	enrollment, err := h.tfService.Enroll(userID, c.GetString(auth.SessionUserPaymail))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// Confirm two-factor.
+//
+//	@Summary Confirm two-factor enrollment with the code from authenticator app
+//	@Tags 2fa
+//	@Accept json
+//	@Produce json
+//	@Success 200
+//	@Router /api/v1/user/2fa/confirm [post]
+//	@Param data body Confirm true "TOTP code"
+func (h *handler) confirm(c *gin.Context) {
+	var req Confirm
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	if err := h.tfService.Confirm(c.GetInt(auth.SessionUserID), req.Code); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// Disable two-factor.
+//
+//	@Summary Disable two-factor authentication
+//	@Tags 2fa
+//	@Accept json
+//	@Produce json
+//	@Success 200
+//	@Router /api/v1/user/2fa [delete]
+//	@Param data body Disable true "User password and TOTP or recovery code"
+func (h *handler) disable(c *gin.Context) {
+	var req Disable
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	userID := c.GetInt(auth.SessionUserID)
+	if _, err := h.uService.GetUserXpriv(userID, req.Password); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	if err := h.tfService.Disable(userID, req.Code); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// Set transaction threshold.
+//
+//	@Summary Set value in satoshis above which transactions require two-factor code
+//	@Tags 2fa
+//	@Accept json
+//	@Produce json
+//	@Success 200
+//	@Router /api/v1/user/2fa/transaction-threshold [put]
+//	@Param data body SetTransactionThreshold true "Threshold and TOTP or recovery code"
+func (h *handler) setTransactionThreshold(c *gin.Context) {
+	var req SetTransactionThreshold
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	if err := h.tfService.SetTransactionThreshold(c.GetInt(auth.SessionUserID), req.Satoshis, req.Code); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,24 @@
+package twofactor
+
+// Enroll represents a request for two-factor enrollment.
+type Enroll struct {
+	Password string `json:"password"`
+}
+
+// Confirm represents a request for confirming two-factor enrollment.
+type Confirm struct {
+	Code string `json:"code"`
+}
+
+// Disable represents a request for disabling two-factor authentication.
+type Disable struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+// SetTransactionThreshold represents a request for setting value above which transactions require the code.
+type SetTransactionThreshold struct {
+	// Satoshis is a threshold value, null disables the code requirement for transactions.
+	Satoshis *uint64 `json:"satoshis"`
+	Code     string  `json:"code"`
+}
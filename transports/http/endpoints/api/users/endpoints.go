@@ -1,9 +1,20 @@
 package users
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/audit"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/exports"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/grants"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/lockout"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/profiles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/roles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/sessions"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/tokens"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/spverrors"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/auth"
@@ -13,34 +24,58 @@ import (
 )
 
 type handler struct {
-	service *users.UserService
-	log     *zerolog.Logger
+	auditService    *audit.Service
+	service         *users.UserService
+	sessionsService *sessions.Service
+	tokensService   *tokens.Service
+	lockoutService  *lockout.Service
+	grantsService   *grants.Service
+	profilesService *profiles.Service
+	exportsService  *exports.Service
+	log             *zerolog.Logger
 }
 
 // NewHandler creates new endpoint handler.
 func NewHandler(s *domain.Services, log *zerolog.Logger) (router.RootEndpoints, router.APIEndpoints) {
 	h := &handler{
-		service: s.UsersService,
-		log:     log,
+		auditService:    s.AuditService,
+		service:         s.UsersService,
+		sessionsService: s.SessionsService,
+		tokensService:   s.TokensService,
+		lockoutService:  s.LockoutService,
+		grantsService:   s.GrantsService,
+		profilesService: s.ProfilesService,
+		exportsService:  s.ExportsService,
+		log:             log,
 	}
 
 	prefix := "/api/v1"
 
 	// Register root endpoints.
 	rootEndpoints := router.RootEndpointsFunc(func(router *gin.RouterGroup) {
-		router.POST(prefix+"/user", h.register)
+		router.POST(prefix+"/user", auth.Audit(h.auditService, audit.ActionRegister), h.register)
+		router.POST(prefix+"/user/recover", auth.Audit(h.auditService, audit.ActionRecover), h.recover)
+		router.GET(prefix+"/user/verify", auth.Audit(h.auditService, audit.ActionVerifyEmail), h.verify)
+		router.GET(prefix+"/paymail/available", auth.Audit(h.auditService, audit.ActionCheckAlias), h.checkAliasAvailability)
 	})
 
 	// Register api endpoints which are athorized by session token.
 	apiEndpoints := router.APIEndpointsFunc(func(router *gin.RouterGroup) {
-		router.GET("/user", h.getUser)
+		routes := auth.NewRoutes(router)
+		routes.GET("/user", roles.PermissionWalletRead, auth.Audit(h.auditService, audit.ActionViewAccount), h.getUser)
+		routes.PUT("/user/password", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionChangePassword), h.changePassword)
+		routes.PUT("/user/wallet-passphrase", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionSetWalletPassphrase), h.setWalletPassphrase)
+		routes.DELETE("/user", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionDeleteAccount), h.deleteUser)
+		routes.GET("/user/export", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionExportAccount), h.exportUser)
+		routes.GET("/user/activity", roles.PermissionAccountManage, auth.Audit(h.auditService, audit.ActionViewActivity), h.getActivity)
 	})
 
 	return rootEndpoints, apiEndpoints
 }
 
 // register registers new user.
-// @Description Register new user with given data, paymail is created based on username from sended email.
+// @Description Register new user with given data. Verification link is sent to the email, paymail is created after it's opened.
+// @Description Paymail alias is optional, if it's not provided it's based on username from sended email.
 //
 //	@Summary Register new user
 //	@Tags user
@@ -56,6 +91,7 @@ func (h *handler) register(c *gin.Context) {
 		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
 		return
 	}
+	auth.SetAuditActor(c, nil, reqUser.Email)
 
 	// Check if sended passwords match
 	if reqUser.Password != reqUser.PasswordConfirmation {
@@ -63,11 +99,12 @@ func (h *handler) register(c *gin.Context) {
 		return
 	}
 
-	newUser, err := h.service.CreateNewUser(reqUser.Email, reqUser.Password)
+	newUser, err := h.service.CreateNewUser(reqUser.Email, reqUser.Password, reqUser.Alias)
 	if err != nil {
 		spverrors.ErrorResponse(c, err, h.log)
 		return
 	}
+	auth.SetAuditActor(c, &newUser.User.ID, reqUser.Email)
 
 	// Create response
 	response := RegisterResponse{
@@ -78,6 +115,104 @@ func (h *handler) register(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// verify verifies user email and activates the wallet.
+// @Description Verify user email with the token from verification email. User xPub and paymail are registered in SPV Wallet.
+//
+//	@Summary Verify user email
+//	@Tags user
+//	@Produce json
+//	@Success 200 {object} VerifyResponse
+//	@Router /api/v1/user/verify [get]
+//	@Param token query string true "Verification token"
+func (h *handler) verify(c *gin.Context) {
+	user, err := h.service.VerifyUser(c.Query("token"))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+	auth.SetAuditActor(c, &user.ID, user.Email)
+
+	c.JSON(http.StatusOK, VerifyResponse{Paymail: user.Paymail})
+}
+
+// checkAliasAvailability checks if paymail alias can be registered.
+// @Description Check if paymail alias is valid and not taken. If it can't be registered, free variants of it are suggested.
+//
+//	@Summary Check paymail alias availability
+//	@Tags user
+//	@Produce json
+//	@Success 200 {object} AliasAvailabilityResponse
+//	@Router /api/v1/paymail/available [get]
+//	@Param alias query string true "Paymail alias"
+func (h *handler) checkAliasAvailability(c *gin.Context) {
+	auth.SetAuditTarget(c, c.Query("alias"))
+	availability, err := h.service.CheckAliasAvailability(c.Query("alias"))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, AliasAvailabilityResponse{
+		Alias:       availability.Alias,
+		Available:   availability.Available,
+		Suggestions: availability.Suggestions,
+	})
+}
+
+// recover restores access to the user wallet with mnemonic.
+// @Description Recover access to the wallet with mnemonic returned on registration. xPriv is encrypted with the new password, wallet passphrase is removed, passkeys are deleted and must be registered again,
+// @Description and all user sessions, signing grants and API tokens are terminated. Failed attempts are counted as failed sign-in attempts.
+//
+//	@Summary Recover user wallet
+//	@Tags user
+//	@Accept json
+//	@Produce json
+//	@Success 200
+//	@Router /api/v1/user/recover [post]
+//	@Param data body RecoverUser true "User recovery data"
+func (h *handler) recover(c *gin.Context) {
+	var req RecoverUser
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+	auth.SetAuditActor(c, nil, req.Email)
+
+	// Check if sended passwords match
+	if req.Password != req.PasswordConfirmation {
+		spverrors.ErrorResponse(c, spverrors.ErrPasswordMismatch, h.log)
+		return
+	}
+
+	// Mnemonic guessing is limited by the same counters as password guessing.
+	ip := c.ClientIP()
+	if retryAfter, err := h.lockoutService.CheckSignIn(req.Email, ip); err != nil {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	user, err := h.service.RecoverUser(req.Email, req.Mnemonic, req.Password)
+	if err != nil {
+		if errors.Is(err, spverrors.ErrInvalidCredentials) || errors.Is(err, spverrors.ErrInvalidMnemonic) {
+			h.lockoutService.RecordFailure(req.Email, ip)
+		}
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	// Access keys of all sessions and of API tokens were already revoked while recovering. Whoever held a session could
+	// still sign with its signing grant, so grants are revoked too.
+	_ = h.sessionsService.ForgetOtherSessions(user.ID, "")
+	_ = h.tokensService.ForgetUserTokens(user.ID)
+	h.grantsService.RevokeUserGrants(user.ID)
+
+	// Owner proved access with mnemonic, so failed attempts are reset. Lock set by operator isn't affected, locked account can't be recovered.
+	h.lockoutService.RecordSuccess(req.Email)
+
+	c.Status(http.StatusOK)
+}
+
 // getUser return information about user from context.
 //
 //	@Summary Get user information
@@ -110,3 +245,192 @@ func (h *handler) getUser(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// changePassword changes password of the signed-in user.
+// @Description Change user password. All other user sessions and API tokens are terminated first, if it fails the password isn't changed
+// @Description and the request can be repeated. Then xPriv is re-encrypted with the new password, wallet passphrase is removed and passkeys are deleted,
+// @Description so they must be set up again.
+//
+//	@Summary Change user password
+//	@Tags user
+//	@Accept json
+//	@Produce json
+//	@Success 200
+//	@Router /api/v1/user/password [put]
+//	@Param data body ChangePassword true "Password change data"
+func (h *handler) changePassword(c *gin.Context) {
+	var req ChangePassword
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	// Check if sended passwords match
+	if req.NewPassword != req.NewPasswordConfirmation {
+		spverrors.ErrorResponse(c, spverrors.ErrPasswordMismatch, h.log)
+		return
+	}
+
+	userID, accessKeyID := c.GetInt(auth.SessionUserID), c.GetString(auth.SessionAccessKeyID)
+	err := h.service.ChangePassword(userID, accessKeyID, req.OldPassword, req.NewPassword)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	// Access keys of other sessions and of API tokens were already revoked while changing password.
+	_ = h.sessionsService.ForgetOtherSessions(userID, accessKeyID)
+	_ = h.tokensService.ForgetUserTokens(userID)
+
+	c.Status(http.StatusOK)
+}
+
+// setWalletPassphrase sets wallet passphrase of the signed-in user.
+// @Description Set wallet passphrase, which unlocks the wallet independently of the password, e.g. to link identity signed in with single sign-on
+// @Description and to spend in sessions signed in with it. Passphrase must be different from the password, the password is required to unlock the wallet.
+//
+//	@Summary Set wallet passphrase
+//	@Tags user
+//	@Accept json
+//	@Produce json
+//	@Success 200
+//	@Router /api/v1/user/wallet-passphrase [put]
+//	@Param data body SetWalletPassphrase true "Wallet passphrase data"
+func (h *handler) setWalletPassphrase(c *gin.Context) {
+	var req SetWalletPassphrase
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	// Check if sended passphrases match
+	if req.Passphrase != req.PassphraseConfirmation {
+		spverrors.ErrorResponse(c, spverrors.ErrPasswordMismatch, h.log)
+		return
+	}
+
+	if err := h.service.SetWalletPassphrase(c.GetInt(auth.SessionUserID), req.Password, req.Passphrase); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// deleteUser permanently deletes the signed-in user.
+// @Description Delete user and its data. If sweepTo paymail is provided, all funds are sent to it first and the user isn't deleted if it fails.
+// @Description Access keys are revoked, paymails are removed from SPV Wallet and the user is deleted together with its sessions.
+//
+//	@Summary Delete user
+//	@Tags user
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} DeleteUserResponse
+//	@Router /api/v1/user [delete]
+//	@Param data body DeleteUser true "User deletion data"
+func (h *handler) deleteUser(c *gin.Context) {
+	var req DeleteUser
+	if err := c.Bind(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	// Sweeping funds is spending, so it's not allowed to roles which can't spend.
+	if req.SweepTo != "" && !auth.HasPermission(c, roles.PermissionWalletSpend) {
+		spverrors.ErrorResponse(c, spverrors.ErrForbidden, h.log)
+		return
+	}
+
+	userID := c.GetInt(auth.SessionUserID)
+
+	// Profile is read first, because it's removed together with the user.
+	profile, err := h.profilesService.GetProfile(userID)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	sweepTx, err := h.service.DeleteUser(userID, req.Password, req.SweepTo)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	if profile.AvatarKey != "" {
+		h.profilesService.RemoveAvatar(userID, profile.AvatarKey)
+	}
+	h.grantsService.RevokeGrant(c.GetString(auth.SessionSigningGrantID))
+
+	if err = auth.TerminateSession(c); err != nil {
+		h.log.Error().Msgf("User was deleted but session wasn't terminated: %s", err)
+	}
+
+	response := DeleteUserResponse{}
+	if sweepTx != nil {
+		response.SweepTransactionID = sweepTx.GetTransactionID()
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// exportUser exports data of the signed-in user.
+// @Description Export user record, profile, paymails, contacts and transaction history as JSON or zip archive with export.json and uploaded avatar.
+//
+//	@Summary Export user data
+//	@Tags user
+//	@Produce json,application/zip
+//	@Success 200 {object} exports.Export
+//	@Router /api/v1/user/export [get]
+//	@Param format query string false "Export format" Enums(json, zip)
+func (h *handler) exportUser(c *gin.Context) {
+	userID, accessKey := c.GetInt(auth.SessionUserID), c.GetString(auth.SessionAccessKey)
+
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+		export, err := h.exportsService.Export(userID, accessKey)
+		if err != nil {
+			spverrors.ErrorResponse(c, err, h.log)
+			return
+		}
+
+		c.Header("Content-Disposition", `attachment; filename="export.json"`)
+		c.JSON(http.StatusOK, export)
+	case "zip":
+		archive, err := h.exportsService.ExportZip(userID, accessKey)
+		if err != nil {
+			spverrors.ErrorResponse(c, err, h.log)
+			return
+		}
+
+		c.Header("Content-Disposition", `attachment; filename="export.zip"`)
+		c.Data(http.StatusOK, "application/zip", archive)
+	default:
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+	}
+}
+
+// getActivity returns security-relevant actions of the signed-in user.
+// @Description Sign-ins, password changes, sent transactions and other actions recorded in the audit log, also failed ones.
+//
+//	@Summary Get activity of the user, newest first
+//	@Tags user
+//	@Produce json
+//	@Success 200 {object} []audit.Entry
+//	@Router /api/v1/user/activity [get]
+//	@Param page query int false "Page number"
+//	@Param pageSize query int false "Page size"
+func (h *handler) getActivity(c *gin.Context) {
+	var req GetActivity
+	if err := c.BindQuery(&req); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	entries, err := h.auditService.GetUserActivity(c.GetInt(auth.SessionUserID), req.Page, req.PageSize)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
@@ -7,12 +7,63 @@ type RegisterUser struct {
 	Email                string `json:"email"`
 	Password             string `json:"password"`
 	PasswordConfirmation string `json:"passwordConfirmation"`
+	// Alias is optional, paymail alias is based on email username if it's empty.
+	Alias string `json:"alias,omitempty"`
+}
+
+// RecoverUser is a struct that contains wallet recovery data.
+type RecoverUser struct {
+	Email                string `json:"email"`
+	Mnemonic             string `json:"mnemonic"`
+	Password             string `json:"password"`
+	PasswordConfirmation string `json:"passwordConfirmation"`
+}
+
+// ChangePassword is a struct that contains password change data.
+type ChangePassword struct {
+	OldPassword             string `json:"oldPassword"`
+	NewPassword             string `json:"newPassword"`
+	NewPasswordConfirmation string `json:"newPasswordConfirmation"`
+}
+
+// SetWalletPassphrase is a struct that contains wallet passphrase data.
+type SetWalletPassphrase struct {
+	// Password unlocks the wallet, so its xPriv can be encrypted with the passphrase.
+	Password               string `json:"password"`
+	Passphrase             string `json:"passphrase"`
+	PassphraseConfirmation string `json:"passphraseConfirmation"`
+}
+
+// DeleteUser is a struct that contains user deletion data.
+type DeleteUser struct {
+	Password string `json:"password"`
+	// SweepTo is optional, if it's provided all funds are sent to this paymail before the user is deleted.
+	SweepTo string `json:"sweepTo,omitempty"`
+}
+
+// GetActivity is a struct that contains user activity query.
+type GetActivity struct {
+	Page     int `form:"page"`
+	PageSize int `form:"pageSize"`
 }
 
 // RegisterResponse represents response that is sent after user creation.
 type RegisterResponse struct {
 	Mnemonic string `json:"mnemonic"`
-	Paymail  string `json:"paymail"`
+	// Paymail is empty until the user verifies email.
+	Paymail string `json:"paymail"`
+}
+
+// VerifyResponse represents response that is sent after user email verification.
+type VerifyResponse struct {
+	Paymail string `json:"paymail"`
+}
+
+// AliasAvailabilityResponse represents response of paymail alias availability check.
+type AliasAvailabilityResponse struct {
+	Alias       string   `json:"alias"`
+	Available   bool     `json:"available"`
+	Suggestions []string `json:"suggestions,omitempty"`
 }
 
 // UserResponse is a struct that represents user information.
@@ -22,3 +73,9 @@ type UserResponse struct {
 	Email   string        `json:"email"`
 	Balance users.Balance `json:"balance"`
 }
+
+// DeleteUserResponse represents response that is sent after user deletion.
+type DeleteUserResponse struct {
+	// SweepTransactionID is empty if funds weren't sent.
+	SweepTransactionID string `json:"sweepTransactionId,omitempty"`
+}
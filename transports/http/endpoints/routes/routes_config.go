@@ -20,6 +20,15 @@ type APIEndpoints interface {
 	RegisterAPIEndpoints(router *gin.RouterGroup)
 }
 
+// AdminEndpointsFunc wrapping type for function to mark it as implementation of AdminEndpoints.
+type AdminEndpointsFunc func(router *gin.RouterGroup)
+
+// AdminEndpoints registrar which will register routes in admin API routes group, which is authorized by operator token.
+type AdminEndpoints interface {
+	// RegisterAdminEndpoints register admin API endpoints.
+	RegisterAdminEndpoints(router *gin.RouterGroup)
+}
+
 // RegisterEndpoints register root endpoints by registrar RootEndpointsFunc.
 func (f RootEndpointsFunc) RegisterEndpoints(router *gin.RouterGroup) {
 	f(router)
@@ -30,6 +39,11 @@ func (f APIEndpointsFunc) RegisterAPIEndpoints(router *gin.RouterGroup) {
 	f(router)
 }
 
+// RegisterAdminEndpoints register admin API endpoints by registrar AdminEndpointsFunc.
+func (f AdminEndpointsFunc) RegisterAdminEndpoints(router *gin.RouterGroup) {
+	f(router)
+}
+
 // APIMiddleware middleware that should handle API requests.
 type APIMiddleware interface {
 	// ApplyToAPI handle API request by middleware.
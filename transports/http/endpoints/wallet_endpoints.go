@@ -7,9 +7,18 @@ import (
 	"github.com/bitcoin-sv/spv-wallet-web-backend/domain"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/auth"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/api/access"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/api/admin"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/api/config"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/api/contacts"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/api/passkeys"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/api/paymails"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/api/profiles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/api/roles"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/api/sessions"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/api/sso"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/api/tokens"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/api/transactions"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/api/twofactor"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/api/users"
 	router "github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/routes"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/transports/http/endpoints/status"
@@ -26,6 +35,10 @@ import (
 func SetupWalletRoutes(s *domain.Services, db *sql.DB, log *zerolog.Logger, ws websocket.Server) httpserver.GinEngineOpt {
 	accessRootEndpoints, accessAPIEndpoints := access.NewHandler(s, log)
 	usersRootEndpoints, usersAPIEndpoints := users.NewHandler(s, log)
+	profilesRootEndpoints, profilesAPIEndpoints := profiles.NewHandler(s, log)
+	adminRootEndpoints, adminEndpoints := admin.NewHandler(s, log)
+	ssoRootEndpoints, ssoAPIEndpoints := sso.NewHandler(s, log)
+	passkeysRootEndpoints, passkeysAPIEndpoints := passkeys.NewHandler(s, log)
 
 	routes := []interface{}{
 		swagger.NewHandler(),
@@ -35,24 +48,47 @@ func SetupWalletRoutes(s *domain.Services, db *sql.DB, log *zerolog.Logger, ws w
 		usersAPIEndpoints,
 		accessRootEndpoints,
 		accessAPIEndpoints,
+		ssoRootEndpoints,
+		ssoAPIEndpoints,
+		passkeysRootEndpoints,
+		passkeysAPIEndpoints,
 		transactions.NewHandler(s, log, ws),
 		contacts.NewHandler(s, log),
+		sessions.NewHandler(s, log),
+		tokens.NewHandler(s, log),
+		twofactor.NewHandler(s, log),
+		paymails.NewHandler(s, log),
+		profilesRootEndpoints,
+		profilesAPIEndpoints,
+		roles.NewHandler(s, log),
+		adminRootEndpoints,
+		adminEndpoints,
 	}
 
 	return func(engine *gin.Engine) {
 		apiMiddlewares := router.ToHandlers(
 			auth.NewSessionMiddleware(db, engine),
-			auth.NewAuthMiddleware(s, log),
+			// Requests with API token in Authorization header are authorized by the token, others by the session.
+			auth.NewTokenMiddleware(s, auth.NewAuthMiddleware(s, log), log),
+			auth.NewPermissionsMiddleware(s, log),
+		)
+
+		// Admin API is authorized by operator token, user session cookie is never used there.
+		adminMiddlewares := router.ToHandlers(
+			auth.NewAdminMiddleware(s, log),
 		)
 
 		rootRouter := engine.Group("")
 		apiRouter := engine.Group("/api/v1", apiMiddlewares...)
+		adminRouter := engine.Group("/api/admin/v1", adminMiddlewares...)
 		for _, r := range routes {
 			switch r := r.(type) {
 			case router.RootEndpoints:
 				r.RegisterEndpoints(rootRouter)
 			case router.APIEndpoints:
 				r.RegisterAPIEndpoints(apiRouter)
+			case router.AdminEndpoints:
+				r.RegisterAdminEndpoints(adminRouter)
 			default:
 				panic(errors.New("unexpected router endpoints registrar"))
 			}
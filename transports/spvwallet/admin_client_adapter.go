@@ -2,13 +2,19 @@ package spvwallet
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"strings"
 
 	walletclient "github.com/bitcoin-sv/spv-wallet-go-client"
 	"github.com/bitcoin-sv/spv-wallet-go-client/commands"
 	walletclientCfg "github.com/bitcoin-sv/spv-wallet-go-client/config"
+	"github.com/bitcoin-sv/spv-wallet-go-client/queries"
 	"github.com/bitcoin-sv/spv-wallet-web-backend/config"
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
 	"github.com/bitcoin-sv/spv-wallet/models"
+	"github.com/bitcoin-sv/spv-wallet/models/filter"
 	"github.com/libsv/go-bk/bip32"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -41,24 +47,187 @@ func (a *adminClientAdapter) RegisterPaymail(alias, xpub string) (string, error)
 	// Get paymail domain from env.
 	domain := viper.GetString(config.EnvPaymailDomain)
 
+	return a.CreatePaymail(alias, domain, xpub)
+}
+
+func (a *adminClientAdapter) CreatePaymail(alias, domain, xpub string) (string, error) {
 	// Create paymail address.
 	address := fmt.Sprintf("%s@%s", alias, domain)
 
-	// Get avatar url from env.
-	avatar := viper.GetString(config.EnvPaymailAvatar)
+	if err := a.createPaymail(address, xpub, "", ""); err != nil {
+		return "", err
+	}
+
+	return address, nil
+}
+
+func (a *adminClientAdapter) DeletePaymail(address string) error {
+	alias, domain, found := strings.Cut(address, "@")
+	if !found {
+		return fmt.Errorf("invalid paymail address: %s", address)
+	}
+
+	page, err := a.searchPaymails(alias, domain, false)
+	if err != nil {
+		return err
+	}
+
+	for _, paymail := range page.Content {
+		if err = a.api.DeletePaymail(context.Background(), paymail.ID); err != nil {
+			a.log.Error().Str("paymail", address).Msgf("Error while deleting paymail: %v", err.Error())
+			return errors.Wrap(err, "error while deleting paymail")
+		}
+	}
+
+	return nil
+}
+
+// UpdatePaymailProfile changes public name and avatar of the paymail.
+// SPV Wallet doesn't allow to edit paymail, so it's removed and created again with the same address and xPub.
+func (a *adminClientAdapter) UpdatePaymailProfile(address, xpub, publicName, avatar string) error {
+	alias, domain, found := strings.Cut(address, "@")
+	if !found {
+		return fmt.Errorf("invalid paymail address: %s", address)
+	}
+
+	page, err := a.searchPaymails(alias, domain, false)
+	if err != nil {
+		return err
+	}
+	if len(page.Content) == 0 {
+		return fmt.Errorf("paymail not found: %s", address)
+	}
+
+	current := page.Content[0]
+	xpubHash := sha256.Sum256([]byte(xpub))
+	if current.XpubID != hex.EncodeToString(xpubHash[:]) {
+		return fmt.Errorf("paymail %s doesn't belong to the xPub", address)
+	}
+
+	if err = a.api.DeletePaymail(context.Background(), current.ID); err != nil {
+		a.log.Error().Str("paymail", address).Msgf("Error while deleting paymail: %v", err.Error())
+		return errors.Wrap(err, "error while deleting paymail")
+	}
+
+	if err = a.createPaymail(address, xpub, publicName, avatar); err != nil {
+		// Paymail is restored with the previous profile, so it isn't lost.
+		if restoreErr := a.createPaymail(address, xpub, current.PublicName, current.Avatar); restoreErr != nil {
+			a.log.Error().Str("paymail", address).Msgf("Paymail was deleted and it cannot be restored: %v", restoreErr.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// createPaymail creates paymail address. Alias is used as public name and configured avatar as avatar if they're empty.
+func (a *adminClientAdapter) createPaymail(address, xpub, publicName, avatar string) error {
+	if publicName == "" {
+		publicName, _, _ = strings.Cut(address, "@")
+	}
+	if avatar == "" {
+		// Get avatar url from env.
+		avatar = viper.GetString(config.EnvPaymailAvatar)
+	}
 
 	_, err := a.api.CreatePaymail(context.Background(), &commands.CreatePaymail{
 		Key:        xpub,
 		Address:    address,
-		PublicName: alias,
+		PublicName: publicName,
 		Avatar:     avatar,
 	})
 	if err != nil {
-		a.log.Error().Msgf("Error while creating new paymail: %v", err.Error())
-		return "", errors.Wrap(err, "error while creating new paymail")
+		a.log.Error().Str("paymail", address).Msgf("Error while creating new paymail: %v", err.Error())
+		return errors.Wrap(err, "error while creating new paymail")
 	}
 
-	return address, nil
+	return nil
+}
+
+func (a *adminClientAdapter) PaymailExists(alias, domain string) (bool, error) {
+	// Deleted paymails are included so an address which was already used is never given to someone else.
+	page, err := a.searchPaymails(alias, domain, true)
+	if err != nil {
+		return false, err
+	}
+
+	return len(page.Content) > 0, nil
+}
+
+// GetPaymailXPub returns ID and current balance of the xPub which the paymail belongs to.
+func (a *adminClientAdapter) GetPaymailXPub(address string) (users.PubKey, error) {
+	alias, domain, found := strings.Cut(address, "@")
+	if !found {
+		return nil, fmt.Errorf("invalid paymail address: %s", address)
+	}
+
+	paymails, err := a.searchPaymails(alias, domain, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(paymails.Content) == 0 {
+		return nil, fmt.Errorf("paymail not found: %s", address)
+	}
+
+	xpubID := paymails.Content[0].XpubID
+	xpubs, err := a.api.XPubs(context.Background(), queries.QueryWithFilter(filter.XpubFilter{ID: &xpubID}))
+	if err != nil {
+		a.log.Error().Str("paymail", address).Msgf("Error while searching xPubs: %v", err.Error())
+		return nil, errors.Wrap(err, "error while searching xPubs")
+	}
+	if len(xpubs.Content) == 0 {
+		return nil, fmt.Errorf("xPub of paymail not found: %s", address)
+	}
+
+	return &XPub{ID: xpubs.Content[0].ID, CurrentBalance: xpubs.Content[0].CurrentBalance}, nil
+}
+
+// GetXPubTransactions returns page of transactions of the xPub and total number of its transactions.
+func (a *adminClientAdapter) GetXPubTransactions(xpubID string, queryParam *filter.QueryParams, userPaymail string) ([]users.Transaction, int64, error) {
+	if queryParam.OrderByField == "" {
+		queryParam.OrderByField = "created_at"
+	}
+
+	if queryParam.SortDirection == "" {
+		queryParam.SortDirection = "desc"
+	}
+
+	page, err := a.api.Transactions(context.Background(),
+		queries.QueryWithFilter(filter.AdminTransactionFilter{XPubID: &xpubID}),
+		queries.QueryWithPageFilter[filter.AdminTransactionFilter](filter.Page{
+			Number: queryParam.Page,
+			Size:   queryParam.PageSize,
+			Sort:   queryParam.SortDirection,
+			SortBy: queryParam.OrderByField,
+		}),
+	)
+	if err != nil {
+		a.log.Error().Str("xpubID", xpubID).Msgf("Error while getting transactions: %v", err.Error())
+		return nil, 0, errors.Wrap(err, "error while getting transactions")
+	}
+
+	transactionsData := make([]users.Transaction, 0, len(page.Content))
+	for _, transaction := range page.Content {
+		transactionsData = append(transactionsData, toTransaction(transaction, userPaymail))
+	}
+
+	return transactionsData, int64(page.Page.TotalElements), nil
+}
+
+func (a *adminClientAdapter) searchPaymails(alias, domain string, includeDeleted bool) (*queries.PaymailsPage, error) {
+	page, err := a.api.Paymails(context.Background(), queries.QueryWithFilter(filter.AdminPaymailFilter{
+		PaymailFilter: filter.PaymailFilter{
+			ModelFilter: filter.ModelFilter{IncludeDeleted: &includeDeleted},
+			Alias:       &alias,
+			Domain:      &domain,
+		},
+	}))
+	if err != nil {
+		a.log.Error().Str("alias", alias).Str("domain", domain).Msgf("Error while searching paymails: %v", err.Error())
+		return nil, errors.Wrap(err, "error while searching paymails")
+	}
+
+	return page, nil
 }
 
 func (a *adminClientAdapter) GetSharedConfig() (*models.SharedConfig, error) {
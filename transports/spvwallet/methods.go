@@ -1,13 +1,16 @@
 package spvwallet
 
 import (
+	"fmt"
 	"math"
+	"strings"
 
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
 	"github.com/bitcoin-sv/spv-wallet/models/response"
 )
 
-// GetPaymailsFromMetadata returns sender and receiver paymails from metadata.
-// If no paymail was found in metadata, fallback paymail is returned.
+// GetPaymailsFromMetadata returns sender and receiver paymails from metadata. Receivers of transactions with several recipients
+// are joined with comma. If no paymail was found in metadata, fallback paymail is returned.
 func GetPaymailsFromMetadata(transaction *response.Transaction, fallbackPaymail string) (string, string) {
 	senderPaymail := ""
 	receiverPaymail := ""
@@ -24,6 +27,10 @@ func GetPaymailsFromMetadata(transaction *response.Transaction, fallbackPaymail
 		if transaction.Model.Metadata["receiver"] != nil {
 			receiverPaymail = transaction.Model.Metadata["receiver"].(string)
 		}
+		// Transactions with several recipients list all of them, receiver has only the first one.
+		if receivers := getReceivers(transaction.Model.Metadata["receivers"]); len(receivers) > 0 {
+			receiverPaymail = strings.Join(receivers, ", ")
+		}
 
 		if senderPaymail == "" {
 			// Try to get paymails from metadata if the transaction was made outside SPV Wallet.
@@ -45,6 +52,84 @@ func GetPaymailsFromMetadata(transaction *response.Transaction, fallbackPaymail
 	return senderPaymail, receiverPaymail
 }
 
+// getReceivers returns receivers listed in metadata. The list is decoded from JSON as []any, unless metadata wasn't serialized yet.
+func getReceivers(value any) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []any:
+		result := make([]string, 0, len(v))
+		for _, receiver := range v {
+			if paymail, ok := receiver.(string); ok && paymail != "" {
+				result = append(result, paymail)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+// toTransaction converts transaction from SPV Wallet to transaction shown in the history of the paymail owner.
+func toTransaction(transaction *response.Transaction, userPaymail string) *Transaction {
+	sender, receiver := GetPaymailsFromMetadata(transaction, userPaymail)
+	status := "unconfirmed"
+	if transaction.BlockHeight > 0 {
+		status = "confirmed"
+	}
+
+	return &Transaction{
+		ID:         transaction.ID,
+		Direction:  fmt.Sprint(transaction.TransactionDirection),
+		TotalValue: getAbsoluteValue(transaction.OutputValue),
+		Fee:        transaction.Fee,
+		Status:     status,
+		CreatedAt:  transaction.Model.CreatedAt,
+		Sender:     sender,
+		Receiver:   receiver,
+	}
+}
+
+// toDraftTransaction converts draft from SPV Wallet, the draft is kept so it can be signed later.
+func toDraftTransaction(draft *response.DraftTransaction) *DraftTransaction {
+	config := draft.Configuration
+	change := make(map[string]struct{}, 2*len(config.ChangeDestinations))
+	for _, destination := range config.ChangeDestinations {
+		change[destination.LockingScript] = struct{}{}
+		change[destination.Address] = struct{}{}
+	}
+
+	inputs := make([]users.DraftInput, 0, len(config.Inputs))
+	for _, input := range config.Inputs {
+		inputs = append(inputs, users.DraftInput{
+			TransactionID: input.TransactionID,
+			OutputIndex:   input.OutputIndex,
+			Satoshis:      input.Satoshis,
+		})
+	}
+
+	outputs := make([]users.DraftOutput, 0, len(config.Outputs))
+	for _, output := range config.Outputs {
+		_, isChangeScript := change[output.Script]
+		_, isChangeAddress := change[output.To]
+		outputs = append(outputs, users.DraftOutput{
+			To:       output.To,
+			Satoshis: output.Satoshis,
+			Change:   (output.To != "" && isChangeAddress) || (output.Script != "" && isChangeScript),
+		})
+	}
+
+	return &DraftTransaction{
+		TxDraftID: draft.ID,
+		TxHex:     draft.Hex,
+		Fee:       config.Fee,
+		Change:    config.ChangeSatoshis,
+		Inputs:    inputs,
+		Outputs:   outputs,
+		draft:     draft,
+	}
+}
+
 func getAbsoluteValue(value int64) uint64 {
 	return uint64(math.Abs(float64(value)))
 }
@@ -1,6 +1,12 @@
 package spvwallet
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/bitcoin-sv/spv-wallet-web-backend/domain/users"
+	"github.com/bitcoin-sv/spv-wallet/models/response"
+)
 
 // AccessKey is a struct that contains access key data.
 type AccessKey struct {
@@ -44,8 +50,14 @@ type FullTransaction struct {
 
 // DraftTransaction is a struct that contains draft transaction data.
 type DraftTransaction struct {
-	TxDraftID string `json:"txDraftId"`
-	TxHex     string `json:"txHex"`
+	TxDraftID string              `json:"txDraftId"`
+	TxHex     string              `json:"txHex"`
+	Fee       uint64              `json:"fee"`
+	Change    uint64              `json:"change"`
+	Inputs    []users.DraftInput  `json:"inputs"`
+	Outputs   []users.DraftOutput `json:"outputs"`
+	// draft is kept to sign the transaction, it's set only for drafts which are not finalized yet.
+	draft *response.DraftTransaction
 }
 
 // GetAccessKey returns access key.
@@ -177,3 +189,66 @@ func (t *DraftTransaction) GetDraftTransactionID() string {
 func (t *DraftTransaction) GetDraftTransactionHex() string {
 	return t.TxHex
 }
+
+// GetDraftTransactionFee returns draft transaction fee.
+func (t *DraftTransaction) GetDraftTransactionFee() uint64 {
+	return t.Fee
+}
+
+// GetDraftTransactionChange returns satoshis returned to the wallet as change.
+func (t *DraftTransaction) GetDraftTransactionChange() uint64 {
+	return t.Change
+}
+
+// GetDraftTransactionInputs returns draft transaction inputs.
+func (t *DraftTransaction) GetDraftTransactionInputs() []users.DraftInput {
+	return t.Inputs
+}
+
+// GetDraftTransactionOutputs returns draft transaction outputs.
+func (t *DraftTransaction) GetDraftTransactionOutputs() []users.DraftOutput {
+	return t.Outputs
+}
+
+// draftTransactionJSON is the stored form of DraftTransaction, it includes the draft which is not finalized yet.
+type draftTransactionJSON struct {
+	TxDraftID string                     `json:"txDraftId"`
+	TxHex     string                     `json:"txHex"`
+	Fee       uint64                     `json:"fee"`
+	Change    uint64                     `json:"change"`
+	Inputs    []users.DraftInput         `json:"inputs"`
+	Outputs   []users.DraftOutput        `json:"outputs"`
+	Draft     *response.DraftTransaction `json:"draft,omitempty"`
+}
+
+// MarshalJSON encodes draft transaction with the draft, so it can be finalized after it's decoded.
+func (t *DraftTransaction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(draftTransactionJSON{ //nolint:wrapcheck // error wrapped higher in call stack
+		TxDraftID: t.TxDraftID,
+		TxHex:     t.TxHex,
+		Fee:       t.Fee,
+		Change:    t.Change,
+		Inputs:    t.Inputs,
+		Outputs:   t.Outputs,
+		Draft:     t.draft,
+	})
+}
+
+// UnmarshalJSON decodes draft transaction encoded by MarshalJSON.
+func (t *DraftTransaction) UnmarshalJSON(data []byte) error {
+	var d draftTransactionJSON
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	*t = DraftTransaction{
+		TxDraftID: d.TxDraftID,
+		TxHex:     d.TxHex,
+		Fee:       d.Fee,
+		Change:    d.Change,
+		Inputs:    d.Inputs,
+		Outputs:   d.Outputs,
+		draft:     d.Draft,
+	}
+	return nil
+}
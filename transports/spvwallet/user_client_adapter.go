@@ -3,6 +3,7 @@ package spvwallet
 import (
 	"context"
 	"fmt"
+	"time"
 
 	walletclient "github.com/bitcoin-sv/spv-wallet-go-client"
 	"github.com/bitcoin-sv/spv-wallet-go-client/commands"
@@ -13,11 +14,15 @@ import (
 	"github.com/bitcoin-sv/spv-wallet/models"
 	"github.com/bitcoin-sv/spv-wallet/models/common"
 	"github.com/bitcoin-sv/spv-wallet/models/filter"
+	"github.com/bitcoin-sv/spv-wallet/models/response"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
 )
 
+// accessKeysPageSize is a number of access keys read from SPV Wallet at once.
+const accessKeysPageSize = 100
+
 type userClientAdapter struct {
 	api *walletclient.UserAPI
 	log *zerolog.Logger
@@ -43,6 +48,32 @@ func (u *userClientAdapter) GetAccessKey(accessKeyID string) (users.AccKey, erro
 	return &AccessKey{ID: accessKey.ID, Key: accessKey.Key}, nil
 }
 
+func (u *userClientAdapter) GetAccessKeys() ([]users.AccKey, error) {
+	// SPV Wallet returns access keys by pages, all pages are read so no active key is missed.
+	accessKeys := make([]users.AccKey, 0)
+	for number := 1; ; number++ {
+		page, err := u.api.AccessKeys(context.Background(), queries.QueryWithPageFilter[filter.AccessKeyFilter](filter.Page{
+			Number: number,
+			Size:   accessKeysPageSize,
+		}))
+		if err != nil {
+			u.log.Error().Msgf("Error while getting accessKeys: %v", err.Error())
+			return nil, errors.Wrap(err, "error while getting accessKeys")
+		}
+
+		for _, accessKey := range page.Content {
+			if accessKey.RevokedAt != nil {
+				continue
+			}
+			accessKeys = append(accessKeys, &AccessKey{ID: accessKey.ID, Key: accessKey.Key})
+		}
+
+		if len(page.Content) == 0 || number >= page.Page.TotalPages {
+			return accessKeys, nil
+		}
+	}
+}
+
 func (u *userClientAdapter) RevokeAccessKey(accessKeyID string) (users.AccKey, error) {
 	accessKey, err := u.api.AccessKey(context.Background(), accessKeyID)
 	if err != nil {
@@ -70,14 +101,27 @@ func (u *userClientAdapter) GetXPub() (users.PubKey, error) {
 	return &XPub{ID: xpub.ID, CurrentBalance: xpub.CurrentBalance}, nil
 }
 
-func (u *userClientAdapter) SendToRecipients(recipients []*commands.Recipients, senderPaymail string) (users.Transaction, error) {
-	// Send transaction.
+// Paymail methods
+func (u *userClientAdapter) GetPaymails() ([]string, error) {
+	page, err := u.api.Paymails(context.Background())
+	if err != nil {
+		u.log.Error().Msgf("Error while getting paymails: %v", err.Error())
+		return nil, errors.Wrap(err, "error while getting paymails")
+	}
+
+	paymails := make([]string, 0, len(page.Content))
+	for _, paymail := range page.Content {
+		paymails = append(paymails, paymail.Address)
+	}
+
+	return paymails, nil
+}
+
+// SendToRecipients drafts, finalizes and records transaction to the recipients with the metadata.
+func (u *userClientAdapter) SendToRecipients(recipients []*commands.Recipients, metadata map[string]any) (users.Transaction, error) {
 	transaction, err := u.api.SendToRecipients(context.Background(), &commands.SendToRecipients{
 		Recipients: recipients,
-		Metadata: map[string]any{
-			"receiver": recipients[0].To,
-			"sender":   senderPaymail,
-		},
+		Metadata:   metadata,
 	})
 	if err != nil {
 		u.log.Error().Msgf("Error while creating new tx: %v", err.Error())
@@ -93,6 +137,52 @@ func (u *userClientAdapter) SendToRecipients(recipients []*commands.Recipients,
 	}, nil
 }
 
+// SendAllTo sends all funds of the xPub to the recipient, fee is deducted from the sent amount.
+func (u *userClientAdapter) SendAllTo(recipient, senderPaymail string) (users.Transaction, error) {
+	metadata := map[string]any{
+		"receiver": recipient,
+		"sender":   senderPaymail,
+	}
+
+	draftTx, err := u.api.DraftTransaction(context.Background(), &commands.DraftTransaction{
+		Config: response.TransactionConfig{
+			SendAllTo: &response.TransactionOutput{To: recipient},
+		},
+		Metadata: metadata,
+	})
+	if err != nil {
+		u.log.Error().Msgf("Error while creating draft tx: %v", err.Error())
+		return nil, errors.Wrap(err, "error while creating draft tx")
+	}
+
+	hex, err := u.api.FinalizeTransaction(draftTx)
+	if err != nil {
+		u.log.Error().Str("draftTxID", draftTx.ID).Msgf("Error while finalizing tx: %v", err.Error())
+		return nil, errors.Wrap(err, "error while finalizing tx")
+	}
+
+	transaction, err := u.api.RecordTransaction(context.Background(), &commands.RecordTransaction{
+		Metadata:    metadata,
+		Hex:         hex,
+		ReferenceID: draftTx.ID,
+	})
+	if err != nil {
+		u.log.Error().Str("draftTxID", draftTx.ID).Msgf("Error while recording tx: %v", err.Error())
+		return nil, errors.Wrap(err, "error while recording tx")
+	}
+
+	return &Transaction{
+		ID:         transaction.ID,
+		Direction:  fmt.Sprint(transaction.TransactionDirection),
+		TotalValue: transaction.TotalValue,
+		Fee:        transaction.Fee,
+		Status:     transaction.Status,
+		CreatedAt:  transaction.Model.CreatedAt,
+		Sender:     senderPaymail,
+		Receiver:   recipient,
+	}, nil
+}
+
 func (u *userClientAdapter) GetTransactions(queryParam *filter.QueryParams, userPaymail string) ([]users.Transaction, error) {
 	if queryParam.OrderByField == "" {
 		queryParam.OrderByField = "created_at"
@@ -115,22 +205,23 @@ func (u *userClientAdapter) GetTransactions(queryParam *filter.QueryParams, user
 
 	var transactionsData = make([]users.Transaction, 0)
 	for _, transaction := range page.Content {
-		sender, receiver := GetPaymailsFromMetadata(transaction, userPaymail)
-		status := "unconfirmed"
-		if transaction.BlockHeight > 0 {
-			status = "confirmed"
-		}
+		transactionsData = append(transactionsData, toTransaction(transaction, userPaymail))
+	}
 
-		transactionsData = append(transactionsData, &Transaction{
-			ID:         transaction.ID,
-			Direction:  fmt.Sprint(transaction.TransactionDirection),
-			TotalValue: getAbsoluteValue(transaction.OutputValue),
-			Fee:        transaction.Fee,
-			Status:     status,
-			CreatedAt:  transaction.Model.CreatedAt,
-			Sender:     sender,
-			Receiver:   receiver,
-		})
+	return transactionsData, nil
+}
+
+// GetTransactionsWithMetadata returns transactions which have all given metadata.
+func (u *userClientAdapter) GetTransactionsWithMetadata(metadata map[string]any, userPaymail string) ([]users.Transaction, error) {
+	page, err := u.api.Transactions(context.Background(), queries.QueryWithMetadataFilter[filter.TransactionFilter](metadata))
+	if err != nil {
+		u.log.Error().Str("userPaymail", userPaymail).Msgf("Error while getting transactions with metadata: %v", err.Error())
+		return nil, errors.Wrap(err, "error while getting transactions with metadata")
+	}
+
+	var transactionsData = make([]users.Transaction, 0, len(page.Content))
+	for _, transaction := range page.Content {
+		transactionsData = append(transactionsData, toTransaction(transaction, userPaymail))
 	}
 
 	return transactionsData, nil
@@ -164,20 +255,47 @@ func (u *userClientAdapter) GetTransactionsCount() (int64, error) {
 	return 0, nil // Note: Functionality it's not a part of the SPV Wallet Go client.
 }
 
-func (u *userClientAdapter) CreateAndFinalizeTransaction(recipients []*commands.Recipients, metadata map[string]any) (users.DraftTransaction, error) {
-	draftTx, err := u.api.SendToRecipients(context.Background(), &commands.SendToRecipients{
-		Recipients: recipients,
-		Metadata:   metadata,
+func (u *userClientAdapter) DraftToRecipients(recipients []*commands.Recipients, metadata map[string]any, expiresIn time.Duration) (users.DraftTransaction, error) {
+	outputs := make([]*response.TransactionOutput, 0, len(recipients))
+	for _, recipient := range recipients {
+		outputs = append(outputs, &response.TransactionOutput{
+			To:       recipient.To,
+			Satoshis: recipient.Satoshis,
+			OpReturn: recipient.OpReturn,
+		})
+	}
+
+	draftTx, err := u.api.DraftTransaction(context.Background(), &commands.DraftTransaction{
+		Config: response.TransactionConfig{
+			Outputs:   outputs,
+			ExpiresIn: expiresIn,
+		},
+		Metadata: metadata,
 	})
 	if err != nil {
-		u.log.Error().Msgf("Error while sending to recipients: %v", err.Error())
-		return nil, errors.Wrap(err, "error while sending to recipients")
+		u.log.Error().Msgf("Error while creating draft tx: %v", err.Error())
+		return nil, errors.Wrap(err, "error while creating draft tx")
 	}
 
-	return &DraftTransaction{
-		TxDraftID: draftTx.DraftID,
-		TxHex:     draftTx.Hex,
-	}, nil
+	return toDraftTransaction(draftTx), nil
+}
+
+func (u *userClientAdapter) FinalizeTransaction(draft users.DraftTransaction) (users.DraftTransaction, error) {
+	draftTx, ok := draft.(*DraftTransaction)
+	if !ok || draftTx.draft == nil {
+		return nil, errors.New("draft tx can't be finalized, it wasn't created by draft to recipients")
+	}
+
+	hex, err := u.api.FinalizeTransaction(draftTx.draft)
+	if err != nil {
+		u.log.Error().Str("draftTxID", draftTx.TxDraftID).Msgf("Error while finalizing tx: %v", err.Error())
+		return nil, errors.Wrap(err, "error while finalizing tx")
+	}
+
+	finalized := *draftTx
+	finalized.TxHex = hex
+	finalized.draft = nil
+	return &finalized, nil
 }
 
 func (u *userClientAdapter) RecordTransaction(hex, draftTxID string, metadata map[string]any) (*models.Transaction, error) {
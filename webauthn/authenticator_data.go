@@ -0,0 +1,74 @@
+package webauthn
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+// Flags of authenticator data.
+const (
+	flagUserPresent        = 0x01
+	flagUserVerified       = 0x04
+	flagAttestedCredential = 0x40
+)
+
+const (
+	rpIDHashLength     = 32
+	aaguidLength       = 16
+	minAuthDataLength  = rpIDHashLength + 1 + 4
+	credentialIDMaxLen = 1023
+)
+
+// authenticatorData is the data signed by the authenticator, see https://www.w3.org/TR/webauthn-3/#sctn-authenticator-data.
+type authenticatorData struct {
+	rpIDHash     []byte
+	flags        byte
+	signCount    uint32
+	credentialID []byte
+	publicKey    []byte
+}
+
+type attestationObject struct {
+	Format       string         `codec:"fmt"`
+	AttStatement map[string]any `codec:"attStmt"`
+	AuthData     []byte         `codec:"authData"`
+}
+
+func parseAuthenticatorData(data []byte) (*authenticatorData, error) {
+	if len(data) < minAuthDataLength {
+		return nil, errors.New("authenticator data too short")
+	}
+
+	result := &authenticatorData{
+		rpIDHash:  data[:rpIDHashLength],
+		flags:     data[rpIDHashLength],
+		signCount: binary.BigEndian.Uint32(data[rpIDHashLength+1 : minAuthDataLength]),
+	}
+
+	if result.flags&flagAttestedCredential == 0 {
+		return result, nil
+	}
+
+	rest := data[minAuthDataLength:]
+	if len(rest) < aaguidLength+2 {
+		return nil, errors.New("attested credential data too short")
+	}
+	rest = rest[aaguidLength:]
+
+	idLength := int(binary.BigEndian.Uint16(rest[:2]))
+	rest = rest[2:]
+	if idLength > credentialIDMaxLen || len(rest) < idLength {
+		return nil, errors.New("invalid credential id length")
+	}
+	result.credentialID = rest[:idLength]
+	rest = rest[idLength:]
+
+	_, keyLength, err := decodeCOSEKey(rest)
+	if err != nil {
+		return nil, err
+	}
+	result.publicKey = rest[:keyLength]
+
+	return result, nil
+}
@@ -0,0 +1,186 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"slices"
+
+	"github.com/pkg/errors"
+	"github.com/ugorji/go/codec"
+)
+
+// Types of client data.
+const (
+	clientDataCreate = "webauthn.create"
+	clientDataGet    = "webauthn.get"
+)
+
+// VerifyRegistration verifies the result of the registration ceremony started with the challenge and returns the new credential.
+// Attestation statement isn't verified, attestation "none" is requested, so any authenticator is trusted.
+func (rp *RelyingParty) VerifyRegistration(challenge string, response *RegistrationResponse) (*Credential, error) {
+	if response.Type != credentialType {
+		return nil, errors.Errorf("unsupported credential type: %s", response.Type)
+	}
+
+	if err := rp.verifyClientData(response.Response.ClientDataJSON, clientDataCreate, challenge); err != nil {
+		return nil, err
+	}
+
+	attestation, err := decode(response.Response.AttestationObject)
+	if err != nil {
+		return nil, err
+	}
+	var object attestationObject
+	if err = codec.NewDecoderBytes(attestation, cborHandle).Decode(&object); err != nil {
+		return nil, errors.Wrap(err, "invalid attestation object")
+	}
+
+	authData, err := rp.verifyAuthenticatorData(object.AuthData)
+	if err != nil {
+		return nil, err
+	}
+	if authData.credentialID == nil {
+		return nil, errors.New("attestation contains no credential")
+	}
+
+	rawID, err := response.CredentialID()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(rawID, authData.credentialID) {
+		return nil, errors.New("credential id doesn't match attested credential")
+	}
+
+	if _, err = parsePublicKey(authData.publicKey); err != nil {
+		return nil, err
+	}
+
+	return &Credential{
+		ID:         authData.credentialID,
+		PublicKey:  authData.publicKey,
+		SignCount:  authData.signCount,
+		Transports: response.Response.Transports,
+	}, nil
+}
+
+// VerifyAssertion verifies the result of the authentication ceremony started with the challenge, signed by the registered credential.
+// It returns the new signature counter of the credential which must be stored.
+func (rp *RelyingParty) VerifyAssertion(challenge string, response *AssertionResponse, credential *Credential) (uint32, error) {
+	if response.Type != credentialType {
+		return 0, errors.Errorf("unsupported credential type: %s", response.Type)
+	}
+
+	if err := rp.verifyClientData(response.Response.ClientDataJSON, clientDataGet, challenge); err != nil {
+		return 0, err
+	}
+
+	rawAuthData, err := decode(response.Response.AuthenticatorData)
+	if err != nil {
+		return 0, err
+	}
+	authData, err := rp.verifyAuthenticatorData(rawAuthData)
+	if err != nil {
+		return 0, err
+	}
+
+	key, err := parsePublicKey(credential.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+
+	clientDataJSON, err := decode(response.Response.ClientDataJSON)
+	if err != nil {
+		return 0, err
+	}
+	signature, err := decode(response.Response.Signature)
+	if err != nil {
+		return 0, err
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	if err = key.verify(append(rawAuthData, clientDataHash[:]...), signature); err != nil {
+		return 0, err
+	}
+
+	// Authenticators which don't count signatures always return zero.
+	if (authData.signCount != 0 || credential.SignCount != 0) && authData.signCount <= credential.SignCount {
+		return 0, errors.New("signature counter didn't increase, credential may be cloned")
+	}
+
+	return authData.signCount, nil
+}
+
+// CredentialID returns decoded id of the created credential.
+func (r *RegistrationResponse) CredentialID() ([]byte, error) {
+	return decode(r.RawID)
+}
+
+// CredentialID returns decoded id of the credential used for the assertion.
+func (r *AssertionResponse) CredentialID() ([]byte, error) {
+	return decode(r.RawID)
+}
+
+// UserHandle returns the user handle returned by the authenticator, it's empty if the credential isn't discoverable.
+func (r *AssertionResponse) UserHandle() string {
+	return r.Response.UserHandle
+}
+
+// PRFSecret returns the secret derived by the credential from the PRF extension input, nil is returned if it wasn't evaluated.
+// Browsers evaluate PRF on registration only with some authenticators, the frontend can get the secret with an assertion
+// with the same extension inputs then and send it in the registration response.
+func (r ClientExtensionResults) PRFSecret() ([]byte, error) {
+	if r.PRF == nil || r.PRF.Results == nil || r.PRF.Results.First == "" {
+		return nil, nil
+	}
+
+	secret, err := decode(r.PRF.Results.First)
+	if err != nil {
+		return nil, err
+	}
+	if len(secret) != prfSecretLength {
+		return nil, errors.New("invalid PRF output length")
+	}
+	return secret, nil
+}
+
+func (rp *RelyingParty) verifyClientData(encoded, expectedType, challenge string) error {
+	data, err := decode(encoded)
+	if err != nil {
+		return err
+	}
+
+	var client clientData
+	if err = json.Unmarshal(data, &client); err != nil {
+		return errors.Wrap(err, "invalid client data")
+	}
+
+	switch {
+	case client.Type != expectedType:
+		return errors.Errorf("unexpected client data type: %s", client.Type)
+	case challenge == "" || subtle.ConstantTimeCompare([]byte(client.Challenge), []byte(challenge)) != 1:
+		return errors.New("challenge doesn't match")
+	case !slices.Contains(rp.origins, client.Origin):
+		return errors.Errorf("origin %s is not allowed", client.Origin)
+	case client.CrossOrigin:
+		return errors.New("cross-origin ceremony is not allowed")
+	}
+	return nil
+}
+
+func (rp *RelyingParty) verifyAuthenticatorData(data []byte) (*authenticatorData, error) {
+	authData, err := parseAuthenticatorData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case subtle.ConstantTimeCompare(authData.rpIDHash, rp.rpIDHash[:]) != 1:
+		return nil, errors.New("credential belongs to another relying party")
+	case authData.flags&flagUserPresent == 0:
+		return nil, errors.New("user wasn't present")
+	case authData.flags&flagUserVerified == 0:
+		return nil, errors.New("user wasn't verified")
+	}
+	return authData, nil
+}
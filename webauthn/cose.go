@@ -0,0 +1,161 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/ugorji/go/codec"
+)
+
+// Labels and values of COSE keys, see RFC 9053.
+const (
+	coseKeyType      = 1
+	coseAlgorithm    = 3
+	coseCurve        = -1
+	coseX            = -2
+	coseY            = -3
+	coseRSAModulus   = -1
+	coseRSAExponent  = -2
+	coseKeyTypeOKP   = 1
+	coseKeyTypeEC2   = 2
+	coseKeyTypeRSA   = 3
+	coseCurveP256    = 1
+	coseCurveEd25519 = 6
+)
+
+var cborHandle = &codec.CborHandle{}
+
+// publicKey is a credential key parsed from COSE encoding.
+type publicKey struct {
+	algorithm int
+	key       crypto.PublicKey
+}
+
+// decodeCOSEKey decodes COSE key at the beginning of data and returns it with its length.
+func decodeCOSEKey(data []byte) (map[int]any, int, error) {
+	var coseKey map[int]any
+	decoder := codec.NewDecoderBytes(data, cborHandle)
+	if err := decoder.Decode(&coseKey); err != nil {
+		return nil, 0, errors.Wrap(err, "invalid credential public key")
+	}
+	return coseKey, decoder.NumBytesRead(), nil
+}
+
+// parsePublicKey parses COSE encoded credential key, only keys of supported algorithms are accepted.
+func parsePublicKey(data []byte) (*publicKey, error) {
+	coseKey, _, err := decodeCOSEKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	keyType, _ := intValue(coseKey[coseKeyType])
+	algorithm, _ := intValue(coseKey[coseAlgorithm])
+
+	switch {
+	case keyType == coseKeyTypeEC2 && algorithm == AlgES256:
+		return parseES256Key(coseKey)
+	case keyType == coseKeyTypeOKP && algorithm == AlgEdDSA:
+		return parseEdDSAKey(coseKey)
+	case keyType == coseKeyTypeRSA && algorithm == AlgRS256:
+		return parseRS256Key(coseKey)
+	default:
+		return nil, errors.Errorf("unsupported credential key type %d with algorithm %d", keyType, algorithm)
+	}
+}
+
+func parseES256Key(coseKey map[int]any) (*publicKey, error) {
+	curve, _ := intValue(coseKey[coseCurve])
+	x, okX := coseKey[coseX].([]byte)
+	y, okY := coseKey[coseY].([]byte)
+	if curve != coseCurveP256 || !okX || !okY {
+		return nil, errors.New("invalid ES256 credential key")
+	}
+
+	// ecdh checks that the point is on the curve.
+	point := append(append([]byte{4}, x...), y...)
+	if _, err := ecdh.P256().NewPublicKey(point); err != nil {
+		return nil, errors.Wrap(err, "invalid ES256 credential key")
+	}
+
+	return &publicKey{
+		algorithm: AlgES256,
+		key: &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		},
+	}, nil
+}
+
+func parseEdDSAKey(coseKey map[int]any) (*publicKey, error) {
+	curve, _ := intValue(coseKey[coseCurve])
+	x, ok := coseKey[coseX].([]byte)
+	if curve != coseCurveEd25519 || !ok || len(x) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid EdDSA credential key")
+	}
+
+	return &publicKey{
+		algorithm: AlgEdDSA,
+		key:       ed25519.PublicKey(x),
+	}, nil
+}
+
+func parseRS256Key(coseKey map[int]any) (*publicKey, error) {
+	n, okN := coseKey[coseRSAModulus].([]byte)
+	e, okE := coseKey[coseRSAExponent].([]byte)
+	if !okN || !okE || len(e) > 4 {
+		return nil, errors.New("invalid RS256 credential key")
+	}
+
+	return &publicKey{
+		algorithm: AlgRS256,
+		key: &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		},
+	}, nil
+}
+
+// verify checks signature of the data made by the credential.
+func (k *publicKey) verify(data, signature []byte) error {
+	switch key := k.key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return errors.New("invalid signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, data, signature) {
+			return errors.New("invalid signature")
+		}
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return errors.Wrap(err, "invalid signature")
+		}
+	default:
+		return errors.New("unsupported credential key")
+	}
+	return nil
+}
+
+// intValue converts integer decoded from CBOR, which is decoded as int64 or uint64 depending on its sign.
+func intValue(v any) (int, bool) {
+	switch i := v.(type) {
+	case int64:
+		return int(i), true
+	case uint64:
+		return int(i), true //nolint:gosec // COSE labels and values are small
+	case int:
+		return i, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,133 @@
+package webauthn
+
+// Binary values of options and responses are base64url encoded, as in the JSON serialization of WebAuthn,
+// so the frontend can pass them to PublicKeyCredential.parseCreationOptionsFromJSON and parseRequestOptionsFromJSON
+// and send the result of PublicKeyCredential.toJSON back.
+
+// CreationOptions are options of the registration ceremony passed to navigator.credentials.create.
+type CreationOptions struct {
+	Challenge              string                 `json:"challenge"`
+	RP                     RelyingPartyEntity     `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	PubKeyCredParams       []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+	Extensions             Extensions             `json:"extensions"`
+}
+
+// RequestOptions are options of the authentication ceremony passed to navigator.credentials.get.
+type RequestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	Timeout          int64                  `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+	Extensions       Extensions             `json:"extensions"`
+}
+
+// RelyingPartyEntity identifies the relying party.
+type RelyingPartyEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserEntity identifies the user at the authenticator. ID is the user handle, it must not contain personal data.
+type UserEntity struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// CredentialParameter is a credential type and algorithm supported by the relying party.
+type CredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+// CredentialDescriptor identifies a registered credential.
+type CredentialDescriptor struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// AuthenticatorSelection are requirements for the authenticator.
+type AuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// Extensions are client extension inputs.
+type Extensions struct {
+	PRF *PRFInputs `json:"prf,omitempty"`
+}
+
+// PRFInputs are inputs of the PRF extension.
+type PRFInputs struct {
+	Eval *PRFValues `json:"eval,omitempty"`
+}
+
+// PRFValues are inputs or outputs of the PRF extension.
+type PRFValues struct {
+	First string `json:"first"`
+}
+
+// RegistrationResponse is the credential returned by navigator.credentials.create.
+type RegistrationResponse struct {
+	ID                     string                   `json:"id"`
+	RawID                  string                   `json:"rawId"`
+	Type                   string                   `json:"type"`
+	Response               AuthenticatorAttestation `json:"response"`
+	ClientExtensionResults ClientExtensionResults   `json:"clientExtensionResults"`
+}
+
+// AuthenticatorAttestation is the response of the authenticator to the registration ceremony.
+type AuthenticatorAttestation struct {
+	ClientDataJSON    string   `json:"clientDataJSON"`
+	AttestationObject string   `json:"attestationObject"`
+	Transports        []string `json:"transports,omitempty"`
+}
+
+// AssertionResponse is the credential returned by navigator.credentials.get.
+type AssertionResponse struct {
+	ID                     string                 `json:"id"`
+	RawID                  string                 `json:"rawId"`
+	Type                   string                 `json:"type"`
+	Response               AuthenticatorAssertion `json:"response"`
+	ClientExtensionResults ClientExtensionResults `json:"clientExtensionResults"`
+}
+
+// AuthenticatorAssertion is the response of the authenticator to the authentication ceremony.
+type AuthenticatorAssertion struct {
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AuthenticatorData string `json:"authenticatorData"`
+	Signature         string `json:"signature"`
+	UserHandle        string `json:"userHandle,omitempty"`
+}
+
+// ClientExtensionResults are client extension outputs.
+type ClientExtensionResults struct {
+	PRF *PRFOutputs `json:"prf,omitempty"`
+}
+
+// PRFOutputs are outputs of the PRF extension.
+type PRFOutputs struct {
+	Enabled bool       `json:"enabled,omitempty"`
+	Results *PRFValues `json:"results,omitempty"`
+}
+
+// Credential is a verified public key credential.
+type Credential struct {
+	ID         []byte
+	PublicKey  []byte // COSE encoded public key
+	SignCount  uint32
+	Transports []string
+}
+
+type clientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
@@ -0,0 +1,140 @@
+package webauthn
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// COSE algorithms of supported credential keys, in order of preference.
+const (
+	AlgES256 = -7
+	AlgEdDSA = -8
+	AlgRS256 = -257
+)
+
+const (
+	credentialType  = "public-key"
+	challengeLength = 32
+	prfSecretLength = 32
+)
+
+// Requirements passed to the authenticator. User verification is always required, because the passkey
+// unlocks the wallet the same way as the password does.
+const (
+	userVerificationRequired = "required"
+	residentKeyRequired      = "required"
+	attestationNone          = "none"
+)
+
+// prfSalt is the PRF extension input evaluated on every ceremony. It can be the same for all credentials,
+// because every credential derives its own secret from it, which never leaves the authenticator unless the user is verified.
+var prfSalt = sha256.Sum256([]byte("spv-wallet-web-backend passkey xpriv wrapping"))
+
+// RelyingParty creates options of WebAuthn ceremonies and verifies their results.
+type RelyingParty struct {
+	id       string
+	name     string
+	origins  []string
+	timeout  time.Duration
+	rpIDHash [32]byte
+}
+
+// NewRelyingParty creates RelyingParty for the rpID, ceremonies are accepted only from the origins.
+// Timeout is passed to the browser and should match how long the challenge is kept.
+func NewRelyingParty(rpID, name string, origins []string, timeout time.Duration) *RelyingParty {
+	return &RelyingParty{
+		id:       rpID,
+		name:     name,
+		origins:  origins,
+		timeout:  timeout,
+		rpIDHash: sha256.Sum256([]byte(rpID)),
+	}
+}
+
+// NewChallenge returns a random challenge of a ceremony. It must be kept by the server until the ceremony is finished.
+func (rp *RelyingParty) NewChallenge() (string, error) {
+	return randomValue(challengeLength)
+}
+
+// NewUserHandle returns a random handle of the user, it's stored by the authenticator together with discoverable credential.
+func (rp *RelyingParty) NewUserHandle() (string, error) {
+	return randomValue(challengeLength)
+}
+
+// CreationOptions returns options of the registration ceremony, already registered credentials are excluded.
+func (rp *RelyingParty) CreationOptions(challenge string, user UserEntity, exclude [][]byte) *CreationOptions {
+	return &CreationOptions{
+		Challenge: challenge,
+		RP: RelyingPartyEntity{
+			ID:   rp.id,
+			Name: rp.name,
+		},
+		User: user,
+		PubKeyCredParams: []CredentialParameter{
+			{Type: credentialType, Alg: AlgES256},
+			{Type: credentialType, Alg: AlgEdDSA},
+			{Type: credentialType, Alg: AlgRS256},
+		},
+		Timeout:            rp.timeout.Milliseconds(),
+		ExcludeCredentials: descriptors(exclude),
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:      residentKeyRequired,
+			UserVerification: userVerificationRequired,
+		},
+		Attestation: attestationNone,
+		Extensions:  prfExtension(),
+	}
+}
+
+// RequestOptions returns options of the authentication ceremony. If allow is empty, the user chooses from discoverable credentials.
+func (rp *RelyingParty) RequestOptions(challenge string, allow [][]byte) *RequestOptions {
+	return &RequestOptions{
+		Challenge:        challenge,
+		Timeout:          rp.timeout.Milliseconds(),
+		RPID:             rp.id,
+		AllowCredentials: descriptors(allow),
+		UserVerification: userVerificationRequired,
+		Extensions:       prfExtension(),
+	}
+}
+
+func prfExtension() Extensions {
+	return Extensions{
+		PRF: &PRFInputs{
+			Eval: &PRFValues{First: base64.RawURLEncoding.EncodeToString(prfSalt[:])},
+		},
+	}
+}
+
+func descriptors(ids [][]byte) []CredentialDescriptor {
+	result := make([]CredentialDescriptor, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, CredentialDescriptor{
+			Type: credentialType,
+			ID:   base64.RawURLEncoding.EncodeToString(id),
+		})
+	}
+	return result
+}
+
+func randomValue(length int) (string, error) {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "internal error")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decode decodes base64url value, browsers encode it without padding, but some libraries add it.
+func decode(value string) ([]byte, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid base64url encoding")
+	}
+	return data, nil
+}